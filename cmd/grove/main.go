@@ -2,35 +2,221 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
-	tea "github.com/charmbracelet/bubbletea"
-
+	"github.com/iatopilskii/grove/internal/cli"
+	"github.com/iatopilskii/grove/internal/config"
+	"github.com/iatopilskii/grove/internal/git"
 	"github.com/iatopilskii/grove/internal/ui"
 )
 
+// version is the application version, overridden at build time via -ldflags.
+var version = "dev"
+
 func main() {
-	// Load and apply configuration from ~/.config/grove/config.yaml
-	// Invalid config falls back to defaults; missing file is not an error
-	if err := ui.LoadAndApplyTheme(); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: theme config error: %v (using defaults)\n", err)
+	args := os.Args[1:]
+
+	// Subcommands run non-interactively and exit without launching the TUI
+	if len(args) > 0 {
+		switch args[0] {
+		case "list":
+			os.Exit(runList(args[1:]))
+		case "completion":
+			os.Exit(runCompletion(args[1:]))
+		case "shell-init":
+			os.Exit(runShellInit(args[1:]))
+		case "add":
+			os.Exit(runAdd(args[1:]))
+		case "config":
+			os.Exit(runConfig(args[1:]))
+		}
+	}
+
+	flags := flag.NewFlagSet("grove", flag.ExitOnError)
+	jsonOutput := flags.Bool("json", false, "print worktrees as JSON and exit")
+	showVersion := flags.Bool("version", false, "print version and exit")
+	flags.Parse(args)
+
+	if *showVersion {
+		fmt.Println(cli.CommandName, version)
+		if major, minor, patch, err := git.GitVersion(); err == nil {
+			fmt.Printf("git %d.%d.%d\n", major, minor, patch)
+		}
+		return
 	}
 
-	app := ui.NewApp()
-	p := tea.NewProgram(app)
+	if *jsonOutput {
+		os.Exit(printWorktreesJSON())
+	}
+
+	runTUI()
+}
 
-	m, err := p.Run()
+// runTUI launches the interactive Bubble Tea application.
+func runTUI() {
+	// Load and apply configuration from $GROVE_CONFIG or ~/.config/grove/config.yaml
+	// Invalid config falls back to defaults; missing file is not an error
+	cfg, err := config.LoadConfig(config.ResolveConfigPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: config error: %v (using defaults)\n", err)
+	}
+	ui.ApplyThemeConfig(cfg)
+
+	app := ui.NewAppWithConfig(cfg)
+	targetPath, err := ui.Run(app)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Check if we should cd to a target path (after worktree creation)
-	if finalApp, ok := m.(*ui.App); ok {
-		if targetPath := finalApp.TargetPath(); targetPath != "" {
-			fmt.Println(targetPath)
-			os.Exit(2)
-		}
+	// Report what the closed UI can no longer show, e.g. a finished hook;
+	// stderr keeps it out of the output the shell wrapper reads
+	if message := app.ExitMessage(); message != "" {
+		fmt.Fprintln(os.Stderr, message)
+	}
+
+	// Tell the shell wrapper where to cd (e.g. after creating a worktree)
+	if targetPath != "" {
+		os.Exit(cli.PrintTargetPath(os.Stdout, targetPath))
+	}
+}
+
+// runList handles the "list" subcommand and returns the exit code.
+// Without --json it prints one worktree path per line.
+func runList(args []string) int {
+	flags := flag.NewFlagSet("grove list", flag.ExitOnError)
+	jsonOutput := flags.Bool("json", false, "print worktrees as JSON")
+	flags.Parse(args)
+
+	if *jsonOutput {
+		return printWorktreesJSON()
+	}
+
+	dir, err := git.GetCurrentDirectory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	worktrees, err := git.ListWorktrees(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, wt := range worktrees {
+		fmt.Println(wt.Path)
+	}
+	return 0
+}
+
+// runCompletion handles the "completion" subcommand and returns the exit code.
+func runCompletion(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s completion [%s]\n", cli.CommandName, strings.Join(cli.Shells, "|"))
+		return 1
+	}
+
+	script, err := cli.GenerateCompletion(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Print(script)
+	return 0
+}
+
+// runShellInit handles the "shell-init" subcommand and returns the exit code.
+// It prints a wrapper function that cds into the worktree chosen in the TUI.
+func runShellInit(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s shell-init [%s]\n", cli.CommandName, strings.Join(cli.Shells, "|"))
+		return 1
+	}
+
+	script, err := cli.GenerateShellInit(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Print(script)
+	return 0
+}
+
+// runAdd handles the "add" subcommand and returns the exit code.
+// On success it announces the absolute path of the new worktree like the
+// TUI does, so a shell wrapper can cd into it.
+func runAdd(args []string) int {
+	opts, err := cli.ParseAddArgs(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	dir, err := git.GetCurrentDirectory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if err := git.AddWorktree(dir, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	path, err := filepath.Abs(opts.Path)
+	if err != nil {
+		path = opts.Path
+	}
+	return cli.PrintTargetPath(os.Stdout, path)
+}
+
+// runConfig handles the "config" subcommand and returns the exit code.
+// "config init [--force]" writes the default configuration file.
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "init" {
+		fmt.Fprintf(os.Stderr, "Usage: %s config init [--force]\n", cli.CommandName)
+		return 1
+	}
+
+	flags := flag.NewFlagSet("grove config init", flag.ExitOnError)
+	force := flags.Bool("force", false, "overwrite an existing config file")
+	flags.Parse(args[1:])
+
+	path := config.ResolveConfigPath()
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "Error: could not determine config directory")
+		return 1
+	}
+
+	if err := config.WriteDefaultConfig(path, *force); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
 	}
+
+	fmt.Println(path)
+	return 0
+}
+
+// printWorktreesJSON prints the worktrees of the current repository as JSON
+// and returns the exit code.
+func printWorktreesJSON() int {
+	dir, err := git.GetCurrentDirectory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	data, err := git.WorktreesJSON(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Println(string(data))
+	return 0
 }
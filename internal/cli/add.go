@@ -0,0 +1,74 @@
+// Package cli provides helpers for the non-interactive command-line interface.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/iatopilskii/grove/internal/git"
+)
+
+// AddUsage is the usage line for the "add" subcommand.
+const AddUsage = "Usage: grove add <path> <branch> [--base <branch>] [--existing]"
+
+// UsageError is returned when command-line arguments are invalid.
+type UsageError struct {
+	Reason string
+	Usage  string
+}
+
+func (e *UsageError) Error() string {
+	return fmt.Sprintf("%s\n%s", e.Reason, e.Usage)
+}
+
+// ParseAddArgs parses the arguments of the "add" subcommand into worktree options.
+// Flags may appear before, between, or after the positional arguments.
+// By default a new branch is created; --existing checks out an existing branch instead.
+func ParseAddArgs(args []string) (git.AddWorktreeOptions, error) {
+	flags := flag.NewFlagSet("grove add", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	base := flags.String("base", "", "starting point for the new branch")
+	existing := flags.Bool("existing", false, "check out an existing branch instead of creating one")
+
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return git.AddWorktreeOptions{}, &UsageError{Reason: err.Error(), Usage: AddUsage}
+	}
+	if len(positional) != 2 {
+		return git.AddWorktreeOptions{}, &UsageError{
+			Reason: "expected <path> and <branch> arguments",
+			Usage:  AddUsage,
+		}
+	}
+	if *existing && *base != "" {
+		return git.AddWorktreeOptions{}, &UsageError{
+			Reason: "--base cannot be used with --existing",
+			Usage:  AddUsage,
+		}
+	}
+
+	return git.AddWorktreeOptions{
+		Path:         positional[0],
+		Branch:       positional[1],
+		CreateBranch: !*existing,
+		BaseBranch:   *base,
+	}, nil
+}
+
+// parseInterspersed parses flags that may be mixed with positional arguments
+// and returns the positional arguments in order.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		remaining := flags.Args()
+		if len(remaining) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, remaining[0])
+		args = remaining[1:]
+	}
+}
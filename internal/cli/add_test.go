@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseAddArgs verifies parsing of the add subcommand arguments.
+func TestParseAddArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		path         string
+		branch       string
+		base         string
+		createBranch bool
+	}{
+		{
+			name:         "new branch",
+			args:         []string{"../feature", "feature"},
+			path:         "../feature",
+			branch:       "feature",
+			createBranch: true,
+		},
+		{
+			name:         "new branch with base",
+			args:         []string{"../feature", "feature", "--base", "develop"},
+			path:         "../feature",
+			branch:       "feature",
+			base:         "develop",
+			createBranch: true,
+		},
+		{
+			name:         "flags before positionals",
+			args:         []string{"--base=develop", "../feature", "feature"},
+			path:         "../feature",
+			branch:       "feature",
+			base:         "develop",
+			createBranch: true,
+		},
+		{
+			name:         "existing branch",
+			args:         []string{"../main", "--existing", "main"},
+			path:         "../main",
+			branch:       "main",
+			createBranch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseAddArgs(tt.args)
+			if err != nil {
+				t.Fatalf("ParseAddArgs failed: %v", err)
+			}
+			if opts.Path != tt.path {
+				t.Errorf("Expected Path %q, got %q", tt.path, opts.Path)
+			}
+			if opts.Branch != tt.branch {
+				t.Errorf("Expected Branch %q, got %q", tt.branch, opts.Branch)
+			}
+			if opts.BaseBranch != tt.base {
+				t.Errorf("Expected BaseBranch %q, got %q", tt.base, opts.BaseBranch)
+			}
+			if opts.CreateBranch != tt.createBranch {
+				t.Errorf("Expected CreateBranch %v, got %v", tt.createBranch, opts.CreateBranch)
+			}
+		})
+	}
+}
+
+// TestParseAddArgsErrors verifies invalid arguments return a UsageError.
+func TestParseAddArgsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no arguments", nil},
+		{"missing branch", []string{"../feature"}},
+		{"too many arguments", []string{"../feature", "feature", "extra"}},
+		{"unknown flag", []string{"../feature", "feature", "--bogus"}},
+		{"base with existing", []string{"../feature", "feature", "--existing", "--base", "main"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAddArgs(tt.args)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if _, ok := err.(*UsageError); !ok {
+				t.Errorf("Expected UsageError, got %T", err)
+			}
+			if !strings.Contains(err.Error(), AddUsage) {
+				t.Errorf("Error should include usage, got: %v", err)
+			}
+		})
+	}
+}
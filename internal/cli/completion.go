@@ -0,0 +1,99 @@
+// Package cli provides helpers for the non-interactive command-line interface.
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandName is the name of the executable used in generated scripts.
+const CommandName = "grove"
+
+// Commands lists the subcommands offered for shell completion.
+var Commands = []string{"list", "add", "config", "completion", "shell-init"}
+
+// Flags lists the top-level flags offered for shell completion.
+var Flags = []string{"--json", "--version"}
+
+// Shells lists the shells supported by GenerateCompletion and GenerateShellInit.
+var Shells = []string{"bash", "zsh", "fish"}
+
+// UnsupportedShellError is returned when completion is requested for an unknown shell.
+type UnsupportedShellError struct {
+	Shell string
+}
+
+func (e *UnsupportedShellError) Error() string {
+	return fmt.Sprintf("unsupported shell %q (supported: %s)", e.Shell, strings.Join(Shells, ", "))
+}
+
+// GenerateCompletion returns a completion script for the given shell.
+func GenerateCompletion(shell string) (string, error) {
+	switch shell {
+	case "bash":
+		return bashCompletion(), nil
+	case "zsh":
+		return zshCompletion(), nil
+	case "fish":
+		return fishCompletion(), nil
+	default:
+		return "", &UnsupportedShellError{Shell: shell}
+	}
+}
+
+// bashCompletion generates a bash completion script.
+func bashCompletion() string {
+	words := strings.Join(append(append([]string{}, Commands...), Flags...), " ")
+	shells := strings.Join(Shells, " ")
+
+	return fmt.Sprintf(`# bash completion for %[1]s
+_%[1]s() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    if [[ "$prev" == "completion" ]]; then
+        COMPREPLY=($(compgen -W "%[3]s" -- "$cur"))
+        return
+    fi
+
+    COMPREPLY=($(compgen -W "%[2]s" -- "$cur"))
+}
+complete -F _%[1]s %[1]s
+`, CommandName, words, shells)
+}
+
+// zshCompletion generates a zsh completion script.
+func zshCompletion() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "#compdef %s\n\n", CommandName)
+	fmt.Fprintf(&b, "_%s() {\n", CommandName)
+	b.WriteString("    if (( CURRENT == 3 )) && [[ \"${words[2]}\" == \"completion\" ]]; then\n")
+	fmt.Fprintf(&b, "        compadd %s\n", strings.Join(Shells, " "))
+	b.WriteString("        return\n")
+	b.WriteString("    fi\n\n")
+	fmt.Fprintf(&b, "    compadd %s\n", strings.Join(Commands, " "))
+	fmt.Fprintf(&b, "    compadd -- %s\n", strings.Join(Flags, " "))
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "compdef _%[1]s %[1]s\n", CommandName)
+
+	return b.String()
+}
+
+// fishCompletion generates a fish completion script.
+func fishCompletion() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# fish completion for %s\n", CommandName)
+	for _, cmd := range Commands {
+		fmt.Fprintf(&b, "complete -c %s -n '__fish_use_subcommand' -f -a %s\n", CommandName, cmd)
+	}
+	for _, flag := range Flags {
+		fmt.Fprintf(&b, "complete -c %s -f -l %s\n", CommandName, strings.TrimPrefix(flag, "--"))
+	}
+	fmt.Fprintf(&b, "complete -c %s -n '__fish_seen_subcommand_from completion' -f -a '%s'\n",
+		CommandName, strings.Join(Shells, " "))
+
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenerateCompletion verifies each supported shell emits a script
+// containing the command name, subcommands, and flags.
+func TestGenerateCompletion(t *testing.T) {
+	for _, shell := range Shells {
+		t.Run(shell, func(t *testing.T) {
+			script, err := GenerateCompletion(shell)
+			if err != nil {
+				t.Fatalf("GenerateCompletion(%q) failed: %v", shell, err)
+			}
+
+			if !strings.Contains(script, CommandName) {
+				t.Errorf("Script should contain command name %q", CommandName)
+			}
+			for _, cmd := range Commands {
+				if !strings.Contains(script, cmd) {
+					t.Errorf("Script should contain subcommand %q", cmd)
+				}
+			}
+			for _, flag := range Flags {
+				// fish uses long option names without the leading dashes
+				if !strings.Contains(script, strings.TrimPrefix(flag, "--")) {
+					t.Errorf("Script should contain flag %q", flag)
+				}
+			}
+		})
+	}
+}
+
+// TestGenerateCompletionBashFlags verifies bash completion lists full flag names.
+func TestGenerateCompletionBashFlags(t *testing.T) {
+	script, err := GenerateCompletion("bash")
+	if err != nil {
+		t.Fatalf("GenerateCompletion failed: %v", err)
+	}
+	if !strings.Contains(script, "--json") || !strings.Contains(script, "--version") {
+		t.Errorf("Bash script should contain --json and --version flags:\n%s", script)
+	}
+	if !strings.Contains(script, "complete -F _grove grove") {
+		t.Error("Bash script should register the completion function")
+	}
+}
+
+// TestGenerateCompletionUnsupportedShell verifies an error for unknown shells.
+func TestGenerateCompletionUnsupportedShell(t *testing.T) {
+	_, err := GenerateCompletion("powershell")
+	if err == nil {
+		t.Fatal("Expected error for unsupported shell")
+	}
+	if _, ok := err.(*UnsupportedShellError); !ok {
+		t.Errorf("Expected UnsupportedShellError, got %T", err)
+	}
+	if !strings.Contains(err.Error(), "powershell") {
+		t.Errorf("Error should mention the shell, got: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Package cli provides helpers for the non-interactive command-line interface.
+package cli
+
+import (
+	"fmt"
+	"io"
+)
+
+// TargetPathPrefix starts the line printed after quitting the TUI with the
+// worktree path to switch to, so shell wrappers can pick it out of any
+// other output.
+const TargetPathPrefix = "grove-cd:"
+
+// TargetPathExitCode is the exit status telling a shell wrapper that a
+// target path line was printed and it should cd there.
+const TargetPathExitCode = 2
+
+// TargetPathLine returns the line announcing targetPath to shell wrappers,
+// e.g. "grove-cd:/src/repo-feature".
+func TargetPathLine(targetPath string) string {
+	return TargetPathPrefix + targetPath
+}
+
+// PrintTargetPath writes the line announcing targetPath to w and returns
+// TargetPathExitCode, the status to exit with so shell wrappers cd there.
+func PrintTargetPath(w io.Writer, targetPath string) int {
+	fmt.Fprintln(w, TargetPathLine(targetPath))
+	return TargetPathExitCode
+}
+
+// GenerateShellInit returns a shell function for the given shell that wraps
+// the binary and changes the shell's directory to the target path it prints
+// when it exits with TargetPathExitCode. Other output is passed through.
+func GenerateShellInit(shell string) (string, error) {
+	switch shell {
+	case "bash", "zsh":
+		return posixShellInit(shell), nil
+	case "fish":
+		return fishShellInit(), nil
+	default:
+		return "", &UnsupportedShellError{Shell: shell}
+	}
+}
+
+// posixShellInit generates the wrapper function for bash and zsh, which
+// share the same syntax. The exit code is kept in "ec" because "status" is
+// read-only in zsh.
+func posixShellInit(shell string) string {
+	return fmt.Sprintf(`# %[1]s shell wrapper for %[2]s: cd into the worktree chosen in %[1]s.
+# Add to your rc file: eval "$(%[1]s shell-init %[2]s)"
+%[1]s() {
+    local output ec target
+    output=$(command %[1]s "$@")
+    ec=$?
+    if [[ $ec -eq %[4]d ]]; then
+        target=$(printf '%%s\n' "$output" | sed -n 's/^%[3]s//p' | tail -n 1)
+        if [[ -d "$target" ]]; then
+            cd "$target"
+            return
+        fi
+    fi
+    [[ -n "$output" ]] && printf '%%s\n' "$output"
+    return $ec
+}
+`, CommandName, shell, TargetPathPrefix, TargetPathExitCode)
+}
+
+// fishShellInit generates the wrapper function for fish.
+func fishShellInit() string {
+	return fmt.Sprintf(`# %[1]s shell wrapper for fish: cd into the worktree chosen in %[1]s.
+# Add to ~/.config/fish/config.fish: %[1]s shell-init fish | source
+function %[1]s
+    set -l output (command %[1]s $argv)
+    set -l ec $status
+    if test $ec -eq %[3]d
+        set -l target (string replace --filter --regex '^%[2]s' '' -- $output)[-1]
+        if test -n "$target" -a -d "$target"
+            cd $target
+            return
+        end
+    end
+    test (count $output) -gt 0; and printf '%%s\n' $output
+    return $ec
+end
+`, CommandName, TargetPathPrefix, TargetPathExitCode)
+}
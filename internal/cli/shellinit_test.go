@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestGenerateShellInit verifies each wrapper runs the binary and acts on the target path exit code.
+func TestGenerateShellInit(t *testing.T) {
+	for _, shell := range Shells {
+		t.Run(shell, func(t *testing.T) {
+			script, err := GenerateShellInit(shell)
+			if err != nil {
+				t.Fatalf("GenerateShellInit(%q) failed: %v", shell, err)
+			}
+
+			if !strings.Contains(script, "command "+CommandName) {
+				t.Errorf("Script should run the %q binary:\n%s", CommandName, script)
+			}
+			if !strings.Contains(script, "-eq "+strconv.Itoa(TargetPathExitCode)) {
+				t.Errorf("Script should check exit code %d:\n%s", TargetPathExitCode, script)
+			}
+			if !strings.Contains(script, TargetPathPrefix) {
+				t.Errorf("Script should look for the %q line:\n%s", TargetPathPrefix, script)
+			}
+		})
+	}
+}
+
+// TestGenerateShellInitUnsupportedShell verifies an error for unknown shells.
+func TestGenerateShellInitUnsupportedShell(t *testing.T) {
+	_, err := GenerateShellInit("powershell")
+	if _, ok := err.(*UnsupportedShellError); !ok {
+		t.Errorf("Expected UnsupportedShellError, got %T", err)
+	}
+}
+
+// TestTargetPathLine verifies the target path line can be found among other output.
+func TestTargetPathLine(t *testing.T) {
+	output := "Goodbye!\n" + TargetPathLine("/src/repo feature") + "\n"
+
+	var found string
+	for _, line := range strings.Split(output, "\n") {
+		if path, ok := strings.CutPrefix(line, TargetPathPrefix); ok {
+			found = path
+		}
+	}
+	if found != "/src/repo feature" {
+		t.Errorf("Expected to find '/src/repo feature', got %q", found)
+	}
+}
+
+// TestPrintTargetPath verifies the target path is announced on its own line
+// with the exit code shell wrappers act on, as "grove add" and the TUI do.
+func TestPrintTargetPath(t *testing.T) {
+	var out strings.Builder
+	code := PrintTargetPath(&out, "/src/repo-feature")
+	if code != TargetPathExitCode {
+		t.Errorf("Expected exit code %d, got %d", TargetPathExitCode, code)
+	}
+	if want := "grove-cd:/src/repo-feature\n"; out.String() != want {
+		t.Errorf("Expected output %q, got %q", want, out.String())
+	}
+}
+
+// TestShellInitBash verifies the bash wrapper changes directory on exit code 2 and passes other output through.
+func TestShellInitBash(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+
+	// A stand-in binary that behaves like the TUI after choosing a worktree
+	binDir := t.TempDir()
+	target := t.TempDir()
+	fake := "#!/bin/sh\nif [ \"$1\" = list ]; then echo one; exit 0; fi\n" +
+		"echo Goodbye!\necho '" + TargetPathLine(target) + "'\nexit " + strconv.Itoa(TargetPathExitCode) + "\n"
+	if err := os.WriteFile(filepath.Join(binDir, CommandName), []byte(fake), 0755); err != nil {
+		t.Fatalf("Failed to write fake binary: %v", err)
+	}
+
+	script, err := GenerateShellInit("bash")
+	if err != nil {
+		t.Fatalf("GenerateShellInit failed: %v", err)
+	}
+	cmd := exec.Command(bash, "-c", script+"\n"+CommandName+" list; "+CommandName+"; pwd")
+	cmd.Env = append(os.Environ(), "PATH="+binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Wrapper failed: %v\n%s", err, output)
+	}
+
+	resolved, _ := filepath.EvalSymlinks(target)
+	want := "one\n" + resolved + "\n"
+	if got := string(output); got != want && got != "one\n"+target+"\n" {
+		t.Errorf("Expected output %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,81 @@
+// Package config handles application configuration including theme settings.
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// InvalidColorsError is returned when theme colors are not valid color
+// values. The listed colors fall back to their defaults; the rest of the
+// theme still applies.
+type InvalidColorsError struct {
+	// Keys are the config paths of the invalid colors, e.g.
+	// "theme.colors.primary.light".
+	Keys []string
+}
+
+func (e *InvalidColorsError) Error() string {
+	return fmt.Sprintf("invalid theme colors (using defaults for them): %s", strings.Join(e.Keys, ", "))
+}
+
+// IsValidColor reports whether value is a color lipgloss can render: a hex
+// color in #RGB or #RRGGBB form, or an ANSI color number from 0 to 255.
+func IsValidColor(value string) bool {
+	if hex, ok := strings.CutPrefix(value, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255 && strconv.Itoa(n) == value
+}
+
+// colorEntry is a theme color together with its key in the config file.
+type colorEntry struct {
+	key   string
+	color *AdaptiveColor
+}
+
+// entries returns every color of the palette with its key.
+func (c *ThemeColors) entries() []colorEntry {
+	return []colorEntry{
+		{"primary", &c.Primary},
+		{"on_primary", &c.OnPrimary},
+		{"text", &c.Text},
+		{"text_muted", &c.TextMuted},
+		{"border", &c.Border},
+		{"success", &c.Success},
+		{"error", &c.Error},
+		{"info", &c.Info},
+		{"on_success", &c.OnSuccess},
+		{"on_error", &c.OnError},
+		{"on_info", &c.OnInfo},
+	}
+}
+
+// validateThemeColors clears every set color in colors that is not valid,
+// so merging keeps the default in its place, and returns an
+// InvalidColorsError naming them. Unset colors are left alone.
+func validateThemeColors(colors *ThemeColors) error {
+	var keys []string
+	for _, entry := range colors.entries() {
+		if entry.color.Light != "" && !IsValidColor(entry.color.Light) {
+			keys = append(keys, "theme.colors."+entry.key+".light")
+			entry.color.Light = ""
+		}
+		if entry.color.Dark != "" && !IsValidColor(entry.color.Dark) {
+			keys = append(keys, "theme.colors."+entry.key+".dark")
+			entry.color.Dark = ""
+		}
+	}
+
+	if len(keys) > 0 {
+		return &InvalidColorsError{Keys: keys}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Package config handles application configuration including theme settings.
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsValidColor(t *testing.T) {
+	valid := []string{"#fff", "#FFF", "#7D56F4", "#abcdef", "0", "9", "196", "255"}
+	for _, value := range valid {
+		if !IsValidColor(value) {
+			t.Errorf("expected %q to be a valid color", value)
+		}
+	}
+
+	invalid := []string{"", "#", "#ff", "#ffff", "#fffffff", "#GGG", "#12345g", "red", "256", "-1", "007", "1.5", "ffffff", "# fff"}
+	for _, value := range invalid {
+		if IsValidColor(value) {
+			t.Errorf("expected %q to be an invalid color", value)
+		}
+	}
+}
+
+func TestValidateThemeColors(t *testing.T) {
+	colors := ThemeColors{
+		Primary: AdaptiveColor{Light: "#874BFD", Dark: "purple"},
+		Border:  AdaptiveColor{Light: "#1234"},
+		OnInfo:  AdaptiveColor{Dark: "15"},
+	}
+
+	err := validateThemeColors(&colors)
+	colorsErr, ok := err.(*InvalidColorsError)
+	if !ok {
+		t.Fatalf("expected InvalidColorsError, got: %v", err)
+	}
+	if strings.Join(colorsErr.Keys, ",") != "theme.colors.primary.dark,theme.colors.border.light" {
+		t.Errorf("unexpected invalid keys: %v", colorsErr.Keys)
+	}
+	if !strings.Contains(err.Error(), "theme.colors.border.light") {
+		t.Errorf("expected error to name the invalid keys, got: %v", err)
+	}
+
+	// Invalid colors are cleared so merging keeps the defaults
+	if colors.Primary.Dark != "" || colors.Border.Light != "" {
+		t.Error("expected invalid colors to be cleared")
+	}
+	if colors.Primary.Light != "#874BFD" || colors.OnInfo.Dark != "15" {
+		t.Error("expected valid colors to be kept")
+	}
+
+	empty := ThemeColors{}
+	if err := validateThemeColors(&empty); err != nil {
+		t.Errorf("expected unset colors to be valid, got: %v", err)
+	}
+}
+
+func TestLoadConfigInvalidColorsWithPreset(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	yamlContent := `theme:
+  preset: nord
+  colors:
+    primary:
+      dark: "oops"
+    success:
+      dark: "#123456"
+`
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	var colorsErr *InvalidColorsError
+	if !errors.As(err, &colorsErr) {
+		t.Fatalf("expected InvalidColorsError, got: %v", err)
+	}
+
+	// An invalid color falls back to the preset's, not the built-in default
+	nord, _ := PresetConfig("nord")
+	if cfg.Theme.Colors.Primary.Dark != nord.Theme.Colors.Primary.Dark {
+		t.Errorf("expected preset primary color, got: %s", cfg.Theme.Colors.Primary.Dark)
+	}
+	if cfg.Theme.Colors.Success.Dark != "#123456" {
+		t.Errorf("expected valid color to apply, got: %s", cfg.Theme.Colors.Success.Dark)
+	}
+}
+
+func TestLoadRepoConfigInvalidColors(t *testing.T) {
+	repoRoot := t.TempDir()
+	repoYAML := "theme:\n  colors:\n    text:\n      light: \"#zzzzzz\"\n      dark: \"#eeeeee\"\n"
+	if err := os.WriteFile(filepath.Join(repoRoot, RepoConfigFile), []byte(repoYAML), 0644); err != nil {
+		t.Fatalf("failed to write repository config: %v", err)
+	}
+
+	repoCfg, err := LoadRepoConfig(repoRoot)
+	var colorsErr *InvalidColorsError
+	if !errors.As(err, &colorsErr) || len(colorsErr.Keys) != 1 || colorsErr.Keys[0] != "theme.colors.text.light" {
+		t.Fatalf("expected InvalidColorsError for text.light, got: %v", err)
+	}
+
+	cfg := DefaultConfig().Merge(repoCfg)
+	if cfg.Theme.Colors.Text.Light != DefaultConfig().Theme.Colors.Text.Light || cfg.Theme.Colors.Text.Dark != "#eeeeee" {
+		t.Errorf("expected only the invalid color to fall back, got: %+v", cfg.Theme.Colors.Text)
+	}
+}
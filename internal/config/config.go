@@ -2,9 +2,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -39,12 +42,241 @@ type ThemeColors struct {
 
 // Theme defines the visual theme configuration.
 type Theme struct {
+	// Preset names a built-in color scheme (e.g. "dracula"). Colors set
+	// explicitly override individual preset colors.
+	Preset string      `yaml:"preset"`
 	Colors ThemeColors `yaml:"colors"`
 }
 
+// Hooks defines shell commands run at points in the worktree lifecycle.
+// An empty command disables the hook.
+type Hooks struct {
+	// PostCreate runs in the new worktree directory after it is created.
+	PostCreate string `yaml:"post_create"`
+}
+
+// Status controls how worktree status is computed.
+type Status struct {
+	// CountUntracked counts untracked files as changes, so they show in the
+	// counts and make a worktree dirty. Nil means the default (enabled).
+	CountUntracked *bool `yaml:"count_untracked"`
+}
+
+// Delete controls how worktrees are deleted.
+type Delete struct {
+	// UseTrash moves a deleted worktree's directory into grove's trash
+	// instead of removing it, so it can be restored later. Nil means the
+	// default (disabled).
+	UseTrash *bool `yaml:"use_trash"`
+	// ForceDefault pre-checks the force option of the delete confirmation.
+	// Nil means the default (unchecked).
+	ForceDefault *bool `yaml:"force_default"`
+}
+
+// Terminal controls how worktrees are opened in a terminal.
+type Terminal struct {
+	// OpenIn is where iTerm2 and Terminal.app open a worktree on macOS:
+	// TerminalOpenInWindow or TerminalOpenInTab. Other values and empty
+	// mean a new window.
+	OpenIn string `yaml:"open_in"`
+}
+
+// Worktree controls where new worktrees are created.
+type Worktree struct {
+	// BaseDir is the directory new worktrees go in when no path is given,
+	// e.g. "~/worktrees/{repo}". {repo} and {branch} are replaced by the
+	// repository and branch names; without {branch} the branch name is
+	// appended. Empty means a sibling of the main worktree.
+	BaseDir string `yaml:"base_dir"`
+	// FetchDefaultBranch fetches before creating a branch off the default
+	// branch, so it starts at the remote's latest commit. Nil means the
+	// default (disabled).
+	FetchDefaultBranch *bool `yaml:"fetch_default_branch"`
+}
+
+// Refresh controls how the worktree list is kept up to date.
+type Refresh struct {
+	// IntervalSeconds reloads the worktrees and their status this often
+	// while the application is idle. Zero disables it. Nil means the
+	// default (disabled).
+	IntervalSeconds *int `yaml:"interval_seconds"`
+	// Watch refreshes the selected worktree's status when its files change,
+	// and the list when worktrees are added or removed, instead of polling.
+	// Nil means the default (disabled).
+	Watch *bool `yaml:"watch"`
+}
+
+// List controls what the worktree list shows.
+type List struct {
+	// HideMain leaves the main worktree out of the Worktrees tab; the
+	// Branches tab still lists it. Nil means the default (disabled).
+	HideMain *bool `yaml:"hide_main"`
+}
+
+// Values for Terminal.OpenIn.
+const (
+	TerminalOpenInWindow = "window"
+	TerminalOpenInTab    = "tab"
+)
+
 // Config represents the application configuration.
 type Config struct {
-	Theme Theme `yaml:"theme"`
+	Theme  Theme  `yaml:"theme"`
+	Hooks  Hooks  `yaml:"hooks"`
+	Status Status `yaml:"status"`
+	Delete Delete `yaml:"delete"`
+	// Terminal controls how worktrees are opened in a terminal.
+	Terminal Terminal `yaml:"terminal"`
+	// Worktree controls where new worktrees are created.
+	Worktree Worktree `yaml:"worktree"`
+	// Refresh controls how the worktree list is kept up to date.
+	Refresh Refresh `yaml:"refresh"`
+	// List controls what the worktree list shows.
+	List List `yaml:"list"`
+	// CopyOnCreate lists files (relative paths or globs) copied from the
+	// main worktree into newly created worktrees, e.g. ".env".
+	CopyOnCreate []string `yaml:"copy_on_create"`
+	// RememberState restores the last active tab and selected worktree on
+	// startup. Nil means the default (enabled).
+	RememberState *bool `yaml:"remember_state"`
+	// ConfirmDelete asks for confirmation before deleting a clean worktree.
+	// Dirty worktrees and ones with unpushed commits always ask. Nil means the default (enabled).
+	ConfirmDelete *bool `yaml:"confirm_delete"`
+	// RecentCommits is how many recent commits the details pane shows.
+	// Zero hides the section. Nil means the default (DefaultRecentCommits).
+	RecentCommits *int `yaml:"recent_commits"`
+	// StatusCacheTTL is how long a worktree's git status is reused between
+	// refreshes, e.g. "3s". Zero disables caching. Nil means the default.
+	StatusCacheTTL *time.Duration `yaml:"status_cache_ttl"`
+	// GitTimeout limits how long a single git command may run before it is
+	// stopped, e.g. "10s". Zero disables the limit. Nil means the default.
+	GitTimeout *time.Duration `yaml:"git_timeout"`
+	// DiskUsage shows the size of the selected worktree in the details pane.
+	// Disable it for huge worktrees. Nil means the default (enabled).
+	DiskUsage *bool `yaml:"disk_usage"`
+	// RowNumbers prefixes each list row with its 1-based index, e.g.
+	// "1. main". Nil means the default (disabled).
+	RowNumbers *bool `yaml:"row_numbers"`
+	// BulkOpenLimit is how many terminals opening all dirty worktrees may
+	// spawn before asking for confirmation. Nil means the default
+	// (DefaultBulkOpenLimit).
+	BulkOpenLimit *int `yaml:"bulk_open_limit"`
+}
+
+// DefaultRecentCommits is the number of recent commits shown by default.
+const DefaultRecentCommits = 5
+
+// DefaultBulkOpenLimit is how many terminals may open at once without confirmation.
+const DefaultBulkOpenLimit = 5
+
+// DefaultStatusCacheTTL is how long a worktree status is reused by default.
+const DefaultStatusCacheTTL = 3 * time.Second
+
+// DefaultGitTimeout is how long a git command may run by default.
+const DefaultGitTimeout = 10 * time.Second
+
+// RememberStateEnabled reports whether UI state should persist between runs.
+func (c Config) RememberStateEnabled() bool {
+	return c.RememberState == nil || *c.RememberState
+}
+
+// ConfirmDeleteEnabled reports whether deleting a clean worktree requires confirmation.
+func (c Config) ConfirmDeleteEnabled() bool {
+	return c.ConfirmDelete == nil || *c.ConfirmDelete
+}
+
+// CountUntrackedEnabled reports whether untracked files count as worktree changes.
+func (c Config) CountUntrackedEnabled() bool {
+	return c.Status.CountUntracked == nil || *c.Status.CountUntracked
+}
+
+// UseTrashEnabled reports whether deleted worktree directories are moved to the trash.
+func (c Config) UseTrashEnabled() bool {
+	return c.Delete.UseTrash != nil && *c.Delete.UseTrash
+}
+
+// DeleteForceDefault reports whether the delete confirmation opens with
+// the force option checked.
+func (c Config) DeleteForceDefault() bool {
+	return c.Delete.ForceDefault != nil && *c.Delete.ForceDefault
+}
+
+// HideMainEnabled reports whether the main worktree is left out of the
+// Worktrees tab.
+func (c Config) HideMainEnabled() bool {
+	return c.List.HideMain != nil && *c.List.HideMain
+}
+
+// FetchDefaultBranchEnabled reports whether new branches off the default
+// branch start at the freshly fetched remote branch.
+func (c Config) FetchDefaultBranchEnabled() bool {
+	return c.Worktree.FetchDefaultBranch != nil && *c.Worktree.FetchDefaultBranch
+}
+
+// OpenTerminalInTab reports whether terminals open worktrees in a new tab
+// rather than a new window.
+func (c Config) OpenTerminalInTab() bool {
+	return c.Terminal.OpenIn == TerminalOpenInTab
+}
+
+// RefreshInterval returns how often the worktree list is refreshed
+// automatically. Zero (or a negative value) means never.
+func (c Config) RefreshInterval() time.Duration {
+	if c.Refresh.IntervalSeconds == nil {
+		return 0
+	}
+	return time.Duration(max(*c.Refresh.IntervalSeconds, 0)) * time.Second
+}
+
+// WatchEnabled reports whether file changes trigger refreshes.
+func (c Config) WatchEnabled() bool {
+	return c.Refresh.Watch != nil && *c.Refresh.Watch
+}
+
+// DiskUsageEnabled reports whether the details pane shows worktree disk usage.
+func (c Config) DiskUsageEnabled() bool {
+	return c.DiskUsage == nil || *c.DiskUsage
+}
+
+// RowNumbersEnabled reports whether list rows are prefixed with their index.
+func (c Config) RowNumbersEnabled() bool {
+	return c.RowNumbers != nil && *c.RowNumbers
+}
+
+// RecentCommitsCount returns how many recent commits the details pane shows.
+// Negative values are treated as zero.
+func (c Config) RecentCommitsCount() int {
+	if c.RecentCommits == nil {
+		return DefaultRecentCommits
+	}
+	return max(*c.RecentCommits, 0)
+}
+
+// BulkOpenLimitCount returns how many terminals may be opened at once
+// without confirmation. Negative values are treated as zero (always confirm).
+func (c Config) BulkOpenLimitCount() int {
+	if c.BulkOpenLimit == nil {
+		return DefaultBulkOpenLimit
+	}
+	return max(*c.BulkOpenLimit, 0)
+}
+
+// StatusCacheTTLDuration returns how long a worktree status is reused.
+// Negative values are treated as zero (caching disabled).
+func (c Config) StatusCacheTTLDuration() time.Duration {
+	if c.StatusCacheTTL == nil {
+		return DefaultStatusCacheTTL
+	}
+	return max(*c.StatusCacheTTL, 0)
+}
+
+// GitTimeoutDuration returns how long a git command may run.
+// Zero (or a negative value) means no limit.
+func (c Config) GitTimeoutDuration() time.Duration {
+	if c.GitTimeout == nil {
+		return DefaultGitTimeout
+	}
+	return max(*c.GitTimeout, 0)
 }
 
 // DefaultConfig returns the default configuration with the built-in color scheme.
@@ -93,9 +325,52 @@ func DefaultConfigPath() string {
 	return filepath.Join(configDir, "grove", "config.yaml")
 }
 
+// ResolveWorktreePath returns where the worktree for branch goes under the
+// base directory template base, e.g. "~/worktrees/{repo}". {repo} and
+// {branch} are replaced by repo and branch, with slashes in the branch
+// name turned into dashes so it stays one directory; without {branch} the
+// branch name is appended. A leading "~" is the home directory. Returns ""
+// if base is empty.
+func ResolveWorktreePath(base, repo, branch string) string {
+	if base == "" {
+		return ""
+	}
+
+	name := strings.ReplaceAll(branch, "/", "-")
+	path := strings.ReplaceAll(base, "{repo}", repo)
+	if strings.Contains(path, "{branch}") {
+		path = strings.ReplaceAll(path, "{branch}", name)
+	} else {
+		path = filepath.Join(path, name)
+	}
+
+	if rest, ok := strings.CutPrefix(path, "~"); ok && (rest == "" || rest[0] == '/' || rest[0] == filepath.Separator) {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + rest
+		}
+	}
+	return filepath.Clean(path)
+}
+
+// ConfigPathEnv names the environment variable that points grove at a
+// config file other than the default one.
+const ConfigPathEnv = "GROVE_CONFIG"
+
+// ResolveConfigPath returns the path of the configuration file to use: the
+// full path in $GROVE_CONFIG when set, otherwise DefaultConfigPath.
+func ResolveConfigPath() string {
+	if path := os.Getenv(ConfigPathEnv); path != "" {
+		return path
+	}
+	return DefaultConfigPath()
+}
+
 // LoadConfig loads configuration from the specified path.
 // If the file doesn't exist, returns default configuration with no error.
 // If the file exists but is invalid, returns default configuration with an error.
+// An unknown theme preset falls back to the default colors and invalid colors
+// fall back to their defaults individually; both return an error as a warning,
+// while the rest of the file is still applied.
 func LoadConfig(path string) (Config, error) {
 	cfg := DefaultConfig()
 
@@ -114,15 +389,166 @@ func LoadConfig(path string) (Config, error) {
 		return cfg, fmt.Errorf("parsing config file: %w", err)
 	}
 
+	// Apply the preset first so explicit colors can override it
+	var presetErr error
+	if name := fileCfg.Theme.Preset; name != "" {
+		if preset, ok := PresetConfig(name); ok {
+			cfg.Theme = preset.Theme
+		} else {
+			presetErr = unknownPresetError(name)
+		}
+	}
+
+	// Invalid colors are dropped so each falls back to its default
+	colorsErr := validateThemeColors(&fileCfg.Theme.Colors)
+
 	// Merge file config with defaults (file values override defaults)
 	mergeConfig(&cfg, &fileCfg)
 
-	return cfg, nil
+	return cfg, errors.Join(presetErr, colorsErr)
+}
+
+// RepoConfigFile is the name of the per-repository config file, read from
+// the top-level directory of the repository.
+const RepoConfigFile = ".grove.yaml"
+
+// LoadRepoConfig loads the per-repository overrides from RepoConfigFile in
+// repoRoot. Unlike LoadConfig it does not fill in defaults: options the file
+// leaves out stay unset, so the result can be applied with Merge.
+// If the file doesn't exist, returns an empty configuration with no error.
+// An unknown theme preset and invalid colors are dropped and returned as a
+// warning, while the rest of the file is still returned.
+func LoadRepoConfig(repoRoot string) (Config, error) {
+	data, err := os.ReadFile(filepath.Join(repoRoot, RepoConfigFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Config{}, nil
+		}
+		return Config{}, fmt.Errorf("reading repository config file: %w", err)
+	}
+
+	var repoCfg Config
+	if err := yaml.Unmarshal(data, &repoCfg); err != nil {
+		return Config{}, fmt.Errorf("parsing repository config file: %w", err)
+	}
+
+	var presetErr error
+	if name := repoCfg.Theme.Preset; name != "" {
+		if _, ok := PresetConfig(name); !ok {
+			repoCfg.Theme.Preset = ""
+			presetErr = unknownPresetError(name)
+		}
+	}
+	colorsErr := validateThemeColors(&repoCfg.Theme.Colors)
+
+	return repoCfg, errors.Join(presetErr, colorsErr)
+}
+
+// Merge returns c with the options set in override applied on top, e.g. a
+// per-repository config over the user config. A theme preset named in
+// override replaces the colors first, so its explicit colors still win.
+func (c Config) Merge(override Config) Config {
+	merged := c
+	if preset, ok := PresetConfig(override.Theme.Preset); ok {
+		merged.Theme = preset.Theme
+	}
+	mergeConfig(&merged, &override)
+	return merged
+}
+
+// unknownPresetError reports a theme preset name that is not built in.
+func unknownPresetError(name string) error {
+	return fmt.Errorf("unknown theme preset %q (available: %s)", name, strings.Join(PresetNames(), ", "))
 }
 
 // mergeConfig merges source config into dest, overriding only non-empty values.
 func mergeConfig(dest, source *Config) {
 	mergeTheme(&dest.Theme, &source.Theme)
+	mergeHooks(&dest.Hooks, &source.Hooks)
+	mergeStatus(&dest.Status, &source.Status)
+	mergeDelete(&dest.Delete, &source.Delete)
+	mergeTerminal(&dest.Terminal, &source.Terminal)
+	mergeWorktree(&dest.Worktree, &source.Worktree)
+	mergeRefresh(&dest.Refresh, &source.Refresh)
+	mergeList(&dest.List, &source.List)
+	if len(source.CopyOnCreate) > 0 {
+		dest.CopyOnCreate = source.CopyOnCreate
+	}
+	if source.RememberState != nil {
+		dest.RememberState = source.RememberState
+	}
+	if source.ConfirmDelete != nil {
+		dest.ConfirmDelete = source.ConfirmDelete
+	}
+	if source.RecentCommits != nil {
+		dest.RecentCommits = source.RecentCommits
+	}
+	if source.StatusCacheTTL != nil {
+		dest.StatusCacheTTL = source.StatusCacheTTL
+	}
+	if source.GitTimeout != nil {
+		dest.GitTimeout = source.GitTimeout
+	}
+	if source.DiskUsage != nil {
+		dest.DiskUsage = source.DiskUsage
+	}
+	if source.RowNumbers != nil {
+		dest.RowNumbers = source.RowNumbers
+	}
+	if source.BulkOpenLimit != nil {
+		dest.BulkOpenLimit = source.BulkOpenLimit
+	}
+}
+
+func mergeHooks(dest, source *Hooks) {
+	if source.PostCreate != "" {
+		dest.PostCreate = source.PostCreate
+	}
+}
+
+func mergeStatus(dest, source *Status) {
+	if source.CountUntracked != nil {
+		dest.CountUntracked = source.CountUntracked
+	}
+}
+
+func mergeDelete(dest, source *Delete) {
+	if source.UseTrash != nil {
+		dest.UseTrash = source.UseTrash
+	}
+	if source.ForceDefault != nil {
+		dest.ForceDefault = source.ForceDefault
+	}
+}
+
+func mergeTerminal(dest, source *Terminal) {
+	if source.OpenIn != "" {
+		dest.OpenIn = source.OpenIn
+	}
+}
+
+func mergeWorktree(dest, source *Worktree) {
+	if source.BaseDir != "" {
+		dest.BaseDir = source.BaseDir
+	}
+	if source.FetchDefaultBranch != nil {
+		dest.FetchDefaultBranch = source.FetchDefaultBranch
+	}
+}
+
+func mergeRefresh(dest, source *Refresh) {
+	if source.IntervalSeconds != nil {
+		dest.IntervalSeconds = source.IntervalSeconds
+	}
+	if source.Watch != nil {
+		dest.Watch = source.Watch
+	}
+}
+
+func mergeList(dest, source *List) {
+	if source.HideMain != nil {
+		dest.HideMain = source.HideMain
+	}
 }
 
 func mergeTheme(dest, source *Theme) {
@@ -154,14 +580,21 @@ func mergeAdaptiveColor(dest, source *AdaptiveColor) {
 
 // GenerateSampleConfig generates a sample configuration YAML string with comments.
 func GenerateSampleConfig() string {
-	return `# Grove Theme Configuration
-# This file allows customization of the application's color scheme.
-# Colors use hex format (#RRGGBB) and support light/dark terminal themes.
+	return `# Grove Configuration
+# This file allows customization of the application's color scheme and behavior.
+# Colors use hex format (#RRGGBB or #RGB) or ANSI color numbers (0-255) and
+# support light/dark terminal themes. Invalid colors fall back to the defaults.
 #
 # Location: ~/.config/grove/config.yaml
-# Changes require application restart to take effect.
+# A .grove.yaml at the top level of a repository overrides these settings there.
+# Theme changes apply while grove is running; other options take effect on the
+# next start. Press R on the Settings tab to reload the theme on demand.
 
 theme:
+  # Built-in color scheme: default, dracula, nord, solarized-dark.
+  # Colors below override individual preset colors.
+  preset: default
+
   colors:
     # Primary accent color (used for selection, active states)
     primary:
@@ -214,6 +647,85 @@ theme:
     on_info:
       light: "#FFFFFF"
       dark: "#FFFFFF"
+
+# Commands run during the worktree lifecycle (empty = disabled).
+# post_create runs in the new worktree directory with GROVE_WORKTREE_PATH
+# and GROVE_BRANCH set. A failing hook does not remove the worktree.
+hooks:
+  post_create: ""
+
+# How worktree status is computed.
+# count_untracked: count untracked files (e.g. build output) as changes.
+status:
+  count_untracked: true
+
+# How worktrees are deleted.
+# use_trash: move the directory to ~/.local/share/grove/trash instead of
+# removing it, so it can be restored later.
+# force_default: open the delete confirmation with "Force removal" checked.
+delete:
+  use_trash: false
+  force_default: false
+
+# Where new worktrees go when the create form's path is left empty.
+# base_dir: e.g. "~/worktrees/{repo}"; {repo} and {branch} are replaced by
+# the repository and branch names (slashes become dashes). Without {branch}
+# the branch name is appended. Empty = next to the main worktree.
+worktree:
+  base_dir: ""
+
+# Keep the worktree list and status counts current while grove is open.
+# interval_seconds: reload this often; 0 = only on changes made in grove.
+# watch: refresh when files change instead (falls back to polling every
+# 10 seconds, or interval_seconds if set, when watching fails).
+refresh:
+  interval_seconds: 0
+  watch: false
+
+# What the worktree list shows.
+# hide_main: leave the main worktree out of the Worktrees tab; the Branches
+# tab still lists it.
+list:
+  hide_main: false
+
+# How worktrees open in a terminal.
+# open_in: "window" or "tab" (iTerm2 and Terminal.app on macOS only; tabs in
+# Terminal.app need accessibility permission for System Events).
+terminal:
+  open_in: window
+
+# Untracked files copied from the main worktree into new worktrees.
+# Entries are relative paths or glob patterns; missing files are skipped.
+copy_on_create: []
+
+# Restore the last active tab and selected worktree on startup.
+remember_state: true
+
+# Ask before deleting a clean worktree. Worktrees with uncommitted
+# changes or unpushed commits always ask, regardless of this setting.
+confirm_delete: true
+
+# Number of recent commits shown in the details pane (0 hides them).
+recent_commits: 5
+
+# How long a worktree's git status is reused between refreshes (0 disables).
+status_cache_ttl: 3s
+
+# Stop git commands that run longer than this, e.g. on a hung network
+# filesystem (0 disables the limit).
+git_timeout: 10s
+
+# Show the disk usage of the selected worktree in the details pane.
+# It is computed in the background; disable it for huge worktrees.
+disk_usage: true
+
+# Prefix each list row with its number, e.g. "1. main". Typing a number
+# jumps to that row either way.
+row_numbers: false
+
+# Opening all dirty worktrees (O) asks for confirmation before spawning more
+# terminals than this.
+bulk_open_limit: 5
 `
 }
 
@@ -234,3 +746,25 @@ func WriteSampleConfig(path string) error {
 
 	return nil
 }
+
+// ConfigExistsError is returned when writing a config file would overwrite an existing one.
+type ConfigExistsError struct {
+	Path string
+}
+
+func (e *ConfigExistsError) Error() string {
+	return fmt.Sprintf("config file already exists: %s (use --force to overwrite)", e.Path)
+}
+
+// WriteDefaultConfig writes the fully-commented default configuration to the
+// specified path, creating parent directories. An existing file is only
+// overwritten when force is true; otherwise a ConfigExistsError is returned.
+func WriteDefaultConfig(path string, force bool) error {
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			return &ConfigExistsError{Path: path}
+		}
+	}
+
+	return WriteSampleConfig(path)
+}
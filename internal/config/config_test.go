@@ -2,9 +2,12 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -42,6 +45,29 @@ func TestDefaultConfigPath(t *testing.T) {
 	}
 }
 
+func TestResolveConfigPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ConfigPathEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	if got, want := ResolveConfigPath(), filepath.Join(home, ".config", "grove", "config.yaml"); got != want {
+		t.Errorf("expected %s without env vars, got: %s", want, got)
+	}
+
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	if got, want := ResolveConfigPath(), filepath.Join(xdg, "grove", "config.yaml"); got != want {
+		t.Errorf("expected %s with XDG_CONFIG_HOME, got: %s", want, got)
+	}
+
+	custom := filepath.Join(t.TempDir(), "grove.yaml")
+	t.Setenv(ConfigPathEnv, custom)
+	if got := ResolveConfigPath(); got != custom {
+		t.Errorf("expected GROVE_CONFIG to win, got: %s", got)
+	}
+}
+
 func TestLoadConfigNoFile(t *testing.T) {
 	// Load from a non-existent path should return defaults
 	cfg, err := LoadConfig("/non/existent/path/config.yaml")
@@ -96,7 +122,7 @@ func TestLoadConfigPartialYAML(t *testing.T) {
 	yamlContent := `theme:
   colors:
     primary:
-      light: "#CUSTOM"
+      light: "#ABCDEF"
 `
 
 	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
@@ -109,8 +135,8 @@ func TestLoadConfigPartialYAML(t *testing.T) {
 	}
 
 	// Custom value should be applied
-	if cfg.Theme.Colors.Primary.Light != "#CUSTOM" {
-		t.Errorf("expected Primary.Light to be '#CUSTOM', got: %s", cfg.Theme.Colors.Primary.Light)
+	if cfg.Theme.Colors.Primary.Light != "#ABCDEF" {
+		t.Errorf("expected Primary.Light to be '#ABCDEF', got: %s", cfg.Theme.Colors.Primary.Light)
 	}
 
 	// Default values should still be present for unspecified fields
@@ -146,7 +172,7 @@ func TestLoadConfigInvalidYAML(t *testing.T) {
 }
 
 func TestLoadConfigMalformedColors(t *testing.T) {
-	// Test that malformed color values are handled gracefully
+	// Invalid colors fall back to their defaults one by one
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.yaml")
 
@@ -154,7 +180,14 @@ func TestLoadConfigMalformedColors(t *testing.T) {
   colors:
     primary:
       light: "not-a-color"
-      dark: "#VALID"
+      dark: "#abc"
+    text:
+      light: "#12345"
+      dark: "#EEEEEE"
+    error:
+      dark: "196"
+    info:
+      light: "#GGGGGG"
 `
 
 	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
@@ -162,13 +195,22 @@ func TestLoadConfigMalformedColors(t *testing.T) {
 	}
 
 	cfg, err := LoadConfig(configPath)
-	if err != nil {
-		t.Fatalf("failed to load config: %v", err)
+	var colorsErr *InvalidColorsError
+	if !errors.As(err, &colorsErr) {
+		t.Fatalf("expected InvalidColorsError, got: %v", err)
+	}
+	wantKeys := []string{"theme.colors.primary.light", "theme.colors.text.light", "theme.colors.info.light"}
+	if strings.Join(colorsErr.Keys, ",") != strings.Join(wantKeys, ",") {
+		t.Errorf("expected invalid keys %v, got: %v", wantKeys, colorsErr.Keys)
 	}
 
-	// The value should be loaded as-is (lipgloss will handle it)
-	if cfg.Theme.Colors.Primary.Light != "not-a-color" {
-		t.Errorf("expected color value to be loaded as-is")
+	defaults := DefaultConfig().Theme.Colors
+	colors := cfg.Theme.Colors
+	if colors.Primary.Light != defaults.Primary.Light || colors.Text.Light != defaults.Text.Light || colors.Info.Light != defaults.Info.Light {
+		t.Error("expected invalid colors to fall back to their defaults")
+	}
+	if colors.Primary.Dark != "#abc" || colors.Text.Dark != "#EEEEEE" || colors.Error.Dark != "196" {
+		t.Error("expected valid colors to still apply")
 	}
 }
 
@@ -226,6 +268,11 @@ func TestGenerateSampleConfig(t *testing.T) {
 	if !contains(sample, "primary:") {
 		t.Error("expected sample to contain 'primary:' color")
 	}
+
+	// Theme changes are reloaded live; the header must not say otherwise
+	if !contains(sample, "Theme changes apply while grove is running") || contains(sample, "require application restart") {
+		t.Error("expected sample to say theme changes apply without a restart")
+	}
 }
 
 func TestWriteSampleConfig(t *testing.T) {
@@ -253,6 +300,661 @@ func TestWriteSampleConfig(t *testing.T) {
 	}
 }
 
+func TestLoadConfigHooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	yamlContent := `hooks:
+  post_create: "npm install"
+`
+
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if cfg.Hooks.PostCreate != "npm install" {
+		t.Errorf("expected Hooks.PostCreate to be 'npm install', got: %s", cfg.Hooks.PostCreate)
+	}
+
+	// Hooks are disabled by default
+	if DefaultConfig().Hooks.PostCreate != "" {
+		t.Error("expected post_create hook to be disabled by default")
+	}
+}
+
+func TestLoadConfigCopyOnCreate(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	yamlContent := `copy_on_create: [".env", ".env.local"]
+`
+
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if len(cfg.CopyOnCreate) != 2 || cfg.CopyOnCreate[0] != ".env" || cfg.CopyOnCreate[1] != ".env.local" {
+		t.Errorf("expected copy_on_create [.env .env.local], got: %v", cfg.CopyOnCreate)
+	}
+}
+
+func TestLoadConfigRememberState(t *testing.T) {
+	if !DefaultConfig().RememberStateEnabled() {
+		t.Error("expected remember_state to be enabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("remember_state: false\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.RememberStateEnabled() {
+		t.Error("expected remember_state: false to disable state persistence")
+	}
+}
+
+func TestLoadConfigConfirmDelete(t *testing.T) {
+	if !DefaultConfig().ConfirmDeleteEnabled() {
+		t.Error("expected confirm_delete to be enabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("confirm_delete: false\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.ConfirmDeleteEnabled() {
+		t.Error("expected confirm_delete: false to disable delete confirmation")
+	}
+}
+
+func TestLoadConfigRecentCommits(t *testing.T) {
+	if got := DefaultConfig().RecentCommitsCount(); got != DefaultRecentCommits {
+		t.Errorf("expected default recent commits %d, got %d", DefaultRecentCommits, got)
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("recent_commits: 0\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if got := cfg.RecentCommitsCount(); got != 0 {
+		t.Errorf("expected recent_commits: 0 to hide commits, got %d", got)
+	}
+}
+
+func TestLoadConfigStatusCacheTTL(t *testing.T) {
+	if got := DefaultConfig().StatusCacheTTLDuration(); got != DefaultStatusCacheTTL {
+		t.Errorf("expected default status cache TTL %v, got %v", DefaultStatusCacheTTL, got)
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("status_cache_ttl: 10s\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if got := cfg.StatusCacheTTLDuration(); got != 10*time.Second {
+		t.Errorf("expected status_cache_ttl: 10s, got %v", got)
+	}
+}
+
+func TestLoadConfigGitTimeout(t *testing.T) {
+	if got := DefaultConfig().GitTimeoutDuration(); got != DefaultGitTimeout {
+		t.Errorf("expected default git timeout %v, got %v", DefaultGitTimeout, got)
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("git_timeout: 0s\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if got := cfg.GitTimeoutDuration(); got != 0 {
+		t.Errorf("expected git_timeout: 0s to disable the limit, got %v", got)
+	}
+}
+
+func TestLoadConfigDiskUsage(t *testing.T) {
+	if !DefaultConfig().DiskUsageEnabled() {
+		t.Error("expected disk usage to be enabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("disk_usage: false\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.DiskUsageEnabled() {
+		t.Error("expected disk_usage: false to disable disk usage")
+	}
+}
+
+func TestLoadConfigRowNumbers(t *testing.T) {
+	if DefaultConfig().RowNumbersEnabled() {
+		t.Error("expected row numbers to be disabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("row_numbers: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.RowNumbersEnabled() {
+		t.Error("expected row_numbers: true to enable row numbers")
+	}
+}
+
+func TestLoadConfigBulkOpenLimit(t *testing.T) {
+	if DefaultConfig().BulkOpenLimitCount() != DefaultBulkOpenLimit {
+		t.Errorf("expected default bulk open limit %d, got %d", DefaultBulkOpenLimit, DefaultConfig().BulkOpenLimitCount())
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("bulk_open_limit: 2\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.BulkOpenLimitCount() != 2 {
+		t.Errorf("expected bulk open limit 2, got %d", cfg.BulkOpenLimitCount())
+	}
+
+	negative := -3
+	cfg.BulkOpenLimit = &negative
+	if cfg.BulkOpenLimitCount() != 0 {
+		t.Errorf("expected negative limit to be treated as zero, got %d", cfg.BulkOpenLimitCount())
+	}
+}
+
+func TestLoadConfigCountUntracked(t *testing.T) {
+	if !DefaultConfig().CountUntrackedEnabled() {
+		t.Error("expected untracked files to be counted by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("status:\n  count_untracked: false\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.CountUntrackedEnabled() {
+		t.Error("expected status.count_untracked: false to stop counting untracked files")
+	}
+}
+
+func TestLoadConfigUseTrash(t *testing.T) {
+	if DefaultConfig().UseTrashEnabled() {
+		t.Error("expected trash to be disabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("delete:\n  use_trash: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.UseTrashEnabled() {
+		t.Error("expected delete.use_trash: true to enable the trash")
+	}
+}
+
+func TestLoadConfigDeleteForceDefault(t *testing.T) {
+	if DefaultConfig().DeleteForceDefault() {
+		t.Error("expected force to be unchecked by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("delete:\n  force_default: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.DeleteForceDefault() {
+		t.Error("expected delete.force_default: true to check force")
+	}
+	if cfg.UseTrashEnabled() {
+		t.Error("expected use_trash to keep its default")
+	}
+}
+
+func TestLoadConfigFetchDefaultBranch(t *testing.T) {
+	if DefaultConfig().FetchDefaultBranchEnabled() {
+		t.Error("expected fetching the default branch to be disabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("worktree:\n  fetch_default_branch: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.FetchDefaultBranchEnabled() {
+		t.Error("expected worktree.fetch_default_branch: true to enable fetching")
+	}
+
+	merged := DefaultConfig().Merge(Config{Worktree: Worktree{BaseDir: "~/worktrees"}})
+	if merged.FetchDefaultBranchEnabled() {
+		t.Error("expected a merge without the option to keep the default")
+	}
+}
+
+func TestLoadConfigHideMain(t *testing.T) {
+	if DefaultConfig().HideMainEnabled() {
+		t.Error("expected the main worktree to be listed by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("list:\n  hide_main: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.HideMainEnabled() {
+		t.Error("expected list.hide_main: true to hide the main worktree")
+	}
+
+	merged := cfg.Merge(Config{Worktree: Worktree{BaseDir: "~/worktrees"}})
+	if !merged.HideMainEnabled() {
+		t.Error("expected a merge without the option to keep it")
+	}
+}
+
+func TestLoadConfigTerminalOpenIn(t *testing.T) {
+	if DefaultConfig().OpenTerminalInTab() {
+		t.Error("expected terminals to open in a window by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("terminal:\n  open_in: tab\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.OpenTerminalInTab() {
+		t.Error("expected terminal.open_in: tab to open tabs")
+	}
+
+	cfg.Terminal.OpenIn = "split"
+	if cfg.OpenTerminalInTab() {
+		t.Error("expected unknown values to open a window")
+	}
+}
+
+func TestLoadConfigWorktreeBaseDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("worktree:\n  base_dir: ~/worktrees/{repo}\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Worktree.BaseDir != "~/worktrees/{repo}" {
+		t.Errorf("expected base_dir to load, got %q", cfg.Worktree.BaseDir)
+	}
+}
+
+func TestResolveWorktreePath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name   string
+		base   string
+		branch string
+		want   string
+	}{
+		{"no base", "", "feature", ""},
+		{"appends branch", "/wt/{repo}", "feature", "/wt/grove/feature"},
+		{"slashes become dashes", "/wt/{repo}", "feature/login", "/wt/grove/feature-login"},
+		{"branch placeholder", "/wt/{repo}-{branch}", "fix/typo", "/wt/grove-fix-typo"},
+		{"home", "~/worktrees/{repo}", "main", filepath.Join(home, "worktrees", "grove", "main")},
+		{"tilde inside a name", "/wt/~{repo}", "main", "/wt/~grove/main"},
+		{"cleaned", "/wt//{repo}/", "main", "/wt/grove/main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveWorktreePath(tt.base, "grove", tt.branch); got != tt.want {
+				t.Errorf("ResolveWorktreePath(%q, grove, %q) = %q, want %q", tt.base, tt.branch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPresetConfig(t *testing.T) {
+	for _, name := range PresetNames() {
+		cfg, ok := PresetConfig(name)
+		if !ok {
+			t.Errorf("expected preset %q to resolve", name)
+			continue
+		}
+		if cfg.Theme.Preset != name {
+			t.Errorf("expected Theme.Preset %q, got %q", name, cfg.Theme.Preset)
+		}
+		if cfg.Theme.Colors.Primary.Light == "" || cfg.Theme.Colors.Primary.Dark == "" {
+			t.Errorf("expected preset %q to define primary color", name)
+		}
+	}
+
+	cfg, ok := PresetConfig("dracula")
+	if !ok || cfg.Theme.Colors.Primary.Dark != "#BD93F9" {
+		t.Errorf("expected dracula primary '#BD93F9', got: %s", cfg.Theme.Colors.Primary.Dark)
+	}
+
+	if _, ok := PresetConfig("no-such-preset"); ok {
+		t.Error("expected unknown preset to not resolve")
+	}
+}
+
+func TestLoadConfigPresetWithOverride(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	yamlContent := `theme:
+  preset: nord
+  colors:
+    primary:
+      dark: "#123456"
+`
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	nord, _ := PresetConfig("nord")
+	if cfg.Theme.Colors.Primary.Dark != "#123456" {
+		t.Errorf("expected explicit color to override preset, got: %s", cfg.Theme.Colors.Primary.Dark)
+	}
+	if cfg.Theme.Colors.Primary.Light != nord.Theme.Colors.Primary.Light {
+		t.Errorf("expected unset color to come from preset, got: %s", cfg.Theme.Colors.Primary.Light)
+	}
+	if cfg.Theme.Colors.Success != nord.Theme.Colors.Success {
+		t.Error("expected other colors to come from preset")
+	}
+}
+
+func TestLoadConfigUnknownPreset(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	yamlContent := `theme:
+  preset: no-such-preset
+hooks:
+  post_create: "make setup"
+`
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err == nil {
+		t.Error("expected warning error for unknown preset")
+	} else if !contains(err.Error(), "no-such-preset") {
+		t.Errorf("expected error to name the preset, got: %v", err)
+	}
+
+	// Colors fall back to defaults, other settings still apply
+	if cfg.Theme.Colors != DefaultConfig().Theme.Colors {
+		t.Error("expected default colors for unknown preset")
+	}
+	if cfg.Hooks.PostCreate != "make setup" {
+		t.Error("expected other settings to be applied despite unknown preset")
+	}
+}
+
+func TestLoadRepoConfigNoFile(t *testing.T) {
+	cfg, err := LoadRepoConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected no error for missing repository config, got: %v", err)
+	}
+	merged := DefaultConfig().Merge(cfg)
+	if merged.Theme.Colors != DefaultConfig().Theme.Colors || merged.RecentCommits != nil {
+		t.Error("expected an empty repository config to change nothing")
+	}
+}
+
+func TestLoadRepoConfigOverridesGlobal(t *testing.T) {
+	globalPath := filepath.Join(t.TempDir(), "config.yaml")
+	globalYAML := `theme:
+  colors:
+    primary:
+      dark: "#111111"
+    error:
+      dark: "#222222"
+recent_commits: 3
+row_numbers: true
+`
+	if err := os.WriteFile(globalPath, []byte(globalYAML), 0644); err != nil {
+		t.Fatalf("failed to write global config: %v", err)
+	}
+
+	repoRoot := t.TempDir()
+	repoYAML := `theme:
+  colors:
+    primary:
+      dark: "#333333"
+recent_commits: 8
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, RepoConfigFile), []byte(repoYAML), 0644); err != nil {
+		t.Fatalf("failed to write repository config: %v", err)
+	}
+
+	global, err := LoadConfig(globalPath)
+	if err != nil {
+		t.Fatalf("failed to load global config: %v", err)
+	}
+	repoCfg, err := LoadRepoConfig(repoRoot)
+	if err != nil {
+		t.Fatalf("failed to load repository config: %v", err)
+	}
+	cfg := global.Merge(repoCfg)
+
+	// The repository wins over the global config for what it sets...
+	if cfg.Theme.Colors.Primary.Dark != "#333333" {
+		t.Errorf("expected repository primary color, got: %s", cfg.Theme.Colors.Primary.Dark)
+	}
+	if cfg.RecentCommitsCount() != 8 {
+		t.Errorf("expected repository recent_commits 8, got: %d", cfg.RecentCommitsCount())
+	}
+	// ...the global config still applies to what it leaves out...
+	if cfg.Theme.Colors.Error.Dark != "#222222" {
+		t.Errorf("expected global error color, got: %s", cfg.Theme.Colors.Error.Dark)
+	}
+	if !cfg.RowNumbersEnabled() {
+		t.Error("expected global row_numbers to be kept")
+	}
+	// ...and defaults fill in the rest
+	if cfg.Theme.Colors.Primary.Light != DefaultConfig().Theme.Colors.Primary.Light {
+		t.Errorf("expected default light primary color, got: %s", cfg.Theme.Colors.Primary.Light)
+	}
+	if global.Theme.Colors.Primary.Dark != "#111111" {
+		t.Error("expected Merge to leave the global config unchanged")
+	}
+}
+
+func TestLoadRepoConfigPreset(t *testing.T) {
+	repoRoot := t.TempDir()
+	repoYAML := `theme:
+  preset: nord
+  colors:
+    success:
+      dark: "#444444"
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, RepoConfigFile), []byte(repoYAML), 0644); err != nil {
+		t.Fatalf("failed to write repository config: %v", err)
+	}
+
+	repoCfg, err := LoadRepoConfig(repoRoot)
+	if err != nil {
+		t.Fatalf("failed to load repository config: %v", err)
+	}
+	cfg := DefaultConfig().Merge(repoCfg)
+
+	nord, _ := PresetConfig("nord")
+	if cfg.Theme.Colors.Primary != nord.Theme.Colors.Primary {
+		t.Error("expected repository preset colors")
+	}
+	if cfg.Theme.Colors.Success.Dark != "#444444" {
+		t.Errorf("expected explicit color to override the preset, got: %s", cfg.Theme.Colors.Success.Dark)
+	}
+}
+
+func TestLoadRepoConfigErrors(t *testing.T) {
+	repoRoot := t.TempDir()
+	path := filepath.Join(repoRoot, RepoConfigFile)
+
+	if err := os.WriteFile(path, []byte("theme: [broken"), 0644); err != nil {
+		t.Fatalf("failed to write repository config: %v", err)
+	}
+	if _, err := LoadRepoConfig(repoRoot); err == nil {
+		t.Error("expected error for invalid repository config")
+	}
+
+	if err := os.WriteFile(path, []byte("theme:\n  preset: no-such-preset\nrow_numbers: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write repository config: %v", err)
+	}
+	cfg, err := LoadRepoConfig(repoRoot)
+	if err == nil || !contains(err.Error(), "no-such-preset") {
+		t.Errorf("expected warning naming the unknown preset, got: %v", err)
+	}
+	if merged := DefaultConfig().Merge(cfg); merged.Theme.Colors != DefaultConfig().Theme.Colors || !merged.RowNumbersEnabled() {
+		t.Error("expected other settings to apply despite unknown preset")
+	}
+}
+
+func TestWriteDefaultConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "grove", "config.yaml")
+
+	if err := WriteDefaultConfig(configPath, false); err != nil {
+		t.Fatalf("failed to write default config: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("expected config file to be created: %v", err)
+	}
+	if string(data) != GenerateSampleConfig() {
+		t.Error("expected written file to match the sample config")
+	}
+}
+
+func TestWriteDefaultConfigNoOverwrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	if err := os.WriteFile(configPath, []byte("custom: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	err := WriteDefaultConfig(configPath, false)
+	if _, ok := err.(*ConfigExistsError); !ok {
+		t.Fatalf("expected ConfigExistsError, got: %v", err)
+	}
+
+	data, _ := os.ReadFile(configPath)
+	if string(data) != "custom: true\n" {
+		t.Error("expected existing config to be left untouched")
+	}
+}
+
+func TestWriteDefaultConfigForceOverwrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	if err := os.WriteFile(configPath, []byte("custom: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	if err := WriteDefaultConfig(configPath, true); err != nil {
+		t.Fatalf("expected force to overwrite, got: %v", err)
+	}
+
+	data, _ := os.ReadFile(configPath)
+	if string(data) != GenerateSampleConfig() {
+		t.Error("expected existing config to be replaced with the sample config")
+	}
+}
+
 // contains checks if substr is in s
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
@@ -266,3 +968,52 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestLoadConfigRefreshInterval(t *testing.T) {
+	if DefaultConfig().RefreshInterval() != 0 {
+		t.Error("expected auto-refresh to be disabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("refresh:\n  interval_seconds: 30\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.RefreshInterval() != 30*time.Second {
+		t.Errorf("expected a 30s refresh interval, got %v", cfg.RefreshInterval())
+	}
+
+	negative := -5
+	cfg.Refresh.IntervalSeconds = &negative
+	if cfg.RefreshInterval() != 0 {
+		t.Errorf("expected a negative interval to disable refresh, got %v", cfg.RefreshInterval())
+	}
+}
+
+func TestLoadConfigRefreshWatch(t *testing.T) {
+	if DefaultConfig().WatchEnabled() {
+		t.Error("expected watching to be disabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("refresh:\n  watch: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.WatchEnabled() {
+		t.Error("expected refresh.watch: true to enable watching")
+	}
+	if cfg.RefreshInterval() != 0 {
+		t.Errorf("expected watching to leave the interval unset, got %v", cfg.RefreshInterval())
+	}
+}
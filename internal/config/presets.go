@@ -0,0 +1,86 @@
+// Package config handles application configuration including theme settings.
+package config
+
+import (
+	"sort"
+)
+
+// DefaultPreset is the name of the built-in color scheme.
+const DefaultPreset = "default"
+
+// presets maps preset names to their theme colors.
+// Dark-only palettes use the same values for light and dark terminals.
+var presets = map[string]ThemeColors{
+	DefaultPreset: DefaultConfig().Theme.Colors,
+
+	"dracula": {
+		Primary:   solid("#BD93F9"),
+		OnPrimary: solid("#282A36"),
+		Text:      solid("#F8F8F2"),
+		TextMuted: solid("#6272A4"),
+		Border:    solid("#BD93F9"),
+		Success:   solid("#50FA7B"),
+		OnSuccess: solid("#282A36"),
+		Error:     solid("#FF5555"),
+		OnError:   solid("#282A36"),
+		Info:      solid("#8BE9FD"),
+		OnInfo:    solid("#282A36"),
+	},
+
+	"solarized-dark": {
+		Primary:   solid("#268BD2"),
+		OnPrimary: solid("#FDF6E3"),
+		Text:      solid("#839496"),
+		TextMuted: solid("#586E75"),
+		Border:    solid("#268BD2"),
+		Success:   solid("#859900"),
+		OnSuccess: solid("#002B36"),
+		Error:     solid("#DC322F"),
+		OnError:   solid("#FDF6E3"),
+		Info:      solid("#2AA198"),
+		OnInfo:    solid("#002B36"),
+	},
+
+	"nord": {
+		Primary:   solid("#88C0D0"),
+		OnPrimary: solid("#2E3440"),
+		Text:      solid("#ECEFF4"),
+		TextMuted: solid("#4C566A"),
+		Border:    solid("#81A1C1"),
+		Success:   solid("#A3BE8C"),
+		OnSuccess: solid("#2E3440"),
+		Error:     solid("#BF616A"),
+		OnError:   solid("#ECEFF4"),
+		Info:      solid("#5E81AC"),
+		OnInfo:    solid("#ECEFF4"),
+	},
+}
+
+// solid returns an AdaptiveColor using the same color for light and dark terminals.
+func solid(hex string) AdaptiveColor {
+	return AdaptiveColor{Light: hex, Dark: hex}
+}
+
+// PresetNames returns the names of all built-in theme presets in sorted order.
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PresetConfig returns the default configuration with the named preset's
+// colors applied. Returns false if no preset has that name.
+func PresetConfig(name string) (Config, bool) {
+	colors, ok := presets[name]
+	if !ok {
+		return Config{}, false
+	}
+
+	cfg := DefaultConfig()
+	cfg.Theme.Preset = name
+	cfg.Theme.Colors = colors
+	return cfg, true
+}
@@ -0,0 +1,75 @@
+// Package config handles application configuration including theme settings.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State records UI state that is restored between runs.
+type State struct {
+	// ActiveTab is the name of the last active tab (e.g. "Worktrees").
+	ActiveTab string `yaml:"active_tab"`
+	// SelectedWorktree is the path of the last selected worktree.
+	SelectedWorktree string `yaml:"selected_worktree"`
+	// Layout is the preferred pane layout ("horizontal", "vertical" or
+	// "compact").
+	// Empty means automatic, based on the terminal width.
+	Layout string `yaml:"layout,omitempty"`
+	// Labels maps worktree paths to the names shown for them instead of
+	// their directory names.
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// Reviewed marks the worktree paths flagged as reviewed or done.
+	Reviewed map[string]bool `yaml:"reviewed,omitempty"`
+}
+
+// DefaultStatePath returns the default path for the state file,
+// stored next to the configuration file.
+func DefaultStatePath() string {
+	configPath := DefaultConfigPath()
+	if configPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(configPath), "state.yaml")
+}
+
+// LoadState loads the saved state from the specified path.
+// A missing file returns an empty state with no error.
+func LoadState(path string) (State, error) {
+	var state State
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, fmt.Errorf("reading state file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("parsing state file: %w", err)
+	}
+
+	return state, nil
+}
+
+// SaveState writes the state to the specified path, creating parent directories.
+func SaveState(path string, state State) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing state file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDefaultStatePath(t *testing.T) {
+	path := DefaultStatePath()
+
+	if filepath.Base(path) != "state.yaml" {
+		t.Errorf("expected path to end with 'state.yaml', got: %s", path)
+	}
+	if filepath.Dir(path) != filepath.Dir(DefaultConfigPath()) {
+		t.Errorf("expected state file next to config file, got: %s", path)
+	}
+}
+
+func TestLoadStateNoFile(t *testing.T) {
+	state, err := LoadState("/non/existent/path/state.yaml")
+	if err != nil {
+		t.Errorf("expected no error for non-existent file, got: %v", err)
+	}
+	if !reflect.DeepEqual(state, State{}) {
+		t.Errorf("expected empty state, got: %+v", state)
+	}
+}
+
+func TestSaveAndLoadState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subdir", "state.yaml")
+	saved := State{
+		ActiveTab:        "Branches",
+		SelectedWorktree: "/path/to/feature",
+		Labels:           map[string]string{"/path/to/feature": "login rework"},
+		Reviewed:         map[string]bool{"/path/to/feature": true},
+	}
+
+	if err := SaveState(path, saved); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, saved) {
+		t.Errorf("expected %+v, got %+v", saved, loaded)
+	}
+}
+
+func TestLoadStateReviewed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.yaml")
+	data := "active_tab: Worktrees\nreviewed:\n  /path/to/a: true\n  /path/to/b: true\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	want := map[string]bool{"/path/to/a": true, "/path/to/b": true}
+	if !reflect.DeepEqual(state.Reviewed, want) {
+		t.Errorf("expected reviewed %v, got %v", want, state.Reviewed)
+	}
+}
+
+func TestLoadStateInvalidYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.yaml")
+	if err := os.WriteFile(path, []byte("invalid yaml: [[["), 0644); err != nil {
+		t.Fatalf("failed to write test state: %v", err)
+	}
+
+	if _, err := LoadState(path); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
@@ -0,0 +1,111 @@
+// Package fsutil provides filesystem helpers used when setting up worktrees.
+package fsutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// CopyFiles copies files matching the given patterns from the src directory
+// into the same relative locations under dst. Patterns are relative to src
+// and may use filepath.Match glob syntax. Patterns that match nothing are
+// skipped silently; directories are copied recursively and symlinks are
+// recreated rather than followed. Existing files in dst are overwritten.
+// All copy failures are collected and returned together.
+func CopyFiles(src, dst string, patterns []string) error {
+	var errs []error
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(src, pattern))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid pattern %q: %w", pattern, err))
+			continue
+		}
+
+		for _, match := range matches {
+			rel, err := filepath.Rel(src, match)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if err := copyPath(match, filepath.Join(dst, rel)); err != nil {
+				errs = append(errs, fmt.Errorf("copying %s: %w", rel, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// copyPath copies a file, symlink, or directory tree from src to dst.
+func copyPath(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			return copySymlink(path, target)
+		default:
+			return copyFile(path, target, info.Mode().Perm())
+		}
+	})
+}
+
+// copySymlink recreates the symlink at src as dst, pointing at the same target.
+func copySymlink(src, dst string) error {
+	link, err := os.Readlink(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	// Replace any existing entry so repeated copies are idempotent
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Symlink(link, dst)
+}
+
+// copyFile copies the contents of a regular file from src to dst with the given permissions.
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
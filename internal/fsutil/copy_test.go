@@ -0,0 +1,98 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// writeFile creates a file with the given content, creating parent directories.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
+
+// TestCopyFiles verifies matching files are copied and missing ones are skipped.
+func TestCopyFiles(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+
+	writeFile(t, filepath.Join(src, ".env"), "SECRET=1")
+	writeFile(t, filepath.Join(src, "config", "local.yaml"), "debug: true")
+
+	err := CopyFiles(src, dst, []string{".env", ".env.local", "config/*.yaml"})
+	if err != nil {
+		t.Fatalf("CopyFiles failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dst, ".env"))
+	if err != nil || string(data) != "SECRET=1" {
+		t.Errorf("Expected .env to be copied, got %q (err: %v)", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "config", "local.yaml")); err != nil {
+		t.Errorf("Expected glob match to be copied: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, ".env.local")); !os.IsNotExist(err) {
+		t.Error("Missing source file should be skipped, not created")
+	}
+
+	// Permissions are preserved
+	info, err := os.Stat(filepath.Join(dst, ".env"))
+	if err == nil && runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("Expected mode 0600, got %v", info.Mode().Perm())
+	}
+}
+
+// TestCopyFilesDirectoryAndSymlink verifies directories are copied recursively and symlinks recreated.
+func TestCopyFilesDirectoryAndSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Symlinks require elevated privileges on Windows")
+	}
+
+	src := t.TempDir()
+	dst := t.TempDir()
+
+	writeFile(t, filepath.Join(src, "secrets", "a.txt"), "a")
+	if err := os.Symlink("/shared/node_modules", filepath.Join(src, "node_modules")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	if err := CopyFiles(src, dst, []string{"secrets", "node_modules"}); err != nil {
+		t.Fatalf("CopyFiles failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dst, "secrets", "a.txt")); err != nil {
+		t.Errorf("Expected directory contents to be copied: %v", err)
+	}
+	link, err := os.Readlink(filepath.Join(dst, "node_modules"))
+	if err != nil || link != "/shared/node_modules" {
+		t.Errorf("Expected symlink to /shared/node_modules, got %q (err: %v)", link, err)
+	}
+}
+
+// TestCopyFilesReportsErrors verifies copy failures are returned.
+func TestCopyFilesReportsErrors(t *testing.T) {
+	src := t.TempDir()
+	writeFile(t, filepath.Join(src, ".env"), "SECRET=1")
+
+	// Destination is a regular file, so creating children inside it fails
+	dst := filepath.Join(t.TempDir(), "file")
+	writeFile(t, dst, "not a directory")
+
+	if err := CopyFiles(src, dst, []string{".env"}); err == nil {
+		t.Error("Expected error when destination cannot be written")
+	}
+}
+
+// TestCopyFilesInvalidPattern verifies malformed patterns are reported.
+func TestCopyFilesInvalidPattern(t *testing.T) {
+	if err := CopyFiles(t.TempDir(), t.TempDir(), []string{"["}); err == nil {
+		t.Error("Expected error for malformed pattern")
+	}
+}
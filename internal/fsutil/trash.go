@@ -0,0 +1,143 @@
+// Package fsutil provides filesystem helpers used when setting up worktrees.
+package fsutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// trashTimeFormat prefixes trash entries so they sort by deletion time.
+const trashTimeFormat = "20060102-150405"
+
+// TrashInfo describes a directory moved to the trash, so it can be
+// restored later. It is saved next to the entry as "<entry>.yaml".
+type TrashInfo struct {
+	// OriginalPath is where the directory was before it was trashed.
+	OriginalPath string `yaml:"original_path"`
+	// Branch is the branch the worktree had checked out, empty if detached.
+	Branch string `yaml:"branch,omitempty"`
+	// Commit is the commit the worktree had checked out.
+	Commit string `yaml:"commit,omitempty"`
+	// Repository is the main worktree of the repository it belonged to.
+	Repository string `yaml:"repository,omitempty"`
+	// DeletedAt is when the directory was moved to the trash.
+	DeletedAt time.Time `yaml:"deleted_at"`
+}
+
+// DefaultTrashDir returns the directory deleted worktrees are moved to.
+// Uses the XDG Base Directory Specification (~/.local/share/grove/trash).
+func DefaultTrashDir() string {
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dataDir = filepath.Join(homeDir, ".local", "share")
+	}
+	return filepath.Join(dataDir, "grove", "trash")
+}
+
+// MoveToTrash moves the directory at info.OriginalPath into trashDir as
+// "<timestamp>-<name>" and saves info next to it. It returns the path of
+// the new trash entry. Moves across filesystems fall back to copying.
+func MoveToTrash(trashDir string, info TrashInfo) (string, error) {
+	if trashDir == "" {
+		return "", errors.New("no trash directory")
+	}
+	if err := os.MkdirAll(trashDir, 0755); err != nil {
+		return "", err
+	}
+	if info.DeletedAt.IsZero() {
+		info.DeletedAt = time.Now()
+	}
+
+	entry, err := newTrashEntry(trashDir, info)
+	if err != nil {
+		return "", err
+	}
+	if err := moveDir(info.OriginalPath, entry); err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(info)
+	if err == nil {
+		err = os.WriteFile(entry+".yaml", data, 0644)
+	}
+	if err != nil {
+		// Without its metadata the entry could not be restored, so undo the move
+		if undoErr := moveDir(entry, info.OriginalPath); undoErr != nil {
+			return "", fmt.Errorf("saving trash info: %w (directory left at %s)", err, entry)
+		}
+		return "", fmt.Errorf("saving trash info: %w", err)
+	}
+
+	return entry, nil
+}
+
+// RestoreFromTrash moves the trash entry back to its original path and
+// removes its saved info. It fails if something else now occupies that path.
+func RestoreFromTrash(entry string) (TrashInfo, error) {
+	var info TrashInfo
+
+	data, err := os.ReadFile(entry + ".yaml")
+	if err != nil {
+		return info, err
+	}
+	if err := yaml.Unmarshal(data, &info); err != nil {
+		return info, fmt.Errorf("parsing trash info: %w", err)
+	}
+	if info.OriginalPath == "" {
+		return info, errors.New("trash info has no original path")
+	}
+	if _, err := os.Lstat(info.OriginalPath); err == nil {
+		return info, fmt.Errorf("%s already exists", info.OriginalPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(info.OriginalPath), 0755); err != nil {
+		return info, err
+	}
+	if err := moveDir(entry, info.OriginalPath); err != nil {
+		return info, err
+	}
+	return info, os.Remove(entry + ".yaml")
+}
+
+// newTrashEntry returns an unused entry path in trashDir for info. Entries
+// deleted within the same second get a numeric suffix.
+func newTrashEntry(trashDir string, info TrashInfo) (string, error) {
+	base := info.DeletedAt.Format(trashTimeFormat) + "-" + filepath.Base(info.OriginalPath)
+	entry := filepath.Join(trashDir, base)
+	for n := 2; ; n++ {
+		_, err := os.Lstat(entry)
+		if os.IsNotExist(err) {
+			return entry, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		entry = filepath.Join(trashDir, base+"-"+strconv.Itoa(n))
+	}
+}
+
+// moveDir moves the directory tree at src to dst. When they are on
+// different filesystems the tree is copied and the original removed.
+func moveDir(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	if err := copyPath(src, dst); err != nil {
+		_ = os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
@@ -0,0 +1,128 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMoveToTrash verifies the directory moves into the trash with its info saved next to it.
+func TestMoveToTrash(t *testing.T) {
+	root := t.TempDir()
+	wtPath := filepath.Join(root, "feature-login")
+	writeFile(t, filepath.Join(wtPath, "notes.txt"), "work in progress")
+	trashDir := filepath.Join(root, "trash")
+
+	deletedAt := time.Date(2024, 3, 5, 14, 30, 0, 0, time.Local)
+	entry, err := MoveToTrash(trashDir, TrashInfo{
+		OriginalPath: wtPath,
+		Branch:       "feature/login",
+		DeletedAt:    deletedAt,
+	})
+	if err != nil {
+		t.Fatalf("MoveToTrash failed: %v", err)
+	}
+
+	if want := filepath.Join(trashDir, "20240305-143000-feature-login"); entry != want {
+		t.Errorf("Expected entry %q, got %q", want, entry)
+	}
+	if _, err := os.Stat(wtPath); !os.IsNotExist(err) {
+		t.Error("Expected the original directory to be gone")
+	}
+	data, err := os.ReadFile(filepath.Join(entry, "notes.txt"))
+	if err != nil || string(data) != "work in progress" {
+		t.Errorf("Expected contents to move with the directory, got %q (err: %v)", data, err)
+	}
+
+	meta, err := os.ReadFile(entry + ".yaml")
+	if err != nil {
+		t.Fatalf("Expected trash info to be saved: %v", err)
+	}
+	for _, want := range []string{"original_path: " + wtPath, "branch: feature/login"} {
+		if !strings.Contains(string(meta), want) {
+			t.Errorf("Expected trash info to contain %q, got:\n%s", want, meta)
+		}
+	}
+
+	// A second directory with the same name in the same second gets a suffix
+	writeFile(t, filepath.Join(wtPath, "notes.txt"), "again")
+	second, err := MoveToTrash(trashDir, TrashInfo{OriginalPath: wtPath, DeletedAt: deletedAt})
+	if err != nil {
+		t.Fatalf("MoveToTrash failed: %v", err)
+	}
+	if second != entry+"-2" {
+		t.Errorf("Expected entry %q, got %q", entry+"-2", second)
+	}
+}
+
+// TestMoveToTrashMissingDirectory verifies nothing is recorded when the directory cannot be moved.
+func TestMoveToTrashMissingDirectory(t *testing.T) {
+	trashDir := filepath.Join(t.TempDir(), "trash")
+
+	if _, err := MoveToTrash(trashDir, TrashInfo{OriginalPath: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Fatal("Expected an error for a missing directory")
+	}
+
+	entries, _ := os.ReadDir(trashDir)
+	if len(entries) != 0 {
+		t.Errorf("Expected an empty trash, got %d entries", len(entries))
+	}
+}
+
+// TestRestoreFromTrash verifies an entry moves back to its original path.
+func TestRestoreFromTrash(t *testing.T) {
+	root := t.TempDir()
+	wtPath := filepath.Join(root, "wt")
+	writeFile(t, filepath.Join(wtPath, "file.txt"), "content")
+
+	entry, err := MoveToTrash(filepath.Join(root, "trash"), TrashInfo{OriginalPath: wtPath, Branch: "main"})
+	if err != nil {
+		t.Fatalf("MoveToTrash failed: %v", err)
+	}
+
+	info, err := RestoreFromTrash(entry)
+	if err != nil {
+		t.Fatalf("RestoreFromTrash failed: %v", err)
+	}
+	if info.Branch != "main" {
+		t.Errorf("Expected branch 'main', got %q", info.Branch)
+	}
+	if _, err := os.Stat(filepath.Join(wtPath, "file.txt")); err != nil {
+		t.Errorf("Expected the directory to be restored: %v", err)
+	}
+	if _, err := os.Stat(entry + ".yaml"); !os.IsNotExist(err) {
+		t.Error("Expected the trash info to be removed")
+	}
+}
+
+// TestRestoreFromTrashOccupied verifies restoring never overwrites an existing path.
+func TestRestoreFromTrashOccupied(t *testing.T) {
+	root := t.TempDir()
+	wtPath := filepath.Join(root, "wt")
+	writeFile(t, filepath.Join(wtPath, "file.txt"), "old")
+
+	entry, err := MoveToTrash(filepath.Join(root, "trash"), TrashInfo{OriginalPath: wtPath})
+	if err != nil {
+		t.Fatalf("MoveToTrash failed: %v", err)
+	}
+	writeFile(t, filepath.Join(wtPath, "file.txt"), "new")
+
+	if _, err := RestoreFromTrash(entry); err == nil {
+		t.Fatal("Expected an error when the original path is taken")
+	}
+	if _, err := os.Stat(entry); err != nil {
+		t.Errorf("Expected the entry to stay in the trash: %v", err)
+	}
+}
+
+// TestDefaultTrashDir verifies the trash follows XDG_DATA_HOME.
+func TestDefaultTrashDir(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+
+	if got, want := DefaultTrashDir(), filepath.Join(dataHome, "grove", "trash"); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
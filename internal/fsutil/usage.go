@@ -0,0 +1,47 @@
+// Package fsutil provides filesystem helpers used when setting up worktrees.
+package fsutil
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+// WorktreeDiskUsage returns the total size in bytes of the regular files
+// under the worktree at path. The .git directory of the main worktree is
+// skipped, since it holds the shared repository rather than worktree files,
+// and symlinks are not followed. Entries that cannot be read (e.g. removed
+// while walking or without permission) are skipped.
+func WorktreeDiskUsage(path string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// The root itself must be readable; anything below is best effort
+			if p == path {
+				return err
+			}
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" && p != path {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,38 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestWorktreeDiskUsage verifies file sizes are summed and .git is skipped.
+func TestWorktreeDiskUsage(t *testing.T) {
+	root := t.TempDir()
+
+	writeFile(t, filepath.Join(root, "main.go"), "package main\n") // 13 bytes
+	writeFile(t, filepath.Join(root, "build", "out.bin"), "0123456789")
+	writeFile(t, filepath.Join(root, ".git", "objects", "pack"), "should not count")
+
+	if runtime.GOOS != "windows" {
+		if err := os.Symlink(filepath.Join(root, "main.go"), filepath.Join(root, "link")); err != nil {
+			t.Fatalf("Failed to create symlink: %v", err)
+		}
+	}
+
+	size, err := WorktreeDiskUsage(root)
+	if err != nil {
+		t.Fatalf("WorktreeDiskUsage failed: %v", err)
+	}
+	if size != 23 {
+		t.Errorf("Expected 23 bytes, got %d", size)
+	}
+}
+
+// TestWorktreeDiskUsageMissing verifies a missing worktree is an error.
+func TestWorktreeDiskUsageMissing(t *testing.T) {
+	if _, err := WorktreeDiskUsage(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
@@ -0,0 +1,280 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// InvalidBranchNameError is returned when a branch name is not allowed by git.
+type InvalidBranchNameError struct {
+	Name   string
+	Reason string
+}
+
+func (e *InvalidBranchNameError) Error() string {
+	return fmt.Sprintf("invalid branch name %q: %s", e.Name, e.Reason)
+}
+
+// BranchExistsError is returned when creating a branch whose name is taken.
+type BranchExistsError struct {
+	Branch string
+}
+
+func (e *BranchExistsError) Error() string {
+	return fmt.Sprintf("a branch named '%s' already exists", e.Branch)
+}
+
+// IsBranchExistsError checks if an error is a BranchExistsError.
+func IsBranchExistsError(err error) bool {
+	var existsErr *BranchExistsError
+	return errors.As(err, &existsErr)
+}
+
+// BranchCreateError is returned when a branch cannot be created in a worktree.
+type BranchCreateError struct {
+	Path   string
+	Branch string
+	Reason string
+}
+
+func (e *BranchCreateError) Error() string {
+	return fmt.Sprintf("failed to create branch %s in %s: %s", e.Branch, e.Path, e.Reason)
+}
+
+// BranchRenameError is returned when a branch cannot be renamed.
+type BranchRenameError struct {
+	OldName string
+	NewName string
+	Reason  string
+}
+
+func (e *BranchRenameError) Error() string {
+	return fmt.Sprintf("failed to rename branch %s to %s: %s", e.OldName, e.NewName, e.Reason)
+}
+
+// ValidateBranchName checks name against the rules of `git check-ref-format --branch`
+// without running git, so it can be used while the user is typing.
+func ValidateBranchName(name string) error {
+	invalid := func(reason string) error {
+		return &InvalidBranchNameError{Name: name, Reason: reason}
+	}
+
+	switch {
+	case name == "":
+		return invalid("name is empty")
+	case name == "@":
+		return invalid("'@' is not allowed")
+	case strings.HasPrefix(name, "-"):
+		return invalid("cannot start with '-'")
+	case strings.HasSuffix(name, "/") || strings.HasSuffix(name, "."):
+		return invalid("cannot end with '/' or '.'")
+	case strings.HasSuffix(name, ".lock"):
+		return invalid("cannot end with '.lock'")
+	case strings.Contains(name, ".."):
+		return invalid("cannot contain '..'")
+	case strings.Contains(name, "@{"):
+		return invalid("cannot contain '@{'")
+	case strings.Contains(name, "//"):
+		return invalid("cannot contain '//'")
+	}
+
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f || r == ' ' || strings.ContainsRune("~^:?*[\\", r) {
+			return invalid(fmt.Sprintf("cannot contain %q", r))
+		}
+	}
+
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") {
+			return invalid("path components cannot start with '.'")
+		}
+	}
+
+	return nil
+}
+
+// CreateBranchAtHead creates a branch at the current HEAD of the worktree at
+// path and checks it out there. It is mainly used to keep work done on a
+// detached HEAD; the worktree's files are left untouched.
+func CreateBranchAtHead(path, branch string) error {
+	return CreateBranchAtHeadContext(context.Background(), path, branch)
+}
+
+// CreateBranchAtHeadContext is like CreateBranchAtHead but stops git when ctx is done.
+func CreateBranchAtHeadContext(ctx context.Context, path, branch string) error {
+	if err := checkRepository(ctx, path); err != nil {
+		return err
+	}
+
+	if err := ValidateBranchName(branch); err != nil {
+		return err
+	}
+
+	// checkout -b works on every git version; switch -c needs git 2.23
+	output, err := runGit(ctx, path, "checkout", "-b", branch)
+	if err != nil {
+		reason := failureReason(output, err)
+		if strings.Contains(reason, "already exists") {
+			return &BranchExistsError{Branch: branch}
+		}
+		return &BranchCreateError{
+			Path:   path,
+			Branch: branch,
+			Reason: reason,
+		}
+	}
+
+	return nil
+}
+
+// RenameBranch renames the local branch oldName to newName in the repository
+// containing dir. Git allows renaming a branch that is checked out, and every
+// worktree on it follows the new name.
+func RenameBranch(dir, oldName, newName string) error {
+	return RenameBranchContext(context.Background(), dir, oldName, newName)
+}
+
+// RenameBranchContext is like RenameBranch but stops git when ctx is done.
+func RenameBranchContext(ctx context.Context, dir, oldName, newName string) error {
+	if err := checkRepository(ctx, dir); err != nil {
+		return err
+	}
+
+	if err := ValidateBranchName(newName); err != nil {
+		return err
+	}
+
+	// Without -M git refuses to overwrite an existing branch
+	output, err := runGit(ctx, dir, "branch", "-m", oldName, newName)
+	if err != nil {
+		reason := failureReason(output, err)
+		if strings.Contains(reason, "already exists") {
+			return &BranchExistsError{Branch: newName}
+		}
+		return &BranchRenameError{
+			OldName: oldName,
+			NewName: newName,
+			Reason:  reason,
+		}
+	}
+
+	return nil
+}
+
+// CheckoutError is returned when a branch cannot be checked out in a worktree.
+type CheckoutError struct {
+	Path   string
+	Branch string
+	Reason string
+	// Overwritten is true when git refused because local changes would be
+	// overwritten by the checkout.
+	Overwritten bool
+}
+
+func (e *CheckoutError) Error() string {
+	return fmt.Sprintf("failed to switch %s to %s: %s", e.Path, e.Branch, e.Reason)
+}
+
+// IsCheckoutOverwriteError checks if an error is a CheckoutError caused by
+// local changes that the checkout would overwrite.
+func IsCheckoutOverwriteError(err error) bool {
+	var checkoutErr *CheckoutError
+	return errors.As(err, &checkoutErr) && checkoutErr.Overwritten
+}
+
+// CheckoutBranch switches the worktree at path to branch with "git checkout".
+// If no local branch has that name but exactly one remote has, git creates
+// a local branch tracking it. Local changes are never discarded: git refuses
+// when they would be overwritten.
+func CheckoutBranch(path, branch string) error {
+	return CheckoutBranchContext(context.Background(), path, branch)
+}
+
+// CheckoutBranchContext is like CheckoutBranch but stops git when ctx is done.
+func CheckoutBranchContext(ctx context.Context, path, branch string) error {
+	if err := checkRepository(ctx, path); err != nil {
+		return err
+	}
+
+	if err := ValidateBranchName(branch); err != nil {
+		return err
+	}
+
+	// checkout works on every git version; switch needs git 2.23
+	output, err := runGit(ctx, path, "checkout", branch)
+	if err != nil {
+		reason := failureReason(output, err)
+		return &CheckoutError{
+			Path:        path,
+			Branch:      branch,
+			Reason:      reason,
+			Overwritten: strings.Contains(reason, "would be overwritten"),
+		}
+	}
+
+	return nil
+}
+
+// DefaultBranch returns the name of the repository's default branch, e.g.
+// "main", as recorded by origin/HEAD. Without it, a local "main" or "master"
+// branch is used. Returns an empty string with no error if none is found.
+func DefaultBranch(dir string) (string, error) {
+	return DefaultBranchContext(context.Background(), dir)
+}
+
+// DefaultBranchContext is like DefaultBranch but stops git when ctx is done.
+func DefaultBranchContext(ctx context.Context, dir string) (string, error) {
+	if err := checkRepository(ctx, dir); err != nil {
+		return "", err
+	}
+
+	// origin/HEAD is set by clone and "git remote set-head"
+	output, err := runGit(ctx, dir, "symbolic-ref", "--short", "-q", "refs/remotes/origin/HEAD")
+	if err == nil {
+		if branch := strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/"); branch != "" {
+			return branch, nil
+		}
+	} else if IsTimeoutError(err) {
+		return "", err
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		_, err := runGit(ctx, dir, "rev-parse", "--verify", "-q", "refs/heads/"+branch)
+		if err == nil {
+			return branch, nil
+		}
+		if IsTimeoutError(err) {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// IsBranchMerged reports whether every commit of branch is reachable from
+// into, i.e. whether branch is listed by "git branch --merged <into>".
+func IsBranchMerged(dir, branch, into string) (bool, error) {
+	return IsBranchMergedContext(context.Background(), dir, branch, into)
+}
+
+// IsBranchMergedContext is like IsBranchMerged but stops git when ctx is done.
+func IsBranchMergedContext(ctx context.Context, dir, branch, into string) (bool, error) {
+	if err := checkRepository(ctx, dir); err != nil {
+		return false, err
+	}
+
+	// The format leaves out the "*" and "+" markers of checked out branches
+	output, err := runGit(ctx, dir, "branch", "--merged", into, "--format=%(refname:short)")
+	if err != nil {
+		return false, fmt.Errorf("failed to list branches merged into %s: %s", into, failureReason(output, err))
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.TrimSpace(line) == branch {
+			return true, nil
+		}
+	}
+	return false, nil
+}
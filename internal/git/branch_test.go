@@ -0,0 +1,355 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestValidateBranchName verifies names are checked like git check-ref-format --branch.
+func TestValidateBranchName(t *testing.T) {
+	valid := []string{"main", "feature/login", "fix-123", "release/v1.2", "user@work"}
+	for _, name := range valid {
+		if err := ValidateBranchName(name); err != nil {
+			t.Errorf("ValidateBranchName(%q) = %v, want nil", name, err)
+		}
+	}
+
+	invalid := []string{
+		"", "@", "-feature", "feature/", "feature.", "feature.lock", "a..b",
+		"a@{b", "a//b", "has space", "a~1", "a^", "a:b", "a?", "a*", "a[b", "a\\b",
+		".hidden", "feature/.hidden", "tab\there",
+	}
+	for _, name := range invalid {
+		err := ValidateBranchName(name)
+		if err == nil {
+			t.Errorf("ValidateBranchName(%q) = nil, want error", name)
+			continue
+		}
+		if _, ok := err.(*InvalidBranchNameError); !ok {
+			t.Errorf("ValidateBranchName(%q) returned %T, want *InvalidBranchNameError", name, err)
+		}
+	}
+}
+
+// TestCreateBranchAtHeadCommand verifies the branch is created with checkout -b in the worktree.
+func TestCreateBranchAtHeadCommand(t *testing.T) {
+	fake := &fakeRunner{}
+	useFakeRunner(t, fake)
+
+	if err := CreateBranchAtHead("/repo-x", "keep-work"); err != nil {
+		t.Fatalf("CreateBranchAtHead failed: %v", err)
+	}
+
+	last := fake.calls[len(fake.calls)-1]
+	if last != "checkout -b keep-work" {
+		t.Errorf("Last git call = %q, want %q", last, "checkout -b keep-work")
+	}
+}
+
+// TestCreateBranchAtHeadInvalidName verifies invalid names are rejected before running git.
+func TestCreateBranchAtHeadInvalidName(t *testing.T) {
+	fake := &fakeRunner{}
+	useFakeRunner(t, fake)
+
+	err := CreateBranchAtHead("/repo-x", "bad name")
+	if _, ok := err.(*InvalidBranchNameError); !ok {
+		t.Fatalf("Expected InvalidBranchNameError, got %v", err)
+	}
+	for _, call := range fake.calls {
+		if strings.HasPrefix(call, "checkout") {
+			t.Errorf("git checkout should not run for an invalid name, got %q", call)
+		}
+	}
+}
+
+// TestCreateBranchAtHeadFailure verifies other git failures become a BranchCreateError.
+func TestCreateBranchAtHeadFailure(t *testing.T) {
+	useFakeRunner(t, &fakeRunner{failures: map[string]string{
+		"checkout -b keep-work": "fatal: cannot lock ref 'refs/heads/keep-work'",
+	}})
+
+	err := CreateBranchAtHead("/repo-x", "keep-work")
+	createErr, ok := err.(*BranchCreateError)
+	if !ok {
+		t.Fatalf("Expected BranchCreateError, got %v", err)
+	}
+	if !strings.Contains(createErr.Reason, "cannot lock ref") {
+		t.Errorf("Reason = %q, want git's message", createErr.Reason)
+	}
+}
+
+// TestCreateBranchAtHeadIntegration verifies a detached worktree ends up on the new branch.
+func TestCreateBranchAtHeadIntegration(t *testing.T) {
+	repo := initTestRepo(t)
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	run("checkout", "-q", "--detach")
+	if err := CreateBranchAtHead(repo, "keep-work"); err != nil {
+		t.Fatalf("CreateBranchAtHead failed: %v", err)
+	}
+	if branch := run("symbolic-ref", "--short", "HEAD"); branch != "keep-work" {
+		t.Errorf("HEAD is on %q, want keep-work", branch)
+	}
+
+	run("checkout", "-q", "--detach")
+	err := CreateBranchAtHead(repo, "keep-work")
+	if !IsBranchExistsError(err) {
+		t.Errorf("Expected BranchExistsError for an existing branch, got %v", err)
+	}
+}
+
+// TestRenameBranchCommand verifies the branch is renamed with branch -m.
+func TestRenameBranchCommand(t *testing.T) {
+	fake := &fakeRunner{}
+	useFakeRunner(t, fake)
+
+	if err := RenameBranch("/repo-x", "old", "feature/new"); err != nil {
+		t.Fatalf("RenameBranch failed: %v", err)
+	}
+
+	last := fake.calls[len(fake.calls)-1]
+	if last != "branch -m old feature/new" {
+		t.Errorf("Last git call = %q, want %q", last, "branch -m old feature/new")
+	}
+}
+
+// TestRenameBranchInvalidName verifies invalid names are rejected before running git.
+func TestRenameBranchInvalidName(t *testing.T) {
+	fake := &fakeRunner{}
+	useFakeRunner(t, fake)
+
+	err := RenameBranch("/repo-x", "old", "bad..name")
+	if _, ok := err.(*InvalidBranchNameError); !ok {
+		t.Fatalf("Expected InvalidBranchNameError, got %v", err)
+	}
+	for _, call := range fake.calls {
+		if strings.HasPrefix(call, "branch") {
+			t.Errorf("git branch should not run for an invalid name, got %q", call)
+		}
+	}
+}
+
+// TestRenameBranchFailure verifies other git failures become a BranchRenameError.
+func TestRenameBranchFailure(t *testing.T) {
+	useFakeRunner(t, &fakeRunner{failures: map[string]string{
+		"branch -m missing new": "error: refname refs/heads/missing not found",
+	}})
+
+	err := RenameBranch("/repo-x", "missing", "new")
+	renameErr, ok := err.(*BranchRenameError)
+	if !ok {
+		t.Fatalf("Expected BranchRenameError, got %v", err)
+	}
+	if !strings.Contains(renameErr.Reason, "not found") {
+		t.Errorf("Reason = %q, want git's message", renameErr.Reason)
+	}
+}
+
+// TestRenameBranchIntegration verifies a checked-out branch is renamed in its worktree
+// and an existing name is reported as a conflict.
+func TestRenameBranchIntegration(t *testing.T) {
+	repo := initTestRepo(t)
+	run := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	linked := filepath.Join(t.TempDir(), "linked")
+	run(repo, "worktree", "add", "-b", "old-name", linked)
+	run(repo, "branch", "taken")
+
+	if err := RenameBranch(repo, "old-name", "new-name"); err != nil {
+		t.Fatalf("RenameBranch failed: %v", err)
+	}
+	if branch := run(linked, "symbolic-ref", "--short", "HEAD"); branch != "new-name" {
+		t.Errorf("Linked worktree is on %q, want new-name", branch)
+	}
+
+	worktrees, err := ListWorktrees(repo)
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	found := false
+	for _, wt := range worktrees {
+		found = found || wt.Branch == "new-name"
+	}
+	if !found {
+		t.Errorf("Expected the worktree list to show new-name, got %+v", worktrees)
+	}
+
+	err = RenameBranch(repo, "new-name", "taken")
+	if !IsBranchExistsError(err) {
+		t.Errorf("Expected BranchExistsError for an existing name, got %v", err)
+	}
+}
+
+// TestDefaultBranch verifies origin/HEAD is preferred, falling back to a local main or master.
+func TestDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs map[string]string
+		fails   []string
+		want    string
+	}{
+		{
+			name:    "origin HEAD",
+			outputs: map[string]string{"symbolic-ref --short -q refs/remotes/origin/HEAD": "origin/trunk\n"},
+			want:    "trunk",
+		},
+		{
+			name:  "local master",
+			fails: []string{"symbolic-ref --short -q refs/remotes/origin/HEAD", "rev-parse --verify -q refs/heads/main"},
+			want:  "master",
+		},
+		{
+			name:  "none",
+			fails: []string{"symbolic-ref --short -q refs/remotes/origin/HEAD", "rev-parse --verify -q refs/heads/main", "rev-parse --verify -q refs/heads/master"},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeRunner{outputs: tt.outputs, failures: map[string]string{}}
+			for _, args := range tt.fails {
+				fake.failures[args] = ""
+			}
+			useFakeRunner(t, fake)
+
+			got, err := DefaultBranch("/repo")
+			if err != nil {
+				t.Fatalf("DefaultBranch failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DefaultBranch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestIsBranchMergedIntegration verifies merged and unmerged branches are told apart.
+func TestIsBranchMergedIntegration(t *testing.T) {
+	repo := initTestRepo(t)
+	run := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	base := run(repo, "symbolic-ref", "--short", "HEAD")
+
+	// A branch checked out in a worktree is listed with a "+" marker by default
+	run(repo, "worktree", "add", "-b", "merged", filepath.Join(t.TempDir(), "merged"))
+	unmerged := filepath.Join(t.TempDir(), "unmerged")
+	run(repo, "worktree", "add", "-b", "unmerged", unmerged)
+	run(unmerged, "commit", "--allow-empty", "-m", "not merged yet")
+
+	if merged, err := IsBranchMerged(repo, "merged", base); err != nil || !merged {
+		t.Errorf("IsBranchMerged(merged) = %v, %v; want true, nil", merged, err)
+	}
+	if merged, err := IsBranchMerged(repo, "unmerged", base); err != nil || merged {
+		t.Errorf("IsBranchMerged(unmerged) = %v, %v; want false, nil", merged, err)
+	}
+
+	run(repo, "merge", "--ff-only", "unmerged")
+	if merged, err := IsBranchMerged(repo, "unmerged", base); err != nil || !merged {
+		t.Errorf("IsBranchMerged after merging = %v, %v; want true, nil", merged, err)
+	}
+
+	// Without a remote the local branch is used, if it has a usual name
+	if base == "main" || base == "master" {
+		if branch, err := DefaultBranch(repo); err != nil || branch != base {
+			t.Errorf("DefaultBranch() = %q, %v; want %q", branch, err, base)
+		}
+	}
+}
+
+// TestCheckoutBranchCommand verifies the checkout command and that invalid names never reach git.
+func TestCheckoutBranchCommand(t *testing.T) {
+	fake := &fakeRunner{}
+	useFakeRunner(t, fake)
+
+	if err := CheckoutBranch("/repo", "feature/login"); err != nil {
+		t.Fatalf("CheckoutBranch failed: %v", err)
+	}
+	if last := fake.calls[len(fake.calls)-1]; last != "checkout feature/login" {
+		t.Errorf("Last git call = %q, want %q", last, "checkout feature/login")
+	}
+
+	calls := len(fake.calls)
+	if err := CheckoutBranch("/repo", "-f"); err == nil {
+		t.Error("Expected an error for an option-like branch name")
+	}
+	for _, call := range fake.calls[calls:] {
+		if strings.HasPrefix(call, "checkout") {
+			t.Errorf("Expected no checkout for an invalid name, got %q", call)
+		}
+	}
+}
+
+// TestCheckoutBranchOverwrite verifies git's refusal to overwrite local changes is recognised.
+func TestCheckoutBranchOverwrite(t *testing.T) {
+	useFakeRunner(t, &fakeRunner{failures: map[string]string{
+		"checkout other": "error: Your local changes to the following files would be overwritten by checkout:\n\tmain.go\nAborting",
+	}})
+
+	err := CheckoutBranch("/repo", "other")
+	if !IsCheckoutOverwriteError(err) {
+		t.Errorf("Expected an overwrite CheckoutError, got %v", err)
+	}
+}
+
+// TestCheckoutBranchIntegration verifies switching to a remote branch creates a tracking branch.
+func TestCheckoutBranchIntegration(t *testing.T) {
+	origin := initTestRepo(t)
+	run := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	run(origin, "branch", "feature")
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	run(origin, "clone", origin, clone)
+
+	if err := CheckoutBranch(clone, "feature"); err != nil {
+		t.Fatalf("CheckoutBranch failed: %v", err)
+	}
+	if branch := run(clone, "symbolic-ref", "--short", "HEAD"); branch != "feature" {
+		t.Errorf("Clone is on %q, want feature", branch)
+	}
+	if upstream := run(clone, "rev-parse", "--abbrev-ref", "feature@{upstream}"); upstream != "origin/feature" {
+		t.Errorf("Upstream = %q, want origin/feature", upstream)
+	}
+
+	if err := CheckoutBranch(clone, "missing"); err == nil {
+		t.Error("Expected an error for a branch that does not exist")
+	}
+}
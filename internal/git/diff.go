@@ -0,0 +1,73 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DiffStat returns how many files, inserted lines and deleted lines differ
+// between the last commit and the working tree at path, counting staged and
+// unstaged changes. Untracked files are not included.
+func DiffStat(path string) (filesChanged, insertions, deletions int, err error) {
+	return DiffStatContext(context.Background(), path)
+}
+
+// DiffStatContext is like DiffStat but stops git when ctx is done.
+func DiffStatContext(ctx context.Context, path string) (filesChanged, insertions, deletions int, err error) {
+	if err := checkRepository(ctx, path); err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Against HEAD a file both staged and modified again counts once
+	if _, err := runGit(ctx, path, "rev-parse", "--verify", "-q", "HEAD"); err == nil {
+		output, err := runGit(ctx, path, "diff", "HEAD", "--shortstat")
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("failed to get diff stat: %s", failureReason(output, err))
+		}
+		filesChanged, insertions, deletions = ParseShortstat(string(output))
+		return filesChanged, insertions, deletions, nil
+	} else if IsTimeoutError(err) {
+		return 0, 0, 0, fmt.Errorf("failed to get diff stat: %s", failureReason(nil, err))
+	}
+
+	// Without a commit there is no HEAD, so add up the staged and unstaged totals
+	for _, args := range [][]string{{"diff", "--staged", "--shortstat"}, {"diff", "--shortstat"}} {
+		output, err := runGit(ctx, path, args...)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("failed to get diff stat: %s", failureReason(output, err))
+		}
+		files, ins, del := ParseShortstat(string(output))
+		filesChanged += files
+		insertions += ins
+		deletions += del
+	}
+	return filesChanged, insertions, deletions, nil
+}
+
+// ParseShortstat parses the output of git diff --shortstat, such as
+// " 4 files changed, 120 insertions(+), 33 deletions(-)". Parts git leaves
+// out, and empty output for no changes, count as zero.
+func ParseShortstat(output string) (filesChanged, insertions, deletions int) {
+	for _, part := range strings.Split(strings.TrimSpace(output), ",") {
+		fields := strings.Fields(part)
+		if len(fields) < 2 {
+			continue
+		}
+		n, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(fields[1], "file"):
+			filesChanged = n
+		case strings.HasPrefix(fields[1], "insertion"):
+			insertions = n
+		case strings.HasPrefix(fields[1], "deletion"):
+			deletions = n
+		}
+	}
+	return filesChanged, insertions, deletions
+}
@@ -0,0 +1,79 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestParseShortstat verifies shortstat output is parsed, including
+// singular forms and missing parts.
+func TestParseShortstat(t *testing.T) {
+	tests := []struct {
+		output                       string
+		files, insertions, deletions int
+	}{
+		{" 4 files changed, 120 insertions(+), 33 deletions(-)\n", 4, 120, 33},
+		{" 1 file changed, 1 insertion(+)\n", 1, 1, 0},
+		{" 2 files changed, 5 deletions(-)\n", 2, 0, 5},
+		{" 1 file changed, 0 insertions(+), 0 deletions(-)\n", 1, 0, 0},
+		{"", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		files, insertions, deletions := ParseShortstat(tt.output)
+		if files != tt.files || insertions != tt.insertions || deletions != tt.deletions {
+			t.Errorf("ParseShortstat(%q) = %d, %d, %d; want %d, %d, %d",
+				tt.output, files, insertions, deletions, tt.files, tt.insertions, tt.deletions)
+		}
+	}
+}
+
+// TestDiffStat verifies staged and unstaged changes are counted once per file.
+func TestDiffStat(t *testing.T) {
+	repo := initTestRepo(t)
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	files, insertions, deletions, err := DiffStat(repo)
+	if err != nil {
+		t.Fatalf("DiffStat failed: %v", err)
+	}
+	if files != 0 || insertions != 0 || deletions != 0 {
+		t.Errorf("Expected no changes, got %d, %d, %d", files, insertions, deletions)
+	}
+
+	// Stage one edit, then change the same file again and add another
+	write("test.txt", "one\ntwo\n")
+	cmd := exec.Command("git", "add", "test.txt")
+	cmd.Dir = repo
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git add failed: %v\n%s", err, output)
+	}
+	write("test.txt", "one\ntwo\nthree\n")
+	write("new.txt", "untracked\n")
+
+	files, insertions, deletions, err = DiffStat(repo)
+	if err != nil {
+		t.Fatalf("DiffStat failed: %v", err)
+	}
+	if files != 1 || insertions != 3 || deletions != 1 {
+		t.Errorf("Expected 1 file, +3 -1, got %d, +%d -%d", files, insertions, deletions)
+	}
+}
+
+// TestDiffStatNotGitRepo verifies a NotGitRepoError outside a repository.
+func TestDiffStatNotGitRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	if _, _, _, err := DiffStat(t.TempDir()); !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError, got %v", err)
+	}
+}
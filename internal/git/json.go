@@ -0,0 +1,64 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"encoding/json"
+)
+
+// WorktreeJSON is the machine-readable representation of a worktree.
+type WorktreeJSON struct {
+	Path            string `json:"path"`
+	Branch          string `json:"branch"`
+	Commit          string `json:"commit"`
+	IsBare          bool   `json:"bare"`
+	IsDetached      bool   `json:"detached"`
+	IsLocked        bool   `json:"locked"`
+	IsMain          bool   `json:"main"`
+	ModifiedCount   int    `json:"modified"`
+	StagedCount     int    `json:"staged"`
+	UntrackedCount  int    `json:"untracked"`
+	ConflictedCount int    `json:"conflicted"`
+}
+
+// NewWorktreeJSON builds the JSON representation of a worktree and its status.
+// A nil status leaves all counts at zero.
+func NewWorktreeJSON(wt Worktree, status *WorktreeStatus) WorktreeJSON {
+	result := WorktreeJSON{
+		Path:       wt.Path,
+		Branch:     wt.Branch,
+		Commit:     wt.CommitHash,
+		IsBare:     wt.IsBare,
+		IsDetached: wt.IsDetached,
+		IsLocked:   wt.IsLocked,
+		IsMain:     wt.IsMain,
+	}
+	if status != nil {
+		result.ModifiedCount = status.ModifiedCount
+		result.StagedCount = status.StagedCount
+		result.UntrackedCount = status.UntrackedCount
+		result.ConflictedCount = status.ConflictedCount
+	}
+	return result
+}
+
+// WorktreesJSON lists the worktrees of the repository containing dir and
+// serializes them, including their status counts, as a JSON array.
+func WorktreesJSON(dir string) ([]byte, error) {
+	worktrees, err := ListWorktrees(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Always emit an array, even when there are no worktrees
+	result := make([]WorktreeJSON, 0, len(worktrees))
+	for _, wt := range worktrees {
+		var status *WorktreeStatus
+		if !wt.IsBare {
+			// Status errors (e.g. a missing directory) leave counts at zero
+			status, _ = GetWorktreeStatus(wt.Path)
+		}
+		result = append(result, NewWorktreeJSON(wt, status))
+	}
+
+	return json.MarshalIndent(result, "", "  ")
+}
@@ -0,0 +1,78 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNewWorktreeJSON verifies worktree fields and status counts are copied.
+func TestNewWorktreeJSON(t *testing.T) {
+	wt := Worktree{
+		Path:       "/path/to/feature",
+		Branch:     "feature",
+		CommitHash: "abc1234",
+		IsLocked:   true,
+	}
+	status := &WorktreeStatus{ModifiedCount: 1, StagedCount: 2, UntrackedCount: 3}
+
+	result := NewWorktreeJSON(wt, status)
+	if result.Path != wt.Path || result.Branch != wt.Branch || result.Commit != wt.CommitHash {
+		t.Errorf("Expected worktree fields to be copied, got %+v", result)
+	}
+	if !result.IsLocked {
+		t.Error("Expected IsLocked to be true")
+	}
+	if result.ModifiedCount != 1 || result.StagedCount != 2 || result.UntrackedCount != 3 {
+		t.Errorf("Expected status counts 1/2/3, got %d/%d/%d",
+			result.ModifiedCount, result.StagedCount, result.UntrackedCount)
+	}
+
+	// Nil status leaves counts at zero
+	result = NewWorktreeJSON(wt, nil)
+	if result.ModifiedCount != 0 || result.StagedCount != 0 || result.UntrackedCount != 0 {
+		t.Errorf("Expected zero counts for nil status, got %+v", result)
+	}
+}
+
+// TestWorktreesJSONInNonGitDir verifies a NotGitRepoError is returned.
+func TestWorktreesJSONInNonGitDir(t *testing.T) {
+	_, err := WorktreesJSON(t.TempDir())
+	if !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError, got: %v", err)
+	}
+}
+
+// TestWorktreesJSONIntegration verifies the JSON output for a real repository.
+func TestWorktreesJSONIntegration(t *testing.T) {
+	repo := initTestRepo(t)
+
+	// Add an untracked file so status counts are reported
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	data, err := WorktreesJSON(repo)
+	if err != nil {
+		t.Fatalf("WorktreesJSON failed: %v", err)
+	}
+
+	var result []map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Output is not a valid JSON array: %v\n%s", err, data)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 worktree, got %d", len(result))
+	}
+
+	for _, key := range []string{"path", "branch", "commit", "bare", "detached", "locked", "main", "modified", "staged", "untracked"} {
+		if _, ok := result[0][key]; !ok {
+			t.Errorf("Expected key %q in JSON output", key)
+		}
+	}
+	if result[0]["untracked"] != float64(1) {
+		t.Errorf("Expected 1 untracked file, got %v", result[0]["untracked"])
+	}
+}
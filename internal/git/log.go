@@ -0,0 +1,92 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// commitFieldSep separates fields in the custom git log format.
+const commitFieldSep = "\x1f"
+
+// commitLogFormat is the git log format parsed by ParseCommitLog:
+// abbreviated hash, relative committer date and subject.
+const commitLogFormat = "%h" + commitFieldSep + "%cr" + commitFieldSep + "%s"
+
+// CommitInfo describes a single commit.
+type CommitInfo struct {
+	// Hash is the abbreviated commit hash.
+	Hash string
+	// RelativeDate is the commit date relative to now (e.g. "2 days ago").
+	RelativeDate string
+	// Subject is the first line of the commit message.
+	Subject string
+}
+
+// GetRecentCommits returns up to n of the most recent commits of the
+// worktree at path, newest first. A branch without commits returns an
+// empty list with no error.
+func GetRecentCommits(path string, n int) ([]CommitInfo, error) {
+	return GetRecentCommitsContext(context.Background(), path, n)
+}
+
+// GetRecentCommitsContext is like GetRecentCommits but stops git when ctx is done.
+func GetRecentCommitsContext(ctx context.Context, path string, n int) ([]CommitInfo, error) {
+	if err := checkRepository(ctx, path); err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+
+	output, err := runGit(ctx, path, "log", "-n", strconv.Itoa(n), "--format="+commitLogFormat)
+	if err != nil {
+		reason := failureReason(output, err)
+		if strings.Contains(reason, "does not have any commits") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get recent commits: %s", reason)
+	}
+
+	return ParseCommitLog(string(output)), nil
+}
+
+// GetFullCommitHash returns the full 40-character hash of the commit checked
+// out in the worktree at path.
+func GetFullCommitHash(path string) (string, error) {
+	return GetFullCommitHashContext(context.Background(), path)
+}
+
+// GetFullCommitHashContext is like GetFullCommitHash but stops git when ctx is done.
+func GetFullCommitHashContext(ctx context.Context, path string) (string, error) {
+	if err := checkRepository(ctx, path); err != nil {
+		return "", err
+	}
+
+	output, err := runGit(ctx, path, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit hash: %s", failureReason(output, err))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ParseCommitLog parses git log output produced with commitLogFormat.
+// Malformed lines are skipped.
+func ParseCommitLog(output string) []CommitInfo {
+	var commits []CommitInfo
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, commitFieldSep, 3)
+		if len(fields) != 3 {
+			continue
+		}
+		commits = append(commits, CommitInfo{
+			Hash:         fields[0],
+			RelativeDate: fields[1],
+			Subject:      fields[2],
+		})
+	}
+	return commits
+}
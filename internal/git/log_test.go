@@ -0,0 +1,97 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestParseCommitLog verifies commit fields are split and malformed lines skipped.
+func TestParseCommitLog(t *testing.T) {
+	output := "abc1234\x1f2 days ago\x1fAdd feature\n" +
+		"def5678\x1f3 weeks ago\x1fFix: handle a|b \x1f in subject\n" +
+		"garbage\n"
+
+	commits := ParseCommitLog(output)
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d: %+v", len(commits), commits)
+	}
+	if commits[0] != (CommitInfo{Hash: "abc1234", RelativeDate: "2 days ago", Subject: "Add feature"}) {
+		t.Errorf("Unexpected first commit: %+v", commits[0])
+	}
+	if commits[1].Subject != "Fix: handle a|b \x1f in subject" {
+		t.Errorf("Subject should keep everything after the second separator, got %q", commits[1].Subject)
+	}
+}
+
+// TestGetRecentCommits verifies the newest commits are returned up to the limit.
+func TestGetRecentCommits(t *testing.T) {
+	repo := initTestRepo(t)
+	for _, msg := range []string{"second", "third"} {
+		cmd := exec.Command("git", "commit", "--allow-empty", "-m", msg)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git commit failed: %v\n%s", err, output)
+		}
+	}
+
+	commits, err := GetRecentCommits(repo, 2)
+	if err != nil {
+		t.Fatalf("GetRecentCommits failed: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(commits))
+	}
+	if commits[0].Subject != "third" || commits[1].Subject != "second" {
+		t.Errorf("Expected newest first, got %+v", commits)
+	}
+	if commits[0].Hash == "" || commits[0].RelativeDate == "" {
+		t.Errorf("Expected hash and date, got %+v", commits[0])
+	}
+}
+
+// TestGetRecentCommitsEmptyRepo verifies a repository without commits returns no commits.
+func TestGetRecentCommitsEmptyRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available, skipping integration test")
+	}
+	dir := t.TempDir()
+	if output, err := exec.Command("git", "init", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, output)
+	}
+
+	commits, err := GetRecentCommits(dir, 5)
+	if err != nil || len(commits) != 0 {
+		t.Errorf("Expected no commits and no error, got %v (err: %v)", commits, err)
+	}
+}
+
+// TestGetRecentCommitsInNonGitDir tests that GetRecentCommits returns error for non-git directory.
+func TestGetRecentCommitsInNonGitDir(t *testing.T) {
+	_, err := GetRecentCommits(t.TempDir(), 5)
+	if !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError, got: %v", err)
+	}
+}
+
+// TestGetFullCommitHash verifies the full hash of HEAD is returned.
+func TestGetFullCommitHash(t *testing.T) {
+	repo := initTestRepo(t)
+
+	hash, err := GetFullCommitHash(repo)
+	if err != nil {
+		t.Fatalf("GetFullCommitHash failed: %v", err)
+	}
+	if len(hash) != 40 {
+		t.Errorf("Expected a 40-character hash, got %q", hash)
+	}
+	commits, err := GetRecentCommits(repo, 1)
+	if err != nil || len(commits) != 1 || !strings.HasPrefix(hash, commits[0].Hash) {
+		t.Errorf("Expected %q to be the HEAD commit, got %v (err: %v)", hash, commits, err)
+	}
+
+	if _, err := GetFullCommitHash(t.TempDir()); !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError outside a repository, got: %v", err)
+	}
+}
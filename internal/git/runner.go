@@ -0,0 +1,199 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Runner runs git commands. The package-level runner is used by all git
+// operations; tests replace it with SetRunner to avoid needing a real repository.
+type Runner interface {
+	// Run runs git with args in dir and returns its standard output.
+	// If git fails, the error is a *CommandError carrying standard error.
+	// The command is stopped when ctx is done.
+	Run(ctx context.Context, dir string, args ...string) ([]byte, error)
+}
+
+// ErrTimeout is the cause of a CommandError for a git command stopped
+// because its context deadline passed.
+var ErrTimeout = errors.New("git timed out")
+
+// IsTimeoutError reports whether err was caused by a git command timing out.
+func IsTimeoutError(err error) bool {
+	return errors.Is(err, ErrTimeout)
+}
+
+// CommandError is returned by a Runner when a git command fails.
+type CommandError struct {
+	Args   []string
+	Stderr string
+	Err    error
+}
+
+func (e *CommandError) Error() string {
+	if e.Stderr != "" && !errors.Is(e.Err, ErrTimeout) {
+		return "git " + strings.Join(e.Args, " ") + ": " + e.Stderr
+	}
+	return "git " + strings.Join(e.Args, " ") + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error (e.g. *exec.ExitError).
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// GitNotFoundError is returned when the git binary cannot be found on PATH.
+type GitNotFoundError struct {
+	Err error
+}
+
+func (e *GitNotFoundError) Error() string {
+	return "git not found: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying lookup error.
+func (e *GitNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// IsGitNotFoundError checks if an error is a GitNotFoundError.
+func IsGitNotFoundError(err error) bool {
+	var notFound *GitNotFoundError
+	return errors.As(err, &notFound)
+}
+
+// GitAvailable returns the path of the git binary found on PATH,
+// or a GitNotFoundError if git is not installed.
+func GitAvailable() (string, error) {
+	path, err := exec.LookPath("git")
+	if err != nil {
+		return "", &GitNotFoundError{Err: err}
+	}
+	return path, nil
+}
+
+// gitConfigArgs are passed to every git command ahead of its own args, so
+// user config such as color.ui=always cannot add escape codes to output
+// meant for parsing.
+var gitConfigArgs = []string{"-c", "color.ui=false"}
+
+// gitEnv overrides the environment of every git command: LC_ALL=C keeps
+// messages in English for the error checks, and GIT_OPTIONAL_LOCKS=0 keeps
+// read-only commands like status from taking the index lock a git command
+// run by the user may be waiting for.
+var gitEnv = []string{"LC_ALL=C", "GIT_OPTIONAL_LOCKS=0"}
+
+// gitCommandContext returns the command running git with args in dir, with
+// the config and environment the output parsers rely on. The command is
+// killed when ctx is done.
+func gitCommandContext(ctx context.Context, dir string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", append(append([]string{}, gitConfigArgs...), args...)...)
+	cmd.Dir = dir
+	// Later entries win, so these override the user's settings
+	cmd.Env = append(os.Environ(), gitEnv...)
+	return cmd
+}
+
+// execRunner runs the git binary found on PATH.
+type execRunner struct{}
+
+// Run runs git with args in dir.
+func (execRunner) Run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := gitCommandContext(ctx, dir, args...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = ErrTimeout
+		}
+		return output, &CommandError{
+			Args:   args,
+			Stderr: strings.TrimSpace(stderr.String()),
+			Err:    err,
+		}
+	}
+	return output, nil
+}
+
+// RunCombined is like Run but returns standard error interleaved with
+// standard output, for commands that report on standard error even when
+// they succeed, such as `git worktree prune --dry-run`.
+func (execRunner) RunCombined(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := gitCommandContext(ctx, dir, args...)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = ErrTimeout
+		}
+		return output, &CommandError{
+			Args:   args,
+			Stderr: strings.TrimSpace(string(output)),
+			Err:    err,
+		}
+	}
+	return output, nil
+}
+
+// combinedRunner is implemented by runners that can capture standard error
+// of successful commands; see execRunner.RunCombined.
+type combinedRunner interface {
+	RunCombined(ctx context.Context, dir string, args ...string) ([]byte, error)
+}
+
+// runner is the Runner used by all git operations.
+var runner Runner = execRunner{}
+
+// SetRunner replaces the Runner used by git operations and returns the
+// previous one so it can be restored. A nil runner restores the default.
+func SetRunner(r Runner) Runner {
+	previous := runner
+	if r == nil {
+		r = execRunner{}
+	}
+	runner = r
+	return previous
+}
+
+// runGit runs git with args in dir using the package runner.
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	return runner.Run(ctx, dir, args...)
+}
+
+// runGitCombined runs git with args in dir and returns its standard output
+// and standard error together. Runners that cannot capture standard error
+// fall back to Run, returning standard output only.
+func runGitCombined(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	if r, ok := runner.(combinedRunner); ok {
+		return r.RunCombined(ctx, dir, args...)
+	}
+	return runner.Run(ctx, dir, args...)
+}
+
+// failureReason describes why a git command failed: its standard error,
+// falling back to its standard output and then to the error itself.
+// Timeouts are always reported as ErrTimeout.
+func failureReason(output []byte, err error) string {
+	if IsTimeoutError(err) {
+		return ErrTimeout.Error()
+	}
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Stderr != "" {
+		return cmdErr.Stderr
+	}
+	if reason := strings.TrimSpace(string(output)); reason != "" {
+		return reason
+	}
+	if cmdErr != nil {
+		return cmdErr.Err.Error()
+	}
+	return err.Error()
+}
@@ -0,0 +1,270 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRunner is a Runner returning canned results keyed by the git subcommand args.
+type fakeRunner struct {
+	// outputs maps space-joined args to stdout
+	outputs map[string]string
+	// failures maps space-joined args to stderr of a failed command
+	failures map[string]string
+	// hangs lists space-joined args of commands that block until ctx is done
+	hangs map[string]bool
+	// calls records the args of every call
+	calls []string
+}
+
+// Run returns the canned result for args. Unknown commands succeed with no output.
+func (f *fakeRunner) Run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	key := strings.Join(args, " ")
+	f.calls = append(f.calls, key)
+	if f.hangs[key] {
+		<-ctx.Done()
+		return nil, &CommandError{Args: args, Err: ErrTimeout}
+	}
+	if stderr, ok := f.failures[key]; ok {
+		return nil, &CommandError{Args: args, Stderr: stderr, Err: errors.New("exit status 128")}
+	}
+	return []byte(f.outputs[key]), nil
+}
+
+// useFakeRunner installs runner for the duration of the test.
+func useFakeRunner(t *testing.T, runner *fakeRunner) {
+	t.Helper()
+	previous := SetRunner(runner)
+	t.Cleanup(func() { SetRunner(previous) })
+}
+
+// TestListWorktreesWithFakeRunner verifies parsing without a real repository.
+func TestListWorktreesWithFakeRunner(t *testing.T) {
+	useFakeRunner(t, &fakeRunner{outputs: map[string]string{
+		"worktree list": "/repo  abc1234 [main]\n/repo-feature  def5678 [feature]\n",
+		"rev-parse --path-format=absolute --git-common-dir": "/repo/.git\n",
+	}})
+
+	worktrees, err := ListWorktrees("/repo")
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	if len(worktrees) != 2 {
+		t.Fatalf("Expected 2 worktrees, got %d", len(worktrees))
+	}
+	if !worktrees[0].IsMain || worktrees[1].IsMain {
+		t.Errorf("Expected only /repo to be main, got %+v", worktrees)
+	}
+	if worktrees[1].Branch != "feature" {
+		t.Errorf("Expected branch 'feature', got '%s'", worktrees[1].Branch)
+	}
+}
+
+// TestNotGitRepoWithFakeRunner verifies a failing rev-parse maps to NotGitRepoError.
+func TestNotGitRepoWithFakeRunner(t *testing.T) {
+	useFakeRunner(t, &fakeRunner{failures: map[string]string{
+		"rev-parse --git-dir": "fatal: not a git repository",
+	}})
+
+	if _, err := ListWorktrees("/tmp"); !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError, got: %v", err)
+	}
+}
+
+// TestAddWorktreeErrorWithFakeRunner verifies git's stderr becomes the error reason.
+func TestAddWorktreeErrorWithFakeRunner(t *testing.T) {
+	runner := &fakeRunner{failures: map[string]string{
+		"worktree add -b feature ../feature": "fatal: a branch named 'feature' already exists",
+	}}
+	useFakeRunner(t, runner)
+
+	err := AddWorktree("/repo", AddWorktreeOptions{Path: "../feature", Branch: "feature", CreateBranch: true})
+	var addErr *WorktreeAddError
+	if !errors.As(err, &addErr) {
+		t.Fatalf("Expected WorktreeAddError, got: %v", err)
+	}
+	if addErr.Reason != "fatal: a branch named 'feature' already exists" {
+		t.Errorf("Expected stderr as reason, got %q", addErr.Reason)
+	}
+	if runner.calls[len(runner.calls)-1] != "worktree add -b feature ../feature" {
+		t.Errorf("Unexpected git args: %v", runner.calls)
+	}
+}
+
+// TestFailureReason verifies the reason falls back from stderr to stdout to the error.
+func TestFailureReason(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+
+	if got := failureReason([]byte("out"), &CommandError{Stderr: "err", Err: exitErr}); got != "err" {
+		t.Errorf("Expected stderr, got %q", got)
+	}
+	if got := failureReason([]byte(" out \n"), &CommandError{Err: exitErr}); got != "out" {
+		t.Errorf("Expected stdout, got %q", got)
+	}
+	if got := failureReason(nil, &CommandError{Err: exitErr}); got != "exit status 1" {
+		t.Errorf("Expected error text, got %q", got)
+	}
+}
+
+// TestSetRunnerNilRestoresDefault verifies a nil runner falls back to the git binary.
+func TestSetRunnerNilRestoresDefault(t *testing.T) {
+	previous := SetRunner(nil)
+	defer SetRunner(previous)
+
+	if _, ok := runner.(execRunner); !ok {
+		t.Errorf("Expected default execRunner, got %T", runner)
+	}
+}
+
+// TestContextTimeoutWithFakeRunner verifies a hung git command surfaces as a timeout.
+func TestContextTimeoutWithFakeRunner(t *testing.T) {
+	useFakeRunner(t, &fakeRunner{hangs: map[string]bool{"worktree list": true}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := ListWorktreesContext(ctx, "/repo")
+	if !IsTimeoutError(err) {
+		t.Errorf("Expected timeout error, got: %v", err)
+	}
+}
+
+// TestCheckRepositoryTimeout verifies a hung repository check is not reported as "not a git repository".
+func TestCheckRepositoryTimeout(t *testing.T) {
+	useFakeRunner(t, &fakeRunner{hangs: map[string]bool{"rev-parse --git-dir": true}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := AddWorktreeContext(ctx, "/repo", AddWorktreeOptions{Path: "../x", Branch: "x", CreateBranch: true})
+	if IsNotGitRepoError(err) || !IsTimeoutError(err) {
+		t.Errorf("Expected timeout error, got: %v", err)
+	}
+}
+
+// TestExecRunnerTimeout verifies the real runner kills git and reports ErrTimeout.
+func TestExecRunnerTimeout(t *testing.T) {
+	repo := initTestRepo(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := execRunner{}.Run(ctx, repo, "status")
+	if err == nil {
+		t.Fatal("Expected error for cancelled context")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+	_, err = execRunner{}.Run(ctx, repo, "status")
+	if !IsTimeoutError(err) {
+		t.Errorf("Expected ErrTimeout for expired deadline, got: %v", err)
+	}
+}
+
+// TestExecRunnerRunCombined verifies standard error of a successful command is returned.
+func TestExecRunnerRunCombined(t *testing.T) {
+	repo := initTestRepo(t)
+
+	// `git checkout -b` reports the switch on standard error
+	output, err := execRunner{}.RunCombined(context.Background(), repo, "checkout", "-b", "combined")
+	if err != nil {
+		t.Fatalf("RunCombined failed: %v", err)
+	}
+	if !strings.Contains(string(output), "combined") {
+		t.Errorf("Expected standard error in output, got %q", output)
+	}
+
+	_, err = execRunner{}.RunCombined(context.Background(), repo, "checkout", "missing-branch")
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.Stderr == "" {
+		t.Errorf("Expected CommandError with output, got %v", err)
+	}
+}
+
+// TestGitCommandContext verifies every git command gets the same config and environment.
+func TestGitCommandContext(t *testing.T) {
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+
+	cmd := gitCommandContext(context.Background(), "/repo", "status", "--porcelain")
+	if cmd.Dir != "/repo" {
+		t.Errorf("Dir = %q, want /repo", cmd.Dir)
+	}
+	wantArgs := []string{"-c", "color.ui=false", "status", "--porcelain"}
+	if got := cmd.Args[1:]; strings.Join(got, " ") != strings.Join(wantArgs, " ") {
+		t.Errorf("Args = %q, want %q", got, wantArgs)
+	}
+
+	// The last entry for a variable is the one git sees
+	env := map[string]string{}
+	for _, kv := range cmd.Env {
+		if name, value, ok := strings.Cut(kv, "="); ok {
+			env[name] = value
+		}
+	}
+	if env["LC_ALL"] != "C" || env["GIT_OPTIONAL_LOCKS"] != "0" {
+		t.Errorf("Expected LC_ALL=C and GIT_OPTIONAL_LOCKS=0 to override the environment, got LC_ALL=%q GIT_OPTIONAL_LOCKS=%q", env["LC_ALL"], env["GIT_OPTIONAL_LOCKS"])
+	}
+}
+
+// TestExecRunnerIgnoresColorConfig verifies output stays uncolored when the user forces color.
+func TestExecRunnerIgnoresColorConfig(t *testing.T) {
+	repo := initTestRepo(t)
+	if _, err := (execRunner{}).Run(context.Background(), repo, "config", "color.ui", "always"); err != nil {
+		t.Fatalf("git config failed: %v", err)
+	}
+
+	output, err := execRunner{}.Run(context.Background(), repo, "branch")
+	if err != nil {
+		t.Fatalf("git branch failed: %v", err)
+	}
+	if strings.Contains(string(output), "\x1b[") {
+		t.Errorf("Expected no color codes in output, got %q", output)
+	}
+}
+
+// TestRunGitCombinedFallback verifies runners without RunCombined still return standard output.
+func TestRunGitCombinedFallback(t *testing.T) {
+	useFakeRunner(t, &fakeRunner{outputs: map[string]string{
+		"worktree prune --dry-run": "Removing worktrees/x: gitdir file points to non-existent location\n",
+	}})
+
+	output, err := PruneWorktreesDryRun("/repo")
+	if err != nil {
+		t.Fatalf("PruneWorktreesDryRun failed: %v", err)
+	}
+	if output != "Removing worktrees/x: gitdir file points to non-existent location" {
+		t.Errorf("Unexpected output %q", output)
+	}
+}
+
+// TestGitAvailable verifies the git binary is found on PATH
+func TestGitAvailable(t *testing.T) {
+	path, err := GitAvailable()
+	if err != nil {
+		t.Fatalf("GitAvailable failed: %v", err)
+	}
+	if path == "" {
+		t.Error("GitAvailable should return the path of git")
+	}
+}
+
+// TestGitAvailableMissing verifies a GitNotFoundError when git is not on PATH
+func TestGitAvailableMissing(t *testing.T) {
+	t.Setenv("PATH", "")
+
+	path, err := GitAvailable()
+	if err == nil {
+		t.Fatalf("GitAvailable should fail with an empty PATH, got %q", path)
+	}
+	if !IsGitNotFoundError(err) {
+		t.Errorf("Expected GitNotFoundError, got %T: %v", err, err)
+	}
+	if path != "" {
+		t.Errorf("Path should be empty on failure, got %q", path)
+	}
+}
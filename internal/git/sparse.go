@@ -0,0 +1,71 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SparseCheckoutError is returned when a sparse checkout cannot be set up.
+type SparseCheckoutError struct {
+	Path   string
+	Reason string
+}
+
+func (e *SparseCheckoutError) Error() string {
+	return fmt.Sprintf("failed to set up sparse checkout in %s: %s", e.Path, e.Reason)
+}
+
+// ConfigureSparseCheckout limits the worktree at path to patterns, usually
+// directories, and checks out the files they match. It is meant for
+// worktrees created with AddWorktreeOptions.NoCheckout, so the full tree is
+// never written to disk. Other worktrees of the repository are not affected.
+func ConfigureSparseCheckout(path string, patterns []string) error {
+	return ConfigureSparseCheckoutContext(context.Background(), path, patterns)
+}
+
+// ConfigureSparseCheckoutContext is like ConfigureSparseCheckout but stops
+// git when ctx is done.
+func ConfigureSparseCheckoutContext(ctx context.Context, path string, patterns []string) error {
+	if err := checkRepository(ctx, path); err != nil {
+		return err
+	}
+
+	if len(patterns) == 0 {
+		return &SparseCheckoutError{Path: path, Reason: "no patterns given"}
+	}
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			return &SparseCheckoutError{Path: path, Reason: "empty pattern"}
+		}
+	}
+
+	// "--" keeps a pattern starting with a dash from being read as an option
+	args := append([]string{"sparse-checkout", "set", "--"}, patterns...)
+	if output, err := runGit(ctx, path, args...); err != nil {
+		return &SparseCheckoutError{Path: path, Reason: failureReason(output, err)}
+	}
+
+	if output, err := runGit(ctx, path, "checkout"); err != nil {
+		return &SparseCheckoutError{Path: path, Reason: failureReason(output, err)}
+	}
+
+	return nil
+}
+
+// ParseSparsePatterns splits input on commas and newlines into sparse
+// checkout patterns, dropping surrounding spaces and empty entries.
+func ParseSparsePatterns(input string) []string {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+
+	var patterns []string
+	for _, field := range fields {
+		if pattern := strings.TrimSpace(field); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
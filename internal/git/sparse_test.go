@@ -0,0 +1,126 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestConfigureSparseCheckoutCommand verifies the patterns are set before the checkout.
+func TestConfigureSparseCheckoutCommand(t *testing.T) {
+	fake := &fakeRunner{}
+	useFakeRunner(t, fake)
+
+	if err := ConfigureSparseCheckout("/repo-wt", []string{"services/api", "-docs"}); err != nil {
+		t.Fatalf("ConfigureSparseCheckout failed: %v", err)
+	}
+
+	want := []string{"sparse-checkout set -- services/api -docs", "checkout"}
+	if got := fake.calls[len(fake.calls)-2:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Last git calls = %q, want %q", got, want)
+	}
+}
+
+// TestConfigureSparseCheckoutValidation verifies no command runs without usable patterns.
+func TestConfigureSparseCheckoutValidation(t *testing.T) {
+	fake := &fakeRunner{}
+	useFakeRunner(t, fake)
+
+	for _, patterns := range [][]string{nil, {}, {"a", " "}} {
+		err := ConfigureSparseCheckout("/repo-wt", patterns)
+		if _, ok := err.(*SparseCheckoutError); !ok {
+			t.Errorf("ConfigureSparseCheckout(%q) error = %v, want a SparseCheckoutError", patterns, err)
+		}
+	}
+	for _, call := range fake.calls {
+		if strings.HasPrefix(call, "sparse-checkout") || call == "checkout" {
+			t.Errorf("Expected no sparse checkout command for invalid patterns, got %q", call)
+		}
+	}
+}
+
+// TestConfigureSparseCheckoutFailure verifies a failed set skips the checkout.
+func TestConfigureSparseCheckoutFailure(t *testing.T) {
+	fake := &fakeRunner{failures: map[string]string{
+		"sparse-checkout set -- a": "fatal: not a git repository",
+	}}
+	useFakeRunner(t, fake)
+
+	err := ConfigureSparseCheckout("/repo-wt", []string{"a"})
+	sparseErr, ok := err.(*SparseCheckoutError)
+	if !ok {
+		t.Fatalf("Expected a SparseCheckoutError, got %v", err)
+	}
+	if !strings.Contains(sparseErr.Reason, "not a git repository") {
+		t.Errorf("Reason = %q, want git's message", sparseErr.Reason)
+	}
+	if last := fake.calls[len(fake.calls)-1]; last == "checkout" {
+		t.Error("Expected no checkout after a failed sparse-checkout set")
+	}
+}
+
+// TestParseSparsePatterns verifies patterns split on commas and newlines.
+func TestParseSparsePatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"", nil},
+		{" , ", nil},
+		{"services/api", []string{"services/api"}},
+		{"services/api, libs/shared ,docs", []string{"services/api", "libs/shared", "docs"}},
+		{"services/api\nlibs/shared\n", []string{"services/api", "libs/shared"}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseSparsePatterns(tt.input); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ParseSparsePatterns(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestConfigureSparseCheckoutIntegration verifies only the chosen directory is checked out.
+func TestConfigureSparseCheckoutIntegration(t *testing.T) {
+	repo := initTestRepo(t)
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	for _, dir := range []string{"keep", "skip"} {
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(repo, dir, "file.txt"), []byte(dir), 0644); err != nil {
+			t.Fatalf("Failed to write %s/file.txt: %v", dir, err)
+		}
+	}
+	run("add", ".")
+	run("commit", "-m", "add directories")
+
+	wtPath := filepath.Join(t.TempDir(), "sparse")
+	if err := AddWorktree(repo, AddWorktreeOptions{Path: wtPath, Branch: "sparse", CreateBranch: true, NoCheckout: true}); err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+	if err := ConfigureSparseCheckout(wtPath, []string{"keep"}); err != nil {
+		t.Fatalf("ConfigureSparseCheckout failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(wtPath, "keep", "file.txt")); err != nil {
+		t.Errorf("Expected keep/file.txt to be checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wtPath, "skip")); !os.IsNotExist(err) {
+		t.Errorf("Expected skip/ to stay out of the worktree, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "skip", "file.txt")); err != nil {
+		t.Errorf("Expected the main worktree to keep skip/file.txt: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// detachedStashBranch is the branch git records for stashes made on a detached HEAD.
+const detachedStashBranch = "(no branch)"
+
+// StashEntry describes a single stash.
+type StashEntry struct {
+	// Ref is the stash reference, e.g. "stash@{0}".
+	Ref string
+	// Branch is the branch the stash was made on, or "(no branch)" for a
+	// detached HEAD. Empty if the message does not name a branch.
+	Branch string
+	// Message is the stash description after the branch.
+	Message string
+}
+
+// StashCount returns how many stashes were made on the branch checked out
+// at path. Stashes are shared by all worktrees of a repository, so only the
+// ones related to this worktree's branch are counted.
+func StashCount(path string) (int, error) {
+	return StashCountContext(context.Background(), path)
+}
+
+// StashCountContext is like StashCount but stops git when ctx is done.
+func StashCountContext(ctx context.Context, path string) (int, error) {
+	if err := checkRepository(ctx, path); err != nil {
+		return 0, err
+	}
+
+	// symbolic-ref fails quietly on a detached HEAD
+	branch := detachedStashBranch
+	if output, err := runGit(ctx, path, "symbolic-ref", "--short", "-q", "HEAD"); err == nil {
+		branch = strings.TrimSpace(string(output))
+	} else if IsTimeoutError(err) {
+		return 0, fmt.Errorf("failed to count stashes: %s", failureReason(output, err))
+	}
+
+	output, err := runGit(ctx, path, "stash", "list")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count stashes: %s", failureReason(output, err))
+	}
+
+	count := 0
+	for _, entry := range ParseStashList(string(output)) {
+		if entry.Branch == branch {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// StashChanges stashes the uncommitted changes of the worktree at path,
+// including untracked files, with the given message. The stash is shared by
+// all worktrees, so it can still be applied after the worktree is removed.
+func StashChanges(path, message string) error {
+	return StashChangesContext(context.Background(), path, message)
+}
+
+// StashChangesContext is like StashChanges but stops git when ctx is done.
+func StashChangesContext(ctx context.Context, path, message string) error {
+	if err := checkRepository(ctx, path); err != nil {
+		return err
+	}
+
+	args := []string{"stash", "push", "--include-untracked"}
+	if message != "" {
+		args = append(args, "--message", message)
+	}
+	if output, err := runGit(ctx, path, args...); err != nil {
+		return fmt.Errorf("failed to stash changes: %s", failureReason(output, err))
+	}
+	return nil
+}
+
+// ParseStashList parses the output of `git stash list`, where each line looks
+// like "stash@{0}: WIP on main: abc1234 subject" or "stash@{1}: On main: message".
+// Empty lines are skipped.
+func ParseStashList(output string) []StashEntry {
+	var entries []StashEntry
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		ref, rest, _ := strings.Cut(line, ": ")
+		entry := StashEntry{Ref: ref, Message: rest}
+		for _, prefix := range []string{"WIP on ", "On "} {
+			if after, ok := strings.CutPrefix(rest, prefix); ok {
+				// Branch names cannot contain ':', so the first ": " ends it
+				if branch, message, ok := strings.Cut(after, ": "); ok {
+					entry.Branch = branch
+					entry.Message = message
+				}
+				break
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
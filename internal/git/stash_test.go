@@ -0,0 +1,124 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestParseStashList verifies multi-line stash list output is parsed.
+func TestParseStashList(t *testing.T) {
+	output := "stash@{0}: WIP on main: abc1234 Add feature\n" +
+		"stash@{1}: On feature/login: try: other approach\n" +
+		"stash@{2}: WIP on (no branch): def5678 Detached work\n" +
+		"stash@{3}: autostash\n" +
+		"\n"
+
+	entries := ParseStashList(output)
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d: %+v", len(entries), entries)
+	}
+
+	expected := []StashEntry{
+		{Ref: "stash@{0}", Branch: "main", Message: "abc1234 Add feature"},
+		{Ref: "stash@{1}", Branch: "feature/login", Message: "try: other approach"},
+		{Ref: "stash@{2}", Branch: "(no branch)", Message: "def5678 Detached work"},
+		{Ref: "stash@{3}", Branch: "", Message: "autostash"},
+	}
+	for i, want := range expected {
+		if entries[i] != want {
+			t.Errorf("Entry %d = %+v, want %+v", i, entries[i], want)
+		}
+	}
+
+	if entries := ParseStashList(""); len(entries) != 0 {
+		t.Errorf("Expected no entries for empty output, got %+v", entries)
+	}
+}
+
+// TestStashCount verifies only stashes of the worktree's branch are counted.
+func TestStashCount(t *testing.T) {
+	repo := initTestRepo(t)
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	stash := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, "test.txt"), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to modify test file: %v", err)
+		}
+		run("stash")
+	}
+
+	count, err := StashCount(repo)
+	if err != nil {
+		t.Fatalf("StashCount failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 stashes, got %d", count)
+	}
+
+	stash("one")
+	stash("two")
+	run("checkout", "-q", "-b", "other")
+	stash("three")
+
+	count, err = StashCount(repo)
+	if err != nil {
+		t.Fatalf("StashCount failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 stash on branch other, got %d", count)
+	}
+}
+
+// TestStashCountNotGitRepo verifies a NotGitRepoError outside a repository.
+func TestStashCountNotGitRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	if _, err := StashCount(t.TempDir()); !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError, got %v", err)
+	}
+}
+
+// TestStashChanges verifies tracked and untracked changes are stashed with the message.
+func TestStashChanges(t *testing.T) {
+	repo := initTestRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, "test.txt"), []byte("changed"), 0644); err != nil {
+		t.Fatalf("Failed to modify test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("Failed to create untracked file: %v", err)
+	}
+
+	if err := StashChanges(repo, "before deleting"); err != nil {
+		t.Fatalf("StashChanges failed: %v", err)
+	}
+
+	dirty, err := HasUncommittedChanges(repo)
+	if err != nil || dirty {
+		t.Errorf("Expected a clean worktree after stashing, dirty=%v err=%v", dirty, err)
+	}
+	cmd := exec.Command("git", "stash", "list")
+	cmd.Dir = repo
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git stash list failed: %v", err)
+	}
+	entries := ParseStashList(string(output))
+	if len(entries) != 1 || entries[0].Message != "before deleting" {
+		t.Errorf("Expected one stash with the message, got %+v", entries)
+	}
+
+	if err := StashChanges(t.TempDir(), ""); !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError, got %v", err)
+	}
+}
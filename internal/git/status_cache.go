@@ -0,0 +1,119 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// StatusCache caches worktree statuses per path for a limited time, so
+// repeated refreshes do not re-run git status for every worktree.
+// It is safe for concurrent use.
+type StatusCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]statusCacheEntry
+	// now returns the current time; replaced in tests
+	now func() time.Time
+}
+
+// statusCacheEntry is a cached status and when it was loaded.
+type statusCacheEntry struct {
+	status   WorktreeStatus
+	loadedAt time.Time
+}
+
+// NewStatusCache creates a cache whose entries stay valid for ttl.
+// A non-positive ttl disables caching.
+func NewStatusCache(ttl time.Duration) *StatusCache {
+	return &StatusCache{
+		ttl:     ttl,
+		entries: make(map[string]statusCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// SetTTL changes how long entries stay valid. Existing entries are kept.
+func (c *StatusCache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// Get returns the status of the worktree at path, from the cache if it was
+// loaded within the TTL. Errors are not cached.
+func (c *StatusCache) Get(path string) (*WorktreeStatus, error) {
+	return c.GetContext(context.Background(), path)
+}
+
+// GetContext is like Get but stops git when ctx is done.
+func (c *StatusCache) GetContext(ctx context.Context, path string) (*WorktreeStatus, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[path]
+	fresh := ok && c.ttl > 0 && c.now().Sub(entry.loadedAt) < c.ttl
+	c.mu.Unlock()
+
+	if fresh {
+		status := entry.status
+		return &status, nil
+	}
+
+	status, err := GetWorktreeStatusContext(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[path] = statusCacheEntry{status: *status, loadedAt: c.now()}
+	c.mu.Unlock()
+
+	result := *status
+	return &result, nil
+}
+
+// Invalidate drops the cached status of the worktree at path.
+func (c *StatusCache) Invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, path)
+}
+
+// InvalidateAll drops all cached statuses.
+func (c *StatusCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]statusCacheEntry)
+}
+
+// statusCache is the package-wide cache used by GetWorktreeStatusCached.
+// It caches nothing until SetStatusCacheTTL sets the configured TTL.
+var statusCache = NewStatusCache(0)
+
+// GetWorktreeStatusCached is like GetWorktreeStatus but returns a cached
+// status if one was loaded within the status cache TTL.
+func GetWorktreeStatusCached(path string) (*WorktreeStatus, error) {
+	return statusCache.Get(path)
+}
+
+// GetWorktreeStatusCachedContext is like GetWorktreeStatusCached but stops
+// git when ctx is done.
+func GetWorktreeStatusCachedContext(ctx context.Context, path string) (*WorktreeStatus, error) {
+	return statusCache.GetContext(ctx, path)
+}
+
+// SetStatusCacheTTL sets how long GetWorktreeStatusCached reuses a status.
+func SetStatusCacheTTL(ttl time.Duration) {
+	statusCache.SetTTL(ttl)
+}
+
+// InvalidateWorktreeStatus drops the cached status of the worktree at path,
+// e.g. after an operation that changed it.
+func InvalidateWorktreeStatus(path string) {
+	statusCache.Invalidate(path)
+}
+
+// InvalidateAllWorktreeStatus drops all cached worktree statuses.
+func InvalidateAllWorktreeStatus() {
+	statusCache.InvalidateAll()
+}
@@ -0,0 +1,166 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// statusOutput is the git status output of a worktree with one modified file.
+const statusOutput = "## main\x00 M file.txt\x00"
+
+// useCountingRunner installs a fake runner answering git status for any path
+// and returns a function counting the git status calls made so far.
+func useCountingRunner(t *testing.T) func() int {
+	t.Helper()
+	fake := &fakeRunner{outputs: map[string]string{
+		"status --porcelain=v1 -z --branch":                      statusOutput,
+		"status --porcelain=v1 -z --branch --untracked-files=no": statusOutput,
+	}}
+	useFakeRunner(t, fake)
+	return func() int {
+		calls := 0
+		for _, call := range fake.calls {
+			if strings.HasPrefix(call, "status ") {
+				calls++
+			}
+		}
+		return calls
+	}
+}
+
+// newCountingStatusCache returns a cache with a fake clock, reading statuses
+// from a fake runner, and a function counting the git status calls.
+func newCountingStatusCache(t *testing.T, ttl time.Duration) (*StatusCache, func() int, *time.Time) {
+	t.Helper()
+	calls := useCountingRunner(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cache := NewStatusCache(ttl)
+	cache.now = func() time.Time { return now }
+	return cache, calls, &now
+}
+
+// TestStatusCacheWithinTTL verifies a second call within the TTL doesn't reload.
+func TestStatusCacheWithinTTL(t *testing.T) {
+	cache, calls, now := newCountingStatusCache(t, 3*time.Second)
+
+	first, err := cache.Get("/repo")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	*now = now.Add(time.Second)
+	second, _ := cache.Get("/repo")
+
+	if calls() != 1 {
+		t.Errorf("Expected 1 git status within TTL, got %d", calls())
+	}
+	if *second != *first || second.ModifiedCount != 1 {
+		t.Errorf("Expected cached status, got %+v", second)
+	}
+
+	// Callers get copies, so mutating one doesn't affect the cache
+	second.ModifiedCount = 99
+	if third, _ := cache.Get("/repo"); third.ModifiedCount == 99 {
+		t.Error("Cached status should not be shared with callers")
+	}
+
+	// Other paths are cached separately
+	cache.Get("/other")
+	if calls() != 2 {
+		t.Errorf("Expected a git status for a different path, got %d", calls())
+	}
+}
+
+// TestStatusCacheExpires verifies entries are reloaded after the TTL.
+func TestStatusCacheExpires(t *testing.T) {
+	cache, calls, now := newCountingStatusCache(t, 3*time.Second)
+
+	cache.Get("/repo")
+	*now = now.Add(3 * time.Second)
+	cache.Get("/repo")
+
+	if calls() != 2 {
+		t.Errorf("Expected reload after TTL, got %d git status calls", calls())
+	}
+}
+
+// TestStatusCacheInvalidate verifies invalidated entries are reloaded.
+func TestStatusCacheInvalidate(t *testing.T) {
+	cache, calls, _ := newCountingStatusCache(t, time.Minute)
+
+	cache.Get("/repo")
+	cache.Get("/other")
+	cache.Invalidate("/repo")
+	cache.Get("/repo")
+	cache.Get("/other")
+	if calls() != 3 {
+		t.Errorf("Expected only /repo to reload, got %d git status calls", calls())
+	}
+
+	cache.InvalidateAll()
+	cache.Get("/other")
+	if calls() != 4 {
+		t.Errorf("Expected reload after InvalidateAll, got %d git status calls", calls())
+	}
+}
+
+// TestStatusCacheDisabled verifies a zero TTL always reloads.
+func TestStatusCacheDisabled(t *testing.T) {
+	cache, calls, _ := newCountingStatusCache(t, 0)
+
+	cache.Get("/repo")
+	cache.Get("/repo")
+	if calls() != 2 {
+		t.Errorf("Expected every call to run git status with caching disabled, got %d", calls())
+	}
+}
+
+// TestStatusCacheErrorsNotCached verifies failed loads are retried.
+func TestStatusCacheErrorsNotCached(t *testing.T) {
+	fake := &fakeRunner{failures: map[string]string{
+		"status --porcelain=v1 -z --branch":                      "fatal: index file corrupt",
+		"status --porcelain=v1 -z --branch --untracked-files=no": "fatal: index file corrupt",
+	}}
+	useFakeRunner(t, fake)
+	cache := NewStatusCache(time.Minute)
+
+	if _, err := cache.Get("/repo"); err == nil {
+		t.Error("Expected error from git status")
+	}
+	cache.Get("/repo")
+	calls := 0
+	for _, call := range fake.calls {
+		if strings.HasPrefix(call, "status ") {
+			calls++
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected errors not to be cached, got %d git status calls", calls)
+	}
+}
+
+// TestGetWorktreeStatusCachedSkipsGit verifies the package cache runs git
+// status once for two calls within the TTL.
+func TestGetWorktreeStatusCachedSkipsGit(t *testing.T) {
+	calls := useCountingRunner(t)
+	SetStatusCacheTTL(time.Minute)
+	t.Cleanup(func() { SetStatusCacheTTL(0) })
+	InvalidateAllWorktreeStatus()
+	t.Cleanup(InvalidateAllWorktreeStatus)
+
+	for i := 0; i < 2; i++ {
+		status, err := GetWorktreeStatusCachedContext(context.Background(), "/repo")
+		if err != nil {
+			t.Fatalf("GetWorktreeStatusCachedContext failed: %v", err)
+		}
+		if status.ModifiedCount != 1 {
+			t.Errorf("Expected the status from git, got %+v", status)
+		}
+	}
+	if calls() != 1 {
+		t.Errorf("Expected 1 git status for two calls within the TTL, got %d", calls())
+	}
+}
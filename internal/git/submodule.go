@@ -0,0 +1,75 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SubmoduleStatus counts the submodules of a worktree by state.
+type SubmoduleStatus struct {
+	// Total is the number of submodules listed.
+	Total int
+	// Uninitialized counts submodules that are not checked out.
+	Uninitialized int
+	// Modified counts submodules whose checked out commit differs from the
+	// one recorded in the worktree, or that have merge conflicts.
+	Modified int
+}
+
+// HasSubmodules reports whether the worktree at path has a .gitmodules file.
+func HasSubmodules(path string) (bool, error) {
+	_, err := os.Stat(filepath.Join(path, ".gitmodules"))
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// GetSubmoduleStatus summarizes "git submodule status" for the worktree at
+// path. Without a .gitmodules file git is not run and the status is zero.
+func GetSubmoduleStatus(path string) (SubmoduleStatus, error) {
+	return GetSubmoduleStatusContext(context.Background(), path)
+}
+
+// GetSubmoduleStatusContext is like GetSubmoduleStatus but stops git when ctx is done.
+func GetSubmoduleStatusContext(ctx context.Context, path string) (SubmoduleStatus, error) {
+	if has, err := HasSubmodules(path); err != nil || !has {
+		return SubmoduleStatus{}, err
+	}
+	if err := checkRepository(ctx, path); err != nil {
+		return SubmoduleStatus{}, err
+	}
+
+	output, err := runGit(ctx, path, "submodule", "status")
+	if err != nil {
+		return SubmoduleStatus{}, fmt.Errorf("failed to get submodule status: %s", failureReason(output, err))
+	}
+	return ParseSubmoduleStatus(string(output)), nil
+}
+
+// ParseSubmoduleStatus parses the output of git submodule status. Each line
+// starts with "-" for an uninitialized submodule, "+" for one checked out at
+// a different commit, "U" for one with merge conflicts, or a space.
+func ParseSubmoduleStatus(output string) SubmoduleStatus {
+	var status SubmoduleStatus
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		status.Total++
+		switch line[0] {
+		case '-':
+			status.Uninitialized++
+		case '+', 'U':
+			status.Modified++
+		}
+	}
+	return status
+}
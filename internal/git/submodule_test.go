@@ -0,0 +1,88 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseSubmoduleStatus verifies submodules are counted by their status prefix.
+func TestParseSubmoduleStatus(t *testing.T) {
+	output := " 1111111111111111111111111111111111111111 libs/ok (v1.0)\n" +
+		"-2222222222222222222222222222222222222222 libs/missing\n" +
+		"+3333333333333333333333333333333333333333 libs/moved (v1.1-2-g3333333)\n" +
+		"U4444444444444444444444444444444444444444 libs/conflict\n"
+
+	got := ParseSubmoduleStatus(output)
+	want := SubmoduleStatus{Total: 4, Uninitialized: 1, Modified: 2}
+	if got != want {
+		t.Errorf("ParseSubmoduleStatus() = %+v, want %+v", got, want)
+	}
+	if got := ParseSubmoduleStatus(""); got != (SubmoduleStatus{}) {
+		t.Errorf("ParseSubmoduleStatus(\"\") = %+v, want zero", got)
+	}
+}
+
+// TestGetSubmoduleStatusWithoutSubmodules verifies git is not run without a .gitmodules file.
+func TestGetSubmoduleStatusWithoutSubmodules(t *testing.T) {
+	fake := &fakeRunner{}
+	useFakeRunner(t, fake)
+
+	dir := t.TempDir()
+	if has, err := HasSubmodules(dir); err != nil || has {
+		t.Errorf("HasSubmodules = %v, %v; want false, nil", has, err)
+	}
+	status, err := GetSubmoduleStatus(dir)
+	if err != nil || status != (SubmoduleStatus{}) {
+		t.Errorf("GetSubmoduleStatus = %+v, %v; want zero, nil", status, err)
+	}
+	if len(fake.calls) != 0 {
+		t.Errorf("Expected no git calls, got %v", fake.calls)
+	}
+}
+
+// TestGetSubmoduleStatusIntegration verifies an uninitialized submodule is reported.
+func TestGetSubmoduleStatusIntegration(t *testing.T) {
+	sub := initTestRepo(t)
+	repo := initTestRepo(t)
+	runGit := func(dir string, args ...string) {
+		t.Helper()
+		args = append([]string{"-c", "user.email=test@example.com", "-c", "user.name=Test", "-c", "protocol.file.allow=always"}, args...)
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	runGit(repo, "submodule", "add", sub, "libs/sub")
+	runGit(repo, "commit", "-m", "add submodule")
+
+	if has, err := HasSubmodules(repo); err != nil || !has {
+		t.Fatalf("HasSubmodules = %v, %v; want true, nil", has, err)
+	}
+	status, err := GetSubmoduleStatus(repo)
+	if err != nil {
+		t.Fatalf("GetSubmoduleStatus failed: %v", err)
+	}
+	if status != (SubmoduleStatus{Total: 1}) {
+		t.Errorf("Expected 1 clean submodule, got %+v", status)
+	}
+
+	// A fresh worktree does not check out its submodules
+	wtPath := filepath.Join(t.TempDir(), "wt")
+	runGit(repo, "worktree", "add", "--detach", wtPath)
+	if _, err := os.Stat(filepath.Join(wtPath, ".gitmodules")); err != nil {
+		t.Fatalf("Expected .gitmodules in the new worktree: %v", err)
+	}
+	status, err = GetSubmoduleStatus(wtPath)
+	if err != nil {
+		t.Fatalf("GetSubmoduleStatus failed: %v", err)
+	}
+	if status != (SubmoduleStatus{Total: 1, Uninitialized: 1}) {
+		t.Errorf("Expected 1 uninitialized submodule, got %+v", status)
+	}
+}
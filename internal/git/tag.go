@@ -0,0 +1,105 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TagExistsError is returned when creating a tag whose name is taken.
+type TagExistsError struct {
+	Tag string
+}
+
+func (e *TagExistsError) Error() string {
+	return fmt.Sprintf("a tag named '%s' already exists", e.Tag)
+}
+
+// IsTagExistsError checks if an error is a TagExistsError.
+func IsTagExistsError(err error) bool {
+	var existsErr *TagExistsError
+	return errors.As(err, &existsErr)
+}
+
+// TagError is returned when a tag cannot be created.
+type TagError struct {
+	Tag    string
+	Reason string
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("failed to create tag %s: %s", e.Tag, e.Reason)
+}
+
+// ArchiveTagName returns the tag that keeps the tip of branch reachable
+// after its worktree is deleted on date, e.g. "archive/feature-2024-05-01".
+func ArchiveTagName(branch string, date time.Time) string {
+	return "archive/" + branch + "-" + date.Format("2006-01-02")
+}
+
+// TagCommit creates the lightweight tag at commit, a branch name or commit
+// hash, in the repository containing dir. An existing tag is not moved.
+func TagCommit(dir, tag, commit string) error {
+	return TagCommitContext(context.Background(), dir, tag, commit)
+}
+
+// TagCommitContext is like TagCommit but stops git when ctx is done.
+func TagCommitContext(ctx context.Context, dir, tag, commit string) error {
+	if err := checkRepository(ctx, dir); err != nil {
+		return err
+	}
+
+	// Tags follow the same naming rules as branches
+	var invalid *InvalidBranchNameError
+	if err := ValidateBranchName(tag); errors.As(err, &invalid) {
+		return &TagError{Tag: tag, Reason: invalid.Reason}
+	}
+	if commit == "" || strings.HasPrefix(commit, "-") {
+		return &TagError{Tag: tag, Reason: fmt.Sprintf("invalid commit %q", commit)}
+	}
+
+	output, err := runGit(ctx, dir, "tag", tag, commit)
+	if err != nil {
+		reason := failureReason(output, err)
+		if strings.Contains(reason, "already exists") {
+			return &TagExistsError{Tag: tag}
+		}
+		return &TagError{Tag: tag, Reason: reason}
+	}
+
+	return nil
+}
+
+// ListTags returns the tags of the repository containing dir, newest first
+// by creation date. A repository without tags returns an empty list.
+func ListTags(dir string) ([]string, error) {
+	return ListTagsContext(context.Background(), dir)
+}
+
+// ListTagsContext is like ListTags but stops git when ctx is done.
+func ListTagsContext(ctx context.Context, dir string) ([]string, error) {
+	if err := checkRepository(ctx, dir); err != nil {
+		return nil, err
+	}
+
+	output, err := runGit(ctx, dir, "tag", "--sort=-creatordate")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %s", failureReason(output, err))
+	}
+
+	return ParseTags(string(output)), nil
+}
+
+// ParseTags parses the output of git tag, one tag per line.
+func ParseTags(output string) []string {
+	var tags []string
+	for _, line := range strings.Split(output, "\n") {
+		if tag := strings.TrimSpace(line); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,166 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestArchiveTagName verifies archive tags are named after the branch and date.
+func TestArchiveTagName(t *testing.T) {
+	date := time.Date(2024, time.May, 1, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		branch   string
+		expected string
+	}{
+		{"feature", "archive/feature-2024-05-01"},
+		{"feature/login", "archive/feature/login-2024-05-01"},
+	}
+
+	for _, tt := range tests {
+		if got := ArchiveTagName(tt.branch, date); got != tt.expected {
+			t.Errorf("ArchiveTagName(%q) = %q, want %q", tt.branch, got, tt.expected)
+		}
+		if err := ValidateBranchName(ArchiveTagName(tt.branch, date)); err != nil {
+			t.Errorf("ArchiveTagName(%q) is not a valid ref name: %v", tt.branch, err)
+		}
+	}
+}
+
+// TestTagCommitCommand verifies the git command run and that invalid names are rejected first.
+func TestTagCommitCommand(t *testing.T) {
+	fake := &fakeRunner{}
+	useFakeRunner(t, fake)
+
+	if err := TagCommit("/repo", "archive/feature-2024-05-01", "feature"); err != nil {
+		t.Fatalf("TagCommit failed: %v", err)
+	}
+	want := "tag archive/feature-2024-05-01 feature"
+	if last := fake.calls[len(fake.calls)-1]; last != want {
+		t.Errorf("Last git call = %q, want %q", last, want)
+	}
+
+	calls := len(fake.calls)
+	for _, args := range [][2]string{{"-d", "feature"}, {"bad..name", "feature"}, {"archive/x", "--delete"}, {"archive/x", ""}} {
+		if err := TagCommit("/repo", args[0], args[1]); err == nil {
+			t.Errorf("Expected an error for tag %q at %q", args[0], args[1])
+		}
+	}
+	for _, call := range fake.calls[calls:] {
+		if strings.HasPrefix(call, "tag") {
+			t.Errorf("Expected no tag command for invalid input, got %q", call)
+		}
+	}
+}
+
+// TestTagCommitExists verifies an existing tag is reported as such.
+func TestTagCommitExists(t *testing.T) {
+	useFakeRunner(t, &fakeRunner{failures: map[string]string{
+		"tag archive/feature-2024-05-01 feature": "fatal: tag 'archive/feature-2024-05-01' already exists",
+	}})
+
+	err := TagCommit("/repo", "archive/feature-2024-05-01", "feature")
+	if !IsTagExistsError(err) {
+		t.Errorf("Expected a TagExistsError, got %v", err)
+	}
+}
+
+// TestTagCommitIntegration verifies the tag points at the branch tip.
+func TestTagCommitIntegration(t *testing.T) {
+	repo := initTestRepo(t)
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	run("branch", "feature")
+
+	if err := TagCommit(repo, "archive/feature-2024-05-01", "feature"); err != nil {
+		t.Fatalf("TagCommit failed: %v", err)
+	}
+	if tagged, tip := run("rev-parse", "archive/feature-2024-05-01"), run("rev-parse", "feature"); tagged != tip {
+		t.Errorf("Tag points at %s, want the branch tip %s", tagged, tip)
+	}
+	if err := TagCommit(repo, "archive/feature-2024-05-01", "feature"); !IsTagExistsError(err) {
+		t.Errorf("Expected a TagExistsError for a second tag, got %v", err)
+	}
+}
+
+// TestParseTags verifies one tag is read per line and blank lines are skipped.
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected []string
+	}{
+		{"", nil},
+		{"\n", nil},
+		{"v1.1.0\nv1.0.0\n", []string{"v1.1.0", "v1.0.0"}},
+		{"release/2024-05\n\nv1.0.0", []string{"release/2024-05", "v1.0.0"}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseTags(tt.output); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ParseTags(%q) = %q, want %q", tt.output, got, tt.expected)
+		}
+	}
+}
+
+// TestListTagsCommand verifies tags are listed newest first.
+func TestListTagsCommand(t *testing.T) {
+	fake := &fakeRunner{outputs: map[string]string{
+		"tag --sort=-creatordate": "v1.1.0\nv1.0.0\n",
+	}}
+	useFakeRunner(t, fake)
+
+	tags, err := ListTags("/repo")
+	if err != nil {
+		t.Fatalf("ListTags failed: %v", err)
+	}
+	if strings.Join(tags, ",") != "v1.1.0,v1.0.0" {
+		t.Errorf("ListTags() = %q, want [v1.1.0 v1.0.0]", tags)
+	}
+	if last := fake.calls[len(fake.calls)-1]; last != "tag --sort=-creatordate" {
+		t.Errorf("Last git call = %q, want %q", last, "tag --sort=-creatordate")
+	}
+}
+
+// TestListTagsIntegration verifies the most recently created tag comes first.
+func TestListTagsIntegration(t *testing.T) {
+	repo := initTestRepo(t)
+	run := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE="+date, "GIT_AUTHOR_DATE="+date)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	if tags, err := ListTags(repo); err != nil || len(tags) != 0 {
+		t.Errorf("Expected no tags, got %q (err: %v)", tags, err)
+	}
+
+	run("2024-01-01T00:00:00Z", "commit", "--allow-empty", "-m", "first release")
+	run("2024-01-01T00:00:00Z", "tag", "-a", "v1.0.0", "-m", "v1.0.0")
+	run("2024-06-01T00:00:00Z", "commit", "--allow-empty", "-m", "second release")
+	run("2024-06-01T00:00:00Z", "tag", "-a", "v0.9.9-late", "-m", "created after v1.0.0")
+
+	tags, err := ListTags(repo)
+	if err != nil {
+		t.Fatalf("ListTags failed: %v", err)
+	}
+	if strings.Join(tags, ",") != "v0.9.9-late,v1.0.0" {
+		t.Errorf("ListTags() = %q, want the newest tag first", tags)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 )
@@ -14,6 +15,12 @@ type TerminalOpener struct {
 	// terminalCmd is the terminal emulator command to use.
 	// If empty, will auto-detect based on environment.
 	terminalCmd string
+	// start launches the terminal command without waiting for it.
+	// Defaults to (*exec.Cmd).Start; tests replace it to avoid opening windows.
+	start func(cmd *exec.Cmd) error
+	// openInTab opens a tab in the frontmost window instead of a new window,
+	// for terminals scripted with AppleScript (iTerm2 and Terminal.app).
+	openInTab bool
 }
 
 // NewTerminalOpener creates a new TerminalOpener with auto-detection.
@@ -26,6 +33,13 @@ func NewTerminalOpenerWithCmd(cmd string) *TerminalOpener {
 	return &TerminalOpener{terminalCmd: cmd}
 }
 
+// SetOpenInTab makes iTerm2 and Terminal.app open worktrees in a new tab of
+// the frontmost window rather than a new window. Other terminals always
+// open a window.
+func (t *TerminalOpener) SetOpenInTab(inTab bool) {
+	t.openInTab = inTab
+}
+
 // OpenWorktreeResult contains the result of opening a worktree.
 type OpenWorktreeResult struct {
 	// Success indicates if the terminal was opened successfully.
@@ -121,32 +135,38 @@ func (t *TerminalOpener) detectMacOSTerminal() (string, []string) {
 	return "open", []string{"-a", "Terminal"}
 }
 
-// detectLinuxTerminal detects available terminal on Linux.
+// linuxTerminals lists known Linux terminal emulators in order of preference,
+// with the arguments that open them in the directory appended after them.
+var linuxTerminals = []struct {
+	cmd  string
+	args []string
+}{
+	// GNOME Terminal
+	{"gnome-terminal", []string{"--working-directory"}},
+	// Konsole
+	{"konsole", []string{"--workdir"}},
+	// xfce4-terminal
+	{"xfce4-terminal", []string{"--working-directory"}},
+	// Alacritty
+	{"alacritty", []string{"--working-directory"}},
+	// Kitty
+	{"kitty", []string{"--directory"}},
+	// WezTerm
+	{"wezterm", []string{"start", "--cwd"}},
+	// Terminator
+	{"terminator", []string{"--working-directory"}},
+	// xterm (fallback, uses -e cd)
+	{"xterm", []string{"-e", "cd"}},
+}
+
+// detectLinuxTerminal detects available terminal on Linux. The terminal
+// named by $TERMINAL wins when it is on PATH.
 func (t *TerminalOpener) detectLinuxTerminal() (string, []string) {
-	// Check for common terminal emulators in order of preference
-	terminals := []struct {
-		cmd  string
-		args []string
-	}{
-		// GNOME Terminal
-		{"gnome-terminal", []string{"--working-directory"}},
-		// Konsole
-		{"konsole", []string{"--workdir"}},
-		// xfce4-terminal
-		{"xfce4-terminal", []string{"--working-directory"}},
-		// Alacritty
-		{"alacritty", []string{"--working-directory"}},
-		// Kitty
-		{"kitty", []string{"--directory"}},
-		// WezTerm
-		{"wezterm", []string{"start", "--cwd"}},
-		// Terminator
-		{"terminator", []string{"--working-directory"}},
-		// xterm (fallback, uses -e cd)
-		{"xterm", []string{"-e", "cd"}},
+	if cmd, args := preferredLinuxTerminal(); cmd != "" {
+		return cmd, args
 	}
 
-	for _, term := range terminals {
+	for _, term := range linuxTerminals {
 		if path, err := exec.LookPath(term.cmd); err == nil && path != "" {
 			return term.cmd, term.args
 		}
@@ -155,6 +175,28 @@ func (t *TerminalOpener) detectLinuxTerminal() (string, []string) {
 	return "", nil
 }
 
+// preferredLinuxTerminal returns the terminal named by $TERMINAL (a command
+// name or path) if it can be found. Known terminals get their working
+// directory flag; unknown ones are started with -e to run a shell that
+// changes directory first.
+func preferredLinuxTerminal() (string, []string) {
+	cmd := strings.TrimSpace(os.Getenv("TERMINAL"))
+	if cmd == "" {
+		return "", nil
+	}
+	if path, err := exec.LookPath(cmd); err != nil || path == "" {
+		return "", nil
+	}
+
+	name := filepath.Base(cmd)
+	for _, term := range linuxTerminals {
+		if term.cmd == name {
+			return cmd, term.args
+		}
+	}
+	return cmd, []string{"-e"}
+}
+
 // detectWindowsTerminal detects available terminal on Windows.
 func (t *TerminalOpener) detectWindowsTerminal() (string, []string) {
 	// Check for Windows Terminal first
@@ -186,6 +228,9 @@ func (t *TerminalOpener) openTerminal(terminalCmd string, args []string, path st
 		return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
 	}
 
+	if t.start != nil {
+		return t.start(cmd)
+	}
 	return cmd.Start()
 }
 
@@ -193,44 +238,79 @@ func (t *TerminalOpener) openTerminal(terminalCmd string, args []string, path st
 func (t *TerminalOpener) buildMacOSCommand(terminalCmd string, args []string, path string) *exec.Cmd {
 	// For iTerm and Terminal.app, we use AppleScript to open in specific directory
 	if terminalCmd == "open" && len(args) >= 2 {
-		app := args[1]
-		switch app {
-		case "iTerm":
-			// iTerm2 AppleScript
-			script := fmt.Sprintf(`
+		if script := macOSScript(args[1], path, t.openInTab); script != "" {
+			return exec.Command("osascript", "-e", script)
+		}
+	}
+
+	// Direct command with path
+	fullArgs := append(args, path)
+	return exec.Command(terminalCmd, fullArgs...)
+}
+
+// macOSScript returns the AppleScript that opens app ("iTerm" or "Terminal")
+// at path, in a new tab of the frontmost window when inTab is set and one
+// exists, or in a new window otherwise. Returns "" for other apps.
+func macOSScript(app, path string, inTab bool) string {
+	cd := fmt.Sprintf("cd %s && clear", shellQuote(path))
+
+	switch app {
+	case "iTerm":
+		if inTab {
+			return fmt.Sprintf(`
+				tell application "iTerm"
+					if (count of windows) = 0 then
+						create window with default profile
+					else
+						tell current window to create tab with default profile
+					end if
+					tell current session of current window
+						write text "%s"
+					end tell
+					activate
+				end tell
+			`, cd)
+		}
+		return fmt.Sprintf(`
 				tell application "iTerm"
 					create window with default profile
 					tell current session of current window
-						write text "cd %s && clear"
+						write text "%s"
 					end tell
 				end tell
-			`, shellQuote(path))
-			return exec.Command("osascript", "-e", script)
-		case "Terminal":
-			// Terminal.app AppleScript
-			script := fmt.Sprintf(`
+			`, cd)
+	case "Terminal":
+		if inTab {
+			// Terminal.app cannot script new tabs, so press the shortcut
+			// (needs accessibility permission) and run in the new tab
+			return fmt.Sprintf(`
 				tell application "Terminal"
-					do script "cd %s && clear"
 					activate
+					if (count of windows) = 0 then
+						do script "%s"
+					else
+						-- Cmd-T opens a new tab in the front window
+						tell application "System Events" to keystroke "t" using command down
+						do script "%s" in window 1
+					end if
 				end tell
-			`, shellQuote(path))
-			return exec.Command("osascript", "-e", script)
-		default:
-			// Other apps: append path to args
-			fullArgs := append(args, path)
-			return exec.Command(terminalCmd, fullArgs...)
+			`, cd, cd)
 		}
+		return fmt.Sprintf(`
+				tell application "Terminal"
+					do script "%s"
+					activate
+				end tell
+			`, cd)
+	default:
+		return ""
 	}
-
-	// Direct command with path
-	fullArgs := append(args, path)
-	return exec.Command(terminalCmd, fullArgs...)
 }
 
 // buildLinuxCommand builds the command to open a terminal on Linux.
 func (t *TerminalOpener) buildLinuxCommand(terminalCmd string, args []string, path string) *exec.Cmd {
-	// Special handling for xterm
-	if terminalCmd == "xterm" {
+	// xterm and unknown terminals run a shell that changes directory first
+	if filepath.Base(terminalCmd) == "xterm" || (len(args) > 0 && args[0] == "-e") {
 		return exec.Command(terminalCmd, "-e", "bash", "-c", fmt.Sprintf("cd %s && bash", shellQuote(path)))
 	}
 
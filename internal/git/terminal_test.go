@@ -2,7 +2,10 @@
 package git
 
 import (
+	"errors"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -133,6 +136,65 @@ func TestDetectTerminalWithCustomCmd(t *testing.T) {
 	}
 }
 
+// fakeTerminals puts executables with the given names on an otherwise empty PATH.
+func fakeTerminals(t *testing.T, names ...string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("Fake executables need a Unix shell")
+	}
+	dir := t.TempDir()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("Failed to write fake terminal: %v", err)
+		}
+	}
+	t.Setenv("PATH", dir)
+	return dir
+}
+
+// TestDetectLinuxTerminalPrefersTERMINAL verifies $TERMINAL is chosen over the built-in preference order.
+func TestDetectLinuxTerminalPrefersTERMINAL(t *testing.T) {
+	fakeTerminals(t, "gnome-terminal", "alacritty")
+	t.Setenv("TERMINAL", "alacritty")
+
+	cmd, args := NewTerminalOpener().detectLinuxTerminal()
+	if cmd != "alacritty" {
+		t.Errorf("Expected alacritty from $TERMINAL, got %q", cmd)
+	}
+	if len(args) != 1 || args[0] != "--working-directory" {
+		t.Errorf("Expected alacritty's working directory flag, got %v", args)
+	}
+}
+
+// TestDetectLinuxTerminalUnknownTERMINAL verifies unknown terminals are started with -e.
+func TestDetectLinuxTerminalUnknownTERMINAL(t *testing.T) {
+	dir := fakeTerminals(t, "gnome-terminal", "foot")
+	t.Setenv("TERMINAL", filepath.Join(dir, "foot"))
+
+	cmd, args := NewTerminalOpener().detectLinuxTerminal()
+	if cmd != filepath.Join(dir, "foot") || len(args) != 1 || args[0] != "-e" {
+		t.Fatalf("Expected foot started with -e, got %q %v", cmd, args)
+	}
+
+	built := NewTerminalOpener().buildLinuxCommand(cmd, args, "/test/path")
+	want := []string{cmd, "-e", "bash", "-c", "cd '/test/path' && bash"}
+	if strings.Join(built.Args, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %q, got %q", want, built.Args)
+	}
+}
+
+// TestDetectLinuxTerminalFallsThrough verifies an unset or missing $TERMINAL uses the built-in order.
+func TestDetectLinuxTerminalFallsThrough(t *testing.T) {
+	fakeTerminals(t, "gnome-terminal", "alacritty")
+
+	for _, value := range []string{"", "not-installed-terminal"} {
+		t.Setenv("TERMINAL", value)
+		if cmd, _ := NewTerminalOpener().detectLinuxTerminal(); cmd != "gnome-terminal" {
+			t.Errorf("TERMINAL=%q: expected gnome-terminal, got %q", value, cmd)
+		}
+	}
+}
+
 // TestShellQuote tests the shell quoting function.
 func TestShellQuote(t *testing.T) {
 	tests := []struct {
@@ -243,6 +305,36 @@ func TestBuildMacOSCommand(t *testing.T) {
 	}
 }
 
+// TestMacOSScript verifies the AppleScript opens a new window by default and a tab in tab mode.
+func TestMacOSScript(t *testing.T) {
+	path := "/test/path"
+
+	for _, app := range []string{"iTerm", "Terminal"} {
+		window := macOSScript(app, path, false)
+		if !strings.Contains(window, "cd '/test/path'") {
+			t.Errorf("%s: expected the script to cd to the path:\n%s", app, window)
+		}
+		if strings.Contains(window, "tab") {
+			t.Errorf("%s: window mode should not open a tab:\n%s", app, window)
+		}
+
+		tab := macOSScript(app, path, true)
+		if !strings.Contains(tab, "tab") || !strings.Contains(tab, "cd '/test/path'") {
+			t.Errorf("%s: expected tab mode to open a tab at the path:\n%s", app, tab)
+		}
+	}
+
+	if !strings.Contains(macOSScript("iTerm", path, true), "create tab with default profile") {
+		t.Error("iTerm tab mode should create a tab with the default profile")
+	}
+	if !strings.Contains(macOSScript("Terminal", path, true), "in window 1") {
+		t.Error("Terminal.app tab mode should run in the front window")
+	}
+	if macOSScript("kitty", path, true) != "" {
+		t.Error("Apps without AppleScript support should get no script")
+	}
+}
+
 // TestBuildLinuxCommand tests building Linux commands (only runs on Linux).
 func TestBuildLinuxCommand(t *testing.T) {
 	if runtime.GOOS != "linux" {
@@ -322,3 +414,40 @@ func TestDetectWindowsTerminal(t *testing.T) {
 		t.Error("Expected terminal args on Windows, got empty")
 	}
 }
+
+// TestOpenWorktreeWithFakeStart verifies the built command is started and failures fall back.
+func TestOpenWorktreeWithFakeStart(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Command layout checked on Linux only")
+	}
+	tmpDir := t.TempDir()
+
+	var started *exec.Cmd
+	opener := NewTerminalOpenerWithCmd("xterm")
+	opener.start = func(cmd *exec.Cmd) error {
+		started = cmd
+		return nil
+	}
+
+	result, err := opener.OpenWorktree(tmpDir)
+	if err != nil {
+		t.Fatalf("OpenWorktree failed: %v", err)
+	}
+	if result.Method != "terminal" {
+		t.Errorf("Expected method 'terminal', got '%s'", result.Method)
+	}
+	if started == nil || started.Args[0] != "xterm" {
+		t.Errorf("Expected xterm to be started, got %v", started)
+	}
+
+	opener.start = func(cmd *exec.Cmd) error {
+		return errors.New("cannot start")
+	}
+	result, err = opener.OpenWorktree(tmpDir)
+	if err != nil {
+		t.Fatalf("OpenWorktree failed: %v", err)
+	}
+	if result.Method != "cd_command" {
+		t.Errorf("Expected fallback to 'cd_command', got '%s'", result.Method)
+	}
+}
@@ -0,0 +1,115 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// VersionParseError is returned when the output of `git --version` cannot be parsed.
+type VersionParseError struct {
+	Output string
+}
+
+func (e *VersionParseError) Error() string {
+	return "unrecognized git version: " + e.Output
+}
+
+// gitVersion caches the installed git version after the first successful lookup.
+var gitVersion struct {
+	mu                  sync.Mutex
+	known               bool
+	major, minor, patch int
+}
+
+// GitVersion returns the version of the installed git. The result is cached
+// after the first successful call; failures are retried on the next call.
+func GitVersion() (major, minor, patch int, err error) {
+	gitVersion.mu.Lock()
+	defer gitVersion.mu.Unlock()
+
+	if gitVersion.known {
+		return gitVersion.major, gitVersion.minor, gitVersion.patch, nil
+	}
+
+	output, err := runGit(context.Background(), "", "--version")
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to get git version: %s", failureReason(output, err))
+	}
+
+	major, minor, patch, err = ParseGitVersion(string(output))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	gitVersion.known = true
+	gitVersion.major, gitVersion.minor, gitVersion.patch = major, minor, patch
+	return major, minor, patch, nil
+}
+
+// resetGitVersion clears the cached git version; used in tests.
+func resetGitVersion() {
+	gitVersion.mu.Lock()
+	defer gitVersion.mu.Unlock()
+	gitVersion.known = false
+}
+
+// ParseGitVersion parses the output of `git --version`, such as
+// "git version 2.39.3 (Apple Git-145)" or "git version 2.45.1.windows.1".
+// Vendor suffixes are ignored and a missing patch number is treated as 0.
+func ParseGitVersion(output string) (major, minor, patch int, err error) {
+	fields := strings.Fields(output)
+	if len(fields) < 3 || fields[0] != "git" || fields[1] != "version" {
+		return 0, 0, 0, &VersionParseError{Output: strings.TrimSpace(output)}
+	}
+
+	parts := strings.Split(fields[2], ".")
+	if len(parts) < 2 {
+		return 0, 0, 0, &VersionParseError{Output: strings.TrimSpace(output)}
+	}
+
+	numbers := make([]int, 3)
+	for i := 0; i < len(numbers) && i < len(parts); i++ {
+		n, convErr := strconv.Atoi(leadingDigits(parts[i]))
+		if convErr != nil {
+			// Only major and minor are required; a non-numeric third
+			// component (e.g. "2.40.rc0") leaves patch at 0
+			if i < 2 {
+				return 0, 0, 0, &VersionParseError{Output: strings.TrimSpace(output)}
+			}
+			break
+		}
+		numbers[i] = n
+	}
+
+	return numbers[0], numbers[1], numbers[2], nil
+}
+
+// leadingDigits returns the digits at the start of s (e.g. "0" for "0-rc1").
+func leadingDigits(s string) string {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	return s[:end]
+}
+
+// GitVersionAtLeast reports whether the installed git is at least major.minor.
+func GitVersionAtLeast(major, minor int) (bool, error) {
+	gotMajor, gotMinor, _, err := GitVersion()
+	if err != nil {
+		return false, err
+	}
+	return VersionAtLeast(gotMajor, gotMinor, major, minor), nil
+}
+
+// VersionAtLeast reports whether version gotMajor.gotMinor is at least major.minor.
+func VersionAtLeast(gotMajor, gotMinor, major, minor int) bool {
+	if gotMajor != major {
+		return gotMajor > major
+	}
+	return gotMinor >= minor
+}
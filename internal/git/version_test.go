@@ -0,0 +1,89 @@
+// Package git provides git operations for the worktree manager.
+package git
+
+import (
+	"testing"
+)
+
+// TestParseGitVersion verifies parsing of realistic git --version output
+func TestParseGitVersion(t *testing.T) {
+	tests := []struct {
+		output              string
+		major, minor, patch int
+	}{
+		{"git version 2.39.3 (Apple Git-145)\n", 2, 39, 3},
+		{"git version 2.45.1.windows.1\n", 2, 45, 1},
+		{"git version 2.34.1\n", 2, 34, 1},
+		{"git version 2.40.0-rc1", 2, 40, 0},
+		{"git version 2.17", 2, 17, 0},
+		{"git version 2.43.GIT", 2, 43, 0},
+	}
+
+	for _, tt := range tests {
+		major, minor, patch, err := ParseGitVersion(tt.output)
+		if err != nil {
+			t.Errorf("ParseGitVersion(%q) failed: %v", tt.output, err)
+			continue
+		}
+		if major != tt.major || minor != tt.minor || patch != tt.patch {
+			t.Errorf("ParseGitVersion(%q) = %d.%d.%d, want %d.%d.%d",
+				tt.output, major, minor, patch, tt.major, tt.minor, tt.patch)
+		}
+	}
+}
+
+// TestParseGitVersionInvalid verifies unrecognized output is rejected
+func TestParseGitVersionInvalid(t *testing.T) {
+	for _, output := range []string{"", "hub version 2.14.2", "git version", "git version two.three", "git version 2"} {
+		if _, _, _, err := ParseGitVersion(output); err == nil {
+			t.Errorf("ParseGitVersion(%q) should fail", output)
+		} else if _, ok := err.(*VersionParseError); !ok {
+			t.Errorf("ParseGitVersion(%q) should return VersionParseError, got %T", output, err)
+		}
+	}
+}
+
+// TestVersionAtLeast verifies version comparison
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		gotMajor, gotMinor, major, minor int
+		want                             bool
+	}{
+		{2, 39, 2, 30, true},
+		{2, 30, 2, 30, true},
+		{2, 29, 2, 30, false},
+		{3, 0, 2, 30, true},
+		{1, 99, 2, 0, false},
+	}
+
+	for _, tt := range tests {
+		if got := VersionAtLeast(tt.gotMajor, tt.gotMinor, tt.major, tt.minor); got != tt.want {
+			t.Errorf("VersionAtLeast(%d, %d, %d, %d) = %v, want %v",
+				tt.gotMajor, tt.gotMinor, tt.major, tt.minor, got, tt.want)
+		}
+	}
+}
+
+// TestGitVersionCached verifies git --version runs only once
+func TestGitVersionCached(t *testing.T) {
+	resetGitVersion()
+	t.Cleanup(resetGitVersion)
+
+	fake := &fakeRunner{outputs: map[string]string{
+		"--version": "git version 2.39.3 (Apple Git-145)\n",
+	}}
+	useFakeRunner(t, fake)
+
+	for i := 0; i < 3; i++ {
+		major, minor, patch, err := GitVersion()
+		if err != nil {
+			t.Fatalf("GitVersion failed: %v", err)
+		}
+		if major != 2 || minor != 39 || patch != 3 {
+			t.Errorf("GitVersion = %d.%d.%d, want 2.39.3", major, minor, patch)
+		}
+	}
+	if len(fake.calls) != 1 {
+		t.Errorf("git --version should run once, ran %d times", len(fake.calls))
+	}
+}
@@ -2,11 +2,14 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 // Worktree represents a git worktree with its metadata.
@@ -22,6 +25,11 @@ type Worktree struct {
 	IsBare bool
 	// IsDetached indicates if the worktree is in detached HEAD state.
 	IsDetached bool
+	// IsLocked indicates if the worktree is locked against pruning and removal.
+	IsLocked bool
+	// IsMain indicates if this is the main worktree (the one holding the
+	// repository's .git directory). It cannot be removed like linked worktrees.
+	IsMain bool
 }
 
 // Name returns the name of the worktree (last component of the path).
@@ -49,12 +57,28 @@ func IsNotGitRepoError(err error) bool {
 
 // IsGitRepository checks if the given directory is inside a git repository.
 func IsGitRepository(dir string) bool {
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
-	cmd.Dir = dir
-	err := cmd.Run()
+	return IsGitRepositoryContext(context.Background(), dir)
+}
+
+// IsGitRepositoryContext is like IsGitRepository but stops git when ctx is done.
+func IsGitRepositoryContext(ctx context.Context, dir string) bool {
+	_, err := runGit(ctx, dir, "rev-parse", "--git-dir")
 	return err == nil
 }
 
+// checkRepository returns a NotGitRepoError if dir is not inside a git
+// repository, or a timeout error if ctx ended before git could tell.
+func checkRepository(ctx context.Context, dir string) error {
+	_, err := runGit(ctx, dir, "rev-parse", "--git-dir")
+	if err == nil {
+		return nil
+	}
+	if IsTimeoutError(err) {
+		return err
+	}
+	return &NotGitRepoError{Path: dir}
+}
+
 // GetCurrentDirectory returns the current working directory.
 func GetCurrentDirectory() (string, error) {
 	return os.Getwd()
@@ -63,18 +87,121 @@ func GetCurrentDirectory() (string, error) {
 // ListWorktrees lists all worktrees in the git repository containing the given directory.
 // Returns a NotGitRepoError if the directory is not in a git repository.
 func ListWorktrees(dir string) ([]Worktree, error) {
-	if !IsGitRepository(dir) {
-		return nil, &NotGitRepoError{Path: dir}
+	return ListWorktreesContext(context.Background(), dir)
+}
+
+// ListWorktreesContext is like ListWorktrees but stops git when ctx is done.
+func ListWorktreesContext(ctx context.Context, dir string) ([]Worktree, error) {
+	if err := checkRepository(ctx, dir); err != nil {
+		return nil, err
 	}
 
-	cmd := exec.Command("git", "worktree", "list")
-	cmd.Dir = dir
-	output, err := cmd.Output()
+	output, err := runGit(ctx, dir, "worktree", "list")
 	if err != nil {
 		return nil, fmt.Errorf("failed to list worktrees: %w", err)
 	}
 
-	return ParseWorktreeList(string(output)), nil
+	worktrees := ParseWorktreeList(string(output))
+	MarkMainWorktree(worktrees, mainWorktreePath(ctx, dir))
+
+	return worktrees, nil
+}
+
+// mainWorktreePath returns the absolute path of the main worktree for the
+// repository containing dir, derived from the common git directory.
+// Returns an empty string if it cannot be determined.
+func mainWorktreePath(ctx context.Context, dir string) string {
+	output, err := runGit(ctx, dir, "rev-parse", "--path-format=absolute", "--git-common-dir")
+	if err != nil {
+		return ""
+	}
+
+	commonDir := strings.TrimSpace(string(output))
+	if commonDir == "" {
+		return ""
+	}
+	// For non-bare repositories the common dir is <main worktree>/.git;
+	// for bare repositories it is the repository itself.
+	if filepath.Base(commonDir) == ".git" {
+		return filepath.Dir(commonDir)
+	}
+	return commonDir
+}
+
+// RepoInfo returns the top-level directory of the worktree containing dir,
+// its git directory and the common git directory shared by all worktrees,
+// all as absolute paths. The top level is empty for a bare repository,
+// which has no working tree.
+func RepoInfo(dir string) (toplevel, gitDir, commonDir string, err error) {
+	return RepoInfoContext(context.Background(), dir)
+}
+
+// RepoInfoContext is like RepoInfo but stops git when ctx is done.
+func RepoInfoContext(ctx context.Context, dir string) (toplevel, gitDir, commonDir string, err error) {
+	if err := checkRepository(ctx, dir); err != nil {
+		return "", "", "", err
+	}
+
+	output, err := runGit(ctx, dir, "rev-parse", "--path-format=absolute", "--git-dir", "--git-common-dir")
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to resolve git directories: %w", err)
+	}
+	gitDir, commonDir, err = parseRepoDirs(string(output))
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// --show-toplevel fails outside a working tree, e.g. in a bare repository
+	output, err = runGit(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		if IsTimeoutError(err) {
+			return "", "", "", err
+		}
+		return "", gitDir, commonDir, nil
+	}
+
+	return strings.TrimSpace(string(output)), gitDir, commonDir, nil
+}
+
+// parseRepoDirs parses the output of "git rev-parse --git-dir --git-common-dir",
+// which prints one path per line.
+func parseRepoDirs(output string) (gitDir, commonDir string, err error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 2 || lines[0] == "" || lines[1] == "" {
+		return "", "", fmt.Errorf("unexpected git rev-parse output: %q", output)
+	}
+	return strings.TrimSpace(lines[0]), strings.TrimSpace(lines[1]), nil
+}
+
+// MarkMainWorktree sets IsMain on the worktree located at mainPath.
+// If mainPath is empty or matches no worktree, the first worktree is marked,
+// since git always lists the main worktree first.
+func MarkMainWorktree(worktrees []Worktree, mainPath string) {
+	if len(worktrees) == 0 {
+		return
+	}
+
+	if mainPath != "" {
+		for i := range worktrees {
+			if samePath(worktrees[i].Path, mainPath) {
+				worktrees[i].IsMain = true
+				return
+			}
+		}
+	}
+
+	worktrees[0].IsMain = true
+}
+
+// samePath reports whether two paths refer to the same location,
+// resolving symlinks where possible.
+func samePath(a, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	resolvedA, errA := filepath.EvalSymlinks(a)
+	resolvedB, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && resolvedA == resolvedB
 }
 
 // ParseWorktreeList parses the output of "git worktree list" command.
@@ -83,6 +210,8 @@ func ListWorktrees(dir string) ([]Worktree, error) {
 //	/path/to/worktree  <commit> [branch]
 //	/path/to/bare      (bare)
 //	/path/to/detached  <commit> (detached HEAD)
+//
+// Any line may carry trailing "locked" or "prunable" annotations.
 func ParseWorktreeList(output string) []Worktree {
 	var worktrees []Worktree
 
@@ -106,6 +235,9 @@ func ParseWorktreeList(output string) []Worktree {
 func parseWorktreeLine(line string) Worktree {
 	var wt Worktree
 
+	// Strip trailing annotations (e.g. "locked", "prunable")
+	line, wt.IsLocked = stripWorktreeAnnotations(line)
+
 	// Check for bare repository
 	if strings.HasSuffix(line, "(bare)") {
 		wt.IsBare = true
@@ -147,6 +279,23 @@ func parseWorktreeLine(line string) Worktree {
 	return wt
 }
 
+// stripWorktreeAnnotations removes the trailing "locked" and "prunable"
+// annotations from a worktree line and reports whether it was locked.
+func stripWorktreeAnnotations(line string) (string, bool) {
+	locked := false
+	for {
+		switch {
+		case strings.HasSuffix(line, " locked"):
+			line = strings.TrimSpace(strings.TrimSuffix(line, " locked"))
+			locked = true
+		case strings.HasSuffix(line, " prunable"):
+			line = strings.TrimSpace(strings.TrimSuffix(line, " prunable"))
+		default:
+			return line, locked
+		}
+	}
+}
+
 // splitWorktreePath splits the path and hash portion of a worktree line.
 // The format is: /path/to/worktree  <hash>
 // Multiple spaces separate the path from the hash.
@@ -173,17 +322,92 @@ func splitWorktreePath(s string) []string {
 	return []string{path, hash}
 }
 
+// ErrorCategory classifies why a worktree operation failed, so callers can
+// react to it without matching git's messages themselves.
+type ErrorCategory int
+
+const (
+	// CategoryUnknown covers failures without a more specific category.
+	CategoryUnknown ErrorCategory = iota
+	// CategoryPathConflict means something already exists at the path,
+	// such as a non-empty directory or another registered worktree.
+	CategoryPathConflict
+	// CategoryBranchExists means a branch to be created already exists.
+	CategoryBranchExists
+	// CategoryBranchInUse means the branch is checked out in another worktree.
+	CategoryBranchInUse
+	// CategoryUncommittedChanges means the worktree has modified or
+	// untracked files, so it is only removed with force.
+	CategoryUncommittedChanges
+	// CategoryNotMerged means the branch is not fully merged, so it is
+	// only deleted with force.
+	CategoryNotMerged
+)
+
 // WorktreeAddError is returned when worktree creation fails.
 type WorktreeAddError struct {
-	Path   string
-	Branch string
-	Reason string
+	Path     string
+	Branch   string
+	Reason   string
+	Category ErrorCategory
 }
 
 func (e *WorktreeAddError) Error() string {
 	return fmt.Sprintf("failed to add worktree at %s for branch %s: %s", e.Path, e.Branch, e.Reason)
 }
 
+// IsPathConflictError returns true if the worktree could not be created
+// because its path is already taken.
+func IsPathConflictError(err error) bool {
+	var addErr *WorktreeAddError
+	return errors.As(err, &addErr) && addErr.Category == CategoryPathConflict
+}
+
+// IsUncommittedChangesError returns true if the worktree could not be
+// removed because it has modified or untracked files.
+func IsUncommittedChangesError(err error) bool {
+	var removeErr *WorktreeRemoveError
+	return errors.As(err, &removeErr) && removeErr.Category == CategoryUncommittedChanges
+}
+
+// classifyGitError classifies the output of a failed git command by the
+// messages git prints for known failures:
+//
+//   - a taken path: "'<path>' already exists" (the branch variant, "a
+//     branch named '<name>' already exists", is not a path conflict) or
+//     "'<path>' is a missing but already registered worktree"
+//   - a checked out branch: "'<branch>' is already checked out at '<path>'",
+//     or "is already used by worktree at" since git 2.42
+//   - a dirty worktree: "'<path>' contains modified or untracked files"
+//   - an unmerged branch: "The branch '<name>' is not fully merged"
+func classifyGitError(stderr string) ErrorCategory {
+	for _, line := range strings.Split(stderr, "\n") {
+		line = strings.TrimSpace(line)
+		for _, prefix := range []string{"fatal:", "error:"} {
+			line = strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
+
+		switch {
+		case strings.HasPrefix(line, "a branch named '") && strings.HasSuffix(line, "' already exists"):
+			return CategoryBranchExists
+		case strings.HasPrefix(line, "The branch '") && strings.HasSuffix(line, "' is not fully merged."):
+			return CategoryNotMerged
+		case !strings.HasPrefix(line, "'"):
+			continue
+		case strings.HasSuffix(line, "' already exists"),
+			strings.Contains(line, "' is a missing but already registered worktree"),
+			strings.Contains(line, "' is a missing but locked worktree"):
+			return CategoryPathConflict
+		case strings.Contains(line, "' is already checked out at '"),
+			strings.Contains(line, "' is already used by worktree at '"):
+			return CategoryBranchInUse
+		case strings.Contains(line, "' contains modified or untracked files"):
+			return CategoryUncommittedChanges
+		}
+	}
+	return CategoryUnknown
+}
+
 // AddWorktreeOptions specifies options for creating a new worktree.
 type AddWorktreeOptions struct {
 	// Path is the absolute or relative path for the new worktree directory.
@@ -197,17 +421,55 @@ type AddWorktreeOptions struct {
 	// BaseBranch is the starting point for the new branch when CreateBranch is true.
 	// If empty, defaults to HEAD.
 	BaseBranch string
+	// Track sets up the new branch to track BaseBranch, which should be a
+	// remote-tracking branch such as "origin/feature". Requires CreateBranch.
+	Track bool
+	// Detach checks out Commit with a detached HEAD instead of a branch,
+	// for throwaway worktrees. It cannot be combined with CreateBranch.
+	Detach bool
+	// Commit is the commit, tag or other ref to check out when Detach is true.
+	Commit string
+	// NoCheckout creates the worktree without checking out any files, for
+	// huge repositories where a different branch or a sparse checkout
+	// follows right away.
+	NoCheckout bool
 }
 
 // AddWorktree creates a new git worktree at the specified path.
 // The dir parameter is the directory of an existing git repository.
 func AddWorktree(dir string, opts AddWorktreeOptions) error {
-	if !IsGitRepository(dir) {
-		return &NotGitRepoError{Path: dir}
+	return AddWorktreeContext(context.Background(), dir, opts)
+}
+
+// AddWorktreeContext is like AddWorktree but stops git when ctx is done.
+func AddWorktreeContext(ctx context.Context, dir string, opts AddWorktreeOptions) error {
+	if err := checkRepository(ctx, dir); err != nil {
+		return err
 	}
 
-	if opts.Path == "" {
+	args, err := worktreeAddArgs(opts)
+	if err != nil {
+		return err
+	}
+
+	output, err := runGit(ctx, dir, args...)
+	if err != nil {
+		reason := failureReason(output, err)
 		return &WorktreeAddError{
+			Path:     opts.Path,
+			Branch:   opts.Branch,
+			Reason:   reason,
+			Category: classifyGitError(reason),
+		}
+	}
+
+	return nil
+}
+
+// worktreeAddArgs builds the git worktree add arguments for opts.
+func worktreeAddArgs(opts AddWorktreeOptions) ([]string, error) {
+	if opts.Path == "" {
+		return nil, &WorktreeAddError{
 			Path:   opts.Path,
 			Branch: opts.Branch,
 			Reason: "path is required",
@@ -216,8 +478,26 @@ func AddWorktree(dir string, opts AddWorktreeOptions) error {
 
 	// Build the git worktree add command
 	args := []string{"worktree", "add"}
+	if opts.NoCheckout {
+		args = append(args, "--no-checkout")
+	}
 
-	if opts.CreateBranch {
+	if opts.Detach {
+		if opts.CreateBranch {
+			return nil, &WorktreeAddError{
+				Path:   opts.Path,
+				Branch: opts.Branch,
+				Reason: "cannot create a branch in a detached worktree",
+			}
+		}
+		if opts.Commit == "" {
+			return nil, &WorktreeAddError{
+				Path:   opts.Path,
+				Reason: "commit is required when detaching",
+			}
+		}
+		args = append(args, "--detach", opts.Path, opts.Commit)
+	} else if opts.CreateBranch {
 		// Create new branch
 		branchName := opts.Branch
 		if branchName == "" {
@@ -225,7 +505,9 @@ func AddWorktree(dir string, opts AddWorktreeOptions) error {
 			branchName = filepath.Base(opts.Path)
 		}
 
-		if opts.BaseBranch != "" {
+		if opts.Track && opts.BaseBranch != "" {
+			args = append(args, "--track", "-b", branchName, opts.Path, opts.BaseBranch)
+		} else if opts.BaseBranch != "" {
 			args = append(args, "-b", branchName, opts.Path, opts.BaseBranch)
 		} else {
 			args = append(args, "-b", branchName, opts.Path)
@@ -233,7 +515,7 @@ func AddWorktree(dir string, opts AddWorktreeOptions) error {
 	} else {
 		// Use existing branch
 		if opts.Branch == "" {
-			return &WorktreeAddError{
+			return nil, &WorktreeAddError{
 				Path:   opts.Path,
 				Branch: opts.Branch,
 				Reason: "branch is required when not creating a new branch",
@@ -242,34 +524,61 @@ func AddWorktree(dir string, opts AddWorktreeOptions) error {
 		args = append(args, opts.Path, opts.Branch)
 	}
 
-	cmd := exec.Command("git", args...)
-	cmd.Dir = dir
+	return args, nil
+}
 
-	output, err := cmd.CombinedOutput()
+// WorktreeAddCommand returns the git worktree add command that recreates wt,
+// ready to paste into a shell. A detached worktree is recreated at its
+// commit. With createBranch the branch is created with -b, for a branch the
+// worktree owns that others do not have yet; otherwise the existing branch
+// is checked out. It returns "" for a bare repository.
+func WorktreeAddCommand(wt Worktree, createBranch bool) string {
+	if wt.IsBare {
+		return ""
+	}
+	opts := AddWorktreeOptions{Path: wt.Path, Branch: wt.Branch, CreateBranch: createBranch}
+	if wt.IsDetached || wt.Branch == "" {
+		opts = AddWorktreeOptions{Path: wt.Path, Detach: true, Commit: wt.CommitHash}
+	}
+	args, err := worktreeAddArgs(opts)
 	if err != nil {
-		reason := strings.TrimSpace(string(output))
-		if reason == "" {
-			reason = err.Error()
-		}
-		return &WorktreeAddError{
-			Path:   opts.Path,
-			Branch: opts.Branch,
-			Reason: reason,
-		}
+		return ""
 	}
 
-	return nil
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellArg(arg)
+	}
+	return "git " + strings.Join(quoted, " ")
+}
+
+// shellArg quotes s for a shell command unless it is plain enough to be
+// pasted as is, which keeps commands readable.
+func shellArg(s string) string {
+	if s == "" {
+		return shellQuote(s)
+	}
+	for _, r := range s {
+		plain := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:@+=,%", r)
+		if !plain {
+			return shellQuote(s)
+		}
+	}
+	return s
 }
 
 // ListBranches lists all local branches in the repository.
 func ListBranches(dir string) ([]string, error) {
-	if !IsGitRepository(dir) {
-		return nil, &NotGitRepoError{Path: dir}
+	return ListBranchesContext(context.Background(), dir)
+}
+
+// ListBranchesContext is like ListBranches but stops git when ctx is done.
+func ListBranchesContext(ctx context.Context, dir string) ([]string, error) {
+	if err := checkRepository(ctx, dir); err != nil {
+		return nil, err
 	}
 
-	cmd := exec.Command("git", "branch", "--format=%(refname:short)")
-	cmd.Dir = dir
-	output, err := cmd.Output()
+	output, err := runGit(ctx, dir, "branch", "--format=%(refname:short)")
 	if err != nil {
 		return nil, fmt.Errorf("failed to list branches: %w", err)
 	}
@@ -285,10 +594,277 @@ func ListBranches(dir string) ([]string, error) {
 	return branches, nil
 }
 
-// WorktreeRemoveError is returned when worktree removal fails.
-type WorktreeRemoveError struct {
+// ListRemoteBranches lists all remote-tracking branches in the repository
+// (e.g. "origin/feature"), excluding symbolic refs such as origin/HEAD.
+func ListRemoteBranches(dir string) ([]string, error) {
+	return ListRemoteBranchesContext(context.Background(), dir)
+}
+
+// ListRemoteBranchesContext is like ListRemoteBranches but stops git when ctx is done.
+func ListRemoteBranchesContext(ctx context.Context, dir string) ([]string, error) {
+	if err := checkRepository(ctx, dir); err != nil {
+		return nil, err
+	}
+
+	output, err := runGit(ctx, dir, "branch", "-r", "--format=%(refname:short)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote branches: %w", err)
+	}
+
+	return ParseRemoteBranches(string(output)), nil
+}
+
+// ParseRemoteBranches parses the output of "git branch -r --format=%(refname:short)".
+// The origin/HEAD symbolic ref is excluded; depending on the git version it is
+// shown either as "origin/HEAD" or as the bare remote name "origin".
+func ParseRemoteBranches(output string) []string {
+	var branches []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || !strings.Contains(line, "/") || strings.HasSuffix(line, "/HEAD") {
+			continue
+		}
+		branches = append(branches, line)
+	}
+	return branches
+}
+
+// SplitRemoteBranch splits a remote-tracking branch name such as
+// "origin/feature/x" into its remote ("origin") and branch ("feature/x").
+func SplitRemoteBranch(ref string) (remote, branch string) {
+	remote, branch, found := strings.Cut(ref, "/")
+	if !found {
+		return "", ref
+	}
+	return remote, branch
+}
+
+// NoRemoteError is returned when fetching from a repository without remotes.
+type NoRemoteError struct {
+	Path string
+}
+
+func (e *NoRemoteError) Error() string {
+	return "no remote configured: " + e.Path
+}
+
+// IsNoRemoteError checks if an error is a NoRemoteError.
+func IsNoRemoteError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*NoRemoteError)
+	return ok
+}
+
+// FetchError is returned when fetching from a remote fails.
+type FetchError struct {
+	Remote string
+	Reason string
+}
+
+func (e *FetchError) Error() string {
+	if e.Remote == "" {
+		return fmt.Sprintf("failed to fetch: %s", e.Reason)
+	}
+	return fmt.Sprintf("failed to fetch from %s: %s", e.Remote, e.Reason)
+}
+
+// ListRemotes lists the names of the remotes configured in the repository.
+func ListRemotes(dir string) ([]string, error) {
+	return ListRemotesContext(context.Background(), dir)
+}
+
+// ListRemotesContext is like ListRemotes but stops git when ctx is done.
+func ListRemotesContext(ctx context.Context, dir string) ([]string, error) {
+	if err := checkRepository(ctx, dir); err != nil {
+		return nil, err
+	}
+
+	output, err := runGit(ctx, dir, "remote")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+
+	var remotes []string
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			remotes = append(remotes, line)
+		}
+	}
+
+	return remotes, nil
+}
+
+// Fetch runs "git fetch --prune" for the given remote, removing
+// remote-tracking branches that no longer exist on the remote.
+// An empty remote fetches the default remote.
+// Returns a NoRemoteError if the repository has no remotes.
+func Fetch(dir string, remote string) error {
+	return FetchContext(context.Background(), dir, remote)
+}
+
+// FetchContext is like Fetch but stops git when ctx is done.
+func FetchContext(ctx context.Context, dir string, remote string) error {
+	remotes, err := ListRemotesContext(ctx, dir)
+	if err != nil {
+		return err
+	}
+	if len(remotes) == 0 {
+		return &NoRemoteError{Path: dir}
+	}
+
+	args := []string{"fetch", "--prune"}
+	if remote != "" {
+		args = append(args, remote)
+	}
+
+	output, err := runGit(ctx, dir, args...)
+	if err != nil {
+		reason := failureReason(output, err)
+		return &FetchError{
+			Remote: remote,
+			Reason: reason,
+		}
+	}
+
+	return nil
+}
+
+// PullError is returned when pulling into a worktree fails.
+type PullError struct {
 	Path   string
 	Reason string
+	// Diverged is true when the branch and its upstream both have new
+	// commits, so the pull cannot fast-forward.
+	Diverged bool
+}
+
+func (e *PullError) Error() string {
+	return fmt.Sprintf("failed to pull in %s: %s", e.Path, e.Reason)
+}
+
+// IsDivergedError checks if an error is a PullError for a branch that
+// cannot be fast-forwarded.
+func IsDivergedError(err error) bool {
+	pullErr, ok := err.(*PullError)
+	return ok && pullErr.Diverged
+}
+
+// Pull runs "git pull --ff-only" in the worktree at path, updating its
+// branch from its upstream only if no merge is needed.
+// Returns git's output, e.g. "Already up to date.".
+func Pull(path string) (string, error) {
+	return PullContext(context.Background(), path)
+}
+
+// PullContext is like Pull but stops git when ctx is done.
+func PullContext(ctx context.Context, path string) (string, error) {
+	if err := checkRepository(ctx, path); err != nil {
+		return "", err
+	}
+
+	output, err := runGit(ctx, path, "pull", "--ff-only")
+	if err != nil {
+		reason := failureReason(output, err)
+		return "", &PullError{
+			Path:     path,
+			Reason:   reason,
+			Diverged: strings.Contains(reason, "Not possible to fast-forward"),
+		}
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// PushError is returned when pushing a worktree's branch fails.
+type PushError struct {
+	Path   string
+	Reason string
+	// Auth is true when the remote rejected or could not ask for credentials.
+	Auth bool
+	// NoRemote is true when there is no "origin" remote to push to.
+	NoRemote bool
+}
+
+func (e *PushError) Error() string {
+	return fmt.Sprintf("failed to push from %s: %s", e.Path, e.Reason)
+}
+
+// IsPushAuthError checks if an error is a PushError caused by authentication.
+func IsPushAuthError(err error) bool {
+	pushErr, ok := err.(*PushError)
+	return ok && pushErr.Auth
+}
+
+// IsPushNoRemoteError checks if an error is a PushError for a missing remote.
+func IsPushNoRemoteError(err error) bool {
+	pushErr, ok := err.(*PushError)
+	return ok && pushErr.NoRemote
+}
+
+// pushAuthFailures are phrases git and common hosts use when credentials
+// are missing or rejected.
+var pushAuthFailures = []string{
+	"Authentication failed",
+	"could not read Username",
+	"could not read Password",
+	"Permission denied",
+	"The requested URL returned error: 403",
+}
+
+// Push pushes the branch checked out in the worktree at path to origin.
+// With setUpstream it runs "git push -u origin <branch>", so a new branch
+// starts tracking its remote counterpart; otherwise it runs "git push" to
+// the existing upstream. Returns git's output.
+func Push(path string, setUpstream bool) (string, error) {
+	return PushContext(context.Background(), path, setUpstream)
+}
+
+// PushContext is like Push but stops git when ctx is done.
+func PushContext(ctx context.Context, path string, setUpstream bool) (string, error) {
+	if err := checkRepository(ctx, path); err != nil {
+		return "", err
+	}
+
+	args := []string{"push"}
+	if setUpstream {
+		// symbolic-ref fails quietly on a detached HEAD
+		output, err := runGit(ctx, path, "symbolic-ref", "--short", "-q", "HEAD")
+		branch := strings.TrimSpace(string(output))
+		if err != nil || branch == "" {
+			if IsTimeoutError(err) {
+				return "", &PushError{Path: path, Reason: failureReason(nil, err)}
+			}
+			return "", &PushError{Path: path, Reason: "HEAD is detached, there is no branch to push"}
+		}
+		args = append(args, "-u", "origin", branch)
+	}
+
+	// git push reports progress and results on standard error
+	output, err := runGitCombined(ctx, path, args...)
+	if err != nil {
+		reason := failureReason(output, err)
+		pushErr := &PushError{
+			Path:     path,
+			Reason:   reason,
+			NoRemote: strings.Contains(reason, "'origin' does not appear to be a git repository"),
+		}
+		for _, phrase := range pushAuthFailures {
+			pushErr.Auth = pushErr.Auth || strings.Contains(reason, phrase)
+		}
+		return "", pushErr
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// WorktreeRemoveError is returned when worktree removal fails.
+type WorktreeRemoveError struct {
+	Path     string
+	Reason   string
+	Category ErrorCategory
 }
 
 func (e *WorktreeRemoveError) Error() string {
@@ -306,8 +882,13 @@ type RemoveWorktreeOptions struct {
 // RemoveWorktree removes a git worktree at the specified path.
 // The dir parameter is the directory of an existing git repository.
 func RemoveWorktree(dir string, opts RemoveWorktreeOptions) error {
-	if !IsGitRepository(dir) {
-		return &NotGitRepoError{Path: dir}
+	return RemoveWorktreeContext(context.Background(), dir, opts)
+}
+
+// RemoveWorktreeContext is like RemoveWorktree but stops git when ctx is done.
+func RemoveWorktreeContext(ctx context.Context, dir string, opts RemoveWorktreeOptions) error {
+	if err := checkRepository(ctx, dir); err != nil {
+		return err
 	}
 
 	if opts.Path == "" {
@@ -324,33 +905,67 @@ func RemoveWorktree(dir string, opts RemoveWorktreeOptions) error {
 	}
 	args = append(args, opts.Path)
 
-	cmd := exec.Command("git", args...)
-	cmd.Dir = dir
-
-	output, err := cmd.CombinedOutput()
+	output, err := runGit(ctx, dir, args...)
 	if err != nil {
-		reason := strings.TrimSpace(string(output))
-		if reason == "" {
-			reason = err.Error()
-		}
+		reason := failureReason(output, err)
 		return &WorktreeRemoveError{
-			Path:   opts.Path,
-			Reason: reason,
+			Path:     opts.Path,
+			Reason:   reason,
+			Category: classifyGitError(reason),
 		}
 	}
 
 	return nil
 }
 
+// AheadBehind returns how many commits the branch checked out at path has
+// that its upstream lacks (ahead) and the reverse (behind). Both are zero
+// with no error if the branch has no upstream configured.
+func AheadBehind(path string) (ahead, behind int, err error) {
+	return AheadBehindContext(context.Background(), path)
+}
+
+// AheadBehindContext is like AheadBehind but stops git when ctx is done.
+func AheadBehindContext(ctx context.Context, path string) (ahead, behind int, err error) {
+	if err := checkRepository(ctx, path); err != nil {
+		return 0, 0, err
+	}
+
+	output, err := runGit(ctx, path, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
+	if err != nil {
+		reason := failureReason(output, err)
+		if strings.Contains(reason, "no upstream configured") {
+			return 0, 0, nil
+		}
+		return 0, 0, fmt.Errorf("failed to count commits ahead of upstream: %s", reason)
+	}
+
+	// The output is "<ahead>\t<behind>"
+	fields := strings.Fields(string(output))
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", strings.TrimSpace(string(output)))
+	}
+	if ahead, err = strconv.Atoi(fields[0]); err == nil {
+		behind, err = strconv.Atoi(fields[1])
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", strings.TrimSpace(string(output)))
+	}
+	return ahead, behind, nil
+}
+
 // HasUncommittedChanges checks if the worktree at the given path has uncommitted changes.
 func HasUncommittedChanges(path string) (bool, error) {
-	if !IsGitRepository(path) {
-		return false, &NotGitRepoError{Path: path}
+	return HasUncommittedChangesContext(context.Background(), path)
+}
+
+// HasUncommittedChangesContext is like HasUncommittedChanges but stops git when ctx is done.
+func HasUncommittedChangesContext(ctx context.Context, path string) (bool, error) {
+	if err := checkRepository(ctx, path); err != nil {
+		return false, err
 	}
 
-	cmd := exec.Command("git", "status", "--porcelain")
-	cmd.Dir = path
-	output, err := cmd.Output()
+	output, err := runGit(ctx, path, "status", "--porcelain")
 	if err != nil {
 		return false, fmt.Errorf("failed to check status: %w", err)
 	}
@@ -369,21 +984,22 @@ func (e *WorktreePruneError) Error() string {
 
 // PruneWorktrees removes stale worktree entries from the git repository.
 // Stale entries are worktrees whose directories no longer exist.
-// Returns the output from the git command.
+// Returns git's verbose output, one "Removing worktrees/<name>: <reason>"
+// line per removed entry; see ParsePruneOutput.
 func PruneWorktrees(dir string) (string, error) {
-	if !IsGitRepository(dir) {
-		return "", &NotGitRepoError{Path: dir}
-	}
+	return PruneWorktreesContext(context.Background(), dir)
+}
 
-	cmd := exec.Command("git", "worktree", "prune")
-	cmd.Dir = dir
+// PruneWorktreesContext is like PruneWorktrees but stops git when ctx is done.
+func PruneWorktreesContext(ctx context.Context, dir string) (string, error) {
+	if err := checkRepository(ctx, dir); err != nil {
+		return "", err
+	}
 
-	output, err := cmd.CombinedOutput()
+	// git reports the removed entries on standard error
+	output, err := runGitCombined(ctx, dir, "worktree", "prune", "--verbose")
 	if err != nil {
-		reason := strings.TrimSpace(string(output))
-		if reason == "" {
-			reason = err.Error()
-		}
+		reason := failureReason(output, err)
 		return "", &WorktreePruneError{
 			Reason: reason,
 		}
@@ -393,21 +1009,22 @@ func PruneWorktrees(dir string) (string, error) {
 }
 
 // PruneWorktreesDryRun shows which worktrees would be pruned without actually removing them.
-// Returns the output from the git command.
+// Returns the output from the git command, one "Removing worktrees/<name>: <reason>"
+// line per stale entry, or an empty string if there is nothing to prune.
 func PruneWorktreesDryRun(dir string) (string, error) {
-	if !IsGitRepository(dir) {
-		return "", &NotGitRepoError{Path: dir}
-	}
+	return PruneWorktreesDryRunContext(context.Background(), dir)
+}
 
-	cmd := exec.Command("git", "worktree", "prune", "--dry-run")
-	cmd.Dir = dir
+// PruneWorktreesDryRunContext is like PruneWorktreesDryRun but stops git when ctx is done.
+func PruneWorktreesDryRunContext(ctx context.Context, dir string) (string, error) {
+	if err := checkRepository(ctx, dir); err != nil {
+		return "", err
+	}
 
-	output, err := cmd.CombinedOutput()
+	// git reports the entries on standard error
+	output, err := runGitCombined(ctx, dir, "worktree", "prune", "--dry-run")
 	if err != nil {
-		reason := strings.TrimSpace(string(output))
-		if reason == "" {
-			reason = err.Error()
-		}
+		reason := failureReason(output, err)
 		return "", &WorktreePruneError{
 			Reason: reason,
 		}
@@ -416,6 +1033,28 @@ func PruneWorktreesDryRun(dir string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// ParsePruneOutput returns the names of the entries removed by
+// git worktree prune --verbose, e.g. "feature" for the line
+// "Removing worktrees/feature: gitdir file points to non-existent location".
+// git names an entry after the directory of its worktree. Other lines are
+// ignored.
+func ParsePruneOutput(output string) []string {
+	var names []string
+	for _, line := range strings.Split(output, "\n") {
+		entry, ok := strings.CutPrefix(strings.TrimSpace(line), "Removing ")
+		if !ok {
+			continue
+		}
+		if i := strings.Index(entry, ": "); i >= 0 {
+			entry = entry[:i]
+		}
+		if name := strings.TrimPrefix(entry, "worktrees/"); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // WorktreeStatus contains the status of a worktree including file counts.
 type WorktreeStatus struct {
 	// ModifiedCount is the number of modified but unstaged files.
@@ -424,11 +1063,25 @@ type WorktreeStatus struct {
 	StagedCount int
 	// UntrackedCount is the number of untracked files.
 	UntrackedCount int
+	// ConflictedCount is the number of files with unresolved merge conflicts.
+	// They are not included in the staged or modified counts.
+	ConflictedCount int
+	// RenamedCount is the number of staged renames and copies. They are
+	// also included in StagedCount.
+	RenamedCount int
+	// Upstream is the upstream (tracking) branch, e.g. "origin/main".
+	// Empty if the branch has no upstream or HEAD is detached.
+	Upstream string
+	// Ahead is the number of local commits not on the upstream (to push).
+	Ahead int
+	// Behind is the number of upstream commits not yet local (to pull).
+	Behind int
 }
 
-// TotalChanges returns the total number of changes (modified + staged + untracked).
+// TotalChanges returns the total number of changes
+// (modified + staged + untracked + conflicted).
 func (s *WorktreeStatus) TotalChanges() int {
-	return s.ModifiedCount + s.StagedCount + s.UntrackedCount
+	return s.ModifiedCount + s.StagedCount + s.UntrackedCount + s.ConflictedCount
 }
 
 // IsClean returns true if the worktree has no changes.
@@ -436,21 +1089,74 @@ func (s *WorktreeStatus) IsClean() bool {
 	return s.TotalChanges() == 0
 }
 
+// Summary returns a one-line description of the changes, such as
+// "2 staged, 3 modified, 1 untracked". Categories without changes are
+// omitted, and a worktree without changes is "Clean".
+func (s *WorktreeStatus) Summary() string {
+	if s.IsClean() {
+		return "Clean"
+	}
+
+	var parts []string
+	if s.ConflictedCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d conflicted", s.ConflictedCount))
+	}
+	if s.StagedCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d staged", s.StagedCount))
+	}
+	if s.ModifiedCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d modified", s.ModifiedCount))
+	}
+	if s.UntrackedCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d untracked", s.UntrackedCount))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// skipUntracked makes GetWorktreeStatus ignore untracked files; the zero
+// value counts them.
+var skipUntracked atomic.Bool
+
+// SetCountUntracked sets whether GetWorktreeStatus counts untracked files
+// as changes. When disabled, untracked files such as build output neither
+// inflate the counts nor make a worktree look dirty. Cached statuses are
+// not invalidated; call InvalidateAllWorktreeStatus after changing it.
+// HasUncommittedChanges is unaffected, since git refuses to remove a
+// worktree with untracked files either way.
+func SetCountUntracked(count bool) {
+	skipUntracked.Store(!count)
+}
+
+// CountUntracked reports whether GetWorktreeStatus counts untracked files.
+func CountUntracked() bool {
+	return !skipUntracked.Load()
+}
+
 // GetWorktreeStatus returns the status of the worktree at the given path.
-// It parses `git status --porcelain` output to count modified, staged, and untracked files.
+// It parses `git status --porcelain=v1 -z --branch` output to count modified, staged,
+// and untracked files and to read the upstream branch. Untracked files are
+// skipped if disabled with SetCountUntracked.
 func GetWorktreeStatus(path string) (*WorktreeStatus, error) {
-	if !IsGitRepository(path) {
-		return nil, &NotGitRepoError{Path: path}
+	return GetWorktreeStatusContext(context.Background(), path)
+}
+
+// GetWorktreeStatusContext is like GetWorktreeStatus but stops git when ctx is done.
+func GetWorktreeStatusContext(ctx context.Context, path string) (*WorktreeStatus, error) {
+	if err := checkRepository(ctx, path); err != nil {
+		return nil, err
 	}
 
-	cmd := exec.Command("git", "status", "--porcelain")
-	cmd.Dir = path
-	output, err := cmd.Output()
+	// NUL-terminated records keep paths with spaces, quotes or arrows intact
+	args := []string{"status", "--porcelain=v1", "-z", "--branch"}
+	if !CountUntracked() {
+		args = append(args, "--untracked-files=no")
+	}
+	output, err := runGit(ctx, path, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get status: %w", err)
 	}
 
-	return ParseWorktreeStatus(string(output)), nil
+	return ParseWorktreeStatusZ(string(output)), nil
 }
 
 // ParseWorktreeStatus parses the output of `git status --porcelain`.
@@ -459,6 +1165,11 @@ func GetWorktreeStatus(path string) (*WorktreeStatus, error) {
 // - Second character: status of the work tree (unstaged changes)
 // - '?' for untracked files
 // - ' ' for no changes in that area
+// - 'R' or 'C' in the index for renames and copies (counted as staged)
+// - DD, AU, UD, UA, DU, AA or UU for unmerged (conflicted) paths
+//
+// A "## " branch header line (from --branch) is parsed for the upstream branch
+// and how far the branch is ahead of and behind it.
 func ParseWorktreeStatus(output string) *WorktreeStatus {
 	status := &WorktreeStatus{}
 
@@ -468,25 +1179,153 @@ func ParseWorktreeStatus(output string) *WorktreeStatus {
 			continue
 		}
 
-		indexStatus := line[0]
-		workTreeStatus := line[1]
-
-		// Untracked files start with "??"
-		if indexStatus == '?' && workTreeStatus == '?' {
-			status.UntrackedCount++
+		if header, ok := strings.CutPrefix(line, "## "); ok {
+			status.Upstream = parseStatusUpstream(header)
+			status.Ahead, status.Behind = parseStatusTracking(header)
 			continue
 		}
 
-		// Staged changes have a non-space, non-? character in the first position
-		if indexStatus != ' ' && indexStatus != '?' {
-			status.StagedCount++
+		status.countEntry(line[0], line[1])
+	}
+
+	return status
+}
+
+// ParseWorktreeStatusZ parses the output of `git status --porcelain=v1 -z`,
+// counting entries like ParseWorktreeStatus. Records end with NUL instead of
+// a newline and paths are never quoted, so file names containing spaces or
+// " -> " are read as they are. A rename or copy record is followed by an
+// extra record holding the original path, which is skipped.
+func ParseWorktreeStatusZ(output string) *WorktreeStatus {
+	status := &WorktreeStatus{}
+
+	records := strings.Split(output, "\x00")
+	for i := 0; i < len(records); i++ {
+		record := records[i]
+		if header, ok := strings.CutPrefix(record, "## "); ok {
+			status.Upstream = parseStatusUpstream(header)
+			status.Ahead, status.Behind = parseStatusTracking(header)
+			continue
+		}
+		// Each entry is "XY path"; anything shorter is not an entry
+		if len(record) < 4 || record[2] != ' ' {
+			continue
 		}
 
-		// Modified (unstaged) changes have a non-space character in the second position
-		if workTreeStatus != ' ' && workTreeStatus != '?' {
-			status.ModifiedCount++
+		status.countEntry(record[0], record[1])
+		if isRenameOrCopy(record[0]) || isRenameOrCopy(record[1]) {
+			i++
 		}
 	}
 
 	return status
 }
+
+// isRenameOrCopy reports whether a porcelain status code marks a rename or
+// copy, whose entry is followed by the original path. The work tree column
+// carries one when git detects renames there, e.g. for intent-to-add files.
+func isRenameOrCopy(code byte) bool {
+	return code == 'R' || code == 'C'
+}
+
+// countEntry counts one porcelain status entry by its index and work tree
+// status codes.
+func (s *WorktreeStatus) countEntry(indexStatus, workTreeStatus byte) {
+	// Untracked files start with "??"
+	if indexStatus == '?' && workTreeStatus == '?' {
+		s.UntrackedCount++
+		return
+	}
+
+	// Unmerged paths are neither staged nor modified until resolved
+	if isUnmergedStatus(indexStatus, workTreeStatus) {
+		s.ConflictedCount++
+		return
+	}
+
+	// Staged changes have a non-space, non-? character in the first position
+	if indexStatus != ' ' && indexStatus != '?' {
+		s.StagedCount++
+		if indexStatus == 'R' || indexStatus == 'C' {
+			s.RenamedCount++
+		}
+	}
+
+	// Modified (unstaged) changes have a non-space character in the second position
+	if workTreeStatus != ' ' && workTreeStatus != '?' {
+		s.ModifiedCount++
+	}
+}
+
+// isUnmergedStatus reports whether a porcelain status code denotes an
+// unmerged path: both sides deleted or added, or either side unmerged.
+func isUnmergedStatus(index, workTree byte) bool {
+	switch {
+	case index == 'D' && workTree == 'D', index == 'A' && workTree == 'A':
+		return true
+	case index == 'U' || workTree == 'U':
+		return true
+	}
+	return false
+}
+
+// parseStatusUpstream extracts the upstream branch from a status branch header
+// such as "main...origin/main [ahead 1]". Returns "" if there is no upstream.
+func parseStatusUpstream(header string) string {
+	_, upstream, found := strings.Cut(header, "...")
+	if !found {
+		return ""
+	}
+	upstream, _, _ = strings.Cut(upstream, " ")
+	return upstream
+}
+
+// parseStatusTracking extracts the ahead and behind counts from a status
+// branch header such as "main...origin/main [ahead 1, behind 2]". Both are
+// zero when the branch is in sync, has no upstream or the upstream is gone.
+func parseStatusTracking(header string) (ahead, behind int) {
+	_, tracking, found := strings.Cut(header, " [")
+	if !found {
+		return 0, 0
+	}
+	tracking = strings.TrimSuffix(tracking, "]")
+	for _, part := range strings.Split(tracking, ", ") {
+		kind, count, _ := strings.Cut(part, " ")
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			continue
+		}
+		switch kind {
+		case "ahead":
+			ahead = n
+		case "behind":
+			behind = n
+		}
+	}
+	return ahead, behind
+}
+
+// UpstreamBranch returns the upstream (tracking) branch of the branch checked
+// out at path, e.g. "origin/feature-x". Returns an empty string with no error
+// if the branch has no upstream configured.
+func UpstreamBranch(path string) (string, error) {
+	return UpstreamBranchContext(context.Background(), path)
+}
+
+// UpstreamBranchContext is like UpstreamBranch but stops git when ctx is done.
+func UpstreamBranchContext(ctx context.Context, path string) (string, error) {
+	if err := checkRepository(ctx, path); err != nil {
+		return "", err
+	}
+
+	output, err := runGit(ctx, path, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	if err != nil {
+		reason := failureReason(output, err)
+		if strings.Contains(reason, "no upstream configured") {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get upstream branch: %s", reason)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
@@ -2,6 +2,7 @@
 package git
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -92,6 +93,16 @@ func TestParseWorktreeList(t *testing.T) {
 				{Path: "/path/to/detached", Branch: "", CommitHash: "abc1234", IsBare: false, IsDetached: true},
 			},
 		},
+		{
+			name: "locked and prunable annotations",
+			input: `/path/to/locked  abc1234 [feature] locked
+/path/to/stale  def5678 (detached HEAD) prunable
+`,
+			expected: []Worktree{
+				{Path: "/path/to/locked", Branch: "feature", CommitHash: "abc1234", IsLocked: true},
+				{Path: "/path/to/stale", CommitHash: "def5678", IsDetached: true},
+			},
+		},
 		{
 			name:     "empty input",
 			input:    "",
@@ -126,6 +137,9 @@ func TestParseWorktreeList(t *testing.T) {
 				if wt.IsDetached != tt.expected[i].IsDetached {
 					t.Errorf("Worktree %d: expected IsDetached %v, got %v", i, tt.expected[i].IsDetached, wt.IsDetached)
 				}
+				if wt.IsLocked != tt.expected[i].IsLocked {
+					t.Errorf("Worktree %d: expected IsLocked %v, got %v", i, tt.expected[i].IsLocked, wt.IsLocked)
+				}
 			}
 		})
 	}
@@ -377,6 +391,70 @@ func TestWorktreeAddError(t *testing.T) {
 	}
 }
 
+// TestClassifyGitError verifies known failures are told apart in git's output.
+func TestClassifyGitError(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   ErrorCategory
+	}{
+		{"fatal: '../repo-feature' already exists", CategoryPathConflict},
+		{"Preparing worktree (new branch 'feature')\nfatal: '/src/repo feature' already exists", CategoryPathConflict},
+		{"fatal: '/src/wt' is a missing but already registered worktree;\nuse 'add -f' to override, or 'prune' or 'remove' to clear", CategoryPathConflict},
+		{"fatal: '/src/wt' is a missing but locked worktree;\nuse 'add -f -f' to override, or 'unlock' and 'prune' or 'remove' to clear", CategoryPathConflict},
+		{"fatal: a branch named 'feature' already exists", CategoryBranchExists},
+		{"Preparing worktree (new branch 'feature')\nfatal: a branch named 'feature' already exists", CategoryBranchExists},
+		{"fatal: 'feature' is already checked out at '/src/repo'", CategoryBranchInUse},
+		{"Preparing worktree (checking out 'feature')\nfatal: 'feature' is already used by worktree at '/src/repo'", CategoryBranchInUse},
+		{"fatal: '/src/wt' contains modified or untracked files, use --force to delete it", CategoryUncommittedChanges},
+		{"error: The branch 'feature' is not fully merged.\nIf you are sure you want to delete it, run 'git branch -D feature'.", CategoryNotMerged},
+		{"fatal: cannot remove a locked worktree, lock reason: on a USB drive\nuse 'remove -f -f' to override or unlock first", CategoryUnknown},
+		{"fatal: invalid reference: nope", CategoryUnknown},
+		{"", CategoryUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := classifyGitError(tt.stderr); got != tt.want {
+			t.Errorf("classifyGitError(%q) = %d, want %d", tt.stderr, got, tt.want)
+		}
+	}
+}
+
+// TestAddWorktreePathConflict verifies adding a worktree at a non-empty directory is reported as a path conflict.
+func TestAddWorktreePathConflict(t *testing.T) {
+	repo := initTestRepo(t)
+	wtPath := filepath.Join(t.TempDir(), "taken")
+	if err := os.MkdirAll(wtPath, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(wtPath, "file.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	err := AddWorktree(repo, AddWorktreeOptions{Path: wtPath, Branch: "feature", CreateBranch: true})
+	if !IsPathConflictError(err) {
+		t.Errorf("Expected a path conflict, got: %v", err)
+	}
+
+	// A taken branch name is told apart from a taken path
+	if output, err := exec.Command("git", "-C", repo, "branch", "existing").CombinedOutput(); err != nil {
+		t.Fatalf("git branch failed: %v\n%s", err, output)
+	}
+	err = AddWorktree(repo, AddWorktreeOptions{Path: filepath.Join(t.TempDir(), "wt"), Branch: "existing", CreateBranch: true})
+	var addErr *WorktreeAddError
+	if !errors.As(err, &addErr) || addErr.Category != CategoryBranchExists {
+		t.Errorf("Expected a branch-exists error, got: %v", err)
+	}
+
+	// Other failures are not path conflicts
+	err = AddWorktree(repo, AddWorktreeOptions{Path: filepath.Join(t.TempDir(), "wt"), Branch: "missing"})
+	if err == nil || IsPathConflictError(err) {
+		t.Errorf("Expected an error that is not a path conflict, got: %v", err)
+	}
+	if IsPathConflictError(nil) {
+		t.Error("IsPathConflictError(nil) should be false")
+	}
+}
+
 // TestAddWorktreeOptions verifies the options struct.
 func TestAddWorktreeOptions(t *testing.T) {
 	opts := AddWorktreeOptions{
@@ -400,6 +478,83 @@ func TestAddWorktreeOptions(t *testing.T) {
 	}
 }
 
+// TestAddWorktreeCommandConstruction verifies the git arguments built for each mode.
+func TestAddWorktreeCommandConstruction(t *testing.T) {
+	tests := []struct {
+		name string
+		opts AddWorktreeOptions
+		want string
+	}{
+		{"existing branch", AddWorktreeOptions{Path: "../wt", Branch: "feature"}, "worktree add ../wt feature"},
+		{"new branch", AddWorktreeOptions{Path: "../wt", Branch: "feature", CreateBranch: true}, "worktree add -b feature ../wt"},
+		{"new branch from base", AddWorktreeOptions{Path: "../wt", Branch: "feature", CreateBranch: true, BaseBranch: "main"}, "worktree add -b feature ../wt main"},
+		{"detached", AddWorktreeOptions{Path: "../inspect", Detach: true, Commit: "v1.2.0"}, "worktree add --detach ../inspect v1.2.0"},
+		{"new branch from tag", AddWorktreeOptions{Path: "../hotfix", Branch: "hotfix", CreateBranch: true, BaseBranch: "v1.2.0"}, "worktree add -b hotfix ../hotfix v1.2.0"},
+		{"no checkout", AddWorktreeOptions{Path: "../wt", Branch: "feature", CreateBranch: true, NoCheckout: true}, "worktree add --no-checkout -b feature ../wt"},
+		{"no checkout detached", AddWorktreeOptions{Path: "../inspect", Detach: true, Commit: "v1.2.0", NoCheckout: true}, "worktree add --no-checkout --detach ../inspect v1.2.0"},
+	}
+
+	for _, tt := range tests {
+		fake := &fakeRunner{}
+		useFakeRunner(t, fake)
+
+		if err := AddWorktree("/repo", tt.opts); err != nil {
+			t.Errorf("%s: AddWorktree failed: %v", tt.name, err)
+			continue
+		}
+		last := fake.calls[len(fake.calls)-1]
+		if last != tt.want {
+			t.Errorf("%s: expected git %q, got %q", tt.name, tt.want, last)
+		}
+	}
+}
+
+// TestWorktreeAddCommand verifies the command that recreates a worktree for each mode.
+func TestWorktreeAddCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		wt           Worktree
+		createBranch bool
+		want         string
+	}{
+		{"new branch", Worktree{Path: "/src/feature", Branch: "feature", CommitHash: "abc1234"}, true, "git worktree add -b feature /src/feature"},
+		{"existing branch", Worktree{Path: "/src/feature", Branch: "feature", CommitHash: "abc1234"}, false, "git worktree add /src/feature feature"},
+		{"detached", Worktree{Path: "/src/inspect", CommitHash: "abc1234", IsDetached: true}, false, "git worktree add --detach /src/inspect abc1234"},
+		{"detached ignores new branch", Worktree{Path: "/src/inspect", CommitHash: "abc1234", IsDetached: true}, true, "git worktree add --detach /src/inspect abc1234"},
+		{"quoted path", Worktree{Path: "/src/my work", Branch: "feature"}, false, "git worktree add '/src/my work' feature"},
+		{"bare", Worktree{Path: "/src/repo.git", IsBare: true}, false, ""},
+		{"detached without commit", Worktree{Path: "/src/inspect", IsDetached: true}, false, ""},
+	}
+
+	for _, tt := range tests {
+		if got := WorktreeAddCommand(tt.wt, tt.createBranch); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestAddWorktreeDetachValidation verifies detaching requires a commit and excludes new branches.
+func TestAddWorktreeDetachValidation(t *testing.T) {
+	fake := &fakeRunner{}
+	useFakeRunner(t, fake)
+
+	invalid := []AddWorktreeOptions{
+		{Path: "../inspect", Detach: true},
+		{Path: "../inspect", Detach: true, Commit: "main", CreateBranch: true, Branch: "x"},
+	}
+	for _, opts := range invalid {
+		err := AddWorktree("/repo", opts)
+		if _, ok := err.(*WorktreeAddError); !ok {
+			t.Errorf("AddWorktree(%+v) should return WorktreeAddError, got %v", opts, err)
+		}
+	}
+	for _, call := range fake.calls {
+		if strings.HasPrefix(call, "worktree add") {
+			t.Errorf("Invalid options should not run git, ran %q", call)
+		}
+	}
+}
+
 // TestAddWorktreeInNonGitDir tests AddWorktree in a non-git directory.
 func TestAddWorktreeInNonGitDir(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "gitworktreetest")
@@ -992,6 +1147,9 @@ func TestRemoveWorktreeWithUncommittedChanges(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for worktree with uncommitted changes, got nil")
 	}
+	if !IsUncommittedChangesError(err) {
+		t.Errorf("Expected the error to be categorized as uncommitted changes, got: %v", err)
+	}
 
 	// Remove with force - should succeed
 	err = RemoveWorktree(tmpDir, RemoveWorktreeOptions{
@@ -1238,8 +1396,10 @@ func TestPruneWorktreesWithStaleEntry(t *testing.T) {
 		t.Fatalf("PruneWorktrees failed: %v", err)
 	}
 
-	// The prune should have worked (even if output is empty)
-	_ = output
+	// The verbose output names the pruned entry
+	if names := ParsePruneOutput(output); len(names) != 1 || names[0] != "worktree-prune-test" {
+		t.Errorf("Expected the pruned entry to be reported, got %v from %q", names, output)
+	}
 
 	// Verify the stale entry was removed from the worktree list
 	worktrees, err = ListWorktrees(tmpDir)
@@ -1318,6 +1478,91 @@ func TestWorktreeStatusIsClean(t *testing.T) {
 	}
 }
 
+// TestWorktreeStatusSummary tests the one-line summary of changes.
+func TestWorktreeStatusSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   WorktreeStatus
+		expected string
+	}{
+		{
+			name:     "clean",
+			status:   WorktreeStatus{},
+			expected: "Clean",
+		},
+		{
+			name:     "modified only",
+			status:   WorktreeStatus{ModifiedCount: 3},
+			expected: "3 modified",
+		},
+		{
+			name:     "untracked only",
+			status:   WorktreeStatus{UntrackedCount: 1},
+			expected: "1 untracked",
+		},
+		{
+			name:     "all categories",
+			status:   WorktreeStatus{ModifiedCount: 3, StagedCount: 2, UntrackedCount: 1},
+			expected: "2 staged, 3 modified, 1 untracked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Summary(); got != tt.expected {
+				t.Errorf("Summary() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseWorktreeStatusConflicts tests counting of unmerged paths.
+func TestParseWorktreeStatusConflicts(t *testing.T) {
+	input := "UU both-modified.go\n" +
+		"AA both-added.go\n" +
+		"DD both-deleted.go\n" +
+		"AU added-by-us.go\n" +
+		"UD deleted-by-them.go\n" +
+		"UA added-by-them.go\n" +
+		"DU deleted-by-us.go\n" +
+		"M  resolved.go\n" +
+		" M edited.go\n"
+
+	status := ParseWorktreeStatus(input)
+	if status.ConflictedCount != 7 {
+		t.Errorf("Expected 7 conflicted files, got %d", status.ConflictedCount)
+	}
+	if status.StagedCount != 1 || status.ModifiedCount != 1 {
+		t.Errorf("Conflicts should not count as staged or modified, got staged=%d modified=%d",
+			status.StagedCount, status.ModifiedCount)
+	}
+	if status.IsClean() {
+		t.Error("A worktree with conflicts should not be clean")
+	}
+	if got := status.Summary(); got != "7 conflicted, 1 staged, 1 modified" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+// TestParseWorktreeStatusRenames tests counting of staged renames and copies.
+func TestParseWorktreeStatusRenames(t *testing.T) {
+	input := "R  old.go -> new.go\n" +
+		"RM moved.go -> moved-and-edited.go\n" +
+		"C  base.go -> copy.go\n" +
+		"A  added.go\n"
+
+	status := ParseWorktreeStatus(input)
+	if status.RenamedCount != 3 {
+		t.Errorf("Expected 3 renames/copies, got %d", status.RenamedCount)
+	}
+	if status.StagedCount != 4 {
+		t.Errorf("Renames should still count as staged, got %d staged", status.StagedCount)
+	}
+	if status.ModifiedCount != 1 {
+		t.Errorf("Expected 1 modified file, got %d", status.ModifiedCount)
+	}
+}
+
 // TestParseWorktreeStatus tests parsing of git status --porcelain output.
 func TestParseWorktreeStatus(t *testing.T) {
 	tests := []struct {
@@ -1421,6 +1666,125 @@ A  added.txt
 	}
 }
 
+// TestParseWorktreeStatusZ verifies NUL-delimited status records, including
+// file names with spaces and arrows and the extra record of a rename.
+func TestParseWorktreeStatusZ(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  WorktreeStatus
+	}{
+		{"empty output", "", WorktreeStatus{}},
+		{
+			name:  "spaced file names",
+			input: " M my file.txt\x00?? notes for later.md\x00A  new dir/a b.go\x00",
+			want:  WorktreeStatus{ModifiedCount: 1, StagedCount: 1, UntrackedCount: 1},
+		},
+		{
+			// The original path of a rename is its own record, which must not be counted
+			name:  "rename",
+			input: "R  new name.go\x00old name.go\x00 M other.go\x00",
+			want:  WorktreeStatus{ModifiedCount: 1, StagedCount: 1, RenamedCount: 1},
+		},
+		{
+			name:  "rename with arrow in names",
+			input: "RM a -> b.txt\x00c -> d.txt\x00",
+			want:  WorktreeStatus{ModifiedCount: 1, StagedCount: 1, RenamedCount: 1},
+		},
+		{
+			// An original path that looks like a status entry is still skipped
+			name:  "copy of a file named like an entry",
+			input: "C  copy.go\x00?? x\x00",
+			want:  WorktreeStatus{StagedCount: 1, RenamedCount: 1},
+		},
+		{
+			// A rename in the work tree column has an original path too
+			name:  "work tree rename",
+			input: " R new name\x00ab cd\x00 M other.go\x00",
+			want:  WorktreeStatus{ModifiedCount: 2},
+		},
+		{
+			name:  "branch header and conflict",
+			input: "## main...origin/main [ahead 2, behind 1]\x00UU merge me.go\x00",
+			want:  WorktreeStatus{ConflictedCount: 1, Upstream: "origin/main", Ahead: 2, Behind: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseWorktreeStatusZ(tt.input); *got != tt.want {
+				t.Errorf("ParseWorktreeStatusZ(%q) = %+v, want %+v", tt.input, *got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGetWorktreeStatusSpacedNames verifies renamed and spaced file names are
+// counted once each in a real repository.
+func TestGetWorktreeStatusSpacedNames(t *testing.T) {
+	repo := initTestRepo(t)
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	run("mv", "test.txt", "renamed -> file.txt")
+	if err := os.WriteFile(filepath.Join(repo, "with space.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	status, err := GetWorktreeStatus(repo)
+	if err != nil {
+		t.Fatalf("GetWorktreeStatus failed: %v", err)
+	}
+	if status.StagedCount != 1 || status.RenamedCount != 1 || status.UntrackedCount != 1 || status.ModifiedCount != 0 {
+		t.Errorf("Expected 1 staged rename and 1 untracked file, got %+v", status)
+	}
+}
+
+// TestGetWorktreeStatusUntrackedModes verifies the status command and counts with and without untracked files.
+func TestGetWorktreeStatusUntrackedModes(t *testing.T) {
+	t.Cleanup(func() { SetCountUntracked(true) })
+
+	porcelain := "## main\x00 M changed.go\x00?? build/\x00"
+	fake := &fakeRunner{outputs: map[string]string{
+		"status --porcelain=v1 -z --branch":                      porcelain,
+		"status --porcelain=v1 -z --branch --untracked-files=no": "## main\x00 M changed.go\x00",
+	}}
+	useFakeRunner(t, fake)
+
+	status, err := GetWorktreeStatus("/repo")
+	if err != nil {
+		t.Fatalf("GetWorktreeStatus failed: %v", err)
+	}
+	if status.ModifiedCount != 1 || status.UntrackedCount != 1 {
+		t.Errorf("Expected 1 modified and 1 untracked by default, got %+v", status)
+	}
+
+	SetCountUntracked(false)
+	status, err = GetWorktreeStatus("/repo")
+	if err != nil {
+		t.Fatalf("GetWorktreeStatus failed: %v", err)
+	}
+	if status.ModifiedCount != 1 || status.UntrackedCount != 0 {
+		t.Errorf("Expected untracked files to be skipped, got %+v", status)
+	}
+	if last := fake.calls[len(fake.calls)-1]; last != "status --porcelain=v1 -z --branch --untracked-files=no" {
+		t.Errorf("Expected --untracked-files=no, got %q", last)
+	}
+
+	// Only untracked files no longer make the worktree dirty
+	fake.outputs["status --porcelain=v1 -z --branch --untracked-files=no"] = "## main\x00"
+	status, _ = GetWorktreeStatus("/repo")
+	if !status.IsClean() {
+		t.Error("Worktree with only untracked files should be clean when they are not counted")
+	}
+}
+
 // TestGetWorktreeStatusInNonGitDir tests GetWorktreeStatus in a non-git directory.
 func TestGetWorktreeStatusInNonGitDir(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "gitworktreetest")
@@ -1534,6 +1898,23 @@ func TestGetWorktreeStatusIntegration(t *testing.T) {
 	}
 }
 
+// TestParsePruneOutput verifies removed entries are read from verbose prune output.
+func TestParsePruneOutput(t *testing.T) {
+	output := "Removing worktrees/old: gitdir file points to non-existent location\n" +
+		"Removing worktrees/feature-x: not a valid directory\n"
+	names := ParsePruneOutput(output)
+	if len(names) != 2 || names[0] != "old" || names[1] != "feature-x" {
+		t.Errorf("Expected [old feature-x], got %v", names)
+	}
+
+	if names := ParsePruneOutput(""); len(names) != 0 {
+		t.Errorf("Expected no names for empty output, got %v", names)
+	}
+	if names := ParsePruneOutput("warning: something else\n"); len(names) != 0 {
+		t.Errorf("Expected unrelated lines to be ignored, got %v", names)
+	}
+}
+
 // TestPruneWorktreesDryRun tests the dry-run mode of pruning.
 func TestPruneWorktreesDryRun(t *testing.T) {
 	// Check if git is available
@@ -1594,11 +1975,9 @@ func TestPruneWorktreesDryRun(t *testing.T) {
 		t.Fatalf("PruneWorktreesDryRun failed: %v", err)
 	}
 
-	// Output should mention the stale worktree path
-	if !strings.Contains(output, "dryrun-test") && !strings.Contains(output, "worktree-dryrun-test") {
-		// Some git versions may have different output format
-		// Just check that it ran successfully
-		_ = output
+	// Output should mention the stale worktree entry
+	if !strings.Contains(output, "dryrun-test") {
+		t.Errorf("Expected dry-run output to mention the stale worktree, got %q", output)
 	}
 
 	// The entry should still be in the list (dry run doesn't remove)
@@ -1611,3 +1990,682 @@ func TestPruneWorktreesDryRun(t *testing.T) {
 	// Note: git worktree list may or may not show stale entries depending on version
 	_ = worktrees
 }
+
+// initTestRepo creates a temporary git repository with a single commit
+// and returns its path. Skips the test if git is not available.
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available, skipping integration test")
+	}
+
+	tmpDir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	run("init")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test User")
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("test"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	run("add", ".")
+	run("commit", "-m", "initial")
+
+	return tmpDir
+}
+
+// TestMarkMainWorktree verifies the main worktree is flagged by path, falling back to the first entry.
+func TestMarkMainWorktree(t *testing.T) {
+	tests := []struct {
+		name     string
+		mainPath string
+		expected int
+	}{
+		{"matches by path", "/path/to/main", 0},
+		{"matches non-first entry", "/path/to/feature/", 1},
+		{"empty path falls back to first", "", 0},
+		{"unknown path falls back to first", "/elsewhere", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worktrees := ParseWorktreeList(`/path/to/main  abc1234 [main]
+/path/to/feature  def5678 [feature]
+`)
+			MarkMainWorktree(worktrees, tt.mainPath)
+
+			for i, wt := range worktrees {
+				if wt.IsMain != (i == tt.expected) {
+					t.Errorf("Worktree %d: expected IsMain %v, got %v", i, i == tt.expected, wt.IsMain)
+				}
+			}
+		})
+	}
+
+	// Empty list should not panic
+	MarkMainWorktree(nil, "/path/to/main")
+}
+
+// TestListWorktreesMarksMain verifies only the main worktree is flagged in a real repository.
+func TestListWorktreesMarksMain(t *testing.T) {
+	repo := initTestRepo(t)
+
+	linkedPath := filepath.Join(t.TempDir(), "linked")
+	cmd := exec.Command("git", "worktree", "add", "-b", "linked", linkedPath)
+	cmd.Dir = repo
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git worktree add failed: %v\n%s", err, output)
+	}
+
+	// Listing from the linked worktree must still identify the main one
+	worktrees, err := ListWorktrees(linkedPath)
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	if len(worktrees) != 2 {
+		t.Fatalf("Expected 2 worktrees, got %d", len(worktrees))
+	}
+
+	for _, wt := range worktrees {
+		isMain := samePath(wt.Path, repo)
+		if wt.IsMain != isMain {
+			t.Errorf("Worktree %s: expected IsMain %v, got %v", wt.Path, isMain, wt.IsMain)
+		}
+	}
+}
+
+// TestRepoInfo verifies the top level, git dir and common dir of main, linked and bare repositories.
+func TestRepoInfo(t *testing.T) {
+	repo := initTestRepo(t)
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	linkedPath := filepath.Join(t.TempDir(), "linked")
+	run(repo, "worktree", "add", "-b", "linked", linkedPath)
+
+	barePath := filepath.Join(t.TempDir(), "bare.git")
+	run(repo, "clone", "--bare", repo, barePath)
+
+	subdir := filepath.Join(repo, "sub")
+	if err := os.Mkdir(subdir, 0755); err != nil {
+		t.Fatalf("Failed to create subdirectory: %v", err)
+	}
+
+	commonDir := filepath.Join(repo, ".git")
+	tests := []struct {
+		name                        string
+		dir                         string
+		toplevel, gitDir, commonDir string
+	}{
+		{"main worktree", repo, repo, commonDir, commonDir},
+		{"subdirectory", subdir, repo, commonDir, commonDir},
+		{"linked worktree", linkedPath, linkedPath, filepath.Join(commonDir, "worktrees", "linked"), commonDir},
+		{"bare repository", barePath, "", barePath, barePath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toplevel, gitDir, commonDir, err := RepoInfo(tt.dir)
+			if err != nil {
+				t.Fatalf("RepoInfo failed: %v", err)
+			}
+			if (tt.toplevel == "") != (toplevel == "") || (tt.toplevel != "" && !samePath(toplevel, tt.toplevel)) {
+				t.Errorf("toplevel = %q, want %q", toplevel, tt.toplevel)
+			}
+			if !samePath(gitDir, tt.gitDir) {
+				t.Errorf("gitDir = %q, want %q", gitDir, tt.gitDir)
+			}
+			if !samePath(commonDir, tt.commonDir) {
+				t.Errorf("commonDir = %q, want %q", commonDir, tt.commonDir)
+			}
+			if !filepath.IsAbs(gitDir) || !filepath.IsAbs(commonDir) {
+				t.Errorf("Expected absolute paths, got %q and %q", gitDir, commonDir)
+			}
+		})
+	}
+
+	if _, _, _, err := RepoInfo(t.TempDir()); !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError outside a repository, got %v", err)
+	}
+}
+
+// TestParseRepoDirs verifies malformed rev-parse output is rejected.
+func TestParseRepoDirs(t *testing.T) {
+	gitDir, commonDir, err := parseRepoDirs("/repo/.git/worktrees/x\n/repo/.git\n")
+	if err != nil || gitDir != "/repo/.git/worktrees/x" || commonDir != "/repo/.git" {
+		t.Errorf("parseRepoDirs() = (%q, %q, %v)", gitDir, commonDir, err)
+	}
+
+	for _, input := range []string{"", "/repo/.git\n", "a\nb\nc\n"} {
+		if _, _, err := parseRepoDirs(input); err == nil {
+			t.Errorf("parseRepoDirs(%q) should fail", input)
+		}
+	}
+}
+
+// TestParseRemoteBranches verifies parsing of remote branch output.
+func TestParseRemoteBranches(t *testing.T) {
+	input := `origin
+origin/HEAD
+origin/main
+origin/feature/login
+upstream/release
+`
+	expected := []string{"origin/main", "origin/feature/login", "upstream/release"}
+
+	result := ParseRemoteBranches(input)
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d branches, got %d: %v", len(expected), len(result), result)
+	}
+	for i, branch := range expected {
+		if result[i] != branch {
+			t.Errorf("Branch %d: expected '%s', got '%s'", i, branch, result[i])
+		}
+	}
+
+	if len(ParseRemoteBranches("")) != 0 {
+		t.Error("Expected no branches for empty output")
+	}
+}
+
+// TestSplitRemoteBranch verifies splitting remote refs into remote and branch.
+func TestSplitRemoteBranch(t *testing.T) {
+	tests := []struct {
+		ref, remote, branch string
+	}{
+		{"origin/main", "origin", "main"},
+		{"origin/feature/login", "origin", "feature/login"},
+		{"main", "", "main"},
+	}
+
+	for _, tt := range tests {
+		remote, branch := SplitRemoteBranch(tt.ref)
+		if remote != tt.remote || branch != tt.branch {
+			t.Errorf("SplitRemoteBranch(%q) = (%q, %q), want (%q, %q)", tt.ref, remote, branch, tt.remote, tt.branch)
+		}
+	}
+}
+
+// TestListRemoteBranchesInNonGitDir tests that ListRemoteBranches returns error for non-git directory.
+func TestListRemoteBranchesInNonGitDir(t *testing.T) {
+	_, err := ListRemoteBranches(t.TempDir())
+	if !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError, got: %v", err)
+	}
+}
+
+// TestAddWorktreeTrackingRemoteBranch verifies creating a worktree that tracks a remote branch.
+func TestAddWorktreeTrackingRemoteBranch(t *testing.T) {
+	origin := initTestRepo(t)
+	runGit := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	runGit(origin, "branch", "feature-x")
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(origin, "clone", origin, clone)
+
+	branches, err := ListRemoteBranches(clone)
+	if err != nil {
+		t.Fatalf("ListRemoteBranches failed: %v", err)
+	}
+	found := false
+	for _, b := range branches {
+		if b == "origin/feature-x" {
+			found = true
+		}
+		if strings.HasSuffix(b, "/HEAD") {
+			t.Errorf("origin/HEAD should be excluded, got %v", branches)
+		}
+	}
+	if !found {
+		t.Fatalf("Expected origin/feature-x in %v", branches)
+	}
+
+	wtPath := filepath.Join(t.TempDir(), "feature-x")
+	err = AddWorktree(clone, AddWorktreeOptions{
+		Path:         wtPath,
+		Branch:       "feature-x",
+		CreateBranch: true,
+		BaseBranch:   "origin/feature-x",
+		Track:        true,
+	})
+	if err != nil {
+		t.Fatalf("AddWorktree with Track failed: %v", err)
+	}
+
+	upstream := runGit(wtPath, "rev-parse", "--abbrev-ref", "feature-x@{upstream}")
+	if upstream != "origin/feature-x" {
+		t.Errorf("Expected upstream origin/feature-x, got %q", upstream)
+	}
+
+	// A second attempt fails because the local branch now exists
+	err = AddWorktree(clone, AddWorktreeOptions{
+		Path:         filepath.Join(t.TempDir(), "again"),
+		Branch:       "feature-x",
+		CreateBranch: true,
+		BaseBranch:   "origin/feature-x",
+		Track:        true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected 'already exists' error, got: %v", err)
+	}
+}
+
+// TestFetchWithoutRemote verifies Fetch reports a NoRemoteError when no remote is configured.
+func TestFetchWithoutRemote(t *testing.T) {
+	repo := initTestRepo(t)
+
+	err := Fetch(repo, "")
+	if !IsNoRemoteError(err) {
+		t.Errorf("Expected NoRemoteError, got: %v", err)
+	}
+}
+
+// TestFetchInNonGitDir tests that Fetch returns error for non-git directory.
+func TestFetchInNonGitDir(t *testing.T) {
+	err := Fetch(t.TempDir(), "")
+	if !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError, got: %v", err)
+	}
+}
+
+// TestFetchPrunesDeletedBranches verifies Fetch picks up new remote branches and prunes deleted ones.
+func TestFetchPrunesDeletedBranches(t *testing.T) {
+	origin := initTestRepo(t)
+	runGit := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+	runGit(origin, "branch", "old")
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(origin, "clone", origin, clone)
+
+	runGit(origin, "branch", "-D", "old")
+	runGit(origin, "branch", "new")
+
+	if err := Fetch(clone, "origin"); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	branches, err := ListRemoteBranches(clone)
+	if err != nil {
+		t.Fatalf("ListRemoteBranches failed: %v", err)
+	}
+	joined := strings.Join(branches, ",")
+	if !strings.Contains(joined, "origin/new") {
+		t.Errorf("Expected origin/new after fetch, got %v", branches)
+	}
+	if strings.Contains(joined, "origin/old") {
+		t.Errorf("Expected origin/old to be pruned, got %v", branches)
+	}
+
+	// Unknown remotes are reported as fetch errors
+	err = Fetch(clone, "missing")
+	if _, ok := err.(*FetchError); !ok {
+		t.Errorf("Expected FetchError for unknown remote, got: %v", err)
+	}
+}
+
+// TestPullCommand verifies Pull only fast-forwards and returns git's output.
+func TestPullCommand(t *testing.T) {
+	fake := &fakeRunner{outputs: map[string]string{
+		"pull --ff-only": "Already up to date.\n",
+	}}
+	useFakeRunner(t, fake)
+
+	output, err := Pull("/repo-x")
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if output != "Already up to date." {
+		t.Errorf("Output = %q, want %q", output, "Already up to date.")
+	}
+	last := fake.calls[len(fake.calls)-1]
+	if last != "pull --ff-only" {
+		t.Errorf("Last git call = %q, want %q", last, "pull --ff-only")
+	}
+}
+
+// TestPullFailures verifies a non-fast-forward is reported as divergence.
+func TestPullFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		stderr   string
+		diverged bool
+	}{
+		{"diverged", "hint: Diverging branches can't be fast-forwarded\nfatal: Not possible to fast-forward, aborting.", true},
+		{"network", "fatal: unable to access 'https://example.com/repo.git/'", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useFakeRunner(t, &fakeRunner{failures: map[string]string{
+				"pull --ff-only": tt.stderr,
+			}})
+
+			_, err := Pull("/repo-x")
+			if _, ok := err.(*PullError); !ok {
+				t.Fatalf("Expected PullError, got %v", err)
+			}
+			if IsDivergedError(err) != tt.diverged {
+				t.Errorf("IsDivergedError = %v, want %v (err: %v)", IsDivergedError(err), tt.diverged, err)
+			}
+		})
+	}
+}
+
+// TestPushCommand verifies the push arguments with and without setting an upstream.
+func TestPushCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		setUpstream bool
+		want        string
+	}{
+		{"set upstream", true, "push -u origin feature-x"},
+		{"existing upstream", false, "push"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeRunner{outputs: map[string]string{
+				"symbolic-ref --short -q HEAD": "feature-x\n",
+			}}
+			useFakeRunner(t, fake)
+
+			if _, err := Push("/repo-x", tt.setUpstream); err != nil {
+				t.Fatalf("Push failed: %v", err)
+			}
+			last := fake.calls[len(fake.calls)-1]
+			if last != tt.want {
+				t.Errorf("Last git call = %q, want %q", last, tt.want)
+			}
+		})
+	}
+}
+
+// TestPushDetachedHead verifies setting an upstream needs a branch.
+func TestPushDetachedHead(t *testing.T) {
+	fake := &fakeRunner{failures: map[string]string{
+		"symbolic-ref --short -q HEAD": "",
+	}}
+	useFakeRunner(t, fake)
+
+	_, err := Push("/repo-x", true)
+	if _, ok := err.(*PushError); !ok {
+		t.Fatalf("Expected PushError, got %v", err)
+	}
+	for _, call := range fake.calls {
+		if strings.HasPrefix(call, "push") {
+			t.Errorf("Expected no push from a detached HEAD, got %q", call)
+		}
+	}
+}
+
+// TestPushFailures verifies authentication and missing remote failures are recognised.
+func TestPushFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		stderr   string
+		auth     bool
+		noRemote bool
+	}{
+		{"https auth", "remote: Invalid username or password.\nfatal: Authentication failed for 'https://example.com/repo.git/'", true, false},
+		{"no credentials", "fatal: could not read Username for 'https://example.com': terminal prompts disabled", true, false},
+		{"ssh key", "git@example.com: Permission denied (publickey).\nfatal: Could not read from remote repository.", true, false},
+		{"no remote", "fatal: 'origin' does not appear to be a git repository\nfatal: Could not read from remote repository.", false, true},
+		{"rejected", " ! [rejected]        x -> x (fetch first)\nerror: failed to push some refs", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useFakeRunner(t, &fakeRunner{failures: map[string]string{
+				"push": tt.stderr,
+			}})
+
+			_, err := Push("/repo-x", false)
+			if _, ok := err.(*PushError); !ok {
+				t.Fatalf("Expected PushError, got %v", err)
+			}
+			if IsPushAuthError(err) != tt.auth {
+				t.Errorf("IsPushAuthError = %v, want %v (err: %v)", IsPushAuthError(err), tt.auth, err)
+			}
+			if IsPushNoRemoteError(err) != tt.noRemote {
+				t.Errorf("IsPushNoRemoteError = %v, want %v (err: %v)", IsPushNoRemoteError(err), tt.noRemote, err)
+			}
+		})
+	}
+}
+
+// TestPushIntegration verifies Push publishes a new branch and sets its upstream.
+func TestPushIntegration(t *testing.T) {
+	repo := initTestRepo(t)
+	runGit := func(dir string, args ...string) {
+		t.Helper()
+		args = append([]string{"-c", "user.email=test@example.com", "-c", "user.name=Test"}, args...)
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	origin := filepath.Join(t.TempDir(), "origin.git")
+	runGit(repo, "clone", "--bare", repo, origin)
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(repo, "clone", origin, clone)
+	runGit(clone, "checkout", "-b", "feature-x")
+	runGit(clone, "commit", "--allow-empty", "-m", "feature work")
+
+	if _, err := Push(clone, true); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	upstream, err := UpstreamBranch(clone)
+	if err != nil || upstream != "origin/feature-x" {
+		t.Errorf("UpstreamBranch = %q, %v; want %q", upstream, err, "origin/feature-x")
+	}
+
+	runGit(clone, "commit", "--allow-empty", "-m", "more feature work")
+	if _, err := Push(clone, false); err != nil {
+		t.Errorf("Push to the existing upstream failed: %v", err)
+	}
+}
+
+// TestPullIntegration verifies Pull fast-forwards a clone and detects divergence.
+func TestPullIntegration(t *testing.T) {
+	origin := initTestRepo(t)
+	runGit := func(dir string, args ...string) {
+		t.Helper()
+		args = append([]string{"-c", "user.email=test@example.com", "-c", "user.name=Test"}, args...)
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(origin, "clone", origin, clone)
+
+	runGit(origin, "commit", "--allow-empty", "-m", "upstream change")
+	if _, err := Pull(clone); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	runGit(origin, "commit", "--allow-empty", "-m", "another upstream change")
+	runGit(clone, "commit", "--allow-empty", "-m", "local change")
+	_, err := Pull(clone)
+	if !IsDivergedError(err) {
+		t.Errorf("Expected a diverged PullError, got %v", err)
+	}
+}
+
+// TestParseWorktreeStatusUpstream verifies the upstream is read from the branch header.
+func TestParseWorktreeStatusUpstream(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"with upstream", "## main...origin/main\n", "origin/main"},
+		{"ahead and behind", "## feature...origin/feature [ahead 1, behind 2]\n M a.txt\n", "origin/feature"},
+		{"no upstream", "## main\n", ""},
+		{"detached", "## HEAD (no branch)\n", ""},
+		{"no header", " M a.txt\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := ParseWorktreeStatus(tt.input)
+			if status.Upstream != tt.expected {
+				t.Errorf("Upstream = %q, want %q", status.Upstream, tt.expected)
+			}
+		})
+	}
+
+	// The header line is not counted as a change
+	if status := ParseWorktreeStatus("## main...origin/main\n"); !status.IsClean() {
+		t.Errorf("Branch header should not count as a change, got %+v", status)
+	}
+}
+
+// TestParseWorktreeStatusAheadBehind verifies the ahead and behind counts are read from the branch header.
+func TestParseWorktreeStatusAheadBehind(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		ahead  int
+		behind int
+	}{
+		{"in sync", "## main...origin/main\n", 0, 0},
+		{"ahead", "## main...origin/main [ahead 2]\n", 2, 0},
+		{"behind", "## main...origin/main [behind 3]\n", 0, 3},
+		{"diverged", "## feature...origin/feature [ahead 1, behind 2]\n M a.txt\n", 1, 2},
+		{"upstream gone", "## feature...origin/feature [gone]\n", 0, 0},
+		{"no upstream", "## main\n", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := ParseWorktreeStatus(tt.input)
+			if status.Ahead != tt.ahead || status.Behind != tt.behind {
+				t.Errorf("Ahead/Behind = %d/%d, want %d/%d", status.Ahead, status.Behind, tt.ahead, tt.behind)
+			}
+		})
+	}
+}
+
+// TestUpstreamBranch verifies the upstream branch is reported for tracking branches only.
+func TestUpstreamBranch(t *testing.T) {
+	origin := initTestRepo(t)
+
+	upstream, err := UpstreamBranch(origin)
+	if err != nil || upstream != "" {
+		t.Errorf("Expected no upstream for repository without remote, got %q (err: %v)", upstream, err)
+	}
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	cmd := exec.Command("git", "clone", origin, clone)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git clone failed: %v\n%s", err, output)
+	}
+
+	upstream, err = UpstreamBranch(clone)
+	if err != nil {
+		t.Fatalf("UpstreamBranch failed: %v", err)
+	}
+	if !strings.HasPrefix(upstream, "origin/") {
+		t.Errorf("Expected origin/ upstream, got %q", upstream)
+	}
+
+	status, err := GetWorktreeStatus(clone)
+	if err != nil {
+		t.Fatalf("GetWorktreeStatus failed: %v", err)
+	}
+	if status.Upstream != upstream {
+		t.Errorf("Expected status upstream %q, got %q", upstream, status.Upstream)
+	}
+}
+
+// TestAheadBehind verifies commits are counted against the upstream only.
+func TestAheadBehind(t *testing.T) {
+	origin := initTestRepo(t)
+
+	ahead, behind, err := AheadBehind(origin)
+	if err != nil || ahead != 0 || behind != 0 {
+		t.Errorf("Expected 0/0 without an upstream, got %d/%d (err: %v)", ahead, behind, err)
+	}
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	if output, err := exec.Command("git", "clone", origin, clone).CombinedOutput(); err != nil {
+		t.Fatalf("git clone failed: %v\n%s", err, output)
+	}
+	// Distinct messages keep the empty commits from sharing a hash
+	for _, c := range []struct{ dir, message string }{
+		{clone, "local one"},
+		{clone, "local two"},
+		{origin, "upstream"},
+	} {
+		cmd := exec.Command("git", "-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", c.message)
+		cmd.Dir = c.dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git commit failed: %v\n%s", err, output)
+		}
+	}
+	fetch := exec.Command("git", "fetch")
+	fetch.Dir = clone
+	if output, err := fetch.CombinedOutput(); err != nil {
+		t.Fatalf("git fetch failed: %v\n%s", err, output)
+	}
+
+	ahead, behind, err = AheadBehind(clone)
+	if err != nil {
+		t.Fatalf("AheadBehind failed: %v", err)
+	}
+	if ahead != 2 || behind != 1 {
+		t.Errorf("Expected 2 ahead and 1 behind, got %d/%d", ahead, behind)
+	}
+}
+
+// TestAheadBehindInNonGitDir verifies AheadBehind reports a non-repository directory.
+func TestAheadBehindInNonGitDir(t *testing.T) {
+	_, _, err := AheadBehind(t.TempDir())
+	if !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError, got: %v", err)
+	}
+}
+
+// TestUpstreamBranchInNonGitDir tests that UpstreamBranch returns error for non-git directory.
+func TestUpstreamBranchInNonGitDir(t *testing.T) {
+	_, err := UpstreamBranch(t.TempDir())
+	if !IsNotGitRepoError(err) {
+		t.Errorf("Expected NotGitRepoError, got: %v", err)
+	}
+}
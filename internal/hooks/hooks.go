@@ -0,0 +1,63 @@
+// Package hooks runs user-configured commands at points in the worktree lifecycle.
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Environment variables exported to hook commands.
+const (
+	// EnvWorktreePath holds the absolute path of the worktree.
+	EnvWorktreePath = "GROVE_WORKTREE_PATH"
+	// EnvBranch holds the branch checked out in the worktree.
+	EnvBranch = "GROVE_BRANCH"
+)
+
+// HookError is returned when a hook command fails.
+type HookError struct {
+	Command string
+	Reason  string
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("hook %q failed: %s", e.Command, e.Reason)
+}
+
+// RunPostCreate runs the post-create hook command in the new worktree directory.
+// The command is run through the system shell, so it may be an inline command
+// or the path to a script. An empty command is a no-op.
+func RunPostCreate(command, worktreePath, branch string) error {
+	if command == "" {
+		return nil
+	}
+
+	cmd := shellCommand(command)
+	cmd.Dir = worktreePath
+	cmd.Env = append(os.Environ(),
+		EnvWorktreePath+"="+worktreePath,
+		EnvBranch+"="+branch,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		reason := strings.TrimSpace(string(output))
+		if reason == "" {
+			reason = err.Error()
+		}
+		return &HookError{Command: command, Reason: reason}
+	}
+
+	return nil
+}
+
+// shellCommand builds a command that runs the given string through the system shell.
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd.exe", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
@@ -0,0 +1,58 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestRunPostCreateEmptyCommand verifies an empty hook is a no-op.
+func TestRunPostCreateEmptyCommand(t *testing.T) {
+	if err := RunPostCreate("", "/nonexistent", "main"); err != nil {
+		t.Errorf("Expected no error for empty hook, got: %v", err)
+	}
+}
+
+// TestRunPostCreateEnvironment verifies the hook runs in the worktree with the expected environment.
+func TestRunPostCreateEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Test uses POSIX shell syntax")
+	}
+
+	dir := t.TempDir()
+	err := RunPostCreate(`echo "$GROVE_WORKTREE_PATH $GROVE_BRANCH" > hook.out`, dir, "feature")
+	if err != nil {
+		t.Fatalf("RunPostCreate failed: %v", err)
+	}
+
+	// The output file is created relative to the worktree directory
+	data, err := os.ReadFile(filepath.Join(dir, "hook.out"))
+	if err != nil {
+		t.Fatalf("Hook did not run in worktree directory: %v", err)
+	}
+	expected := dir + " feature"
+	if got := strings.TrimSpace(string(data)); got != expected {
+		t.Errorf("Expected hook output %q, got %q", expected, got)
+	}
+}
+
+// TestRunPostCreateFailure verifies a failing hook returns a HookError with its output.
+func TestRunPostCreateFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Test uses POSIX shell syntax")
+	}
+
+	err := RunPostCreate("echo broken >&2; exit 3", t.TempDir(), "feature")
+	if err == nil {
+		t.Fatal("Expected error for failing hook")
+	}
+	hookErr, ok := err.(*HookError)
+	if !ok {
+		t.Fatalf("Expected HookError, got %T", err)
+	}
+	if hookErr.Reason != "broken" {
+		t.Errorf("Expected reason 'broken', got %q", hookErr.Reason)
+	}
+}
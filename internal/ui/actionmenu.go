@@ -13,6 +13,9 @@ type Action struct {
 	ID          string
 	Label       string
 	Description string
+	// Key runs the action straight from the open menu (0 = none). It must
+	// not be j or k, which navigate the menu.
+	Key rune
 }
 
 // ActionMenu is a modal dialog that displays available actions for an item.
@@ -35,12 +38,145 @@ func NewActionMenu() *ActionMenu {
 // defaultWorktreeActions returns the default actions available for worktrees.
 func defaultWorktreeActions() []Action {
 	return []Action{
-		{ID: "open", Label: "Open", Description: "Open worktree in new terminal"},
-		{ID: "cd", Label: "Copy Path", Description: "Copy worktree path to clipboard"},
-		{ID: "delete", Label: "Delete", Description: "Remove this worktree"},
+		{ID: "open", Label: "Open", Description: "Open worktree in new terminal", Key: 'o'},
+		{ID: "cd", Label: "Copy Path", Description: "Copy worktree path to clipboard", Key: 'c'},
+		{ID: "addcmd", Label: "Copy Add Command", Description: "Copy the git worktree add command that recreates this worktree", Key: 'a'},
+		{ID: "hash", Label: "Copy Commit Hash", Description: "Copy the full hash of the checked out commit to clipboard", Key: 'h'},
+		{ID: "branch", Label: "Branch from Here", Description: "Create a new branch and worktree from this worktree's HEAD", Key: 'b'},
+		{ID: "label", Label: "Set Label", Description: "Show a name of your choice instead of the directory name", Key: 'l'},
+		{ID: "delete", Label: "Delete", Description: "Remove this worktree", Key: 'd'},
 	}
 }
 
+// remoteBranchActions returns the actions available for remote-tracking branches.
+func remoteBranchActions() []Action {
+	return []Action{
+		{ID: "track", Label: "Track in New Worktree", Description: "Create a worktree with a local branch tracking this remote branch", Key: 't'},
+		{ID: "switch", Label: "Switch Current Worktree", Description: "Switch the current worktree to this branch", Key: 's'},
+	}
+}
+
+// convertToBranchAction turns a detached HEAD into a named branch.
+var convertToBranchAction = Action{ID: "convert", Label: "Convert to Branch", Description: "Create a branch at this detached HEAD and switch to it", Key: 'v'}
+
+// renameBranchAction renames the branch checked out in a worktree.
+var renameBranchAction = Action{ID: "rename", Label: "Rename Branch", Description: "Give the branch checked out here a new name", Key: 'r'}
+
+// pullAction fast-forwards a worktree's branch from its upstream.
+var pullAction = Action{ID: "pull", Label: "Pull", Description: "Fast-forward the branch from its upstream", Key: 'p'}
+
+// pushAction pushes a worktree's branch, setting its upstream if it has none.
+var pushAction = Action{ID: "push", Label: "Push", Description: "Push the branch to origin, setting its upstream if needed", Key: 'u'}
+
+// actionsForItem returns the actions available for the given item.
+// The main worktree cannot be removed, so its Delete action is omitted,
+// a bare repository has no HEAD to branch from, only a detached
+// worktree can be converted to a branch, only a worktree on a branch can
+// rename it or push it, and only a clean branch with an upstream can be
+// pulled.
+func actionsForItem(item *ListItem) []Action {
+	if item != nil {
+		if _, ok := item.Metadata.(*RemoteBranchItemData); ok {
+			return remoteBranchActions()
+		}
+	}
+
+	actions := defaultWorktreeActions()
+	if isBranchWorktreeItem(item) {
+		// Offer it right before Delete, which stays last
+		last := len(actions) - 1
+		actions = append(actions[:last:last], renameBranchAction, actions[last])
+	}
+	if canPushItem(item) {
+		// Offer it right before Delete, which stays last
+		last := len(actions) - 1
+		actions = append(actions[:last:last], pushAction, actions[last])
+	}
+	if canPullItem(item) {
+		// Offer it right before Delete, which stays last
+		last := len(actions) - 1
+		actions = append(actions[:last:last], pullAction, actions[last])
+	}
+	if isDetachedWorktreeItem(item) {
+		// Offer it right before Delete, which stays last
+		last := len(actions) - 1
+		actions = append(actions[:last:last], convertToBranchAction, actions[last])
+	}
+	isMain := isMainWorktreeItem(item)
+	isBare := isBareWorktreeItem(item)
+	if !isMain && !isBare {
+		return actions
+	}
+
+	filtered := actions[:0]
+	for _, action := range actions {
+		// A bare repository has no working tree to open, branch from or copy
+		// the commit of, and neither it nor the main worktree is recreated by
+		// git worktree add
+		if (isMain && (action.ID == "delete" || action.ID == "addcmd")) || (isBare && (action.ID == "branch" || action.ID == "open" || action.ID == "addcmd" || action.ID == "hash")) {
+			continue
+		}
+		filtered = append(filtered, action)
+	}
+	return filtered
+}
+
+// isBareWorktreeItem reports whether the item represents a bare repository.
+func isBareWorktreeItem(item *ListItem) bool {
+	if item == nil {
+		return false
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	return ok && wtData != nil && wtData.IsBare
+}
+
+// canPullItem reports whether the item is a worktree on a branch with an
+// upstream and without local changes, so a fast-forward pull can apply.
+func canPullItem(item *ListItem) bool {
+	if item == nil {
+		return false
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.IsBare || wtData.IsDetached || wtData.Upstream == "" {
+		return false
+	}
+	return !wtData.HasChanges()
+}
+
+// canPushItem reports whether the item is a worktree with a branch to push.
+// Local changes do not matter, since only commits are pushed.
+func canPushItem(item *ListItem) bool {
+	return isBranchWorktreeItem(item)
+}
+
+// isBranchWorktreeItem reports whether the item represents a worktree with
+// a branch checked out.
+func isBranchWorktreeItem(item *ListItem) bool {
+	if item == nil {
+		return false
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	return ok && wtData != nil && !wtData.IsBare && !wtData.IsDetached && wtData.Branch != ""
+}
+
+// isDetachedWorktreeItem reports whether the item represents a worktree with a detached HEAD.
+func isDetachedWorktreeItem(item *ListItem) bool {
+	if item == nil {
+		return false
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	return ok && wtData != nil && wtData.IsDetached
+}
+
+// isMainWorktreeItem reports whether the item represents the main worktree.
+func isMainWorktreeItem(item *ListItem) bool {
+	if item == nil {
+		return false
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	return ok && wtData != nil && wtData.IsMain
+}
+
 // Visible returns whether the action menu is currently visible.
 func (m *ActionMenu) Visible() bool {
 	return m.visible
@@ -137,11 +273,7 @@ func (m *ActionMenu) Update(msg tea.Msg) tea.Cmd {
 		case tea.KeyEnter:
 			// Execute the selected action
 			if action := m.SelectedAction(); action != nil {
-				item := m.item
-				m.Hide()
-				return func() tea.Msg {
-					return ActionExecutedMsg{Action: action, Item: item}
-				}
+				return m.execute(action)
 			}
 		case tea.KeyUp:
 			m.MoveUp()
@@ -154,6 +286,13 @@ func (m *ActionMenu) Update(msg tea.Msg) tea.Cmd {
 					m.MoveUp()
 				case 'j':
 					m.MoveDown()
+				default:
+					// An action's key executes it right away
+					for i := range m.actions {
+						if m.actions[i].Key != 0 && m.actions[i].Key == msg.Runes[0] {
+							return m.execute(&m.actions[i])
+						}
+					}
 				}
 			}
 		}
@@ -161,6 +300,23 @@ func (m *ActionMenu) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// execute hides the menu and returns a command running action on the item.
+func (m *ActionMenu) execute(action *Action) tea.Cmd {
+	item := m.item
+	m.Hide()
+	return func() tea.Msg {
+		return ActionExecutedMsg{Action: action, Item: item}
+	}
+}
+
+// actionLabel renders the label of an action after its key, e.g. "[o] Open".
+func actionLabel(action Action) string {
+	if action.Key == 0 {
+		return action.Label
+	}
+	return "[" + string(action.Key) + "] " + action.Label
+}
+
 // View renders the action menu.
 func (m *ActionMenu) View() string {
 	if !m.visible {
@@ -193,19 +349,19 @@ func (m *ActionMenu) View() string {
 	for i, action := range m.actions {
 		var line string
 		if i == m.selected {
-			line = FocusIndicator.Symbol + selectedStyle.Render(action.Label)
+			line = FocusIndicator.Symbol + selectedStyle.Render(actionLabel(action))
 			if action.Description != "" {
 				line += "\n" + descStyle.Render(action.Description)
 			}
 		} else {
-			line = FocusIndicator.SymbolInactive + normalStyle.Render(action.Label)
+			line = FocusIndicator.SymbolInactive + normalStyle.Render(actionLabel(action))
 		}
 		lines = append(lines, line)
 	}
 
 	// Add help text using centralized style
 	helpStyle := Styles.Help.MarginTop(1)
-	lines = append(lines, helpStyle.Render("↑/↓: navigate • Enter: select • Esc: cancel"))
+	lines = append(lines, helpStyle.Render("↑/↓: navigate • Enter or key: select • Esc: cancel"))
 
 	content := strings.Join(lines, "\n")
 
@@ -399,3 +399,220 @@ func TestActionMenuSetSize(t *testing.T) {
 		t.Errorf("SetSize(100, 50) resulted in width=%d, height=%d", menu.width, menu.height)
 	}
 }
+
+// TestWorktreeActionsForMainWorktree verifies Delete is hidden for the main worktree
+func TestWorktreeActionsForMainWorktree(t *testing.T) {
+	mainItem := &ListItem{ID: "/repo", Metadata: &WorktreeItemData{Path: "/repo", IsMain: true}}
+	linkedItem := &ListItem{ID: "/feature", Metadata: &WorktreeItemData{Path: "/feature"}}
+
+	for _, a := range actionsForItem(mainItem) {
+		if a.ID == "delete" {
+			t.Error("actionsForItem() should not include 'delete' for the main worktree")
+		}
+	}
+
+	if len(actionsForItem(linkedItem)) != len(defaultWorktreeActions()) {
+		t.Error("actionsForItem() should include all actions for linked worktrees")
+	}
+	if len(actionsForItem(nil)) != len(defaultWorktreeActions()) {
+		t.Error("actionsForItem(nil) should include all actions")
+	}
+}
+
+// TestActionsForRemoteBranch verifies remote branches offer the track and switch actions
+func TestActionsForRemoteBranch(t *testing.T) {
+	item := &ListItem{ID: "origin/feature", Metadata: &RemoteBranchItemData{Ref: "origin/feature", Remote: "origin", Branch: "feature"}}
+
+	actions := actionsForItem(item)
+	if len(actions) != 2 || actions[0].ID != "track" || actions[1].ID != "switch" {
+		t.Errorf("actionsForItem() for remote branch = %+v, want 'track' and 'switch'", actions)
+	}
+}
+
+// TestActionsForBareRepository verifies a bare repository cannot be branched from
+func TestActionsForBareRepository(t *testing.T) {
+	bareItem := &ListItem{ID: "/repo.git", Metadata: &WorktreeItemData{Path: "/repo.git", IsBare: true, IsMain: true}}
+
+	for _, a := range actionsForItem(bareItem) {
+		if a.ID == "branch" || a.ID == "delete" {
+			t.Errorf("actionsForItem() should not include %q for a bare main repository", a.ID)
+		}
+	}
+
+	mainItem := &ListItem{ID: "/repo", Metadata: &WorktreeItemData{Path: "/repo", IsMain: true}}
+	found := false
+	for _, a := range actionsForItem(mainItem) {
+		found = found || a.ID == "branch"
+	}
+	if !found {
+		t.Error("actionsForItem() should include 'branch' for the main worktree")
+	}
+}
+
+// TestActionsForDetachedWorktree verifies only detached worktrees can be converted to a branch
+func TestActionsForDetachedWorktree(t *testing.T) {
+	detachedItem := &ListItem{ID: "/repo-x", Metadata: &WorktreeItemData{Path: "/repo-x", CommitHash: "abc1234", IsDetached: true}}
+	actions := actionsForItem(detachedItem)
+
+	if len(actions) != len(defaultWorktreeActions())+1 {
+		t.Fatalf("Expected %d actions for a detached worktree, got %d", len(defaultWorktreeActions())+1, len(actions))
+	}
+	if actions[len(actions)-2].ID != "convert" || actions[len(actions)-1].ID != "delete" {
+		t.Errorf("Expected 'convert' right before 'delete', got %+v", actions)
+	}
+
+	branchItem := &ListItem{ID: "/repo-y", Metadata: &WorktreeItemData{Path: "/repo-y", Branch: "y"}}
+	for _, a := range actionsForItem(branchItem) {
+		if a.ID == "convert" {
+			t.Error("actionsForItem() should not include 'convert' for a worktree on a branch")
+		}
+	}
+}
+
+// TestActionsForPullableWorktree verifies only clean branches with an upstream can be pulled
+func TestActionsForPullableWorktree(t *testing.T) {
+	tests := []struct {
+		name string
+		data *WorktreeItemData
+		want bool
+	}{
+		{"clean with upstream", &WorktreeItemData{Path: "/repo-x", Branch: "x", Upstream: "origin/x"}, true},
+		{"no upstream", &WorktreeItemData{Path: "/repo-x", Branch: "x"}, false},
+		{"modified", &WorktreeItemData{Path: "/repo-x", Branch: "x", Upstream: "origin/x", ModifiedCount: 1}, false},
+		{"staged", &WorktreeItemData{Path: "/repo-x", Branch: "x", Upstream: "origin/x", StagedCount: 1}, false},
+		{"untracked", &WorktreeItemData{Path: "/repo-x", Branch: "x", Upstream: "origin/x", UntrackedCount: 1}, false},
+		{"detached", &WorktreeItemData{Path: "/repo-x", Upstream: "origin/x", IsDetached: true}, false},
+		{"bare", &WorktreeItemData{Path: "/repo.git", Upstream: "origin/x", IsBare: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := actionsForItem(&ListItem{ID: tt.data.Path, Metadata: tt.data})
+			found := false
+			for _, a := range actions {
+				found = found || a.ID == "pull"
+			}
+			if found != tt.want {
+				t.Errorf("actionsForItem() includes 'pull' = %v, want %v", found, tt.want)
+			}
+			if actions[len(actions)-1].ID != "delete" && !tt.data.IsBare {
+				t.Errorf("Expected 'delete' to stay last, got %+v", actions)
+			}
+		})
+	}
+}
+
+// TestActionsForPushableWorktree verifies only worktrees on a branch can be pushed
+func TestActionsForPushableWorktree(t *testing.T) {
+	tests := []struct {
+		name string
+		data *WorktreeItemData
+		want bool
+	}{
+		{"no upstream", &WorktreeItemData{Path: "/repo-x", Branch: "x"}, true},
+		{"with upstream", &WorktreeItemData{Path: "/repo-x", Branch: "x", Upstream: "origin/x"}, true},
+		{"modified", &WorktreeItemData{Path: "/repo-x", Branch: "x", ModifiedCount: 1}, true},
+		{"detached", &WorktreeItemData{Path: "/repo-x", IsDetached: true}, false},
+		{"bare", &WorktreeItemData{Path: "/repo.git", IsBare: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := false
+			for _, a := range actionsForItem(&ListItem{ID: tt.data.Path, Metadata: tt.data}) {
+				found = found || a.ID == "push"
+			}
+			if found != tt.want {
+				t.Errorf("actionsForItem() includes 'push' = %v, want %v", found, tt.want)
+			}
+		})
+	}
+}
+
+// TestActionsForRenameBranch verifies only worktrees on a branch can rename it
+func TestActionsForRenameBranch(t *testing.T) {
+	tests := []struct {
+		name string
+		data *WorktreeItemData
+		want bool
+	}{
+		{"on a branch", &WorktreeItemData{Path: "/repo-x", Branch: "x"}, true},
+		{"main on a branch", &WorktreeItemData{Path: "/repo", Branch: "main", IsMain: true}, true},
+		{"detached", &WorktreeItemData{Path: "/repo-x", IsDetached: true}, false},
+		{"bare", &WorktreeItemData{Path: "/repo.git", IsBare: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := false
+			for _, a := range actionsForItem(&ListItem{ID: tt.data.Path, Metadata: tt.data}) {
+				found = found || a.ID == "rename"
+			}
+			if found != tt.want {
+				t.Errorf("actionsForItem() includes 'rename' = %v, want %v", found, tt.want)
+			}
+		})
+	}
+}
+
+// TestActionMenuKeyExecutesAction verifies pressing an action's key runs it
+func TestActionMenuKeyExecutesAction(t *testing.T) {
+	menu := NewActionMenu()
+	item := &ListItem{ID: "/repo-x", Title: "repo-x"}
+	menu.Show(item)
+
+	if view := menu.View(); !strings.Contains(view, "[o] Open") || !strings.Contains(view, "[d] Delete") {
+		t.Errorf("Expected the keys next to the labels, got %q", view)
+	}
+
+	cmd := menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if cmd == nil {
+		t.Fatal("Expected 'd' to execute an action")
+	}
+	msg, ok := cmd().(ActionExecutedMsg)
+	if !ok || msg.Action.ID != "delete" || msg.Item != item {
+		t.Errorf("Expected the delete action for the item, got %+v", msg)
+	}
+	if menu.Visible() {
+		t.Error("Menu should close after executing an action")
+	}
+
+	// Navigation keys still navigate, and unknown keys do nothing
+	menu.Show(item)
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if menu.Selected() != 1 || !menu.Visible() {
+		t.Error("Expected 'j' to move the selection")
+	}
+	if cmd := menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}}); cmd != nil {
+		t.Error("Expected a key without an action to do nothing")
+	}
+}
+
+// TestActionKeysUnique verifies every action set has distinct keys that
+// leave the navigation keys free
+func TestActionKeysUnique(t *testing.T) {
+	items := []*ListItem{
+		{ID: "/repo", Metadata: &WorktreeItemData{Path: "/repo", Branch: "main", IsMain: true}},
+		{ID: "/repo-x", Metadata: &WorktreeItemData{Path: "/repo-x", Branch: "x", Upstream: "origin/x"}},
+		{ID: "/repo-d", Metadata: &WorktreeItemData{Path: "/repo-d", IsDetached: true}},
+		{ID: "/repo.git", Metadata: &WorktreeItemData{Path: "/repo.git", IsBare: true}},
+		{ID: "origin/x", Metadata: &RemoteBranchItemData{Ref: "origin/x"}},
+	}
+
+	for _, item := range items {
+		seen := make(map[rune]string)
+		for _, action := range actionsForItem(item) {
+			if action.Key == 0 {
+				t.Errorf("Action %q has no key", action.ID)
+				continue
+			}
+			if action.Key == 'j' || action.Key == 'k' {
+				t.Errorf("Action %q uses navigation key %q", action.ID, action.Key)
+			}
+			if other, ok := seen[action.Key]; ok {
+				t.Errorf("Actions %q and %q share key %q", other, action.ID, action.Key)
+			}
+			seen[action.Key] = action.ID
+		}
+	}
+}
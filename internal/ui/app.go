@@ -2,12 +2,32 @@
 package ui
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
+	"github.com/iatopilskii/grove/internal/config"
+	"github.com/iatopilskii/grove/internal/fsutil"
 	"github.com/iatopilskii/grove/internal/git"
+	"github.com/iatopilskii/grove/internal/hooks"
+)
+
+// Pane identifies a pane of the two-pane layout.
+type Pane int
+
+const (
+	// PaneList is the list pane (default focus).
+	PaneList Pane = iota
+	// PaneDetails is the details pane.
+	PaneDetails
 )
 
 // App is the main application model implementing tea.Model.
@@ -25,22 +45,117 @@ type App struct {
 	actionMenu *ActionMenu
 	// feedback is the feedback message component
 	feedback *Feedback
+	// messageLog records feedback messages and shows them in a panel
+	messageLog *MessageLog
 	// createForm is the worktree creation form modal
 	createForm *CreateForm
 	// confirmDialog is the confirmation dialog modal
 	confirmDialog *ConfirmDialog
+	// inputPrompt is the single-line input modal, e.g. for branch names
+	inputPrompt *InputPrompt
+	// spinner shows progress of background tasks such as fetching
+	spinner *Spinner
+	// settings lists the runtime toggles of the Settings tab
+	settings *SettingsView
+	// focusedPane is the pane receiving navigation keys
+	focusedPane Pane
+	// layout is the preferred pane layout, overriding the width-based default
+	layout Layout
+	// commitCache holds recent commits per worktree path, loaded on selection
+	commitCache map[string][]git.CommitInfo
+	// commitsLoading marks worktree paths whose commits are being loaded
+	commitsLoading map[string]bool
+	// diskUsageCache holds worktree sizes in bytes per path, computed on
+	// selection; -1 marks a failed computation
+	diskUsageCache map[string]int64
+	// diskUsageLoading marks worktree paths whose size is being computed
+	diskUsageLoading map[string]bool
+	// stashCache holds stash counts per worktree path, loaded on selection
+	stashCache map[string]int
+	// stashLoading marks worktree paths whose stashes are being counted
+	stashLoading map[string]bool
+	// diffStatCache holds the uncommitted changes per worktree path, loaded
+	// on selection
+	diffStatCache map[string]diffStat
+	// diffStatLoading marks worktree paths whose diff stat is being loaded
+	diffStatLoading map[string]bool
+	// fullHashCache holds the full commit hash per worktree path, loaded on
+	// selection while the details pane shows full hashes
+	fullHashCache map[string]string
+	// fullHashLoading marks worktree paths whose full hash is being read
+	fullHashLoading map[string]bool
+	// submoduleCache holds the submodule summary per worktree path, loaded
+	// on selection
+	submoduleCache map[string]git.SubmoduleStatus
+	// submoduleLoading marks worktree paths whose submodules are being checked
+	submoduleLoading map[string]bool
+	// mergedCache holds, per worktree path, the default branch its branch is
+	// merged into, or "" if it is not merged; loaded on selection
+	mergedCache map[string]string
+	// mergedLoading marks worktree paths whose branch is being checked
+	mergedLoading map[string]bool
 	// width is the terminal width
 	width int
 	// height is the terminal height
 	height int
 	// worktrees stores the git worktrees
 	worktrees []git.Worktree
+	// worktreeItems are the list items for worktrees, shown on every list tab
+	worktreeItems []ListItem
+	// remoteBranches stores remote-tracking branches, shown on the Branches tab
+	remoteBranches []string
 	// gitError stores any error from git operations
 	gitError error
 	// repoPath is the path to the git repository
 	repoPath string
 	// targetPath is the path to cd to after quitting (for shell wrapper)
 	targetPath string
+	// exitMessage is reported on stderr once the UI has closed
+	exitMessage string
+	// config is the loaded application configuration
+	config config.Config
+	// statePath is where UI state is persisted between runs (empty = disabled)
+	statePath string
+	// labels maps worktree paths to the names shown instead of their
+	// directory names; kept in the state file
+	labels map[string]string
+	// reviewed marks the worktree paths flagged as reviewed; kept in the
+	// state file
+	reviewed map[string]bool
+	// configPath is the user config file watched for theme changes
+	// (empty = not watched)
+	configPath string
+	// configStamp summarizes the config files as last seen by the watcher
+	configStamp string
+	// gitVersion reports the installed git version; replaced in tests
+	gitVersion func() (major, minor, patch int, err error)
+	// openTerminal opens a terminal window at a worktree; replaced in tests
+	openTerminal func(path string) (*git.OpenWorktreeResult, error)
+	// trashDir is where deleted worktrees go when the trash is enabled
+	trashDir string
+	// refreshing is set while an automatic refresh runs in the background
+	refreshing bool
+	// watcher refreshes on file changes when enabled (nil = not watching)
+	watcher *worktreeWatcher
+	// watchFailed is set when watching failed and polling took over
+	watchFailed bool
+	// watchSeq identifies the latest change, so only the last of a burst
+	// refreshes once the debounce has passed
+	watchSeq int
+	// watchPendingPath and watchPendingWorktrees are what changed since the
+	// last refresh triggered by the watcher
+	watchPendingPath      string
+	watchPendingWorktrees bool
+	// detailsPopover shows the details below the list in the compact layout
+	detailsPopover bool
+	// statusScan holds the worktrees whose status is still read in the
+	// background after many worktrees were loaded; statusScanQueue holds
+	// those not requested yet and statusScanRunning counts the requests
+	// in flight
+	statusScan        map[string]bool
+	statusScanQueue   []string
+	statusScanTotal   int
+	statusScanRunning int
 }
 
 // NewApp creates and returns a new App instance.
@@ -49,9 +164,29 @@ func NewApp() *App {
 	return NewAppWithPath("")
 }
 
+// NewAppWithConfig creates a new App instance for the current directory
+// using the given configuration, overridden by the repository's .grove.yaml.
+// The last active tab and selection are restored unless disabled.
+func NewAppWithConfig(cfg config.Config) *App {
+	app := newApp("", cfg)
+	if app.config.RememberStateEnabled() {
+		app.statePath = config.DefaultStatePath()
+		app.restoreState()
+	}
+	app.configPath = config.ResolveConfigPath()
+	app.configStamp = configStamp(app.configFiles())
+	return app
+}
+
 // NewAppWithPath creates a new App instance for a specific path.
-// If path is empty, uses the current working directory.
+// If path is empty, uses the current working directory. The default
+// configuration is overridden by the repository's .grove.yaml.
 func NewAppWithPath(path string) *App {
+	return newApp(path, config.DefaultConfig())
+}
+
+// newApp creates a new App instance for the given path and configuration.
+func newApp(path string, cfg config.Config) *App {
 	app := &App{
 		tabs:          NewTabs(),
 		list:          NewList(nil),
@@ -60,7 +195,22 @@ func NewAppWithPath(path string) *App {
 		feedback:      NewFeedback(),
 		createForm:    NewCreateForm(),
 		confirmDialog: NewConfirmDialog(),
+		inputPrompt:   NewInputPrompt(),
+		spinner:       NewSpinner(),
+		settings:      NewSettingsView(nil),
 		repoPath:      path,
+		config:        cfg,
+		gitVersion:    git.GitVersion,
+		trashDir:      fsutil.DefaultTrashDir(),
+		messageLog:    NewMessageLog(defaultMessageLogSize),
+	}
+	app.feedback.SetLog(app.messageLog)
+	app.applyConfig()
+
+	// Without git nothing else can work, so report it up front
+	if _, err := git.GitAvailable(); err != nil {
+		app.gitError = err
+		return app
 	}
 
 	// Determine the repository path
@@ -74,12 +224,75 @@ func NewAppWithPath(path string) *App {
 		app.repoPath = path
 	}
 
+	// The repository's own config overrides the given one, and must be
+	// applied before worktree statuses are computed
+	app.loadRepoInfo()
+	app.loadRepoConfig()
+
 	// Load worktrees
 	app.loadWorktrees()
 
 	return app
 }
 
+// applyConfig applies the configuration to the components and git package
+// settings that depend on it.
+func (a *App) applyConfig() {
+	a.settings.SetSettings(settingsFromConfig(a.config))
+	a.list.SetShowNumbers(a.config.RowNumbersEnabled())
+
+	git.SetStatusCacheTTL(a.config.StatusCacheTTLDuration())
+	git.SetCountUntracked(a.config.CountUntrackedEnabled())
+
+	// New worktrees without a path go under the configured base directory
+	if a.config.Worktree.BaseDir != "" {
+		a.createForm.SetDefaultPath(a.worktreePathFor)
+	} else {
+		a.createForm.SetDefaultPath(nil)
+	}
+
+	opener := git.NewTerminalOpener()
+	opener.SetOpenInTab(a.config.OpenTerminalInTab())
+	a.openTerminal = opener.OpenWorktree
+}
+
+// loadRepoConfig merges the .grove.yaml at the top level of the repository
+// over the configuration. A broken file is reported and the rest of the
+// configuration still applies.
+func (a *App) loadRepoConfig() {
+	info := a.settings.RepoInfo()
+	if info == nil || info.Toplevel == "" {
+		return
+	}
+
+	repoCfg, err := config.LoadRepoConfig(info.Toplevel)
+	if err != nil {
+		// Shown until the next message, as nothing runs the clear command yet
+		a.feedback.ShowError("Repository config " + config.RepoConfigFile + ": " + err.Error())
+	}
+
+	merged := a.config.Merge(repoCfg)
+	themeChanged := merged.Theme.Colors != a.config.Theme.Colors
+	a.config = merged
+	a.applyConfig()
+	if themeChanged {
+		ApplyThemeConfig(a.config)
+	}
+}
+
+// loadRepoInfo shows where git keeps the repository on the Settings tab.
+// It is left hidden when git cannot tell, e.g. outside a repository.
+func (a *App) loadRepoInfo() {
+	ctx, cancel := a.gitContext()
+	defer cancel()
+	toplevel, gitDir, commonDir, err := git.RepoInfoContext(ctx, a.repoPath)
+	if err != nil {
+		a.settings.SetRepoInfo(nil)
+		return
+	}
+	a.settings.SetRepoInfo(&RepoInfo{Toplevel: toplevel, GitDir: gitDir, CommonDir: commonDir})
+}
+
 // NewAppWithItems creates a new App instance with predefined items.
 // This is primarily used for testing.
 func NewAppWithItems(items []ListItem) *App {
@@ -91,67 +304,474 @@ func NewAppWithItems(items []ListItem) *App {
 		details.SetItem(list.SelectedItem())
 	}
 
-	return &App{
+	app := &App{
 		tabs:          NewTabs(),
 		list:          list,
+		worktreeItems: items,
 		details:       details,
 		actionMenu:    NewActionMenu(),
 		feedback:      NewFeedback(),
 		createForm:    NewCreateForm(),
 		confirmDialog: NewConfirmDialog(),
+		inputPrompt:   NewInputPrompt(),
+		spinner:       NewSpinner(),
+		settings:      NewSettingsView(settingsFromConfig(config.DefaultConfig())),
+		config:        config.DefaultConfig(),
+		trashDir:      fsutil.DefaultTrashDir(),
+		openTerminal:  git.NewTerminalOpener().OpenWorktree,
+		messageLog:    NewMessageLog(defaultMessageLogSize),
+	}
+	app.feedback.SetLog(app.messageLog)
+	app.updateEmptyHint()
+	app.updateTabCounts()
+	return app
+}
+
+// restoreState restores the active tab and selected worktree from the state file.
+// Missing or unreadable state is ignored.
+func (a *App) restoreState() {
+	if a.statePath == "" {
+		return
+	}
+	state, err := config.LoadState(a.statePath)
+	if err != nil {
+		return
+	}
+
+	if len(state.Labels) > 0 || len(state.Reviewed) > 0 {
+		a.labels = state.Labels
+		a.reviewed = state.Reviewed
+		a.relabelWorktrees()
+	}
+	if tab, ok := ParseTab(state.ActiveTab); ok {
+		a.tabs.SetActive(tab)
+		a.handleTabChanged()
+	}
+	if state.SelectedWorktree != "" && a.list.SelectByID(state.SelectedWorktree) {
+		a.details.SetItem(a.list.SelectedItem())
+	}
+	if layout, ok := ParseLayout(state.Layout); ok {
+		a.SetLayout(layout)
+	}
+}
+
+// saveState writes the active tab, selected worktree, layout, labels and
+// reviewed marks to the state file.
+// Failures are ignored since state is a convenience, not a requirement.
+func (a *App) saveState() {
+	if a.statePath == "" {
+		return
+	}
+	state := config.State{ActiveTab: a.tabs.Active().String()}
+	if item := a.list.SelectedItem(); item != nil {
+		state.SelectedWorktree = item.ID
+	}
+	if a.layout != LayoutAuto {
+		state.Layout = a.layout.String()
+	}
+	state.Labels = a.labels
+	state.Reviewed = a.reviewed
+	_ = config.SaveState(a.statePath, state)
+}
+
+// quit marks the application as quitting, persists UI state,
+// and returns the quit command.
+func (a *App) quit() tea.Cmd {
+	a.quitting = true
+	a.saveState()
+	if a.watcher != nil {
+		a.watcher.Close()
+	}
+	return tea.Quit
+}
+
+// newGitContext returns a context that stops git commands after timeout.
+// A zero timeout means no limit.
+func newGitContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
 	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// gitContext returns a context limited by the configured git timeout,
+// so a hung git command surfaces as an error instead of freezing the UI.
+func (a *App) gitContext() (context.Context, context.CancelFunc) {
+	return newGitContext(a.config.GitTimeoutDuration())
 }
 
 // loadWorktrees loads git worktrees from the repository and updates the list.
 func (a *App) loadWorktrees() {
-	worktrees, err := git.ListWorktrees(a.repoPath)
+	ctx, cancel := a.gitContext()
+	worktrees, err := git.ListWorktreesContext(ctx, a.repoPath)
+	cancel()
 	if err != nil {
 		a.gitError = err
 		a.worktrees = nil
+		a.worktreeItems = nil
+		a.remoteBranches = nil
 		a.list.SetItems(nil)
+		a.updateTabCounts()
 		return
 	}
 
-	a.worktrees = worktrees
-	a.gitError = nil
+	// Commits and sizes may have changed since they were cached
+	a.commitCache = nil
+	a.diskUsageCache = nil
+	a.stashCache = nil
+	a.diffStatCache = nil
+	a.fullHashCache = nil
+	a.submoduleCache = nil
+	a.mergedCache = nil
+
+	// Remote branches are optional; a failure just hides them
+	ctx, cancel = a.gitContext()
+	remoteBranches, _ := git.ListRemoteBranchesContext(ctx, a.repoPath)
+	cancel()
+
+	a.startStatusScan(worktrees)
+	a.setWorktrees(worktrees, remoteBranches)
+}
+
+// statusScanMin is how many worktrees can be loaded at once; with more the
+// list shows right away and their statuses are read in the background.
+const statusScanMin = 10
+
+// statusScanConcurrency limits how many statuses are read at a time.
+const statusScanConcurrency = 4
+
+// startStatusScan leaves the status of worktrees to be read in the
+// background when there are more than statusScanMin of them, replacing any
+// scan still running. With fewer the statuses are read while loading.
+func (a *App) startStatusScan(worktrees []git.Worktree) {
+	a.statusScan = nil
+	a.statusScanQueue = nil
+	a.statusScanTotal = 0
+	if len(worktrees) <= statusScanMin {
+		return
+	}
+
+	a.statusScan = make(map[string]bool)
+	for _, wt := range worktrees {
+		if wt.IsBare {
+			continue
+		}
+		a.statusScan[wt.Path] = true
+		a.statusScanQueue = append(a.statusScanQueue, wt.Path)
+	}
+	a.statusScanTotal = len(a.statusScanQueue)
+}
+
+// scanning reports whether worktree statuses are still being read.
+func (a *App) scanning() bool {
+	return len(a.statusScan) > 0
+}
+
+// WorktreeStatusLoadedMsg is sent when the status of a worktree has been
+// read in the background while scanning.
+type WorktreeStatusLoadedMsg struct {
+	Path   string
+	Status *git.WorktreeStatus
+	Err    error
+}
+
+// loadWorktreeStatus returns a command that reads the status of the
+// worktree at path asynchronously.
+func loadWorktreeStatus(path string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		status, err := git.GetWorktreeStatusCachedContext(ctx, path)
+		return WorktreeStatusLoadedMsg{Path: path, Status: status, Err: err}
+	}
+}
+
+// ensureStatusScan returns a command reading the next queued statuses, so
+// that at most statusScanConcurrency are read at a time.
+func (a *App) ensureStatusScan() tea.Cmd {
+	var cmds []tea.Cmd
+	for len(a.statusScanQueue) > 0 && a.statusScanRunning < statusScanConcurrency {
+		path := a.statusScanQueue[0]
+		a.statusScanQueue = a.statusScanQueue[1:]
+		a.statusScanRunning++
+		cmds = append(cmds, loadWorktreeStatus(path, a.config.GitTimeoutDuration()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleWorktreeStatusLoaded shows the status of a scanned worktree in its
+// list item, keeping the selection. A failed read leaves the counts empty.
+func (a *App) handleWorktreeStatusLoaded(msg WorktreeStatusLoadedMsg) {
+	if a.statusScanRunning > 0 {
+		a.statusScanRunning--
+	}
+	if !a.statusScan[msg.Path] {
+		// The scan was replaced since the status was requested
+		return
+	}
+	delete(a.statusScan, msg.Path)
+
+	status := msg.Status
+	if msg.Err != nil {
+		status = nil
+	}
+	for i, wt := range a.worktrees {
+		if wt.Path != msg.Path || i >= len(a.worktreeItems) {
+			continue
+		}
+		a.worktreeItems[i] = a.worktreeItem(wt, status)
+
+		selectedID := ""
+		if item := a.list.SelectedItem(); item != nil {
+			selectedID = item.ID
+		}
+		a.syncListItems()
+		if selectedID != "" && a.list.SelectByID(selectedID) {
+			a.details.SetItem(a.list.SelectedItem())
+		}
+		break
+	}
+}
 
+// setWorktrees shows the given worktrees and remote branches in the list.
+func (a *App) setWorktrees(worktrees []git.Worktree, remoteBranches []string) {
 	// Convert worktrees to list items
 	items := make([]ListItem, len(worktrees))
 	for i, wt := range worktrees {
-		items[i] = worktreeToListItem(wt)
+		items[i] = a.worktreeToListItem(wt)
+	}
+	a.setWorktreeItems(worktrees, items, remoteBranches)
+}
+
+// setWorktreeItems shows items, built from worktrees, and the remote branches.
+func (a *App) setWorktreeItems(worktrees []git.Worktree, items []ListItem, remoteBranches []string) {
+	a.worktrees = worktrees
+	a.gitError = nil
+	a.worktreeItems = items
+	a.remoteBranches = remoteBranches
+
+	a.syncListItems()
+	a.updateTabCounts()
+}
+
+// updateTabCounts shows how many items each list tab has. The Branches tab
+// lists the worktrees followed by the remote branches.
+func (a *App) updateTabCounts() {
+	if a.gitError != nil {
+		a.tabs.SetCounts(nil)
+		return
+	}
+	a.tabs.SetCounts(map[Tab]int{
+		TabWorktrees: len(a.worktreeTabItems()),
+		TabBranches:  len(a.worktreeItems) + len(a.remoteBranches),
+	})
+}
+
+// syncListItems sets the list items for the active tab. The Branches tab
+// shows remote-tracking branches after the worktrees, and the Worktrees tab
+// leaves out the main worktree when list.hide_main is set.
+func (a *App) syncListItems() {
+	items := a.worktreeItems
+	if a.tabs.Active() == TabWorktrees {
+		items = a.worktreeTabItems()
+	} else if a.tabs.Active() == TabBranches && len(a.remoteBranches) > 0 {
+		items = make([]ListItem, 0, len(a.worktreeItems)+len(a.remoteBranches))
+		items = append(items, a.worktreeItems...)
+		for _, ref := range a.remoteBranches {
+			items = append(items, remoteBranchToListItem(ref))
+		}
 	}
 
 	a.list.SetItems(items)
+	a.details.SetItem(a.list.SelectedItem())
+	a.updateEmptyHint()
+}
 
-	// Initialize details with first item
-	if len(items) > 0 {
-		a.details.SetItem(a.list.SelectedItem())
+// worktreeTabItems returns the worktrees listed on the Worktrees tab: all of
+// them, or all but the main worktree when list.hide_main is set.
+func (a *App) worktreeTabItems() []ListItem {
+	if !a.config.HideMainEnabled() {
+		return a.worktreeItems
+	}
+	items := make([]ListItem, 0, len(a.worktreeItems))
+	for i := range a.worktreeItems {
+		if !isMainWorktreeItem(&a.worktreeItems[i]) {
+			items = append(items, a.worktreeItems[i])
+		}
+	}
+	return items
+}
+
+// noWorktreesHint is shown on the Worktrees tab when there is nothing
+// besides the main worktree.
+const noWorktreesHint = "No additional worktrees yet — press n to create one."
+
+// updateEmptyHint shows a quick-create hint in the list when the Worktrees
+// tab has no linked worktrees, only the main worktree (or nothing at all).
+func (a *App) updateEmptyHint() {
+	if a.tabs.Active() != TabWorktrees || a.gitError != nil || hasLinkedWorktrees(a.list.Items()) {
+		a.list.SetEmptyHint("")
+		return
+	}
+	a.list.SetEmptyHint(noWorktreesHint)
+}
+
+// hasLinkedWorktrees reports whether items include anything besides the
+// main worktree or a bare repository.
+func hasLinkedWorktrees(items []ListItem) bool {
+	for _, item := range items {
+		wtData, ok := item.Metadata.(*WorktreeItemData)
+		if ok && wtData != nil && (wtData.IsMain || wtData.IsBare) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// handleTabChanged updates the panes after the active tab changes.
+// Focus returns to the list; the list is only rebuilt when the Branches
+// tab has extra remote branch items.
+func (a *App) handleTabChanged() {
+	a.setFocusedPane(PaneList)
+	if len(a.remoteBranches) > 0 || a.config.HideMainEnabled() {
+		a.syncListItems()
+		return
+	}
+	a.updateEmptyHint()
+}
+
+// remoteBranchToListItem converts a remote-tracking branch name to a ListItem.
+func remoteBranchToListItem(ref string) ListItem {
+	remote, branch := git.SplitRemoteBranch(ref)
+	return ListItem{
+		ID:          ref,
+		Title:       ref,
+		Description: "Remote branch",
+		Metadata: &RemoteBranchItemData{
+			Ref:    ref,
+			Remote: remote,
+			Branch: branch,
+		},
+	}
+}
+
+// defaultWorktreePath suggests a worktree path for a branch, as a sibling
+// of the repository directory.
+func defaultWorktreePath(branch string) string {
+	return filepath.Join("..", strings.ReplaceAll(branch, "/", "-"))
+}
+
+// worktreePathFor returns where the worktree for branch goes by default:
+// under the configured base directory, or as a sibling of the main worktree.
+func (a *App) worktreePathFor(branch string) string {
+	if base := a.config.Worktree.BaseDir; base != "" {
+		return config.ResolveWorktreePath(base, a.repoName(), branch)
+	}
+	return defaultWorktreePath(branch)
+}
+
+// repoName returns the name of the repository for worktree path templates:
+// the main worktree's directory name without a ".git" suffix. For a bare
+// repository kept in ".git" or ".bare" inside a directory, that directory
+// names it.
+func (a *App) repoName() string {
+	dir := a.mainWorktreePath()
+	name := filepath.Base(dir)
+	if name == ".git" || name == ".bare" {
+		name = filepath.Base(filepath.Dir(dir))
+	}
+	return strings.TrimSuffix(name, ".git")
+}
+
+// branchBase returns what a new branch created from the worktree item starts
+// at: its branch, or its commit hash when HEAD is detached. Empty if neither
+// is known.
+func branchBase(item *ListItem) string {
+	if item == nil {
+		return ""
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.IsBare {
+		return ""
+	}
+	if !wtData.IsDetached && wtData.Branch != "" {
+		return wtData.Branch
+	}
+	return wtData.CommitHash
+}
+
+// worktreeAddCommand returns the git worktree add command that recreates
+// the worktree item. A branch without an upstream exists only here, so it is
+// created with -b; one with an upstream is checked out and tracked by name.
+// Empty if the item is not a worktree that can be recreated.
+func worktreeAddCommand(item *ListItem) string {
+	if item == nil || isMainWorktreeItem(item) {
+		return ""
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil {
+		return ""
+	}
+	wt := git.Worktree{
+		Path:       wtData.Path,
+		Branch:     wtData.Branch,
+		CommitHash: wtData.CommitHash,
+		IsBare:     wtData.IsBare,
+		IsDetached: wtData.IsDetached,
 	}
+	return git.WorktreeAddCommand(wt, wtData.Upstream == "")
 }
 
-// worktreeToListItem converts a git.Worktree to a ListItem with status information.
-func worktreeToListItem(wt git.Worktree) ListItem {
+// worktreeToListItem converts a git.Worktree to a ListItem with status
+// information. The status of a worktree still being scanned is left empty.
+func (a *App) worktreeToListItem(wt git.Worktree) ListItem {
 	// Get worktree status (modified/staged file counts)
-	var modifiedCount, stagedCount, untrackedCount int
-	if !wt.IsBare {
-		status, err := git.GetWorktreeStatus(wt.Path)
-		if err == nil && status != nil {
-			modifiedCount = status.ModifiedCount
-			stagedCount = status.StagedCount
-			untrackedCount = status.UntrackedCount
+	// and upstream branch with ahead/behind counts, read from the same git call
+	var status *git.WorktreeStatus
+	if !wt.IsBare && !a.statusScan[wt.Path] {
+		ctx, cancel := a.gitContext()
+		loaded, err := git.GetWorktreeStatusCachedContext(ctx, wt.Path)
+		cancel()
+		if err == nil {
+			status = loaded
 		}
 	}
+	return a.worktreeItem(wt, status)
+}
+
+// worktreeItem converts a git.Worktree to a ListItem showing status, which
+// may be nil if it is unknown.
+func (a *App) worktreeItem(wt git.Worktree, status *git.WorktreeStatus) ListItem {
+	var modifiedCount, stagedCount, untrackedCount, conflictedCount int
+	var upstream string
+	var ahead, behind int
+	if status != nil {
+		modifiedCount = status.ModifiedCount
+		stagedCount = status.StagedCount
+		untrackedCount = status.UntrackedCount
+		conflictedCount = status.ConflictedCount
+		upstream = status.Upstream
+		ahead, behind = status.Ahead, status.Behind
+	}
 
 	// Build metadata
 	metadata := &WorktreeItemData{
-		Path:           wt.Path,
-		Branch:         wt.Branch,
-		CommitHash:     wt.CommitHash,
-		IsBare:         wt.IsBare,
-		IsDetached:     wt.IsDetached,
-		ModifiedCount:  modifiedCount,
-		StagedCount:    stagedCount,
-		UntrackedCount: untrackedCount,
+		Path:            wt.Path,
+		Branch:          wt.Branch,
+		CommitHash:      wt.CommitHash,
+		IsBare:          wt.IsBare,
+		IsDetached:      wt.IsDetached,
+		IsMain:          wt.IsMain,
+		ModifiedCount:   modifiedCount,
+		StagedCount:     stagedCount,
+		UntrackedCount:  untrackedCount,
+		ConflictedCount: conflictedCount,
+		Upstream:        upstream,
+		Ahead:           ahead,
+		Behind:          behind,
+		Reviewed:        a.reviewed[wt.Path],
+		StatusKnown:     status != nil,
 	}
 
 	// Build simple description for backwards compatibility
@@ -166,12 +786,21 @@ func worktreeToListItem(wt git.Worktree) ListItem {
 
 	return ListItem{
 		ID:          wt.Path,
-		Title:       wt.Name(),
+		Title:       a.worktreeTitle(wt),
 		Description: description,
 		Metadata:    metadata,
 	}
 }
 
+// worktreeTitle returns the label of a worktree, or its directory name if
+// it has none. The path still shows the directory name in the details.
+func (a *App) worktreeTitle(wt git.Worktree) string {
+	if label := a.labels[wt.Path]; label != "" {
+		return label
+	}
+	return wt.Name()
+}
+
 // Worktrees returns the list of git worktrees.
 func (a *App) Worktrees() []git.Worktree {
 	return a.worktrees
@@ -182,6 +811,12 @@ func (a *App) GitError() error {
 	return a.gitError
 }
 
+// gitUnavailable returns true if git operations cannot run at all, either
+// because git is not installed or the directory is not a repository.
+func (a *App) gitUnavailable() bool {
+	return git.IsNotGitRepoError(a.gitError) || git.IsGitNotFoundError(a.gitError)
+}
+
 // IsInGitRepo returns true if the app is running in a git repository.
 func (a *App) IsInGitRepo() bool {
 	return a.gitError == nil && !git.IsNotGitRepoError(a.gitError)
@@ -195,14 +830,69 @@ func (a *App) RefreshWorktrees() {
 // Init initializes the application and returns an initial command.
 // This is called once when the program starts.
 func (a *App) Init() tea.Cmd {
-	return tea.EnableMouseCellMotion
+	var watchCmd tea.Cmd
+	if a.configPath != "" {
+		watchCmd = watchConfig(a.configFiles())
+	}
+	// Polling also runs when watching fails to start
+	refreshCmd := a.startWatcher()
+	if interval := a.config.RefreshInterval(); interval > 0 {
+		refreshCmd = tea.Batch(refreshCmd, scheduleRefresh(interval))
+	}
+	return tea.Batch(tea.EnableMouseCellMotion, a.ensureStatusScan(), a.ensureRecentCommits(), a.ensureDiskUsage(), a.ensureStashCount(), a.ensureDiffStat(), a.ensureSubmoduleStatus(), a.ensureMerged(), watchCmd, refreshCmd)
 }
 
 // Update handles incoming messages and updates the model accordingly.
 // It returns the updated model and any command to execute.
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := a.update(msg)
+	if a.quitting {
+		return model, cmd
+	}
+
+	// Read the statuses left to scan, and load recent commits, disk usage,
+	// stashes, the diff stat, the full hash, submodules and whether the
+	// branch is merged when the selection moved to an uncached worktree
+	scanCmd := a.ensureStatusScan()
+	commitsCmd := a.ensureRecentCommits()
+	diskUsageCmd := a.ensureDiskUsage()
+	stashCmd := a.ensureStashCount()
+	diffStatCmd := a.ensureDiffStat()
+	fullHashCmd := a.ensureFullHash()
+	submoduleCmd := a.ensureSubmoduleStatus()
+	mergedCmd := a.ensureMerged()
+	watchCmd := a.ensureWatched()
+	if scanCmd != nil || commitsCmd != nil || diskUsageCmd != nil || stashCmd != nil || diffStatCmd != nil || fullHashCmd != nil || submoduleCmd != nil || mergedCmd != nil || watchCmd != nil {
+		return model, tea.Batch(cmd, scanCmd, commitsCmd, diskUsageCmd, stashCmd, diffStatCmd, fullHashCmd, submoduleCmd, mergedCmd, watchCmd)
+	}
+	return model, cmd
+}
+
+// update handles a message; see Update.
+func (a *App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Handle action execution results and form submissions
 	switch msg := msg.(type) {
+	case RecentCommitsLoadedMsg:
+		a.handleRecentCommitsLoaded(msg)
+		return a, nil
+	case DiskUsageLoadedMsg:
+		a.handleDiskUsageLoaded(msg)
+		return a, nil
+	case StashCountLoadedMsg:
+		a.handleStashCountLoaded(msg)
+		return a, nil
+	case DiffStatLoadedMsg:
+		a.handleDiffStatLoaded(msg)
+		return a, nil
+	case FullHashLoadedMsg:
+		a.handleFullHashLoaded(msg)
+		return a, nil
+	case SubmoduleStatusLoadedMsg:
+		a.handleSubmoduleStatusLoaded(msg)
+		return a, nil
+	case MergedLoadedMsg:
+		a.handleMergedLoaded(msg)
+		return a, nil
 	case ActionExecutedMsg:
 		return a.handleActionExecuted(msg)
 	case ClearFeedbackMsg:
@@ -215,6 +905,54 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 	case ConfirmDialogResultMsg:
 		return a.handleConfirmDialogResult(msg)
+	case InputPromptSubmittedMsg:
+		return a.handleInputPromptSubmitted(msg)
+	case InputPromptCancelledMsg:
+		// Prompt was cancelled, nothing to do
+		return a, nil
+	case FilesCopiedMsg:
+		return a.handleFilesCopied(msg)
+	case PostCreateHookFinishedMsg:
+		return a.handlePostCreateHookFinished(msg)
+	case SpinnerTickMsg:
+		return a, a.spinner.Update(msg)
+	case FetchFinishedMsg:
+		return a.handleFetchFinished(msg)
+	case DefaultBaseLoadedMsg:
+		return a.handleDefaultBaseLoaded(msg)
+	case TagsLoadedMsg:
+		return a.handleTagsLoaded(msg)
+	case RefreshTickMsg:
+		return a.handleRefreshTick()
+	case WorktreeChangedMsg:
+		return a.handleWorktreeChanged(msg)
+	case WatchRefreshDueMsg:
+		return a.handleWatchRefreshDue(msg)
+	case WorktreeStatusRefreshedMsg:
+		return a.handleWorktreeStatusRefreshed(msg)
+	case WorktreeStatusLoadedMsg:
+		a.handleWorktreeStatusLoaded(msg)
+		return a, nil
+	case WorktreesRefreshedMsg:
+		return a.handleWorktreesRefreshed(msg)
+	case PrunePreviewLoadedMsg:
+		return a.handlePrunePreviewLoaded(msg)
+	case PullFinishedMsg:
+		return a.handlePullFinished(msg)
+	case PushFinishedMsg:
+		return a.handlePushFinished(msg)
+	case SettingToggledMsg:
+		return a.handleSettingToggled(msg)
+	case ConfigCheckedMsg:
+		return a.handleConfigChecked(msg)
+	case ConfigReloadDueMsg:
+		return a.handleConfigReloadDue(msg)
+	case ThemeReloadedMsg:
+		return a.handleThemeReloaded(msg)
+	case DigitJumpMsg:
+		a.list.Update(msg)
+		a.details.SetItem(a.list.SelectedItem())
+		return a, nil
 	}
 
 	// If confirm dialog is visible, route all key events to it
@@ -222,21 +960,31 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
 			// Allow Ctrl+C to quit even with dialog open
 			if keyMsg.Type == tea.KeyCtrlC {
-				a.quitting = true
-				return a, tea.Quit
+				return a, a.quit()
 			}
 			cmd := a.confirmDialog.Update(keyMsg)
 			return a, cmd
 		}
 	}
 
+	// If input prompt is visible, route all key events to it
+	if a.inputPrompt.Visible() {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			// Allow Ctrl+C to quit even with prompt open
+			if keyMsg.Type == tea.KeyCtrlC {
+				return a, a.quit()
+			}
+			cmd := a.inputPrompt.Update(keyMsg)
+			return a, cmd
+		}
+	}
+
 	// If create form is visible, route all key events to it
 	if a.createForm.Visible() {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
 			// Allow Ctrl+C to quit even with form open
 			if keyMsg.Type == tea.KeyCtrlC {
-				a.quitting = true
-				return a, tea.Quit
+				return a, a.quit()
 			}
 			cmd := a.createForm.Update(keyMsg)
 			return a, cmd
@@ -248,82 +996,261 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
 			// Allow Ctrl+C to quit even with menu open
 			if keyMsg.Type == tea.KeyCtrlC {
-				a.quitting = true
-				return a, tea.Quit
+				return a, a.quit()
 			}
 			cmd := a.actionMenu.Update(keyMsg)
 			return a, cmd
 		}
 	}
 
+	// If the message log is visible, route all key events to it
+	if a.messageLog.Visible() {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			// Allow Ctrl+C to quit even with the log open
+			if keyMsg.Type == tea.KeyCtrlC {
+				return a, a.quit()
+			}
+			cmd := a.messageLog.Update(keyMsg)
+			return a, cmd
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
 		a.tabs.SetWidth(msg.Width)
+		a.settings.SetWidth(msg.Width - 4)
 		a.updatePaneSizes()
+		a.actionMenu.SetSize(msg.Width, msg.Height)
+		a.createForm.SetSize(msg.Width, msg.Height)
+		a.confirmDialog.SetSize(msg.Width, msg.Height)
+		a.inputPrompt.SetSize(msg.Width, msg.Height)
+		a.messageLog.SetSize(msg.Width, msg.Height)
 		return a, nil
 
 	case tea.KeyMsg:
+		// Enter completes a typed item number instead of opening the menu
+		if msg.Type == tea.KeyEnter && a.list.JumpToPendingNumber() {
+			a.details.SetItem(a.list.SelectedItem())
+			return a, nil
+		}
+
+		// Keys not routed to the list still cancel a pending "gg" sequence
+		// or item number
+		if msg.String() != "g" && !isDigitKey(msg) {
+			a.list.CancelPendingKey()
+		}
+
 		switch msg.Type {
 		case tea.KeyCtrlC:
-			a.quitting = true
-			return a, tea.Quit
+			return a, a.quit()
 		case tea.KeyTab, tea.KeyShiftTab:
 			a.tabs.Update(msg)
+			a.handleTabChanged()
 			return a, nil
+		}
+
+		// Navigation and toggling keys belong to the settings on their tab
+		if a.tabs.Active() == TabSettings {
+			switch msg.String() {
+			case "up", "down", "k", "j", "enter", " ":
+				return a, a.settings.Update(msg)
+			case "R":
+				return a, a.reloadTheme(true)
+			case "D":
+				a.confirmResetConfig()
+				return a, nil
+			}
+		}
+
+		switch msg.Type {
 		case tea.KeyEnter:
 			// Open action menu on Worktrees or Branches tabs
 			if a.tabs.Active() == TabWorktrees || a.tabs.Active() == TabBranches {
 				if item := a.list.SelectedItem(); item != nil {
+					a.actionMenu.SetActions(actionsForItem(item))
 					a.actionMenu.Show(item)
 				}
 			}
 			return a, nil
+		case tea.KeyCtrlL:
+			// Focus the details pane (same as '>')
+			a.focusDetails()
+			return a, nil
+		case tea.KeyCtrlH:
+			// Return focus to the list (same as '<')
+			a.setFocusedPane(PaneList)
+			return a, nil
 		case tea.KeyEsc:
-			// Escape cancels action menu (if visible)
+			// Escape cancels action menu (if visible), closes the details
+			// popover or returns focus to the list
 			if a.actionMenu.Visible() {
 				a.actionMenu.Hide()
+			} else if a.detailsPopover {
+				a.toggleDetailsPopover()
+			} else {
+				a.setFocusedPane(PaneList)
 			}
 			return a, nil
 		case tea.KeyUp, tea.KeyDown, tea.KeyPgUp, tea.KeyPgDown:
 			// Handle list navigation on Worktrees and Branches tabs
 			if a.tabs.Active() == TabWorktrees || a.tabs.Active() == TabBranches {
-				a.list.Update(msg)
-				a.details.SetItem(a.list.SelectedItem())
+				if a.focusedPane == PaneDetails {
+					a.scrollDetails(msg)
+				} else {
+					a.list.Update(msg)
+					a.details.SetItem(a.list.SelectedItem())
+				}
 			}
 			return a, nil
 		case tea.KeyRunes:
 			if len(msg.Runes) > 0 {
 				switch msg.Runes[0] {
 				case 'q':
-					a.quitting = true
-					return a, tea.Quit
+					return a, a.quit()
 				case 'n':
 					// Open create form on Worktrees tab
-					if a.tabs.Active() == TabWorktrees && !git.IsNotGitRepoError(a.gitError) {
+					if a.tabs.Active() == TabWorktrees && !a.gitUnavailable() {
 						a.createForm.Show()
 					}
 					return a, nil
+				case 'N':
+					// Open the create form to branch off the default branch
+					if a.tabs.Active() == TabWorktrees && !a.gitUnavailable() && !a.spinner.Active() {
+						fetch := a.config.FetchDefaultBranchEnabled()
+						cmd := loadDefaultBase(a.repoPath, fetch, a.config.GitTimeoutDuration())
+						if fetch {
+							return a, tea.Batch(a.spinner.Start("Fetching..."), cmd)
+						}
+						return a, cmd
+					}
+					return a, nil
+				case 'T':
+					// Open the create form with a picker of the repository's tags
+					if a.tabs.Active() == TabWorktrees && !a.gitUnavailable() {
+						return a, loadTags(a.repoPath, a.config.GitTimeoutDuration())
+					}
+					return a, nil
 				case 'p':
-					// Prune stale worktrees on Worktrees tab
-					if a.tabs.Active() == TabWorktrees && !git.IsNotGitRepoError(a.gitError) {
-						a.confirmDialog.SetConfirmLabel("Prune")
-						a.confirmDialog.SetForceOption(false)
-						a.confirmDialog.ShowWithData(
-							"Prune Stale Worktrees?",
-							"This will remove worktree entries whose directories no longer exist.",
-							"prune",
-						)
+					// Preview stale worktrees on Worktrees tab; the confirmation
+					// is shown once the dry run has finished
+					if a.tabs.Active() == TabWorktrees && !a.gitUnavailable() {
+						return a, loadPrunePreview(a.repoPath, a.config.GitTimeoutDuration())
+					}
+					return a, nil
+				case 'F':
+					// Fetch from remotes in the background
+					if (a.tabs.Active() == TabWorktrees || a.tabs.Active() == TabBranches) &&
+						!a.gitUnavailable() && !a.spinner.Active() {
+						return a, tea.Batch(a.spinner.Start("Fetching..."), runFetch(a.repoPath, a.config.GitTimeoutDuration()))
+					}
+					return a, nil
+				case 'o':
+					// Open a terminal for the selected worktree without the action menu
+					if a.tabs.Active() == TabWorktrees && a.focusedPane == PaneList {
+						if item := a.list.SelectedItem(); item != nil {
+							return a.handleActionExecuted(ActionExecutedMsg{Action: &Action{ID: "open"}, Item: item})
+						}
+					}
+					return a, nil
+				case 'c':
+					// Quit into the selected worktree; the shell wrapper cds there
+					if a.tabs.Active() == TabWorktrees && a.focusedPane == PaneList && a.gitError == nil {
+						if item := a.list.SelectedItem(); item != nil {
+							if _, ok := item.Metadata.(*WorktreeItemData); ok {
+								a.targetPath = item.ID
+								return a, a.quit()
+							}
+						}
+					}
+					return a, nil
+				case 'O':
+					// Open a terminal for every worktree with local changes
+					if a.tabs.Active() == TabWorktrees || a.tabs.Active() == TabBranches {
+						return a, a.openDirtyWorktrees()
+					}
+					return a, nil
+				case ']', '[':
+					// Cycle through the worktrees that need attention
+					if a.tabs.Active() == TabWorktrees {
+						return a, a.jumpToDirtyWorktree(msg.Runes[0] == ']')
+					}
+					return a, nil
+				case 'x':
+					// Flag the selected worktree as reviewed, or clear the flag
+					if a.tabs.Active() == TabWorktrees && a.focusedPane == PaneList {
+						return a, a.toggleReviewed()
+					}
+					return a, nil
+				case 'M':
+					// Review recent messages after they disappeared
+					a.messageLog.Show()
+					return a, nil
+				case 'i':
+					// Show or hide the selected item's details in the compact layout
+					if a.tabs.Active() == TabWorktrees || a.tabs.Active() == TabBranches {
+						a.toggleDetailsPopover()
+					}
+					return a, nil
+				case 'L':
+					// Cycle the pane layout: auto, horizontal, vertical, compact
+					a.detailsPopover = false
+					a.SetLayout(a.layout.Next())
+					return a, a.feedback.ShowInfo("Layout: " + a.layout.String())
+				case 'P':
+					// Show the whole path of the worktree in the focused details pane
+					if a.focusedPane == PaneDetails {
+						a.details.ToggleFullPath()
+					}
+					return a, nil
+				case 'H':
+					// Switch between the short and full commit hash in the focused details pane
+					if a.focusedPane == PaneDetails {
+						a.details.ToggleFullHash()
+					}
+					return a, nil
+				case 'z':
+					// Collapse or expand the section at the top of the focused details pane
+					if a.focusedPane == PaneDetails {
+						a.details.ToggleSection()
 					}
 					return a, nil
+				case 'Z':
+					// Collapse or expand every section of the focused details pane
+					if a.focusedPane == PaneDetails {
+						a.details.ToggleAllSections()
+					}
+					return a, nil
+				case '>':
+					// Focus the details pane so navigation keys scroll it
+					a.focusDetails()
+					return a, nil
+				case '<':
+					// Return focus to the list
+					a.setFocusedPane(PaneList)
+					return a, nil
 				case 'j', 'k':
+					if a.focusedPane == PaneDetails {
+						a.scrollDetails(msg)
+						return a, nil
+					}
+					fallthrough
+				case 'g', 'G':
 					// Handle vim-style navigation
 					if a.tabs.Active() == TabWorktrees || a.tabs.Active() == TabBranches {
 						a.list.Update(msg)
 						a.details.SetItem(a.list.SelectedItem())
 					}
 					return a, nil
+				case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+					// Type an item number to jump to it
+					if (a.tabs.Active() == TabWorktrees || a.tabs.Active() == TabBranches) &&
+						a.focusedPane == PaneList && isDigitKey(msg) {
+						return a, a.list.Update(msg)
+					}
+					a.list.CancelPendingKey()
+					return a, nil
 				}
 			}
 		}
@@ -333,6 +1260,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Y == 0 {
 			// Click on tab bar row
 			a.tabs.Update(msg)
+			a.handleTabChanged()
 		} else if a.tabs.Active() == TabWorktrees || a.tabs.Active() == TabBranches {
 			// Handle mouse in list pane
 			if a.list.IsInBounds(msg.X, msg.Y) || msg.Button == tea.MouseButtonWheelDown || msg.Button == tea.MouseButtonWheelUp {
@@ -345,72 +1273,1959 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
-// handleActionExecuted processes an action that was executed from the menu.
-func (a *App) handleActionExecuted(msg ActionExecutedMsg) (tea.Model, tea.Cmd) {
-	if msg.Action == nil {
-		return a, nil
+// settingsFromConfig returns the runtime toggles shown on the Settings tab.
+func settingsFromConfig(cfg config.Config) []Setting {
+	return []Setting{
+		{
+			Key:         settingCountUntracked,
+			Label:       "Count untracked files as changes",
+			Description: "When off, untracked files such as build output do not make a worktree dirty.",
+			Enabled:     cfg.CountUntrackedEnabled(),
+		},
+		{
+			Key:         settingRowNumbers,
+			Label:       "Show row numbers",
+			Description: "Prefix each list row with its number; type a number to jump to that row.",
+			Enabled:     cfg.RowNumbersEnabled(),
+		},
+		{
+			Key:         settingHideMain,
+			Label:       "Hide the main worktree",
+			Description: "Leave the main worktree out of the Worktrees tab; the Branches tab still lists it.",
+			Enabled:     cfg.HideMainEnabled(),
+		},
 	}
+}
 
-	// Execute the action and show feedback
-	switch msg.Action.ID {
-	case "open":
-		// Open the worktree in a new terminal or provide cd command
-		worktreePath := msg.Item.ID // ID is the worktree path
-		opener := git.NewTerminalOpener()
-		result, err := opener.OpenWorktree(worktreePath)
-		if err != nil {
-			cmd := a.feedback.ShowError("Failed to open worktree: " + err.Error())
-			return a, cmd
-		}
+// configWatchInterval is how often the config files are checked for changes.
+const configWatchInterval = time.Second
 
-		// Show appropriate feedback based on result
-		if result.Success {
-			cmd := a.feedback.ShowSuccess(result.Message)
+// configReloadDebounce is how long the config files must stay unchanged
+// before the theme is reloaded, so an editor's burst of writes reloads once.
+const configReloadDebounce = 300 * time.Millisecond
+
+// ConfigCheckedMsg reports the state of the config files at a watch tick.
+type ConfigCheckedMsg struct {
+	stamp string
+}
+
+// ConfigReloadDueMsg is sent when the debounce after a config change has
+// passed, with the state of the config files at that time.
+type ConfigReloadDueMsg struct {
+	stamp string
+}
+
+// ThemeReloadedMsg is sent when the theme has been reloaded from the config files.
+type ThemeReloadedMsg struct {
+	Config config.Config
+	Err    error
+	// Background is the terminal background queried again with the theme,
+	// or nil if it was not queried
+	Background *BackgroundQuery
+}
+
+// configFiles returns the config files whose changes reload the theme: the
+// user config file and the repository's .grove.yaml.
+func (a *App) configFiles() []string {
+	var paths []string
+	if a.configPath != "" {
+		paths = append(paths, a.configPath)
+	}
+	if info := a.settings.RepoInfo(); info != nil && info.Toplevel != "" {
+		paths = append(paths, filepath.Join(info.Toplevel, config.RepoConfigFile))
+	}
+	return paths
+}
+
+// configStamp summarizes the modification time and size of each file, so
+// writing, creating or removing any of them changes the result.
+func configStamp(paths []string) string {
+	var b strings.Builder
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&b, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+		} else {
+			fmt.Fprintf(&b, "%s:-;", path)
+		}
+	}
+	return b.String()
+}
+
+// watchConfig returns a command that checks the config files after configWatchInterval.
+func watchConfig(paths []string) tea.Cmd {
+	return tea.Tick(configWatchInterval, func(time.Time) tea.Msg {
+		return ConfigCheckedMsg{stamp: configStamp(paths)}
+	})
+}
+
+// debounceConfigReload returns a command that checks the config files again
+// after configReloadDebounce.
+func debounceConfigReload(paths []string) tea.Cmd {
+	return tea.Tick(configReloadDebounce, func(time.Time) tea.Msg {
+		return ConfigReloadDueMsg{stamp: configStamp(paths)}
+	})
+}
+
+// handleConfigChecked keeps watching the config files and starts the
+// debounce when they changed.
+func (a *App) handleConfigChecked(msg ConfigCheckedMsg) (tea.Model, tea.Cmd) {
+	watchCmd := watchConfig(a.configFiles())
+	if msg.stamp == a.configStamp {
+		return a, watchCmd
+	}
+	a.configStamp = msg.stamp
+	return a, tea.Batch(watchCmd, debounceConfigReload(a.configFiles()))
+}
+
+// handleConfigReloadDue reloads the theme once the config files stopped
+// changing, or waits again if they were written during the debounce.
+func (a *App) handleConfigReloadDue(msg ConfigReloadDueMsg) (tea.Model, tea.Cmd) {
+	if msg.stamp != a.configStamp {
+		a.configStamp = msg.stamp
+		return a, debounceConfigReload(a.configFiles())
+	}
+	return a, a.ReloadTheme()
+}
+
+// ReloadTheme returns a command that reloads the theme from the user config
+// file and the repository's .grove.yaml. The theme is applied when the
+// resulting ThemeReloadedMsg is handled.
+func (a *App) ReloadTheme() tea.Cmd {
+	return a.reloadTheme(false)
+}
+
+// reloadTheme returns a command that reloads the theme, asking the terminal
+// for its background again if queryBg is set, for a reload the user asked
+// for after switching the terminal between a light and a dark theme.
+func (a *App) reloadTheme(queryBg bool) tea.Cmd {
+	configPath := a.configPath
+	repoRoot := ""
+	if info := a.settings.RepoInfo(); info != nil {
+		repoRoot = info.Toplevel
+	}
+
+	return func() tea.Msg {
+		cfg := config.DefaultConfig()
+		var err error
+		if configPath != "" {
+			cfg, err = config.LoadConfig(configPath)
+		}
+		if repoRoot != "" {
+			repoCfg, repoErr := config.LoadRepoConfig(repoRoot)
+			cfg = cfg.Merge(repoCfg)
+			err = errors.Join(err, repoErr)
+		}
+		msg := ThemeReloadedMsg{Config: cfg, Err: err}
+		if queryBg {
+			q := queryBackground()
+			msg.Background = &q
+		}
+		return msg
+	}
+}
+
+// handleThemeReloaded applies a reloaded theme and rebuilds the styles.
+// Other options still need a restart to take effect.
+func (a *App) handleThemeReloaded(msg ThemeReloadedMsg) (tea.Model, tea.Cmd) {
+	a.config.Theme = msg.Config.Theme
+	ApplyThemeConfig(a.config)
+	if msg.Background != nil {
+		applyBackground(*msg.Background)
+	}
+
+	if msg.Err != nil {
+		cmd := a.feedback.ShowError("Theme reloaded with warnings: " + msg.Err.Error())
+		return a, cmd
+	}
+	if msg.Background != nil && !msg.Background.Answered {
+		cmd := a.feedback.ShowSuccess("Theme reloaded; the terminal did not report its background, so light/dark colors are unchanged")
+		return a, cmd
+	}
+	cmd := a.feedback.ShowSuccess("Theme reloaded")
+	return a, cmd
+}
+
+// resetConfigRequest is the confirmation dialog data for resetting the
+// configuration to its defaults.
+type resetConfigRequest struct{}
+
+// confirmResetConfig asks before the config file is overwritten with the
+// default configuration.
+func (a *App) confirmResetConfig() {
+	message := "All settings return to their defaults."
+	if a.configPath != "" {
+		message = "This overwrites " + abbreviateHome(a.configPath) + " with the default configuration.\nYour changes to it are lost."
+	}
+	a.confirmDialog.SetConfirmLabel("Reset")
+	a.confirmDialog.SetForceOption(false)
+	a.confirmDialog.ShowWithData("Reset to Defaults?", message, resetConfigRequest{})
+}
+
+// resetConfig rewrites the config file with the default configuration and
+// applies it right away. The repository's .grove.yaml still overrides it.
+func (a *App) resetConfig() (tea.Model, tea.Cmd) {
+	if a.configPath != "" {
+		if err := config.WriteDefaultConfig(a.configPath, true); err != nil {
+			cmd := a.feedback.ShowError("Failed to reset config: " + err.Error())
+			return a, cmd
+		}
+		// The watcher would otherwise reload what was just applied
+		a.configStamp = configStamp(a.configFiles())
+	}
+
+	a.config = config.DefaultConfig()
+	a.applyConfig()
+	a.loadRepoConfig()
+	ApplyThemeConfig(a.config)
+
+	// Cached statuses may have been computed with other settings
+	git.InvalidateAllWorktreeStatus()
+	if a.repoPath != "" {
+		a.loadWorktrees()
+	}
+
+	message := "Settings reset to defaults"
+	if a.configPath != "" {
+		message = "Config reset to defaults: " + abbreviateHome(a.configPath)
+	}
+	cmd := a.feedback.ShowSuccess(message)
+	return a, cmd
+}
+
+// handleSettingToggled applies a setting toggled on the Settings tab.
+func (a *App) handleSettingToggled(msg SettingToggledMsg) (tea.Model, tea.Cmd) {
+	switch msg.Key {
+	case settingCountUntracked:
+		enabled := msg.Enabled
+		a.config.Status.CountUntracked = &enabled
+		git.SetCountUntracked(enabled)
+
+		// Cached statuses were computed in the other mode
+		git.InvalidateAllWorktreeStatus()
+		if a.repoPath != "" {
+			a.loadWorktrees()
+		}
+
+		if enabled {
+			return a, a.feedback.ShowInfo("Untracked files now count as changes")
+		}
+		return a, a.feedback.ShowInfo("Untracked files no longer count as changes")
+	case settingRowNumbers:
+		enabled := msg.Enabled
+		a.config.RowNumbers = &enabled
+		a.list.SetShowNumbers(enabled)
+		return a, nil
+	case settingHideMain:
+		enabled := msg.Enabled
+		a.config.List.HideMain = &enabled
+
+		selectedID := ""
+		if item := a.list.SelectedItem(); item != nil {
+			selectedID = item.ID
+		}
+		a.syncListItems()
+		a.updateTabCounts()
+		if selectedID != "" && a.list.SelectByID(selectedID) {
+			a.details.SetItem(a.list.SelectedItem())
+		}
+		return a, nil
+	}
+	return a, nil
+}
+
+// gitRequirement is the minimum git version an action needs.
+type gitRequirement struct {
+	major, minor int
+}
+
+// actionGitRequirements lists actions backed by git commands newer than the
+// baseline git, keyed by action ID.
+var actionGitRequirements = map[string]gitRequirement{
+	"lock":   {major: 2, minor: 10},
+	"move":   {major: 2, minor: 17},
+	"repair": {major: 2, minor: 30},
+}
+
+// checkActionGitVersion returns a feedback command if the installed git is
+// too old for the action, or nil if it can run. If the version cannot be
+// determined the action is allowed and git reports any failure itself.
+func (a *App) checkActionGitVersion(action *Action) tea.Cmd {
+	req, ok := actionGitRequirements[action.ID]
+	if !ok || a.gitVersion == nil {
+		return nil
+	}
+	major, minor, _, err := a.gitVersion()
+	if err != nil || git.VersionAtLeast(major, minor, req.major, req.minor) {
+		return nil
+	}
+	return a.feedback.ShowError(fmt.Sprintf("%s requires git ≥ %d.%d (installed: %d.%d)",
+		action.Label, req.major, req.minor, major, minor))
+}
+
+// handleActionExecuted processes an action that was executed from the menu.
+func (a *App) handleActionExecuted(msg ActionExecutedMsg) (tea.Model, tea.Cmd) {
+	if msg.Action == nil {
+		return a, nil
+	}
+
+	if cmd := a.checkActionGitVersion(msg.Action); cmd != nil {
+		return a, cmd
+	}
+
+	// Execute the action and show feedback
+	switch msg.Action.ID {
+	case "open":
+		// Open the worktree in a new terminal or provide cd command
+		if isBareWorktreeItem(msg.Item) {
+			cmd := a.feedback.ShowError("Cannot open '" + msg.Item.Title + "': a bare repository has no working tree")
+			return a, cmd
+		}
+		worktreePath := msg.Item.ID // ID is the worktree path
+		result, err := a.openTerminal(worktreePath)
+		if err != nil {
+			cmd := a.feedback.ShowError("Failed to open worktree: " + err.Error())
+			return a, cmd
+		}
+
+		// Show appropriate feedback based on result
+		if result.Success {
+			cmd := a.feedback.ShowSuccess(result.Message)
 			return a, cmd
 		}
 		// Fallback: show the cd command to the user
 		cmd := a.feedback.ShowInfo(result.Message)
 		return a, cmd
-	case "cd":
-		// Get the cd command for the worktree
-		worktreePath := msg.Item.ID
-		cdCommand := git.GetCDCommand(worktreePath)
-		cmd := a.feedback.ShowInfo("Copy: " + cdCommand)
+	case "cd":
+		// Get the cd command for the worktree
+		worktreePath := msg.Item.ID
+		cdCommand := git.GetCDCommand(worktreePath)
+		cmd := a.feedback.ShowInfo("Copy: " + cdCommand)
+		return a, cmd
+	case "addcmd":
+		// Copy the command that recreates the worktree, e.g. for a teammate
+		addCommand := worktreeAddCommand(msg.Item)
+		if addCommand == "" {
+			cmd := a.feedback.ShowError("Cannot build a git worktree add command for '" + msg.Item.Title + "'")
+			return a, cmd
+		}
+		copyToClipboard(addCommand)
+		cmd := a.feedback.ShowSuccess("Copied: " + addCommand)
+		return a, cmd
+	case "hash":
+		// Copy the exact commit, e.g. to reference it in a ticket
+		data, ok := msg.Item.Metadata.(*WorktreeItemData)
+		if !ok || data == nil || data.IsBare {
+			return a, nil
+		}
+		ctx, cancel := a.gitContext()
+		hash, err := git.GetFullCommitHashContext(ctx, data.Path)
+		cancel()
+		if err != nil {
+			cmd := a.feedback.ShowError("Failed to read the commit of '" + msg.Item.Title + "': " + err.Error())
+			return a, cmd
+		}
+		copyToClipboard(hash)
+		cmd := a.feedback.ShowSuccess("Copied commit " + hash)
+		return a, cmd
+	case "switch":
+		// Check out the remote branch in the worktree grove was started in
+		data, ok := msg.Item.Metadata.(*RemoteBranchItemData)
+		if !ok {
+			cmd := a.feedback.ShowError("Not a remote branch: " + msg.Item.Title)
+			return a, cmd
+		}
+		return a.switchCurrentWorktree(data.Branch)
+	case "track":
+		// Open the create form prefilled to track the remote branch
+		data, ok := msg.Item.Metadata.(*RemoteBranchItemData)
+		if !ok {
+			cmd := a.feedback.ShowError("Not a remote branch: " + msg.Item.Title)
+			return a, cmd
+		}
+		a.createForm.ShowTrackRemote(data.Ref, data.Branch, abbreviateHome(a.worktreePathFor(data.Branch)))
+		return a, nil
+	case "branch":
+		// Open the create form prefilled to branch off this worktree's HEAD
+		base := branchBase(msg.Item)
+		if base == "" {
+			cmd := a.feedback.ShowError("Cannot branch from '" + msg.Item.Title + "': no branch or commit checked out")
+			return a, cmd
+		}
+		a.createForm.ShowBranchFrom(base)
+		return a, nil
+	case "pull":
+		// Fast-forward in the background; a dirty or untracked worktree cannot pull
+		if !canPullItem(msg.Item) {
+			cmd := a.feedback.ShowError("Cannot pull '" + msg.Item.Title + "': it needs an upstream branch and no uncommitted changes")
+			return a, cmd
+		}
+		if a.spinner.Active() {
+			cmd := a.feedback.ShowInfo("Wait for the running git operation to finish")
+			return a, cmd
+		}
+		return a, tea.Batch(a.spinner.Start("Pulling "+msg.Item.Title+"..."), runPull(msg.Item.ID, a.config.GitTimeoutDuration()))
+	case "push":
+		// Push in the background, publishing a branch without an upstream to origin
+		if !canPushItem(msg.Item) {
+			cmd := a.feedback.ShowError("Cannot push '" + msg.Item.Title + "': no branch checked out")
+			return a, cmd
+		}
+		if a.spinner.Active() {
+			cmd := a.feedback.ShowInfo("Wait for the running git operation to finish")
+			return a, cmd
+		}
+		wtData := msg.Item.Metadata.(*WorktreeItemData)
+		setUpstream := wtData.Upstream == ""
+		return a, tea.Batch(a.spinner.Start("Pushing "+msg.Item.Title+"..."), runPush(msg.Item.ID, setUpstream, a.config.GitTimeoutDuration()))
+	case "rename":
+		// Ask for the new name, starting from the current one
+		if !isBranchWorktreeItem(msg.Item) {
+			cmd := a.feedback.ShowError("'" + msg.Item.Title + "' has no branch to rename")
+			return a, cmd
+		}
+		branch := msg.Item.Metadata.(*WorktreeItemData).Branch
+		a.inputPrompt.Show("Rename Branch "+branch, "New branch name",
+			git.ValidateBranchName, renameBranchRequest{Item: msg.Item, Branch: branch})
+		a.inputPrompt.SetValue(branch)
+		return a, nil
+	case "label":
+		// Ask for the label, starting from the current one; empty clears it
+		a.inputPrompt.Show("Set Label for "+filepath.Base(msg.Item.ID), "Label (empty to clear)",
+			nil, setLabelRequest{Item: msg.Item})
+		a.inputPrompt.SetOptional(true)
+		a.inputPrompt.SetValue(a.labels[msg.Item.ID])
+		return a, nil
+	case "convert":
+		// Ask for the name of the branch to keep the detached HEAD's work on
+		if !isDetachedWorktreeItem(msg.Item) {
+			cmd := a.feedback.ShowError("'" + msg.Item.Title + "' is already on a branch")
+			return a, cmd
+		}
+		a.inputPrompt.Show("Convert "+msg.Item.Title+" to a Branch", "Branch name",
+			git.ValidateBranchName, convertToBranchRequest{Item: msg.Item})
+		return a, nil
+	case "delete":
+		// The main worktree holds the repository and cannot be removed
+		if isMainWorktreeItem(msg.Item) {
+			cmd := a.feedback.ShowError("Cannot delete the main worktree: it holds the repository's .git directory")
+			return a, cmd
+		}
+
+		// Git refuses to remove the worktree grove runs in
+		if isCurrentWorktree(msg.Item.ID) {
+			cmd := a.feedback.ShowError("You're inside this worktree; cd elsewhere first")
+			return a, cmd
+		}
+
+		// Clean worktrees may skip confirmation; dirty ones and ones with
+		// unpushed commits always confirm
+		unpushed := a.unpushedCommits(msg.Item)
+		if !a.config.ConfirmDeleteEnabled() && unpushed == 0 && a.isCleanWorktreeItem(msg.Item) {
+			return a.removeWorktree(msg.Item, false)
+		}
+		warning := unpushedWarning(unpushed) + mergedNote(a.mergedCache[msg.Item.ID])
+
+		// The trash keeps uncommitted changes, so there is nothing to force
+		if a.config.UseTrashEnabled() {
+			a.confirmDialog.SetConfirmLabel("Move to Trash")
+			a.confirmDialog.SetForceOption(false)
+			a.confirmDialog.SetTagOption(archiveTag(msg.Item))
+			a.confirmDialog.ShowDanger(
+				"Move Worktree to Trash?",
+				"This will move the worktree '"+msg.Item.Title+"' to the trash.\nPath: "+msg.Item.ID+"\nTrash: "+a.trashDir+warning,
+				msg.Item,
+			)
+			return a, nil
+		}
+
+		// Show confirmation dialog for delete action; dirty worktrees can
+		// stash their changes instead of losing them to a forced removal
+		a.confirmDialog.SetConfirmLabel("Delete")
+		a.confirmDialog.SetForceOption(true)
+		a.confirmDialog.SetStashOption(!a.isCleanWorktreeItem(msg.Item))
+		a.confirmDialog.SetTagOption(archiveTag(msg.Item))
+		a.confirmDialog.ShowDanger(
+			"Delete Worktree?",
+			"This will remove the worktree '"+msg.Item.Title+"'.\nPath: "+msg.Item.ID+warning,
+			msg.Item,
+		)
+		a.confirmDialog.SetForceSelected(a.config.DeleteForceDefault())
+		return a, nil
+	default:
+		cmd := a.feedback.ShowError("Unknown action: " + msg.Action.ID)
+		return a, cmd
+	}
+}
+
+// switchCurrentWorktree checks out branch in the worktree grove was started
+// in. A worktree with local changes is left alone, so nothing is lost.
+func (a *App) switchCurrentWorktree(branch string) (tea.Model, tea.Cmd) {
+	current := ""
+	if info := a.settings.RepoInfo(); info != nil {
+		current = info.Toplevel
+	}
+	if current == "" {
+		cmd := a.feedback.ShowError("Cannot switch to " + branch + ": grove was not started inside a worktree")
+		return a, cmd
+	}
+
+	ctx, cancel := a.gitContext()
+	defer cancel()
+	dirty, err := git.HasUncommittedChangesContext(ctx, current)
+	if err != nil {
+		cmd := a.feedback.ShowError(err.Error())
+		return a, cmd
+	}
+	if dirty {
+		cmd := a.feedback.ShowError("Cannot switch " + current + " to " + branch + ": it has uncommitted changes. Commit or stash them first")
+		return a, cmd
+	}
+
+	if err := git.CheckoutBranchContext(ctx, current, branch); err != nil {
+		if git.IsCheckoutOverwriteError(err) {
+			cmd := a.feedback.ShowError("Switching would overwrite local changes in " + current + ", so nothing was changed. " + err.Error())
+			return a, cmd
+		}
+		cmd := a.feedback.ShowError(err.Error())
+		return a, cmd
+	}
+
+	git.InvalidateWorktreeStatus(current)
+	selectedID := ""
+	if item := a.list.SelectedItem(); item != nil {
+		selectedID = item.ID
+	}
+	a.loadWorktrees()
+	if selectedID != "" && a.list.SelectByID(selectedID) {
+		a.details.SetItem(a.list.SelectedItem())
+	}
+	cmd := a.feedback.ShowSuccess("Switched " + current + " to " + branch)
+	return a, cmd
+}
+
+// convertToBranchRequest is the input prompt data for converting a detached
+// worktree to a branch.
+type convertToBranchRequest struct {
+	Item *ListItem
+}
+
+// renameBranchRequest is the input prompt data for renaming the branch
+// checked out in a worktree.
+type renameBranchRequest struct {
+	Item   *ListItem
+	Branch string
+}
+
+// setLabelRequest is the input prompt data for labelling a worktree.
+type setLabelRequest struct {
+	Item *ListItem
+}
+
+// handleInputPromptSubmitted dispatches the value entered in the input prompt.
+func (a *App) handleInputPromptSubmitted(msg InputPromptSubmittedMsg) (tea.Model, tea.Cmd) {
+	switch data := msg.Data.(type) {
+	case convertToBranchRequest:
+		return a.convertToBranch(data.Item, msg.Value)
+	case renameBranchRequest:
+		return a.renameBranch(data.Item, data.Branch, msg.Value)
+	case setLabelRequest:
+		return a.setLabel(data.Item, msg.Value)
+	}
+	return a, nil
+}
+
+// setLabel shows label instead of the directory name of the worktree
+// represented by item, or the directory name again if label is empty, and
+// saves the labels to the state file.
+func (a *App) setLabel(item *ListItem, label string) (tea.Model, tea.Cmd) {
+	if label == "" {
+		delete(a.labels, item.ID)
+	} else {
+		if a.labels == nil {
+			a.labels = make(map[string]string)
+		}
+		a.labels[item.ID] = label
+	}
+	a.relabelWorktrees()
+	a.saveState()
+
+	if label == "" {
+		cmd := a.feedback.ShowSuccess("Cleared label of " + filepath.Base(item.ID))
+		return a, cmd
+	}
+	cmd := a.feedback.ShowSuccess("Labelled " + filepath.Base(item.ID) + " as '" + label + "'")
+	return a, cmd
+}
+
+// toggleReviewed flags the selected worktree as reviewed, or clears the
+// flag, and saves the flags to the state file.
+func (a *App) toggleReviewed() tea.Cmd {
+	item := a.list.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	if _, ok := item.Metadata.(*WorktreeItemData); !ok {
+		return nil
+	}
+
+	name := item.Title
+	reviewed := !a.reviewed[item.ID]
+	if reviewed {
+		if a.reviewed == nil {
+			a.reviewed = make(map[string]bool)
+		}
+		a.reviewed[item.ID] = true
+	} else {
+		delete(a.reviewed, item.ID)
+	}
+	a.relabelWorktrees()
+	a.saveState()
+
+	if reviewed {
+		return a.feedback.ShowInfo("Marked " + name + " as reviewed")
+	}
+	return a.feedback.ShowInfo("Cleared reviewed mark of " + name)
+}
+
+// relabelWorktrees updates the titles and reviewed marks of the listed
+// worktrees from the state, keeping the selection.
+func (a *App) relabelWorktrees() {
+	for i := range a.worktreeItems {
+		if i >= len(a.worktrees) {
+			break
+		}
+		a.worktreeItems[i].Title = a.worktreeTitle(a.worktrees[i])
+		if wtData, ok := a.worktreeItems[i].Metadata.(*WorktreeItemData); ok && wtData != nil {
+			wtData.Reviewed = a.reviewed[a.worktrees[i].Path]
+		}
+	}
+
+	selectedID := ""
+	if item := a.list.SelectedItem(); item != nil {
+		selectedID = item.ID
+	}
+	a.syncListItems()
+	if selectedID != "" && a.list.SelectByID(selectedID) {
+		a.details.SetItem(a.list.SelectedItem())
+	}
+}
+
+// renameBranch renames the branch checked out in the worktree represented
+// by item and refreshes the list, keeping the worktree selected.
+func (a *App) renameBranch(item *ListItem, oldName, newName string) (tea.Model, tea.Cmd) {
+	if newName == oldName {
+		cmd := a.feedback.ShowInfo("Branch name unchanged")
+		return a, cmd
+	}
+
+	ctx, cancel := a.gitContext()
+	err := git.RenameBranchContext(ctx, item.ID, oldName, newName)
+	cancel()
+	if err != nil {
+		message := "Failed to rename branch: " + err.Error()
+		if git.IsBranchExistsError(err) {
+			message = "Branch '" + newName + "' already exists. Choose another name."
+		}
+		cmd := a.feedback.ShowError(message)
+		return a, cmd
+	}
+	git.InvalidateWorktreeStatus(item.ID)
+
+	// Refresh the worktree list so the new name shows
+	selectedID := ""
+	if selected := a.list.SelectedItem(); selected != nil {
+		selectedID = selected.ID
+	}
+	a.loadWorktrees()
+	if selectedID != "" && a.list.SelectByID(selectedID) {
+		a.details.SetItem(a.list.SelectedItem())
+	}
+
+	cmd := a.feedback.ShowSuccess("Renamed branch '" + oldName + "' to '" + newName + "'")
+	return a, cmd
+}
+
+// convertToBranch creates branch at the detached HEAD of the worktree
+// represented by item, switches to it and refreshes the list.
+func (a *App) convertToBranch(item *ListItem, branch string) (tea.Model, tea.Cmd) {
+	ctx, cancel := a.gitContext()
+	err := git.CreateBranchAtHeadContext(ctx, item.ID, branch)
+	cancel()
+	if err != nil {
+		message := "Failed to create branch: " + err.Error()
+		if git.IsBranchExistsError(err) {
+			message = "Branch '" + branch + "' already exists. Choose another name."
+		}
+		cmd := a.feedback.ShowError(message)
+		return a, cmd
+	}
+	git.InvalidateWorktreeStatus(item.ID)
+
+	// Refresh the worktree list
+	a.loadWorktrees()
+
+	cmd := a.feedback.ShowSuccess("Switched " + item.Title + " to new branch '" + branch + "'")
+	return a, cmd
+}
+
+// handleCreateFormSubmitted processes the submitted create worktree form.
+func (a *App) handleCreateFormSubmitted(msg CreateFormSubmittedMsg) (tea.Model, tea.Cmd) {
+	// Relative paths are relative to the repository, and "~/" is expanded
+	path := a.resolvePath(msg.Result.Path)
+	opts := git.AddWorktreeOptions{
+		Path:         path,
+		Branch:       msg.Result.Branch,
+		CreateBranch: msg.Result.CreateBranch,
+		NoCheckout:   msg.Result.NoCheckout,
+	}
+	// A sparse worktree is populated only once its patterns are set
+	sparse := len(msg.Result.SparsePatterns) > 0
+	if sparse {
+		opts.NoCheckout = true
+	}
+	if msg.Result.TrackRemote != "" {
+		opts.CreateBranch = true
+		opts.BaseBranch = msg.Result.TrackRemote
+		opts.Track = true
+	} else if msg.Result.BaseBranch != "" {
+		opts.CreateBranch = true
+		opts.BaseBranch = msg.Result.BaseBranch
+	} else if msg.Result.Detach {
+		opts.Detach = true
+		opts.Commit = msg.Result.Commit
+	}
+
+	ctx, cancel := a.gitContext()
+	err := git.AddWorktreeContext(ctx, a.mainWorktreePath(), opts)
+	cancel()
+	git.InvalidateWorktreeStatus(path)
+	if err != nil {
+		// Reopen the form with what the user entered so they can correct it
+		a.createForm.ShowResult(msg.Result)
+
+		// Let the user pick another path without retyping the rest
+		if git.IsPathConflictError(err) {
+			a.createForm.FocusField(FieldPath)
+			a.createForm.SetError("Path already exists: " + abbreviateHome(path) + ". Choose a different path.")
+			return a, nil
+		}
+
+		reason := err.Error()
+		category := git.CategoryUnknown
+		var addErr *git.WorktreeAddError
+		if errors.As(err, &addErr) {
+			reason = addErr.Reason
+			category = addErr.Category
+		}
+		message := "Failed to create worktree: " + reason
+		if msg.Result.TrackRemote != "" && category == git.CategoryBranchExists {
+			message = "Local branch '" + msg.Result.Branch + "' already exists. " +
+				"Choose another name, or create a worktree for the existing branch."
+		}
+		a.createForm.SetError(message)
+		return a, nil
+	}
+
+	if sparse {
+		ctx, cancel := a.gitContext()
+		err := git.ConfigureSparseCheckoutContext(ctx, path, msg.Result.SparsePatterns)
+		cancel()
+		git.InvalidateWorktreeStatus(path)
+		if err != nil {
+			a.loadWorktrees()
+			cmd := a.feedback.ShowError("Worktree created, but setting up the sparse checkout failed: " + err.Error())
+			return a, cmd
+		}
+	}
+
+	// Copy untracked files such as .env from the main worktree in the
+	// background, and carry on once they are there
+	if len(a.config.CopyOnCreate) > 0 {
+		cmd := a.feedback.ShowInfo("Copying files...")
+		return a, tea.Batch(cmd, copyFilesOnCreate(a.mainWorktreePath(), path, a.config.CopyOnCreate, msg.Result))
+	}
+
+	return a.finishCreate(path, msg.Result)
+}
+
+// FilesCopiedMsg is sent when the files to copy into a new worktree have
+// been copied.
+type FilesCopiedMsg struct {
+	// Path is the resolved path of the new worktree.
+	Path string
+	// Result is the submitted create form.
+	Result CreateFormResult
+	// Err is the copy error, or nil if every file was copied.
+	Err error
+}
+
+// copyFilesOnCreate returns a command that copies the files matching
+// patterns from src into the new worktree at dst asynchronously.
+func copyFilesOnCreate(src, dst string, patterns []string, result CreateFormResult) tea.Cmd {
+	return func() tea.Msg {
+		err := fsutil.CopyFiles(src, dst, patterns)
+		return FilesCopiedMsg{Path: dst, Result: result, Err: err}
+	}
+}
+
+// handleFilesCopied finishes creating the worktree once its files were
+// copied. A failed copy does not roll back the worktree; the app stays open
+// so the error can be read, and the new worktree appears in the list.
+func (a *App) handleFilesCopied(msg FilesCopiedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		a.loadWorktrees()
+		cmd := a.feedback.ShowError("Worktree created, but copying files failed: " + msg.Err.Error())
+		return a, cmd
+	}
+	return a.finishCreate(msg.Path, msg.Result)
+}
+
+// finishCreate hands off the worktree created at path from result: it runs
+// the post-create hook or quits so the shell wrapper can cd there, or stays
+// open when nothing was checked out.
+func (a *App) finishCreate(path string, result CreateFormResult) (tea.Model, tea.Cmd) {
+	// An empty worktree needs a checkout first, so stay and say so; the
+	// post-create hook would have no files to work on
+	if result.NoCheckout && len(result.SparsePatterns) == 0 {
+		a.loadWorktrees()
+		if a.list.SelectByID(path) {
+			a.details.SetItem(a.list.SelectedItem())
+		}
+		message := "Created " + abbreviateHome(path) + " without checking out files; it stays empty until you run git checkout (or set up a sparse checkout) there"
+		if a.config.Hooks.PostCreate != "" {
+			message += ". The post-create hook was skipped"
+		}
+		cmd := a.feedback.ShowInfo(message)
+		return a, cmd
+	}
+
+	// Run the post-create hook in the background before handing off to the shell
+	if hook := a.config.Hooks.PostCreate; hook != "" {
+		cmd := a.feedback.ShowInfo("Running post-create hook...")
+		return a, tea.Batch(cmd, runPostCreateHook(hook, path, result.Branch))
+	}
+
+	// Set target path and quit so shell wrapper can cd to it
+	a.targetPath = path
+	return a, a.quit()
+}
+
+// RecentCommitsLoadedMsg is sent when the recent commits of a worktree have been loaded.
+type RecentCommitsLoadedMsg struct {
+	Path    string
+	Commits []git.CommitInfo
+	Err     error
+}
+
+// loadRecentCommits returns a command that loads recent commits asynchronously.
+func loadRecentCommits(path string, n int, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		commits, err := git.GetRecentCommitsContext(ctx, path, n)
+		return RecentCommitsLoadedMsg{Path: path, Commits: commits, Err: err}
+	}
+}
+
+// ensureRecentCommits shows the cached recent commits of the worktree in the
+// details pane, or returns a command to load them if they are not cached yet.
+// Only the selected worktree is loaded; bare repositories are skipped.
+func (a *App) ensureRecentCommits() tea.Cmd {
+	count := a.config.RecentCommitsCount()
+	item := a.details.Item()
+	if count == 0 || item == nil {
+		return nil
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.IsBare {
+		return nil
+	}
+
+	if commits, ok := a.commitCache[wtData.Path]; ok {
+		a.details.SetCommits(wtData.Path, commits)
+		return nil
+	}
+	if a.commitsLoading[wtData.Path] {
+		return nil
+	}
+
+	if a.commitsLoading == nil {
+		a.commitsLoading = make(map[string]bool)
+	}
+	a.commitsLoading[wtData.Path] = true
+	return loadRecentCommits(wtData.Path, count, a.config.GitTimeoutDuration())
+}
+
+// handleRecentCommitsLoaded caches loaded commits. A failed load is cached
+// as no commits, so it is retried only after the worktrees are reloaded.
+func (a *App) handleRecentCommitsLoaded(msg RecentCommitsLoadedMsg) {
+	delete(a.commitsLoading, msg.Path)
+	if a.commitCache == nil {
+		a.commitCache = make(map[string][]git.CommitInfo)
+	}
+	if msg.Err != nil {
+		a.commitCache[msg.Path] = nil
+		return
+	}
+	a.commitCache[msg.Path] = msg.Commits
+}
+
+// DiskUsageLoadedMsg is sent when the disk usage of a worktree has been computed.
+type DiskUsageLoadedMsg struct {
+	Path string
+	Size int64
+	Err  error
+}
+
+// loadDiskUsage returns a command that computes the size of a worktree asynchronously.
+func loadDiskUsage(path string) tea.Cmd {
+	return func() tea.Msg {
+		size, err := fsutil.WorktreeDiskUsage(path)
+		return DiskUsageLoadedMsg{Path: path, Size: size, Err: err}
+	}
+}
+
+// ensureDiskUsage shows the cached size of the worktree in the details
+// pane, or returns a command to compute it if it is not cached yet.
+// Walking a worktree is expensive, so only the selected one is computed.
+func (a *App) ensureDiskUsage() tea.Cmd {
+	item := a.details.Item()
+	if !a.config.DiskUsageEnabled() || item == nil {
+		return nil
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.IsBare {
+		return nil
+	}
+
+	if size, ok := a.diskUsageCache[wtData.Path]; ok {
+		a.details.SetDiskUsage(wtData.Path, size)
+		return nil
+	}
+	if a.diskUsageLoading[wtData.Path] {
+		return nil
+	}
+
+	if a.diskUsageLoading == nil {
+		a.diskUsageLoading = make(map[string]bool)
+	}
+	a.diskUsageLoading[wtData.Path] = true
+	return loadDiskUsage(wtData.Path)
+}
+
+// handleDiskUsageLoaded caches a computed worktree size. A failure is cached
+// as -1 so the size is hidden and retried only after the worktrees are reloaded.
+func (a *App) handleDiskUsageLoaded(msg DiskUsageLoadedMsg) {
+	delete(a.diskUsageLoading, msg.Path)
+	if a.diskUsageCache == nil {
+		a.diskUsageCache = make(map[string]int64)
+	}
+	if msg.Err != nil {
+		a.diskUsageCache[msg.Path] = -1
+		return
+	}
+	a.diskUsageCache[msg.Path] = msg.Size
+}
+
+// StashCountLoadedMsg is sent when the stashes of a worktree have been counted.
+type StashCountLoadedMsg struct {
+	Path  string
+	Count int
+	Err   error
+}
+
+// loadStashCount returns a command that counts stashes asynchronously.
+func loadStashCount(path string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		count, err := git.StashCountContext(ctx, path)
+		return StashCountLoadedMsg{Path: path, Count: count, Err: err}
+	}
+}
+
+// ensureStashCount shows the cached stash count of the worktree in the
+// details pane, or returns a command to count them if not cached yet.
+// Only the selected worktree is loaded; bare repositories are skipped.
+func (a *App) ensureStashCount() tea.Cmd {
+	item := a.details.Item()
+	if item == nil {
+		return nil
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.IsBare {
+		return nil
+	}
+
+	if count, ok := a.stashCache[wtData.Path]; ok {
+		a.details.SetStashCount(wtData.Path, count)
+		return nil
+	}
+	if a.stashLoading[wtData.Path] {
+		return nil
+	}
+
+	if a.stashLoading == nil {
+		a.stashLoading = make(map[string]bool)
+	}
+	a.stashLoading[wtData.Path] = true
+	return loadStashCount(wtData.Path, a.config.GitTimeoutDuration())
+}
+
+// handleStashCountLoaded caches a stash count. A failed count is cached as
+// zero, so it is retried only after the worktrees are reloaded.
+func (a *App) handleStashCountLoaded(msg StashCountLoadedMsg) {
+	delete(a.stashLoading, msg.Path)
+	if a.stashCache == nil {
+		a.stashCache = make(map[string]int)
+	}
+	if msg.Err != nil {
+		a.stashCache[msg.Path] = 0
+		return
+	}
+	a.stashCache[msg.Path] = msg.Count
+}
+
+// DiffStatLoadedMsg is sent when the uncommitted changes of a worktree have
+// been summarized.
+type DiffStatLoadedMsg struct {
+	Path       string
+	Files      int
+	Insertions int
+	Deletions  int
+	Err        error
+}
+
+// loadDiffStat returns a command that summarizes uncommitted changes asynchronously.
+func loadDiffStat(path string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		files, insertions, deletions, err := git.DiffStatContext(ctx, path)
+		return DiffStatLoadedMsg{Path: path, Files: files, Insertions: insertions, Deletions: deletions, Err: err}
+	}
+}
+
+// ensureDiffStat shows the cached diff stat of the worktree in the details
+// pane, or returns a command to load it if not cached yet. Only the
+// selected worktree is loaded; bare repositories are skipped.
+func (a *App) ensureDiffStat() tea.Cmd {
+	item := a.details.Item()
+	if item == nil {
+		return nil
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.IsBare {
+		return nil
+	}
+
+	if stat, ok := a.diffStatCache[wtData.Path]; ok {
+		a.details.SetDiffStat(wtData.Path, stat.files, stat.insertions, stat.deletions)
+		return nil
+	}
+	if a.diffStatLoading[wtData.Path] {
+		return nil
+	}
+
+	if a.diffStatLoading == nil {
+		a.diffStatLoading = make(map[string]bool)
+	}
+	a.diffStatLoading[wtData.Path] = true
+	return loadDiffStat(wtData.Path, a.config.GitTimeoutDuration())
+}
+
+// handleDiffStatLoaded caches a diff stat. A failed one is cached as no
+// changes, so it is retried only after the worktree is refreshed.
+func (a *App) handleDiffStatLoaded(msg DiffStatLoadedMsg) {
+	delete(a.diffStatLoading, msg.Path)
+	if a.diffStatCache == nil {
+		a.diffStatCache = make(map[string]diffStat)
+	}
+	if msg.Err != nil {
+		a.diffStatCache[msg.Path] = diffStat{}
+		return
+	}
+	a.diffStatCache[msg.Path] = diffStat{files: msg.Files, insertions: msg.Insertions, deletions: msg.Deletions}
+}
+
+// FullHashLoadedMsg is sent when the full commit hash of a worktree has
+// been read.
+type FullHashLoadedMsg struct {
+	Path string
+	Hash string
+	Err  error
+}
+
+// loadFullHash returns a command that reads the full commit hash asynchronously.
+func loadFullHash(path string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		hash, err := git.GetFullCommitHashContext(ctx, path)
+		return FullHashLoadedMsg{Path: path, Hash: hash, Err: err}
+	}
+}
+
+// ensureFullHash shows the cached full commit hash of the worktree in the
+// details pane, or returns a command to read it if not cached yet. It is
+// read only while the details pane shows full hashes, since git lists
+// worktrees with abbreviated ones; bare repositories are skipped.
+func (a *App) ensureFullHash() tea.Cmd {
+	if !a.details.ShowFullHash() {
+		return nil
+	}
+	item := a.details.Item()
+	if item == nil {
+		return nil
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.IsBare {
+		return nil
+	}
+
+	if hash, ok := a.fullHashCache[wtData.Path]; ok {
+		a.details.SetFullHash(wtData.Path, hash)
+		return nil
+	}
+	if a.fullHashLoading[wtData.Path] {
+		return nil
+	}
+
+	if a.fullHashLoading == nil {
+		a.fullHashLoading = make(map[string]bool)
+	}
+	a.fullHashLoading[wtData.Path] = true
+	return loadFullHash(wtData.Path, a.config.GitTimeoutDuration())
+}
+
+// handleFullHashLoaded caches a full commit hash. A failed one is cached as
+// unknown, so the listed hash is shown until the worktree is refreshed.
+func (a *App) handleFullHashLoaded(msg FullHashLoadedMsg) {
+	delete(a.fullHashLoading, msg.Path)
+	if a.fullHashCache == nil {
+		a.fullHashCache = make(map[string]string)
+	}
+	if msg.Err != nil {
+		a.fullHashCache[msg.Path] = ""
+		return
+	}
+	a.fullHashCache[msg.Path] = msg.Hash
+}
+
+// SubmoduleStatusLoadedMsg is sent when the submodules of a worktree have
+// been checked.
+type SubmoduleStatusLoadedMsg struct {
+	Path   string
+	Status git.SubmoduleStatus
+	Err    error
+}
+
+// loadSubmoduleStatus returns a command that summarizes submodules asynchronously.
+func loadSubmoduleStatus(path string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		status, err := git.GetSubmoduleStatusContext(ctx, path)
+		return SubmoduleStatusLoadedMsg{Path: path, Status: status, Err: err}
+	}
+}
+
+// ensureSubmoduleStatus shows the cached submodule summary of the worktree
+// in the details pane, or returns a command to load it if not cached yet.
+// Only the selected worktree is loaded; bare repositories and worktrees
+// without a .gitmodules file are skipped.
+func (a *App) ensureSubmoduleStatus() tea.Cmd {
+	item := a.details.Item()
+	if item == nil {
+		return nil
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.IsBare {
+		return nil
+	}
+
+	if status, ok := a.submoduleCache[wtData.Path]; ok {
+		a.details.SetSubmoduleStatus(wtData.Path, status)
+		return nil
+	}
+	if a.submoduleLoading[wtData.Path] {
+		return nil
+	}
+	if a.submoduleCache == nil {
+		a.submoduleCache = make(map[string]git.SubmoduleStatus)
+	}
+	if has, _ := git.HasSubmodules(wtData.Path); !has {
+		// Cached as none, so git is never run for this worktree
+		a.submoduleCache[wtData.Path] = git.SubmoduleStatus{}
+		return nil
+	}
+
+	if a.submoduleLoading == nil {
+		a.submoduleLoading = make(map[string]bool)
+	}
+	a.submoduleLoading[wtData.Path] = true
+	return loadSubmoduleStatus(wtData.Path, a.config.GitTimeoutDuration())
+}
+
+// handleSubmoduleStatusLoaded caches a submodule summary. A failed one is
+// cached as no submodules, so it is retried only after the worktree is
+// refreshed.
+func (a *App) handleSubmoduleStatusLoaded(msg SubmoduleStatusLoadedMsg) {
+	delete(a.submoduleLoading, msg.Path)
+	if a.submoduleCache == nil {
+		a.submoduleCache = make(map[string]git.SubmoduleStatus)
+	}
+	if msg.Err != nil {
+		a.submoduleCache[msg.Path] = git.SubmoduleStatus{}
+		return
+	}
+	a.submoduleCache[msg.Path] = msg.Status
+}
+
+// MergedLoadedMsg is sent when it is known whether the branch of a worktree
+// is merged into the default branch.
+type MergedLoadedMsg struct {
+	Path string
+	// Into is the default branch the branch is merged into, empty if it is
+	// not merged or there is no default branch to compare with.
+	Into string
+	Err  error
+}
+
+// loadMerged returns a command that checks asynchronously whether branch,
+// checked out in the worktree at path, is merged into the default branch.
+func loadMerged(path, branch string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		into, err := git.DefaultBranchContext(ctx, path)
+		if err != nil || into == "" || into == branch {
+			return MergedLoadedMsg{Path: path, Err: err}
+		}
+		merged, err := git.IsBranchMergedContext(ctx, path, branch, into)
+		if err != nil || !merged {
+			return MergedLoadedMsg{Path: path, Err: err}
+		}
+		return MergedLoadedMsg{Path: path, Into: into}
+	}
+}
+
+// ensureMerged shows whether the branch of the worktree in the details pane
+// is merged into the default branch, or returns a command to find out if not
+// cached yet. Only the selected worktree is checked, and only if it has a
+// branch checked out.
+func (a *App) ensureMerged() tea.Cmd {
+	item := a.details.Item()
+	if !isBranchWorktreeItem(item) {
+		return nil
+	}
+	wtData := item.Metadata.(*WorktreeItemData)
+
+	if into, ok := a.mergedCache[wtData.Path]; ok {
+		a.details.SetMergedInto(wtData.Path, into)
+		return nil
+	}
+	if a.mergedLoading[wtData.Path] {
+		return nil
+	}
+
+	if a.mergedLoading == nil {
+		a.mergedLoading = make(map[string]bool)
+	}
+	a.mergedLoading[wtData.Path] = true
+	return loadMerged(wtData.Path, wtData.Branch, a.config.GitTimeoutDuration())
+}
+
+// handleMergedLoaded caches whether a branch is merged. A failed check is
+// cached as not merged, so it is retried only after the worktree is refreshed.
+func (a *App) handleMergedLoaded(msg MergedLoadedMsg) {
+	delete(a.mergedLoading, msg.Path)
+	if a.mergedCache == nil {
+		a.mergedCache = make(map[string]string)
+	}
+	if msg.Err != nil {
+		a.mergedCache[msg.Path] = ""
+		return
+	}
+	a.mergedCache[msg.Path] = msg.Into
+}
+
+// FetchFinishedMsg is sent when a background fetch has finished.
+type FetchFinishedMsg struct {
+	Err error
+}
+
+// runFetch returns a command that fetches from the default remote asynchronously.
+func runFetch(repoPath string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		return FetchFinishedMsg{Err: git.FetchContext(ctx, repoPath, "")}
+	}
+}
+
+// handleFetchFinished reloads the worktrees and branches after a fetch.
+func (a *App) handleFetchFinished(msg FetchFinishedMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+
+	if git.IsNoRemoteError(msg.Err) {
+		cmd := a.feedback.ShowInfo("No remote configured, nothing to fetch")
+		return a, cmd
+	}
+	if msg.Err != nil {
+		cmd := a.feedback.ShowError(msg.Err.Error())
+		return a, cmd
+	}
+
+	selectedID := ""
+	if item := a.list.SelectedItem(); item != nil {
+		selectedID = item.ID
+	}
+	a.loadWorktrees()
+	if selectedID != "" && a.list.SelectByID(selectedID) {
+		a.details.SetItem(a.list.SelectedItem())
+	}
+
+	cmd := a.feedback.ShowSuccess("Fetched")
+	return a, cmd
+}
+
+// DefaultBaseLoadedMsg is sent when the base for a new branch off the
+// default branch has been found.
+type DefaultBaseLoadedMsg struct {
+	// Base is the default branch, or its remote-tracking branch after a
+	// fetch; empty if the repository has no default branch.
+	Base    string
+	Fetched bool
+	Err     error
+}
+
+// loadDefaultBase returns a command that finds the repository's default
+// branch asynchronously. With fetch, the default remote is fetched first
+// and the new branch starts at its copy of the default branch.
+func loadDefaultBase(repoPath string, fetch bool, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		fetched := false
+		if fetch {
+			err := git.FetchContext(ctx, repoPath, "")
+			if err != nil && !git.IsNoRemoteError(err) {
+				return DefaultBaseLoadedMsg{Err: err}
+			}
+			fetched = err == nil
+		}
+		base, err := git.DefaultBranchContext(ctx, repoPath)
+		if err != nil || base == "" || !fetched {
+			return DefaultBaseLoadedMsg{Base: base, Fetched: fetched, Err: err}
+		}
+		remoteBranches, err := git.ListRemoteBranchesContext(ctx, repoPath)
+		if err != nil {
+			return DefaultBaseLoadedMsg{Err: err}
+		}
+		for _, ref := range remoteBranches {
+			if ref == "origin/"+base {
+				return DefaultBaseLoadedMsg{Base: ref, Fetched: fetched}
+			}
+		}
+		return DefaultBaseLoadedMsg{Base: base, Fetched: fetched}
+	}
+}
+
+// handleDefaultBaseLoaded opens the create form to branch off the default
+// branch, leaving only the new branch name to type.
+func (a *App) handleDefaultBaseLoaded(msg DefaultBaseLoadedMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+
+	if msg.Err != nil {
+		cmd := a.feedback.ShowError(msg.Err.Error())
+		return a, cmd
+	}
+	if msg.Base == "" {
+		cmd := a.feedback.ShowError("No default branch found: origin/HEAD is not set and there is no main or master branch")
+		return a, cmd
+	}
+	if msg.Fetched {
+		// The fetch may have brought new remote branches
+		selectedID := ""
+		if item := a.list.SelectedItem(); item != nil {
+			selectedID = item.ID
+		}
+		a.loadWorktrees()
+		if selectedID != "" && a.list.SelectByID(selectedID) {
+			a.details.SetItem(a.list.SelectedItem())
+		}
+	}
+	a.createForm.ShowBranchFrom(msg.Base)
+	return a, nil
+}
+
+// TagsLoadedMsg is sent when the tags to create a worktree from have been listed.
+type TagsLoadedMsg struct {
+	// Tags are newest first
+	Tags []string
+	Err  error
+}
+
+// loadTags returns a command that lists the repository's tags asynchronously.
+func loadTags(repoPath string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		tags, err := git.ListTagsContext(ctx, repoPath)
+		return TagsLoadedMsg{Tags: tags, Err: err}
+	}
+}
+
+// handleTagsLoaded opens the create form with a picker of the listed tags.
+func (a *App) handleTagsLoaded(msg TagsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		cmd := a.feedback.ShowError(msg.Err.Error())
+		return a, cmd
+	}
+	if len(msg.Tags) == 0 {
+		cmd := a.feedback.ShowInfo("No tags in this repository")
+		return a, cmd
+	}
+	a.createForm.ShowFromTag(msg.Tags)
+	return a, nil
+}
+
+// RefreshTickMsg is sent when it is time for an automatic refresh.
+type RefreshTickMsg struct{}
+
+// WorktreesRefreshedMsg is sent when an automatic refresh has reloaded the
+// worktrees and remote branches in the background.
+type WorktreesRefreshedMsg struct {
+	Worktrees      []git.Worktree
+	RemoteBranches []string
+	// Statuses holds the status read for each worktree path; a worktree
+	// whose status could not be read has none.
+	Statuses map[string]*git.WorktreeStatus
+	Err      error
+}
+
+// scheduleRefresh returns a command that sends RefreshTickMsg after interval.
+func scheduleRefresh(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return RefreshTickMsg{}
+	})
+}
+
+// runRefresh returns a command that lists the worktrees and reads their
+// status afresh asynchronously. Each git call gets its own timeout, so one
+// slow worktree does not leave the others without a status, and the
+// statuses are carried in the message so the list items are built without
+// running git on the UI goroutine.
+func runRefresh(repoPath string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		worktrees, err := git.ListWorktreesContext(ctx, repoPath)
+		cancel()
+		if err != nil {
+			return WorktreesRefreshedMsg{Err: err}
+		}
+
+		statuses := make(map[string]*git.WorktreeStatus)
+		for _, wt := range worktrees {
+			if wt.IsBare {
+				continue
+			}
+			git.InvalidateWorktreeStatus(wt.Path)
+			ctx, cancel := newGitContext(timeout)
+			status, err := git.GetWorktreeStatusCachedContext(ctx, wt.Path)
+			cancel()
+			if err == nil {
+				statuses[wt.Path] = status
+			}
+		}
+
+		ctx, cancel = newGitContext(timeout)
+		defer cancel()
+		remoteBranches, _ := git.ListRemoteBranchesContext(ctx, repoPath)
+		return WorktreesRefreshedMsg{Worktrees: worktrees, RemoteBranches: remoteBranches, Statuses: statuses}
+	}
+}
+
+// modalVisible reports whether a dialog, form, menu or panel has focus.
+func (a *App) modalVisible() bool {
+	return a.actionMenu.Visible() || a.createForm.Visible() || a.confirmDialog.Visible() ||
+		a.inputPrompt.Visible() || a.messageLog.Visible()
+}
+
+// handleRefreshTick starts an automatic refresh and schedules the next one.
+// It is skipped while a refresh is still running, while a modal is open so
+// input is not disrupted, and when git is unavailable.
+func (a *App) handleRefreshTick() (tea.Model, tea.Cmd) {
+	interval := a.refreshInterval()
+	if interval <= 0 {
+		return a, nil
+	}
+	next := scheduleRefresh(interval)
+	if a.refreshing || a.modalVisible() || a.gitUnavailable() || a.repoPath == "" {
+		return a, next
+	}
+	a.refreshing = true
+	return a, tea.Batch(next, runRefresh(a.repoPath, a.config.GitTimeoutDuration()))
+}
+
+// handleWorktreesRefreshed shows the result of an automatic refresh,
+// keeping the selected item. Errors are left for the next refresh or a
+// manual reload to report, and a modal opened meanwhile keeps the list as
+// it is.
+func (a *App) handleWorktreesRefreshed(msg WorktreesRefreshedMsg) (tea.Model, tea.Cmd) {
+	a.refreshing = false
+	if msg.Err != nil || a.modalVisible() {
+		return a, nil
+	}
+
+	// Files may have changed since the diff stats and submodules were cached,
+	// commits since the full hashes were read, and branches since they were
+	// checked for being merged
+	a.diffStatCache = nil
+	a.fullHashCache = nil
+	a.submoduleCache = nil
+	a.mergedCache = nil
+
+	// The refresh read every status, so a scan still running is done
+	a.startStatusScan(nil)
+
+	selectedID := ""
+	if item := a.list.SelectedItem(); item != nil {
+		selectedID = item.ID
+	}
+	items := make([]ListItem, len(msg.Worktrees))
+	for i, wt := range msg.Worktrees {
+		items[i] = a.worktreeItem(wt, msg.Statuses[wt.Path])
+	}
+	a.setWorktreeItems(msg.Worktrees, items, msg.RemoteBranches)
+	if selectedID != "" && a.list.SelectByID(selectedID) {
+		a.details.SetItem(a.list.SelectedItem())
+	}
+	return a, nil
+}
+
+// refreshInterval returns how often the worktrees are polled: the
+// configured interval, or fallbackRefreshInterval when watching was asked
+// for but failed. Zero means never.
+func (a *App) refreshInterval() time.Duration {
+	if interval := a.config.RefreshInterval(); interval > 0 {
+		return interval
+	}
+	if a.watchFailed {
+		return fallbackRefreshInterval
+	}
+	return 0
+}
+
+// fallbackRefreshInterval is how often the worktrees are polled when
+// watching fails and no interval is configured.
+const fallbackRefreshInterval = 10 * time.Second
+
+// watchDebounce is how long files must stay unchanged before the watcher
+// triggers a refresh, so a burst of writes refreshes once.
+const watchDebounce = 300 * time.Millisecond
+
+// WatchRefreshDueMsg is sent when the debounce after a watched change has passed.
+type WatchRefreshDueMsg struct {
+	seq int
+}
+
+// WorktreeStatusRefreshedMsg is sent when the status of a single worktree
+// has been read afresh in the background.
+type WorktreeStatusRefreshedMsg struct {
+	Path   string
+	Status *git.WorktreeStatus
+	Err    error
+}
+
+// runStatusRefresh returns a command that reads the status of the worktree
+// at path afresh asynchronously, leaving it in the status cache.
+func runStatusRefresh(path string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		git.InvalidateWorktreeStatus(path)
+		status, err := git.GetWorktreeStatusCachedContext(ctx, path)
+		return WorktreeStatusRefreshedMsg{Path: path, Status: status, Err: err}
+	}
+}
+
+// startWatcher starts watching the selected worktree and the repository's
+// worktrees when enabled, and returns the command waiting for changes.
+// When watching fails polling takes over.
+func (a *App) startWatcher() tea.Cmd {
+	info := a.settings.RepoInfo()
+	if !a.config.WatchEnabled() || info == nil || a.gitUnavailable() {
+		return nil
+	}
+
+	watcher, err := newWorktreeWatcher(info.CommonDir)
+	if err != nil {
+		return a.fallBackToPolling(err)
+	}
+	a.watcher = watcher
+	if err := watcher.Watch(a.selectedWorktreePath()); err != nil {
+		return a.fallBackToPolling(err)
+	}
+	return watcher.Wait()
+}
+
+// ensureWatched moves the watch to the selected worktree.
+func (a *App) ensureWatched() tea.Cmd {
+	if a.watcher == nil {
+		return nil
+	}
+	path := a.selectedWorktreePath()
+	if path == a.watcher.Path() {
+		return nil
+	}
+	if err := a.watcher.Watch(path); err != nil {
+		return a.fallBackToPolling(err)
+	}
+	return nil
+}
+
+// selectedWorktreePath returns the path of the selected worktree, or ""
+// when a bare repository or remote branch is selected.
+func (a *App) selectedWorktreePath() string {
+	item := a.list.SelectedItem()
+	if item == nil {
+		return ""
+	}
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.IsBare {
+		return ""
+	}
+	return wtData.Path
+}
+
+// fallBackToPolling stops watching after err and polls the worktrees
+// instead, unless an interval is already configured.
+func (a *App) fallBackToPolling(err error) tea.Cmd {
+	if a.watcher != nil {
+		a.watcher.Close()
+		a.watcher = nil
+	}
+	a.watchFailed = true
+
+	interval := a.refreshInterval()
+	message := fmt.Sprintf("Cannot watch for changes (%v); refreshing every %s instead", err, interval)
+	cmd := a.feedback.ShowInfo(message)
+	if a.config.RefreshInterval() > 0 {
+		// The configured polling is already running
+		return cmd
+	}
+	return tea.Batch(cmd, scheduleRefresh(interval))
+}
+
+// handleWorktreeChanged notes what the watcher saw change and waits for the
+// changes to settle before refreshing.
+func (a *App) handleWorktreeChanged(msg WorktreeChangedMsg) (tea.Model, tea.Cmd) {
+	if a.watcher == nil {
+		return a, nil
+	}
+	if msg.Worktrees {
+		a.watchPendingWorktrees = true
+	}
+	if msg.Path != "" {
+		a.watchPendingPath = msg.Path
+	}
+	a.watchSeq++
+	seq := a.watchSeq
+	debounce := tea.Tick(watchDebounce, func(time.Time) tea.Msg {
+		return WatchRefreshDueMsg{seq: seq}
+	})
+	return a, tea.Batch(a.watcher.Wait(), debounce)
+}
+
+// handleWatchRefreshDue refreshes what changed once no newer change came
+// in: the whole list when worktrees were added or removed, otherwise just
+// the status of the changed worktree. While a modal is open or a refresh
+// runs, it waits another debounce.
+func (a *App) handleWatchRefreshDue(msg WatchRefreshDueMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != a.watchSeq || a.watcher == nil {
+		return a, nil
+	}
+	if a.modalVisible() || a.refreshing {
+		return a, tea.Tick(watchDebounce, func(time.Time) tea.Msg {
+			return msg
+		})
+	}
+
+	timeout := a.config.GitTimeoutDuration()
+	path := a.watchPendingPath
+	worktrees := a.watchPendingWorktrees
+	a.watchPendingPath = ""
+	a.watchPendingWorktrees = false
+
+	if worktrees {
+		a.refreshing = true
+		return a, runRefresh(a.repoPath, timeout)
+	}
+	if path != "" {
+		return a, runStatusRefresh(path, timeout)
+	}
+	return a, nil
+}
+
+// handleWorktreeStatusRefreshed updates the list item of a worktree whose
+// status was read afresh, keeping the selection.
+func (a *App) handleWorktreeStatusRefreshed(msg WorktreeStatusRefreshedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return a, nil
+	}
+	delete(a.diffStatCache, msg.Path)
+	delete(a.fullHashCache, msg.Path)
+	delete(a.submoduleCache, msg.Path)
+	delete(a.mergedCache, msg.Path)
+	for i, wt := range a.worktrees {
+		if wt.Path != msg.Path || i >= len(a.worktreeItems) {
+			continue
+		}
+		a.worktreeItems[i] = a.worktreeItem(wt, msg.Status)
+
+		selectedID := ""
+		if item := a.list.SelectedItem(); item != nil {
+			selectedID = item.ID
+		}
+		a.syncListItems()
+		if selectedID != "" && a.list.SelectByID(selectedID) {
+			a.details.SetItem(a.list.SelectedItem())
+		}
+		break
+	}
+	return a, nil
+}
+
+// PrunePreviewLoadedMsg is sent when the prune dry run has finished.
+type PrunePreviewLoadedMsg struct {
+	// Output is the dry-run output, one line per stale entry.
+	Output string
+	Err    error
+}
+
+// loadPrunePreview returns a command that runs a prune dry run asynchronously.
+func loadPrunePreview(repoPath string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		output, err := git.PruneWorktreesDryRunContext(ctx, repoPath)
+		return PrunePreviewLoadedMsg{Output: output, Err: err}
+	}
+}
+
+// handlePrunePreviewLoaded asks to confirm pruning the stale entries found by
+// the dry run. Nothing is asked when there is nothing to prune.
+func (a *App) handlePrunePreviewLoaded(msg PrunePreviewLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		cmd := a.feedback.ShowError("Failed to check for stale worktrees: " + msg.Err.Error())
+		return a, cmd
+	}
+
+	entries := pruneDryRunEntries(msg.Output)
+	if len(entries) == 0 {
+		cmd := a.feedback.ShowInfo("Nothing to prune")
+		return a, cmd
+	}
+
+	noun := "entries"
+	if len(entries) == 1 {
+		noun = "entry"
+	}
+	message := fmt.Sprintf("This will remove %d stale worktree %s:", len(entries), noun)
+	for _, entry := range entries {
+		message += "\n  • " + entry
+	}
+
+	a.confirmDialog.SetConfirmLabel("Prune")
+	a.confirmDialog.SetForceOption(false)
+	a.confirmDialog.ShowWithData("Prune Stale Worktrees?", message, "prune")
+	return a, nil
+}
+
+// pruneDryRunEntries returns the stale entries reported by a prune dry run,
+// such as "worktrees/feature: gitdir file points to non-existent location".
+func pruneDryRunEntries(output string) []string {
+	var entries []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		entries = append(entries, strings.TrimPrefix(line, "Removing "))
+	}
+	return entries
+}
+
+// PullFinishedMsg is sent when a background pull has finished.
+type PullFinishedMsg struct {
+	Path   string
+	Output string
+	Err    error
+}
+
+// runPull returns a command that pulls into the worktree at path asynchronously.
+func runPull(path string, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		output, err := git.PullContext(ctx, path)
+		return PullFinishedMsg{Path: path, Output: output, Err: err}
+	}
+}
+
+// handlePullFinished refreshes the pulled worktree's status and reports the result.
+func (a *App) handlePullFinished(msg PullFinishedMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+
+	if git.IsDivergedError(msg.Err) {
+		cmd := a.feedback.ShowError("Cannot fast-forward: the branch has diverged from its upstream. " +
+			"Merge or rebase it in " + msg.Path + ". " + msg.Err.Error())
+		return a, cmd
+	}
+	if msg.Err != nil {
+		cmd := a.feedback.ShowError(msg.Err.Error())
+		return a, cmd
+	}
+
+	git.InvalidateWorktreeStatus(msg.Path)
+	selectedID := ""
+	if item := a.list.SelectedItem(); item != nil {
+		selectedID = item.ID
+	}
+	a.loadWorktrees()
+	if selectedID != "" && a.list.SelectByID(selectedID) {
+		a.details.SetItem(a.list.SelectedItem())
+	}
+
+	if strings.Contains(msg.Output, "Already up to date") {
+		cmd := a.feedback.ShowInfo("Already up to date: " + msg.Path)
+		return a, cmd
+	}
+	cmd := a.feedback.ShowSuccess("Pulled and fast-forwarded " + msg.Path)
+	return a, cmd
+}
+
+// PushFinishedMsg is sent when a background push has finished.
+type PushFinishedMsg struct {
+	Path string
+	// SetUpstream is true when the push published the branch to origin.
+	SetUpstream bool
+	Output      string
+	Err         error
+}
+
+// runPush returns a command that pushes the worktree at path asynchronously.
+func runPush(path string, setUpstream bool, timeout time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newGitContext(timeout)
+		defer cancel()
+		output, err := git.PushContext(ctx, path, setUpstream)
+		return PushFinishedMsg{Path: path, SetUpstream: setUpstream, Output: output, Err: err}
+	}
+}
+
+// handlePushFinished refreshes the pushed worktree's ahead/behind counts and
+// reports the result.
+func (a *App) handlePushFinished(msg PushFinishedMsg) (tea.Model, tea.Cmd) {
+	a.spinner.Stop()
+
+	if git.IsPushAuthError(msg.Err) {
+		cmd := a.feedback.ShowError("Authentication failed: check your credentials for origin, then push again. " + msg.Err.Error())
 		return a, cmd
-	case "delete":
-		// Show confirmation dialog for delete action
-		a.confirmDialog.SetConfirmLabel("Delete")
-		a.confirmDialog.SetForceOption(true)
-		a.confirmDialog.ShowDanger(
-			"Delete Worktree?",
-			"This will remove the worktree '"+msg.Item.Title+"'.\nPath: "+msg.Item.ID,
-			msg.Item,
-		)
-		return a, nil
-	default:
-		cmd := a.feedback.ShowError("Unknown action: " + msg.Action.ID)
+	}
+	if git.IsPushNoRemoteError(msg.Err) {
+		cmd := a.feedback.ShowError("No 'origin' remote to push to: add one with git remote add. " + msg.Err.Error())
+		return a, cmd
+	}
+	if msg.Err != nil {
+		cmd := a.feedback.ShowError(msg.Err.Error())
 		return a, cmd
 	}
+
+	git.InvalidateWorktreeStatus(msg.Path)
+	selectedID := ""
+	if item := a.list.SelectedItem(); item != nil {
+		selectedID = item.ID
+	}
+	a.loadWorktrees()
+	if selectedID != "" && a.list.SelectByID(selectedID) {
+		a.details.SetItem(a.list.SelectedItem())
+	}
+
+	if strings.Contains(msg.Output, "Everything up-to-date") {
+		cmd := a.feedback.ShowInfo("Everything up-to-date: " + msg.Path)
+		return a, cmd
+	}
+	if msg.SetUpstream {
+		cmd := a.feedback.ShowSuccess("Pushed " + msg.Path + " and set its upstream to origin")
+		return a, cmd
+	}
+	cmd := a.feedback.ShowSuccess("Pushed " + msg.Path)
+	return a, cmd
 }
 
-// handleCreateFormSubmitted processes the submitted create worktree form.
-func (a *App) handleCreateFormSubmitted(msg CreateFormSubmittedMsg) (tea.Model, tea.Cmd) {
-	opts := git.AddWorktreeOptions{
-		Path:         msg.Result.Path,
-		Branch:       msg.Result.Branch,
-		CreateBranch: msg.Result.CreateBranch,
+// PostCreateHookFinishedMsg is sent when the post-create hook has finished running.
+type PostCreateHookFinishedMsg struct {
+	// Path is the worktree path as entered in the create form.
+	Path string
+	// Err is the hook error, or nil if the hook succeeded.
+	Err error
+}
+
+// runPostCreateHook returns a command that runs the post-create hook asynchronously.
+func runPostCreateHook(hook, path, branch string) tea.Cmd {
+	return func() tea.Msg {
+		err := hooks.RunPostCreate(hook, path, branch)
+		return PostCreateHookFinishedMsg{Path: path, Err: err}
 	}
+}
 
-	err := git.AddWorktree(a.repoPath, opts)
-	if err != nil {
-		cmd := a.feedback.ShowError("Failed to create worktree: " + err.Error())
+// handlePostCreateHookFinished processes the result of the post-create hook.
+// A failed hook does not roll back the worktree; the app stays open so the
+// error can be read, and the new worktree appears in the list.
+func (a *App) handlePostCreateHookFinished(msg PostCreateHookFinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		a.loadWorktrees()
+		cmd := a.feedback.ShowError("Post-create hook failed: " + msg.Err.Error())
 		return a, cmd
 	}
 
-	// Set target path and quit so shell wrapper can cd to it
-	a.targetPath = msg.Result.Path
-	a.quitting = true
-	return a, tea.Quit
+	// Set target path and quit so shell wrapper can cd to it; the footer
+	// is gone by then, so the success is reported after the UI closes
+	a.exitMessage = "Post-create hook finished"
+	a.targetPath = msg.Path
+	return a, a.quit()
+}
+
+// mainWorktreePath returns the path of the main worktree, which for a bare
+// repository is the repository itself. Worktrees are added and removed
+// there, so it works whichever worktree grove was started in. Falls back to
+// the repository path if worktrees are unknown.
+func (a *App) mainWorktreePath() string {
+	for _, wt := range a.worktrees {
+		if wt.IsMain {
+			return wt.Path
+		}
+	}
+	return a.repoPath
+}
+
+// resolvePath returns path as an absolute path, resolving relative paths
+// against the main worktree, or the bare repository, so "../feature" is
+// its sibling wherever grove was started. A leading "~/" is the home
+// directory.
+func (a *App) resolvePath(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(a.mainWorktreePath(), path)
 }
 
 // handleConfirmDialogResult processes the result of a confirmation dialog.
@@ -422,53 +3237,346 @@ func (a *App) handleConfirmDialogResult(msg ConfirmDialogResultMsg) (tea.Model,
 
 	// Handle the confirmed action based on the data type
 	if item, ok := msg.Data.(*ListItem); ok {
-		// This is a worktree delete confirmation
-		opts := git.RemoveWorktreeOptions{
-			Path:  item.ID, // ID is the worktree path
-			Force: msg.Force,
+		// This is a worktree delete confirmation; the archive tag comes
+		// first so nothing is deleted if it cannot be created
+		if msg.Tag != "" {
+			if cmd := a.tagBranchTip(item, msg.Tag); cmd != nil {
+				return a, cmd
+			}
 		}
-
-		err := git.RemoveWorktree(a.repoPath, opts)
-		if err != nil {
-			cmd := a.feedback.ShowError("Failed to remove worktree: " + err.Error())
-			return a, cmd
+		if msg.Stash {
+			return a.stashAndRemoveWorktree(item)
 		}
+		return a.removeWorktree(item, msg.Force)
+	}
 
-		// Refresh the worktree list
-		a.loadWorktrees()
+	// Handle confirmation of opening many terminals
+	if request, ok := msg.Data.(openWorktreesRequest); ok {
+		return a, a.openWorktreeTerminals(request.Paths)
+	}
 
-		cmd := a.feedback.ShowSuccess("Removed worktree: " + item.Title)
-		return a, cmd
+	// Handle resetting the configuration to its defaults
+	if _, ok := msg.Data.(resetConfigRequest); ok {
+		return a.resetConfig()
 	}
 
 	// Handle prune confirmation
 	if action, ok := msg.Data.(string); ok && action == "prune" {
-		output, err := git.PruneWorktrees(a.repoPath)
+		ctx, cancel := a.gitContext()
+		output, err := git.PruneWorktreesContext(ctx, a.mainWorktreePath())
+		cancel()
 		if err != nil {
 			cmd := a.feedback.ShowError("Failed to prune worktrees: " + err.Error())
 			return a, cmd
 		}
+		git.InvalidateAllWorktreeStatus()
 
 		// Refresh the worktree list
 		a.loadWorktrees()
 
-		// Show success message
-		message := "Pruned stale worktrees"
-		if output != "" {
-			message = "Pruned: " + output
+		// Name what went away, e.g. "Pruned 2 stale worktrees: old, gone"
+		names := git.ParsePruneOutput(output)
+		if len(names) == 0 {
+			cmd := a.feedback.ShowInfo("Nothing to prune")
+			return a, cmd
+		}
+		noun := "worktrees"
+		if len(names) == 1 {
+			noun = "worktree"
 		}
-		cmd := a.feedback.ShowSuccess(message)
+		cmd := a.feedback.ShowSuccess(fmt.Sprintf("Pruned %d stale %s: %s", len(names), noun, strings.Join(names, ", ")))
 		return a, cmd
 	}
 
 	return a, nil
 }
 
+// archiveTag returns the tag offered for keeping the tip of the branch
+// checked out in the worktree represented by item, or "" if it has no branch.
+func archiveTag(item *ListItem) string {
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.Branch == "" || wtData.IsDetached {
+		return ""
+	}
+	return git.ArchiveTagName(wtData.Branch, time.Now())
+}
+
+// tagBranchTip tags the tip of the branch checked out in the worktree
+// represented by item before it is deleted. It returns a command showing
+// the error if tagging failed, nil otherwise.
+func (a *App) tagBranchTip(item *ListItem, tag string) tea.Cmd {
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	if !ok || wtData == nil || wtData.Branch == "" {
+		return a.feedback.ShowError("Cannot tag '" + item.Title + "': no branch checked out")
+	}
+
+	ctx, cancel := a.gitContext()
+	err := git.TagCommitContext(ctx, a.repoPath, tag, wtData.Branch)
+	cancel()
+	if git.IsTagExistsError(err) {
+		return a.feedback.ShowError("Tag " + tag + " already exists; nothing was deleted")
+	}
+	if err != nil {
+		return a.feedback.ShowError("Failed to tag the branch tip, nothing was deleted: " + err.Error())
+	}
+	return nil
+}
+
+// stashAndRemoveWorktree stashes the uncommitted changes of the worktree
+// represented by item, then removes it without force. The stash stays in
+// the repository's stash list.
+func (a *App) stashAndRemoveWorktree(item *ListItem) (tea.Model, tea.Cmd) {
+	ctx, cancel := a.gitContext()
+	err := git.StashChangesContext(ctx, item.ID, "grove: before deleting worktree "+item.ID)
+	cancel()
+	if err != nil {
+		cmd := a.feedback.ShowError("Failed to stash changes: " + err.Error())
+		return a, cmd
+	}
+	git.InvalidateWorktreeStatus(item.ID)
+
+	ctx, cancel = a.gitContext()
+	err = git.RemoveWorktreeContext(ctx, a.mainWorktreePath(), git.RemoveWorktreeOptions{Path: item.ID})
+	cancel()
+	if err != nil {
+		cmd := a.feedback.ShowError("Changes stashed, but failed to remove worktree: " + err.Error())
+		return a, cmd
+	}
+
+	a.loadWorktrees()
+
+	cmd := a.feedback.ShowSuccess("Removed worktree: " + item.Title + " (changes stashed, see git stash list)")
+	return a, cmd
+}
+
+// removeWorktree removes the worktree represented by item and refreshes the list.
+// With the trash enabled its directory is moved to the trash instead.
+func (a *App) removeWorktree(item *ListItem, force bool) (tea.Model, tea.Cmd) {
+	if a.config.UseTrashEnabled() {
+		return a.trashWorktree(item)
+	}
+
+	opts := git.RemoveWorktreeOptions{
+		Path:  item.ID, // ID is the worktree path
+		Force: force,
+	}
+
+	ctx, cancel := a.gitContext()
+	err := git.RemoveWorktreeContext(ctx, a.mainWorktreePath(), opts)
+	cancel()
+	if err != nil {
+		if !force && git.IsUncommittedChangesError(err) {
+			cmd := a.feedback.ShowError("'" + item.Title + "' has uncommitted changes; delete it again with Force removal checked")
+			return a, cmd
+		}
+		cmd := a.feedback.ShowError("Failed to remove worktree: " + err.Error())
+		return a, cmd
+	}
+	git.InvalidateWorktreeStatus(item.ID)
+
+	// Refresh the worktree list
+	a.loadWorktrees()
+
+	cmd := a.feedback.ShowSuccess("Removed worktree: " + item.Title)
+	return a, cmd
+}
+
+// trashWorktree moves the directory of the worktree represented by item to
+// the trash, then has git forget the worktree. If git refuses (e.g. the
+// worktree is locked) the directory is moved back.
+func (a *App) trashWorktree(item *ListItem) (tea.Model, tea.Cmd) {
+	info := fsutil.TrashInfo{
+		OriginalPath: item.ID,
+		Repository:   a.repoPath,
+	}
+	if wtData, ok := item.Metadata.(*WorktreeItemData); ok && wtData != nil {
+		info.Branch = wtData.Branch
+		info.Commit = wtData.CommitHash
+	}
+
+	entry, err := fsutil.MoveToTrash(a.trashDir, info)
+	if err != nil {
+		cmd := a.feedback.ShowError("Failed to move worktree to trash: " + err.Error())
+		return a, cmd
+	}
+
+	// The directory is gone, so git only drops its administrative files
+	ctx, cancel := a.gitContext()
+	err = git.RemoveWorktreeContext(ctx, a.mainWorktreePath(), git.RemoveWorktreeOptions{Path: item.ID})
+	cancel()
+	if err != nil {
+		if _, restoreErr := fsutil.RestoreFromTrash(entry); restoreErr != nil {
+			cmd := a.feedback.ShowError("Failed to remove worktree: " + err.Error() + " (directory left in " + entry + ")")
+			return a, cmd
+		}
+		cmd := a.feedback.ShowError("Failed to remove worktree: " + err.Error())
+		return a, cmd
+	}
+	git.InvalidateWorktreeStatus(item.ID)
+
+	// Refresh the worktree list
+	a.loadWorktrees()
+
+	cmd := a.feedback.ShowSuccess("Moved worktree to trash: " + item.Title)
+	return a, cmd
+}
+
+// openWorktreesRequest is the confirmation dialog data for opening
+// terminals for more worktrees than the configured limit.
+type openWorktreesRequest struct {
+	Paths []string
+}
+
+// dirtyWorktreePaths returns the paths of the worktrees with local changes,
+// in list order. Bare repositories have no working tree to open.
+func (a *App) dirtyWorktreePaths() []string {
+	var paths []string
+	for _, item := range a.worktreeItems {
+		wtData, ok := item.Metadata.(*WorktreeItemData)
+		if ok && wtData != nil && !wtData.IsBare && wtData.HasChanges() {
+			paths = append(paths, wtData.Path)
+		}
+	}
+	return paths
+}
+
+// openDirtyWorktrees opens a terminal for each worktree with local changes,
+// asking first when that is more than the configured limit.
+func (a *App) openDirtyWorktrees() tea.Cmd {
+	paths := a.dirtyWorktreePaths()
+	if len(paths) == 0 {
+		return a.feedback.ShowInfo("No worktrees with local changes to open")
+	}
+
+	if limit := a.config.BulkOpenLimitCount(); len(paths) > limit {
+		a.confirmDialog.ShowWithData(
+			"Open Terminals?",
+			fmt.Sprintf("This will open %d terminal windows, one per worktree with local changes.", len(paths)),
+			openWorktreesRequest{Paths: paths},
+		)
+		a.confirmDialog.SetConfirmLabel("Open")
+		return nil
+	}
+	return a.openWorktreeTerminals(paths)
+}
+
+// openWorktreeTerminals opens a terminal at each path and reports how many
+// opened. A worktree for which only a cd command could be offered counts as
+// failed.
+func (a *App) openWorktreeTerminals(paths []string) tea.Cmd {
+	opened, failed := 0, 0
+	for _, path := range paths {
+		result, err := a.openTerminal(path)
+		if err != nil || !result.Success {
+			failed++
+			continue
+		}
+		opened++
+	}
+
+	noun := "terminals"
+	if opened == 1 {
+		noun = "terminal"
+	}
+	message := fmt.Sprintf("Opened %d %s", opened, noun)
+	if failed > 0 {
+		message += fmt.Sprintf(", %d failed", failed)
+	}
+
+	switch {
+	case opened == 0:
+		return a.feedback.ShowError(message)
+	case failed > 0:
+		return a.feedback.ShowInfo(message)
+	default:
+		return a.feedback.ShowSuccess(message)
+	}
+}
+
+// jumpToDirtyWorktree selects the next (or previous) worktree with changes,
+// wrapping around the list.
+func (a *App) jumpToDirtyWorktree(forward bool) tea.Cmd {
+	var found bool
+	if forward {
+		found = a.list.NextMatching(isDirtyItem)
+	} else {
+		found = a.list.PrevMatching(isDirtyItem)
+	}
+	if !found {
+		return a.feedback.ShowInfo("No worktrees with changes")
+	}
+	a.details.SetItem(a.list.SelectedItem())
+	return nil
+}
+
+// isCleanWorktreeItem reports whether the worktree has no uncommitted changes.
+// Uses the status counts when they are known and falls back to asking git.
+// A status git cannot read is treated as dirty.
+func (a *App) isCleanWorktreeItem(item *ListItem) bool {
+	if item == nil {
+		return false
+	}
+	if wtData, ok := item.Metadata.(*WorktreeItemData); ok && wtData != nil && wtData.StatusKnown {
+		return !wtData.HasChanges()
+	}
+	ctx, cancel := a.gitContext()
+	defer cancel()
+	dirty, err := git.HasUncommittedChangesContext(ctx, item.ID)
+	return err == nil && !dirty
+}
+
+// unpushedCommits returns how many commits the worktree represented by item
+// has that its upstream lacks. It uses the count from the last status read
+// when known and asks git otherwise; errors count as none.
+func (a *App) unpushedCommits(item *ListItem) int {
+	if item == nil {
+		return 0
+	}
+	if wtData, ok := item.Metadata.(*WorktreeItemData); ok && wtData != nil && wtData.StatusKnown {
+		return wtData.Ahead
+	}
+	ctx, cancel := a.gitContext()
+	defer cancel()
+	ahead, _, err := git.AheadBehindContext(ctx, item.ID)
+	if err != nil {
+		return 0
+	}
+	return ahead
+}
+
+// unpushedWarning returns the delete confirmation line warning about
+// unpushed commits, e.g. "\n⚠ 3 local commits are not pushed", or "" if
+// there are none.
+func unpushedWarning(count int) string {
+	switch {
+	case count <= 0:
+		return ""
+	case count == 1:
+		return "\n⚠ 1 local commit is not pushed"
+	default:
+		return fmt.Sprintf("\n⚠ %d local commits are not pushed", count)
+	}
+}
+
+// mergedNote returns a line for the delete confirmation telling that the
+// worktree's branch is merged into the branch into, or "" if it is not.
+func mergedNote(into string) string {
+	if into == "" {
+		return ""
+	}
+	return "\n✓ This branch is merged into " + into + ", safe to remove"
+}
+
 // ConfirmDialog returns the confirmation dialog component for testing.
 func (a *App) ConfirmDialog() *ConfirmDialog {
 	return a.confirmDialog
 }
 
+// InputPrompt returns the input prompt component for testing.
+func (a *App) InputPrompt() *InputPrompt {
+	return a.inputPrompt
+}
+
 // CreateForm returns the create form component for testing.
 func (a *App) CreateForm() *CreateForm {
 	return a.createForm
@@ -479,15 +3587,147 @@ func (a *App) TargetPath() string {
 	return a.targetPath
 }
 
+// ExitMessage returns what to report on stderr after the UI has closed, or
+// "" if there is nothing to report.
+func (a *App) ExitMessage() string {
+	return a.exitMessage
+}
+
+// FocusedPane returns the pane receiving navigation keys.
+func (a *App) FocusedPane() Pane {
+	return a.focusedPane
+}
+
+// setFocusedPane moves keyboard focus to the given pane.
+func (a *App) setFocusedPane(pane Pane) {
+	a.focusedPane = pane
+	a.list.SetFocused(pane == PaneList)
+	a.details.SetFocused(pane == PaneDetails)
+}
+
+// focusDetails focuses the details pane when it is shown.
+func (a *App) focusDetails() {
+	if (a.tabs.Active() == TabWorktrees || a.tabs.Active() == TabBranches) && a.detailsVisible() {
+		a.setFocusedPane(PaneDetails)
+	}
+}
+
+// detailsVisible reports whether the details pane is shown: always in the
+// two-pane layouts, and only as a popover in the compact layout.
+func (a *App) detailsVisible() bool {
+	if a.isCompact() {
+		return false
+	}
+	return a.currentLayout() != LayoutCompact || a.detailsPopover
+}
+
+// toggleDetailsPopover shows or hides the selected item's details below the
+// list in the compact layout. Other layouts always show the details.
+func (a *App) toggleDetailsPopover() {
+	if a.currentLayout() != LayoutCompact {
+		return
+	}
+	a.detailsPopover = !a.detailsPopover
+	if a.sizeKnown() {
+		a.updatePaneSizes()
+	}
+	if !a.detailsPopover {
+		a.setFocusedPane(PaneList)
+	}
+}
+
+// scrollDetails scrolls the focused details pane for a navigation key.
+func (a *App) scrollDetails(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "up", "k":
+		a.details.ScrollUp()
+	case "down", "j":
+		a.details.ScrollDown()
+	case "pgup":
+		a.details.PageUp()
+	case "pgdown":
+		a.details.PageDown()
+	}
+}
+
+// Terminal size thresholds. Below the compact size only the list is shown;
+// below the minimum size a "too small" message replaces the whole UI.
+const (
+	compactWidth  = 40
+	compactHeight = 10
+	minWidth      = 20
+	minHeight     = 5
+)
+
+// sizeKnown reports whether a window size has been received.
+func (a *App) sizeKnown() bool {
+	return a.width > 0 && a.height > 0
+}
+
+// isCompact reports whether the terminal is too small for the two-pane layout.
+func (a *App) isCompact() bool {
+	return a.sizeKnown() && (a.width < compactWidth || a.height < compactHeight)
+}
+
+// isTooSmall reports whether the terminal is too small to render the UI at all.
+func (a *App) isTooSmall() bool {
+	return a.sizeKnown() && (a.width < minWidth || a.height < minHeight)
+}
+
+// Layout returns the preferred pane layout.
+func (a *App) Layout() Layout {
+	return a.layout
+}
+
+// SetLayout sets the preferred pane layout and resizes the panes.
+func (a *App) SetLayout(layout Layout) {
+	a.layout = layout
+	if a.sizeKnown() {
+		a.updatePaneSizes()
+	}
+}
+
+// currentLayout returns the layout in use: the preferred layout if one was
+// chosen, otherwise the one suiting the terminal width.
+func (a *App) currentLayout() Layout {
+	return a.layout.resolve(a.width)
+}
+
 // updatePaneSizes updates the sizes of list and details panes based on terminal size.
 func (a *App) updatePaneSizes() {
-	// Calculate available space after tabs and help text
-	// Tabs take ~2 lines, help takes ~1 line, leave some margin
-	availableHeight := a.height - 4
+	// The tab bar and its border sit above the panes, the blank line and
+	// help text take 2 lines below; the list reserves rows for its hint
+	// itself
+	listTop := lipgloss.Height(a.tabs.View())
+	availableHeight := a.height - listTop - 2
 	if availableHeight < 0 {
 		availableHeight = 0
 	}
 
+	// Worktree rows carry their branch and status when there is no
+	// details pane beside them
+	a.list.SetInline(a.currentLayout() == LayoutCompact)
+
+	// Compact terminals and the compact layout show the list alone at full
+	// width
+	if !a.detailsVisible() {
+		a.setFocusedPane(PaneList)
+		a.list.SetSize(a.width, availableHeight)
+		a.list.SetOffset(0, listTop)
+		a.details.SetSize(0, 0)
+		return
+	}
+
+	// Stack the list above the details (40% list, 60% details); the
+	// compact layout's popover opens the same way
+	if layout := a.currentLayout(); layout == LayoutVertical || layout == LayoutCompact {
+		listHeight := availableHeight * 40 / 100
+		a.list.SetSize(a.width, listHeight)
+		a.list.SetOffset(0, listTop)
+		a.details.SetSize(a.width, availableHeight-listHeight)
+		return
+	}
+
 	// Split width between list and details (40% list, 60% details)
 	listWidth := a.width * 40 / 100
 	detailsWidth := a.width - listWidth - 1 // -1 for separator
@@ -500,7 +3740,7 @@ func (a *App) updatePaneSizes() {
 	}
 
 	a.list.SetSize(listWidth, availableHeight)
-	a.list.SetOffset(0, 3) // List starts at Y=3 (after tabs and border, which take 2 lines + 1 newline)
+	a.list.SetOffset(0, listTop) // Mouse rows are 0-based, so the first item is at Y=listTop
 	a.details.SetSize(detailsWidth, availableHeight)
 }
 
@@ -514,6 +3754,10 @@ func (a *App) View() string {
 		return "Goodbye!\n"
 	}
 
+	if a.isTooSmall() {
+		return Styles.Muted.Render("Terminal too small")
+	}
+
 	var b strings.Builder
 
 	// Render tab bar at top
@@ -524,20 +3768,42 @@ func (a *App) View() string {
 	switch a.tabs.Active() {
 	case TabWorktrees, TabBranches:
 		// Show error if not in a git repository
-		if git.IsNotGitRepoError(a.gitError) {
+		if git.IsGitNotFoundError(a.gitError) {
+			b.WriteString(a.renderGitNotFound())
+		} else if git.IsNotGitRepoError(a.gitError) {
 			b.WriteString(a.renderGitError())
 		} else {
-			b.WriteString(a.renderTwoPaneLayout())
+			if git.IsTimeoutError(a.gitError) {
+				timeoutStyle := lipgloss.NewStyle().Foreground(Colors.Error)
+				b.WriteString(timeoutStyle.Render("Git timed out loading worktrees; increase git_timeout in the config"))
+				b.WriteString("\n")
+			}
+			panes := a.renderTwoPaneLayout()
+			if a.modalVisible() {
+				// The selection highlight would compete with the overlay
+				panes = a.renderDimmed(panes)
+			}
+			b.WriteString(panes)
 		}
 	case TabSettings:
 		contentStyle := lipgloss.NewStyle().
 			Padding(1, 2)
-		content := "Settings content\n\nThis will show application settings."
-		b.WriteString(contentStyle.Render(content))
+		b.WriteString(contentStyle.Render(a.settings.View()))
 	}
 
 	b.WriteString("\n\n")
 
+	// Show progress of background tasks
+	if a.spinner.Active() {
+		b.WriteString(a.spinner.View())
+		b.WriteString("\n\n")
+	}
+	if a.scanning() {
+		done := a.statusScanTotal - len(a.statusScan)
+		b.WriteString(Styles.Muted.Render(fmt.Sprintf("Scanning %d/%d…", done, a.statusScanTotal)))
+		b.WriteString("\n\n")
+	}
+
 	// Show feedback message if visible
 	if a.feedback.Visible() {
 		b.WriteString(a.feedback.View())
@@ -545,7 +3811,19 @@ func (a *App) View() string {
 	}
 
 	// Help text using centralized style
-	helpText := "↑/↓: navigate • Enter: action • n: new worktree • p: prune • Tab: switch tabs • q: quit"
+	helpText := "↑/↓: navigate • gg/G: top/bottom • >/<: focus details/list • Enter: action • o: open terminal • c: cd • n: new worktree • N: new from default • T: new from tag • p: prune • F: fetch • Tab: switch tabs • q: quit"
+	if a.focusedPane == PaneDetails {
+		helpText = "↑/↓: scroll • z/Z: fold section/all • P: full path • H: full hash • <: focus list • q: quit"
+	}
+	if a.currentLayout() == LayoutCompact && a.focusedPane == PaneList {
+		helpText = "↑/↓: navigate • i: details • Enter: action • n: new worktree • L: layout • Tab: switch tabs • q: quit"
+	}
+	if a.isCompact() {
+		helpText = "Enter: action • q: quit"
+	}
+	if number := a.list.PendingNumber(); number != "" {
+		helpText = "Go to item " + number + " • Enter: jump • Esc: cancel"
+	}
 	b.WriteString(Styles.Help.Render(helpText))
 
 	// If action menu is visible, render it as an overlay
@@ -554,12 +3832,24 @@ func (a *App) View() string {
 		b.WriteString(a.actionMenu.View())
 	}
 
+	// If the message log is visible, render it as an overlay
+	if a.messageLog.Visible() {
+		b.WriteString("\n\n")
+		b.WriteString(a.messageLog.View())
+	}
+
 	// If create form is visible, render it as an overlay
 	if a.createForm.Visible() {
 		b.WriteString("\n\n")
 		b.WriteString(a.createForm.View())
 	}
 
+	// If input prompt is visible, render it as an overlay
+	if a.inputPrompt.Visible() {
+		b.WriteString("\n\n")
+		b.WriteString(a.inputPrompt.View())
+	}
+
 	// If confirm dialog is visible, render it as an overlay
 	if a.confirmDialog.Visible() {
 		b.WriteString("\n\n")
@@ -569,15 +3859,30 @@ func (a *App) View() string {
 	return b.String()
 }
 
-// renderTwoPaneLayout renders the list and details side by side.
+// renderTwoPaneLayout renders the list and details side by side, or
+// stacked in the vertical layout and the compact layout's popover. Compact
+// terminals and the compact layout otherwise show the list only.
 func (a *App) renderTwoPaneLayout() string {
 	listView := a.list.View()
+	if !a.detailsVisible() {
+		return listView
+	}
 	detailsView := a.details.View()
 
+	if layout := a.currentLayout(); layout == LayoutVertical || layout == LayoutCompact {
+		return lipgloss.JoinVertical(lipgloss.Left, listView, detailsView)
+	}
+
 	// Join horizontally
 	return lipgloss.JoinHorizontal(lipgloss.Top, listView, " ", detailsView)
 }
 
+// renderDimmed renders view in the muted color only, dropping its own
+// colors, so it recedes behind a modal that has focus.
+func (a *App) renderDimmed(view string) string {
+	return Styles.Muted.Render(ansi.Strip(view))
+}
+
 // renderGitError renders an error message for git-related errors.
 func (a *App) renderGitError() string {
 	errorStyle := lipgloss.NewStyle().
@@ -601,3 +3906,25 @@ func (a *App) renderGitError() string {
 
 	return errorStyle.Render(b.String())
 }
+
+// renderGitNotFound renders the error screen shown when git is not installed.
+func (a *App) renderGitNotFound() string {
+	errorStyle := lipgloss.NewStyle().
+		Padding(2, 4).
+		Foreground(Colors.Error)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(Colors.Error)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Git Not Found"))
+	b.WriteString("\n\n")
+	b.WriteString("The git executable could not be found on your PATH.")
+	b.WriteString("\n\n")
+	b.WriteString("Install git from https://git-scm.com/downloads or with your package manager,")
+	b.WriteString("\n")
+	b.WriteString("then make sure the git command is available in your shell and restart.")
+
+	return errorStyle.Render(b.String())
+}
@@ -1,12 +1,24 @@
 package ui
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 
+	"github.com/iatopilskii/grove/internal/config"
 	"github.com/iatopilskii/grove/internal/git"
+	"github.com/iatopilskii/grove/internal/hooks"
 )
 
 // TestAppImplementsTeaModel verifies that App implements tea.Model interface
@@ -118,7 +130,7 @@ func TestAppViewShowsActiveTabContent(t *testing.T) {
 	}{
 		{TabWorktrees, "main"}, // List shows worktree names
 		{TabBranches, "main"},  // Branches tab also shows list
-		{TabSettings, "Settings content"},
+		{TabSettings, "Count untracked files"},
 	}
 
 	for _, tt := range tests {
@@ -412,13 +424,13 @@ func TestAppMouseClickListItem(t *testing.T) {
 	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
 
 	// Click on second item in the list
-	// List starts after tabs (2 lines for tabs + border)
+	// List starts after tabs (2 lines for tabs + border); rows are 0-based
 	// Each list item is 1 line
 	app.Update(tea.MouseMsg{
 		Type:   tea.MouseLeft,
 		Button: tea.MouseButtonLeft,
 		X:      10, // Inside list pane width
-		Y:      4,  // Tabs (2) + first item = 3, second item = 4
+		Y:      3,  // Tabs (2) + first item = 2, second item = 3
 	})
 
 	// Selection should have changed
@@ -432,12 +444,12 @@ func TestAppMouseClickTab(t *testing.T) {
 	app := NewApp()
 	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
 
-	// Click on Settings tab (rightmost)
+	// Click on Settings tab (rightmost); count badges shift it right
 	app.Update(tea.MouseMsg{
 		Type:   tea.MouseLeft,
 		Button: tea.MouseButtonLeft,
-		X:      32, // In Settings tab area
-		Y:      0,  // Tab row
+		X:      app.tabs.GetTabPositions()[TabSettings].StartX + 2, // In Settings tab area
+		Y:      0,                                                  // Tab row
 	})
 
 	if app.tabs.Active() != TabSettings {
@@ -445,6 +457,27 @@ func TestAppMouseClickTab(t *testing.T) {
 	}
 }
 
+// TestAppTabCounts verifies the app pushes item counts into the tab bar
+func TestAppTabCounts(t *testing.T) {
+	app := NewAppWithItems([]ListItem{
+		{ID: "main", Title: "main"},
+		{ID: "feature", Title: "feature"},
+	})
+	app.remoteBranches = []string{"origin/a", "origin/b", "origin/c"}
+	app.updateTabCounts()
+
+	view := app.tabs.View()
+	if !strings.Contains(view, "Worktrees (2)") || !strings.Contains(view, "Branches (5)") {
+		t.Errorf("Tab bar should show counts, got %q", view)
+	}
+
+	app.gitError = &git.NotGitRepoError{Path: "/tmp"}
+	app.updateTabCounts()
+	if strings.Contains(app.tabs.View(), "Worktrees (") {
+		t.Error("Counts should be hidden when git failed")
+	}
+}
+
 // TestAppMouseWheelInList verifies mouse wheel scrolls list
 func TestAppMouseWheelInList(t *testing.T) {
 	sampleItems := []ListItem{
@@ -510,6 +543,26 @@ func TestAppMouseDetailsPane(t *testing.T) {
 	}
 }
 
+// TestAppToggleFullPath verifies P shows the full path only while the details pane is focused
+func TestAppToggleFullPath(t *testing.T) {
+	app := NewAppWithItems([]ListItem{{ID: "/a", Title: "a", Metadata: &WorktreeItemData{Path: "/a"}}})
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if app.details.ShowFullPath() {
+		t.Error("P should do nothing while the list is focused")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if !app.details.ShowFullPath() {
+		t.Error("P should show the full path while the details pane is focused")
+	}
+	if !strings.Contains(app.View(), "P: full path") {
+		t.Error("Expected the details help to mention P")
+	}
+}
+
 // TestAppMouseDetailsUpdateAfterClick verifies details updates after mouse selection
 func TestAppMouseDetailsUpdateAfterClick(t *testing.T) {
 	sampleItems := []ListItem{
@@ -529,7 +582,7 @@ func TestAppMouseDetailsUpdateAfterClick(t *testing.T) {
 		Type:   tea.MouseLeft,
 		Button: tea.MouseButtonLeft,
 		X:      10,
-		Y:      4, // Second item
+		Y:      3, // Second item
 	})
 
 	afterItem := app.details.Item()
@@ -974,6 +1027,456 @@ func TestAppViewShowsGitError(t *testing.T) {
 	}
 }
 
+// TestAppSettingsShowRepoInfo verifies the Settings tab shows the repository's git directories.
+func TestAppSettingsShowRepoInfo(t *testing.T) {
+	repo := t.TempDir()
+	linked := filepath.Join(t.TempDir(), "linked")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "-b", "linked", linked)
+
+	app := NewAppWithPath(linked)
+	info := app.settings.RepoInfo()
+	if info == nil {
+		t.Fatal("Expected repository info for a linked worktree")
+	}
+	if filepath.Base(info.GitDir) != "linked" || filepath.Base(info.CommonDir) != ".git" {
+		t.Errorf("Unexpected git dirs %q and %q", info.GitDir, info.CommonDir)
+	}
+
+	app.Update(tea.WindowSizeMsg{Width: 200, Height: 40})
+	app.tabs.SetActive(TabSettings)
+	if view := app.View(); !strings.Contains(view, "Common dir") || !strings.Contains(view, info.CommonDir) {
+		t.Errorf("Settings tab should show the common dir %q", info.CommonDir)
+	}
+
+	if NewAppWithPath(t.TempDir()).settings.RepoInfo() != nil {
+		t.Error("Expected no repository info outside a repository")
+	}
+}
+
+// TestAppRepoConfigOverrides verifies a .grove.yaml in the repository overrides the configuration.
+func TestAppRepoConfigOverrides(t *testing.T) {
+	repo := t.TempDir()
+	newTestRepo(t, repo)
+	repoYAML := "theme:\n  colors:\n    primary:\n      dark: \"#123456\"\nrow_numbers: true\n"
+	if err := os.WriteFile(filepath.Join(repo, config.RepoConfigFile), []byte(repoYAML), 0644); err != nil {
+		t.Fatalf("Failed to write repository config: %v", err)
+	}
+	t.Cleanup(func() { ApplyThemeConfig(config.DefaultConfig()) })
+
+	app := NewAppWithPath(repo)
+
+	if app.config.Theme.Colors.Primary.Dark != "#123456" || Colors.Primary.Dark != "#123456" {
+		t.Errorf("Expected the repository's primary color, got %q", app.config.Theme.Colors.Primary.Dark)
+	}
+	if app.config.Theme.Colors.Error != config.DefaultConfig().Theme.Colors.Error {
+		t.Error("Colors the repository leaves out should keep their defaults")
+	}
+	if !app.list.ShowNumbers() || !app.settings.Settings()[1].Enabled {
+		t.Error("Expected row numbers enabled by the repository config")
+	}
+
+	// A broken file is reported and the defaults still apply
+	if err := os.WriteFile(filepath.Join(repo, config.RepoConfigFile), []byte("theme: [broken"), 0644); err != nil {
+		t.Fatalf("Failed to write repository config: %v", err)
+	}
+	app = NewAppWithPath(repo)
+	if app.feedback.Type() != FeedbackError || !strings.Contains(app.feedback.Message(), config.RepoConfigFile) {
+		t.Errorf("Expected an error naming the repository config, got %q", app.feedback.Message())
+	}
+	if app.list.ShowNumbers() {
+		t.Error("Expected default settings when the repository config is broken")
+	}
+}
+
+// TestAppReloadTheme verifies R on the Settings tab reloads the theme from the config file.
+func TestAppReloadTheme(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("theme:\n  colors:\n    primary:\n      dark: \"#0A0B0C\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	t.Cleanup(func() { ApplyThemeConfig(config.DefaultConfig()) })
+
+	app := NewAppWithItems([]ListItem{{ID: "main", Title: "main"}})
+	app.configPath = configPath
+
+	// R only reloads on the Settings tab
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}}); cmd != nil {
+		t.Error("R should not reload the theme outside the Settings tab")
+	}
+	app.tabs.SetActive(TabSettings)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	if cmd == nil {
+		t.Fatal("Expected a reload command from R on the Settings tab")
+	}
+	msg, ok := cmd().(ThemeReloadedMsg)
+	if !ok {
+		t.Fatalf("Expected ThemeReloadedMsg, got %T", msg)
+	}
+
+	if msg.Background == nil {
+		t.Error("Expected R to ask the terminal for its background again")
+	}
+	msg.Background = &BackgroundQuery{}
+	app.Update(msg)
+	if !strings.Contains(app.feedback.Message(), "did not report its background") {
+		t.Errorf("Expected a note that the background was not re-detected, got %q", app.feedback.Message())
+	}
+	if Colors.Primary.Dark != "#0A0B0C" || app.config.Theme.Colors.Primary.Dark != "#0A0B0C" {
+		t.Errorf("Expected the reloaded primary color, got %s", Colors.Primary.Dark)
+	}
+	if app.feedback.Type() != FeedbackSuccess {
+		t.Errorf("Expected success feedback, got %q", app.feedback.Message())
+	}
+
+	// Warnings are reported, and valid colors still apply
+	if err := os.WriteFile(configPath, []byte("theme:\n  colors:\n    primary:\n      dark: \"bogus\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	reloaded := app.ReloadTheme()().(ThemeReloadedMsg)
+	if reloaded.Background != nil {
+		t.Error("Expected the automatic reload not to query the terminal")
+	}
+	app.Update(reloaded)
+	if app.feedback.Type() != FeedbackError || !strings.Contains(app.feedback.Message(), "theme.colors.primary.dark") {
+		t.Errorf("Expected a warning naming the invalid color, got %q", app.feedback.Message())
+	}
+	if Colors.Primary.Dark != config.DefaultConfig().Theme.Colors.Primary.Dark {
+		t.Errorf("Invalid color should fall back to the default, got %s", Colors.Primary.Dark)
+	}
+}
+
+// TestAppResetConfig verifies D on the Settings tab rewrites the config file with the defaults and applies them.
+func TestAppResetConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("theme:\n  colors:\n    primary:\n      dark: \"#0A0B0C\"\nrow_numbers: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	t.Cleanup(func() { ApplyThemeConfig(config.DefaultConfig()) })
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	ApplyThemeConfig(cfg)
+	app := NewAppWithItems([]ListItem{{ID: "main", Title: "main"}})
+	app.config = cfg
+	app.applyConfig()
+	app.configPath = configPath
+	app.tabs.SetActive(TabSettings)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if !app.confirmDialog.Visible() || app.confirmDialog.Title() != "Reset to Defaults?" {
+		t.Fatalf("Expected a reset confirmation, got %q", app.confirmDialog.Title())
+	}
+
+	// Cancelling leaves the file alone
+	data := app.confirmDialog.Data()
+	app.confirmDialog.Hide()
+	app.Update(ConfirmDialogResultMsg{Confirmed: false, Data: data})
+	if Colors.Primary.Dark != "#0A0B0C" {
+		t.Errorf("Cancelling should keep the theme, got %q", Colors.Primary.Dark)
+	}
+
+	app.Update(ConfirmDialogResultMsg{Confirmed: true, Data: data})
+	defaults := config.DefaultConfig()
+	if Colors.Primary != configToAdaptive(defaults.Theme.Colors.Primary) {
+		t.Errorf("Expected the default primary color, got %+v", Colors.Primary)
+	}
+	if app.list.ShowNumbers() {
+		t.Error("Expected row numbers off after the reset")
+	}
+	if app.feedback.Type() != FeedbackSuccess {
+		t.Errorf("Expected success feedback, got %q", app.feedback.Message())
+	}
+
+	saved, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if saved.Theme.Colors.Primary != defaults.Theme.Colors.Primary || saved.RowNumbersEnabled() {
+		t.Errorf("Expected the config file to hold the defaults, got primary %+v", saved.Theme.Colors.Primary)
+	}
+}
+
+// TestAppConfigWatchDebounce verifies a config change reloads the theme only once writes settle.
+func TestAppConfigWatchDebounce(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	app := NewAppWithItems([]ListItem{{ID: "main", Title: "main"}})
+	app.configPath = configPath
+	app.configStamp = configStamp(app.configFiles())
+
+	// Nothing changed: keep watching without reloading
+	app.Update(ConfigCheckedMsg{stamp: configStamp(app.configFiles())})
+	if app.configStamp != configStamp(app.configFiles()) {
+		t.Error("Stamp should not change while the file is unchanged")
+	}
+
+	if err := os.WriteFile(configPath, []byte("row_numbers: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	changed := configStamp(app.configFiles())
+	if _, cmd := app.Update(ConfigCheckedMsg{stamp: changed}); cmd == nil || app.configStamp != changed {
+		t.Fatal("A changed file should start the debounce")
+	}
+
+	// Another write during the debounce restarts it instead of reloading
+	if err := os.WriteFile(configPath, []byte("row_numbers: false\nrecent_commits: 3\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	rewritten := configStamp(app.configFiles())
+	_, cmd := app.Update(ConfigReloadDueMsg{stamp: rewritten})
+	if cmd == nil || app.configStamp != rewritten {
+		t.Fatal("A write during the debounce should restart it")
+	}
+
+	// Once the file is unchanged for the debounce, the theme reloads
+	_, cmd = app.Update(ConfigReloadDueMsg{stamp: rewritten})
+	if cmd == nil {
+		t.Fatal("Expected a reload once the file settled")
+	}
+	if _, ok := cmd().(ThemeReloadedMsg); !ok {
+		t.Error("Expected the reload to produce ThemeReloadedMsg")
+	}
+}
+
+// TestConfigStamp verifies writing, creating and removing config files changes the stamp.
+func TestConfigStamp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	missing := configStamp([]string{path})
+
+	if err := os.WriteFile(path, []byte("a"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	created := configStamp([]string{path})
+	if created == missing {
+		t.Error("Creating the file should change the stamp")
+	}
+
+	if err := os.WriteFile(path, []byte("ab"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if configStamp([]string{path}) == created {
+		t.Error("Writing the file should change the stamp")
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove config: %v", err)
+	}
+	if configStamp([]string{path}) != missing {
+		t.Error("Removing the file should restore the missing stamp")
+	}
+}
+
+// TestAppOpenKey verifies o opens a terminal for the selected worktree
+// without the action menu, reporting the cd fallback like the menu does.
+func TestAppOpenKey(t *testing.T) {
+	items := []ListItem{
+		{ID: "/repo", Title: "main", Metadata: &WorktreeItemData{Path: "/repo", Branch: "main", IsMain: true}},
+		{ID: "/repo-a", Title: "a", Metadata: &WorktreeItemData{Path: "/repo-a", Branch: "a"}},
+	}
+	app := NewAppWithItems(items)
+	app.list.SetSelected(1)
+	var opened []string
+	app.openTerminal = func(path string) (*git.OpenWorktreeResult, error) {
+		opened = append(opened, path)
+		return &git.OpenWorktreeResult{Success: true, Message: "Opened " + path}, nil
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if strings.Join(opened, ",") != "/repo-a" {
+		t.Fatalf("Expected a terminal for the selected worktree, got %v", opened)
+	}
+	if app.actionMenu.Visible() {
+		t.Error("o should not open the action menu")
+	}
+	if app.feedback.Type() != FeedbackSuccess || app.feedback.Message() != "Opened /repo-a" {
+		t.Errorf("Expected success feedback, got %v %q", app.feedback.Type(), app.feedback.Message())
+	}
+
+	// Without a terminal the cd command is shown instead
+	app.openTerminal = func(path string) (*git.OpenWorktreeResult, error) {
+		return &git.OpenWorktreeResult{Success: false, Message: "Use this command to switch: cd " + path}, nil
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if app.feedback.Type() != FeedbackInfo || !strings.Contains(app.feedback.Message(), "cd /repo-a") {
+		t.Errorf("Expected the cd fallback, got %v %q", app.feedback.Type(), app.feedback.Message())
+	}
+
+	// Errors are reported as in the menu
+	app.openTerminal = func(path string) (*git.OpenWorktreeResult, error) {
+		return nil, errors.New("no display")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if app.feedback.Type() != FeedbackError || !strings.Contains(app.feedback.Message(), "no display") {
+		t.Errorf("Expected the open error, got %v %q", app.feedback.Type(), app.feedback.Message())
+	}
+
+	// Only the Worktrees tab opens terminals with o
+	opened = nil
+	app.openTerminal = func(path string) (*git.OpenWorktreeResult, error) {
+		opened = append(opened, path)
+		return &git.OpenWorktreeResult{Success: true}, nil
+	}
+	app.tabs.SetActive(TabSettings)
+	app.handleTabChanged()
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if len(opened) != 0 {
+		t.Errorf("Expected no terminal outside the Worktrees tab, got %v", opened)
+	}
+}
+
+// TestAppCdKey verifies c hands the selected worktree to the shell wrapper and quits.
+func TestAppCdKey(t *testing.T) {
+	items := []ListItem{
+		{ID: "/repo", Title: "main", Metadata: &WorktreeItemData{Path: "/repo", Branch: "main", IsMain: true}},
+		{ID: "/repo-a", Title: "a", Metadata: &WorktreeItemData{Path: "/repo-a", Branch: "a"}},
+	}
+	app := NewAppWithItems(items)
+	app.list.SetSelected(1)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if app.TargetPath() != "/repo-a" {
+		t.Errorf("Expected target path %q, got %q", "/repo-a", app.TargetPath())
+	}
+	if cmd == nil || !app.quitting {
+		t.Error("Expected c to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected a quit command")
+	}
+
+	// Other tabs ignore c
+	app = NewAppWithItems(items)
+	app.tabs.SetActive(TabSettings)
+	app.handleTabChanged()
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if app.TargetPath() != "" || app.quitting {
+		t.Error("Expected c to be ignored outside the Worktrees tab")
+	}
+
+	// So does a failed repository read
+	app = NewAppWithItems(items)
+	app.gitError = errors.New("failed to list worktrees")
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if app.TargetPath() != "" || app.quitting {
+		t.Error("Expected c to be ignored after a git error")
+	}
+}
+
+// TestAppOpenDirtyWorktrees verifies O opens a terminal per dirty worktree and reports a summary.
+func TestAppOpenDirtyWorktrees(t *testing.T) {
+	items := []ListItem{
+		{ID: "/repo", Title: "main", Metadata: &WorktreeItemData{Path: "/repo", Branch: "main", IsMain: true}},
+		{ID: "/repo-a", Title: "a", Metadata: &WorktreeItemData{Path: "/repo-a", Branch: "a", ModifiedCount: 1}},
+		{ID: "/repo-b", Title: "b", Metadata: &WorktreeItemData{Path: "/repo-b", Branch: "b", UntrackedCount: 2}},
+		{ID: "/repo-c", Title: "c", Metadata: &WorktreeItemData{Path: "/repo-c", Branch: "c", StagedCount: 1}},
+	}
+	app := NewAppWithItems(items)
+	var opened []string
+	app.openTerminal = func(path string) (*git.OpenWorktreeResult, error) {
+		opened = append(opened, path)
+		if path == "/repo-c" {
+			return &git.OpenWorktreeResult{Success: false, Message: "Use this command to switch: cd /repo-c"}, nil
+		}
+		return &git.OpenWorktreeResult{Success: true}, nil
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+
+	if strings.Join(opened, ",") != "/repo-a,/repo-b,/repo-c" {
+		t.Errorf("Expected terminals for the dirty worktrees only, got %v", opened)
+	}
+	if app.feedback.Message() != "Opened 2 terminals, 1 failed" {
+		t.Errorf("Unexpected summary %q", app.feedback.Message())
+	}
+}
+
+// TestAppOpenDirtyWorktreesNone verifies O reports when no worktree has local changes.
+func TestAppOpenDirtyWorktreesNone(t *testing.T) {
+	app := NewAppWithItems([]ListItem{
+		{ID: "/repo", Title: "main", Metadata: &WorktreeItemData{Path: "/repo", Branch: "main"}},
+	})
+	app.openTerminal = func(path string) (*git.OpenWorktreeResult, error) {
+		t.Errorf("No terminal should open, got %s", path)
+		return nil, nil
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	if app.feedback.Type() != FeedbackInfo {
+		t.Errorf("Expected info feedback, got %q", app.feedback.Message())
+	}
+}
+
+// TestAppOpenDirtyWorktreesLimit verifies opening more terminals than the limit asks first.
+func TestAppOpenDirtyWorktreesLimit(t *testing.T) {
+	var items []ListItem
+	for _, name := range []string{"a", "b", "c"} {
+		path := "/repo-" + name
+		items = append(items, ListItem{ID: path, Title: name, Metadata: &WorktreeItemData{Path: path, Branch: name, ModifiedCount: 1}})
+	}
+	app := NewAppWithItems(items)
+	limit := 2
+	app.config.BulkOpenLimit = &limit
+	opened := 0
+	app.openTerminal = func(path string) (*git.OpenWorktreeResult, error) {
+		opened++
+		return &git.OpenWorktreeResult{Success: true}, nil
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	if !app.confirmDialog.Visible() || opened != 0 {
+		t.Fatalf("Expected a confirmation before opening 3 terminals (opened %d)", opened)
+	}
+	if !strings.Contains(app.confirmDialog.Message(), "3 terminal windows") {
+		t.Errorf("Confirmation should say how many terminals open, got %q", app.confirmDialog.Message())
+	}
+
+	// Cancelling opens nothing
+	data := app.confirmDialog.Data()
+	app.confirmDialog.Hide()
+	app.Update(ConfirmDialogResultMsg{Confirmed: false, Data: data})
+	if opened != 0 {
+		t.Errorf("Cancelling should open no terminals, opened %d", opened)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	if !app.confirmDialog.Visible() {
+		t.Fatal("Expected the confirmation again")
+	}
+	data = app.confirmDialog.Data()
+	app.confirmDialog.Hide()
+	app.Update(ConfirmDialogResultMsg{Confirmed: true, Data: data})
+	if opened != 3 || app.feedback.Message() != "Opened 3 terminals" {
+		t.Errorf("Expected 3 terminals after confirming, opened %d (%q)", opened, app.feedback.Message())
+	}
+}
+
+// TestAppViewShowsGitNotFound verifies View shows a dedicated error when git is missing
+func TestAppViewShowsGitNotFound(t *testing.T) {
+	t.Setenv("PATH", "")
+	app := NewAppWithPath(t.TempDir())
+	if !git.IsGitNotFoundError(app.GitError()) {
+		t.Fatalf("Expected GitNotFoundError, got %v", app.GitError())
+	}
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	view := app.View()
+
+	if !strings.Contains(view, "Git Not Found") {
+		t.Error("View should show 'Git Not Found' error message")
+	}
+	if strings.Contains(view, "Not a Git Repository") {
+		t.Error("A missing git binary should not be reported as a missing repository")
+	}
+
+	// Creating a worktree needs git, so the form should stay closed
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if app.createForm.Visible() {
+		t.Error("Create form should not open when git is missing")
+	}
+}
+
 // TestAppViewShowsWorktreeList verifies View shows worktree list in git repo
 func TestAppViewShowsWorktreeList(t *testing.T) {
 	app := NewApp()
@@ -1163,8 +1666,8 @@ func TestAppCreateFormSubmittedSuccess(t *testing.T) {
 		},
 	})
 
-	if !app.feedback.Visible() {
-		t.Error("Form submission should show feedback")
+	if !app.createForm.Visible() || app.createForm.Error() == "" {
+		t.Error("A failed submission should reopen the form with the error")
 	}
 }
 
@@ -1338,7 +1841,7 @@ func TestAppConfirmDialogQuickAnswer(t *testing.T) {
 	}
 }
 
-// TestAppPKeyTriggersPrune verifies 'p' key opens prune confirmation on Worktrees tab
+// TestAppPKeyTriggersPrune verifies 'p' key runs a prune dry run on Worktrees tab
 func TestAppPKeyTriggersPrune(t *testing.T) {
 	items := []ListItem{
 		{ID: "1", Title: "Worktree 1", Description: "Description 1"},
@@ -1347,11 +1850,20 @@ func TestAppPKeyTriggersPrune(t *testing.T) {
 	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
 
 	// Press 'p' to trigger prune
-	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+
+	// The dialog waits for the dry run to finish
+	if cmd == nil {
+		t.Fatal("'p' should start a prune dry run on Worktrees tab")
+	}
+	if app.confirmDialog.Visible() {
+		t.Error("Prune confirmation should not show before the dry run finishes")
+	}
 
-	// Should show confirmation dialog for prune
+	// Should show confirmation dialog for prune once stale entries are found
+	app.Update(PrunePreviewLoadedMsg{Output: "Removing worktrees/old: gitdir file points to non-existent location"})
 	if !app.confirmDialog.Visible() {
-		t.Error("'p' should show prune confirmation dialog on Worktrees tab")
+		t.Error("Stale entries should show prune confirmation dialog")
 	}
 }
 
@@ -1367,9 +1879,9 @@ func TestAppPKeyDoesNotTriggerOnSettingsTab(t *testing.T) {
 	app.tabs.SetActive(TabSettings)
 
 	// Press 'p' - should not trigger prune
-	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
 
-	if app.confirmDialog.Visible() {
+	if app.confirmDialog.Visible() || cmd != nil {
 		t.Error("'p' should not work on Settings tab")
 	}
 }
@@ -1382,8 +1894,9 @@ func TestAppPruneConfirmationFlow(t *testing.T) {
 	app := NewAppWithItems(items)
 	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
 
-	// Press 'p' to trigger prune
-	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	// The dry run found stale entries
+	app.Update(PrunePreviewLoadedMsg{Output: "Removing worktrees/old: gitdir file points to non-existent location\n" +
+		"Removing worktrees/gone: gitdir file points to non-existent location\n"})
 
 	if !app.confirmDialog.Visible() {
 		t.Fatal("Expected prune confirmation dialog to be visible")
@@ -1394,6 +1907,13 @@ func TestAppPruneConfirmationFlow(t *testing.T) {
 	if !strings.Contains(view, "Prune") {
 		t.Error("Confirmation dialog should mention 'Prune'")
 	}
+
+	// The message lists what is going away
+	for _, want := range []string{"2 stale worktree entries", "worktrees/old", "worktrees/gone"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Confirmation dialog should mention %q", want)
+		}
+	}
 }
 
 // TestAppPruneCancellation verifies prune can be cancelled
@@ -1404,8 +1924,8 @@ func TestAppPruneCancellation(t *testing.T) {
 	app := NewAppWithItems(items)
 	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
 
-	// Press 'p' to trigger prune
-	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	// The dry run found a stale entry
+	app.Update(PrunePreviewLoadedMsg{Output: "Removing worktrees/old: gitdir file points to non-existent location"})
 
 	// Press Escape to cancel
 	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
@@ -1448,6 +1968,61 @@ func TestAppPruneResultMsg(t *testing.T) {
 	// but the message handling should work
 }
 
+// TestAppPruneNothingToPrune verifies an empty dry run skips the dialog
+func TestAppPruneNothingToPrune(t *testing.T) {
+	app := NewAppWithItems([]ListItem{{ID: "1", Title: "Worktree 1"}})
+
+	app.Update(PrunePreviewLoadedMsg{Output: ""})
+
+	if app.confirmDialog.Visible() {
+		t.Error("Confirmation should be skipped when there is nothing to prune")
+	}
+	if app.feedback.Type() != FeedbackInfo || app.feedback.Message() != "Nothing to prune" {
+		t.Errorf("Expected 'Nothing to prune' info, got %q", app.feedback.Message())
+	}
+}
+
+// TestAppPrunePreviewError verifies a failed dry run is reported without a dialog
+func TestAppPrunePreviewError(t *testing.T) {
+	app := NewAppWithItems([]ListItem{{ID: "1", Title: "Worktree 1"}})
+
+	app.Update(PrunePreviewLoadedMsg{Err: &git.WorktreePruneError{Reason: "boom"}})
+
+	if app.confirmDialog.Visible() {
+		t.Error("Confirmation should not show when the dry run failed")
+	}
+	if app.feedback.Type() != FeedbackError {
+		t.Error("Expected error feedback for a failed dry run")
+	}
+}
+
+// TestAppPrunePreviewWithStaleWorktree verifies the dry run finds a deleted worktree directory
+func TestAppPrunePreviewWithStaleWorktree(t *testing.T) {
+	repo := t.TempDir()
+	stale := filepath.Join(t.TempDir(), "stale")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "-b", "stale", stale)
+	if err := os.RemoveAll(stale); err != nil {
+		t.Fatalf("Failed to remove worktree directory: %v", err)
+	}
+
+	app := NewAppWithPath(repo)
+	app.Update(loadPrunePreview(repo, 0)())
+
+	if !app.confirmDialog.Visible() {
+		t.Fatalf("Expected prune confirmation for the stale worktree, feedback %q", app.feedback.Message())
+	}
+	if !strings.Contains(app.confirmDialog.View(), "stale") {
+		t.Error("Confirmation should name the stale worktree")
+	}
+
+	// Confirming reports what was pruned by name
+	app.Update(ConfirmDialogResultMsg{Confirmed: true, Data: "prune"})
+	if got := app.feedback.Message(); got != "Pruned 1 stale worktree: stale" {
+		t.Errorf("Expected a summary of the pruned worktree, got %q", got)
+	}
+}
+
 // TestAppPKeyDoesNotTriggerWhenGitError verifies 'p' doesn't work when not in git repo
 func TestAppPKeyDoesNotTriggerWhenGitError(t *testing.T) {
 	app := NewApp() // Will have git error in non-git directory
@@ -1528,33 +2103,2576 @@ func TestAppCDActionExecuted(t *testing.T) {
 	}
 }
 
-// TestAppOpenActionResultsInFeedback verifies open action feedback content
-func TestAppOpenActionResultsInFeedback(t *testing.T) {
-	// Create a temporary directory to use as worktree path
+// TestAppCopyAddCommandAction verifies the add command is copied with feedback.
+func TestAppCopyAddCommandAction(t *testing.T) {
+	var copied string
+	orig := copyToClipboard
+	copyToClipboard = func(text string) { copied = text }
+	t.Cleanup(func() { copyToClipboard = orig })
+
 	items := []ListItem{
-		{ID: "/tmp", Title: "Tmp", Description: "Temp directory"},
+		{ID: "/src/repo", Title: "repo", Metadata: &WorktreeItemData{Path: "/src/repo", Branch: "main", IsMain: true}},
+		{ID: "/src/feature", Title: "feature", Metadata: &WorktreeItemData{Path: "/src/feature", Branch: "feature"}},
+		{ID: "/src/shared", Title: "shared", Metadata: &WorktreeItemData{Path: "/src/shared", Branch: "shared", Upstream: "origin/shared"}},
 	}
 	app := NewAppWithItems(items)
-	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	action := &Action{ID: "addcmd", Label: "Copy Add Command"}
 
-	// Send an open action
-	openAction := &Action{ID: "open", Label: "Open", Description: "Open worktree in new terminal"}
-	app.Update(ActionExecutedMsg{Action: openAction, Item: &items[0]})
+	// A branch without an upstream is created, one with an upstream checked out
+	app.Update(ActionExecutedMsg{Action: action, Item: &items[1]})
+	if copied != "git worktree add -b feature /src/feature" {
+		t.Errorf("Expected the new-branch command to be copied, got %q", copied)
+	}
+	if app.feedback.Type() != FeedbackSuccess || !strings.Contains(app.feedback.Message(), copied) {
+		t.Errorf("Expected feedback showing the copied command, got %q", app.feedback.Message())
+	}
+	app.Update(ActionExecutedMsg{Action: action, Item: &items[2]})
+	if copied != "git worktree add /src/shared shared" {
+		t.Errorf("Expected the existing-branch command to be copied, got %q", copied)
+	}
 
-	// Feedback should be visible with some content
-	if !app.feedback.Visible() {
-		t.Error("Expected feedback to be visible")
+	// The main worktree is not recreated by git worktree add
+	copied = ""
+	app.Update(ActionExecutedMsg{Action: action, Item: &items[0]})
+	if copied != "" || app.feedback.Type() != FeedbackError {
+		t.Errorf("Expected nothing copied for the main worktree, got %q", copied)
 	}
+	for _, a := range actionsForItem(&items[0]) {
+		if a.ID == "addcmd" {
+			t.Error("The main worktree should not offer Copy Add Command")
+		}
+	}
+}
 
-	view := app.feedback.View()
-	// Should contain either success indicator, info indicator, or cd command
-	hasContent := strings.Contains(view, "✓") ||
-		strings.Contains(view, "ℹ") ||
-		strings.Contains(view, "cd") ||
-		strings.Contains(view, "Opened") ||
-		strings.Contains(view, "Use this command")
+// TestAppCopyCommitHashAction verifies the full HEAD hash is copied and reported.
+func TestAppCopyCommitHashAction(t *testing.T) {
+	var copied string
+	orig := copyToClipboard
+	copyToClipboard = func(text string) { copied = text }
+	t.Cleanup(func() { copyToClipboard = orig })
 
-	if !hasContent {
-		t.Errorf("Expected meaningful feedback content, got: %s", view)
+	repo := t.TempDir()
+	run := newTestRepo(t, repo)
+	hash := run("rev-parse", "HEAD")
+
+	items := []ListItem{
+		{ID: repo, Title: "repo", Metadata: &WorktreeItemData{Path: repo, Branch: "main", CommitHash: hash[:7]}},
+		{ID: "/src/repo.git", Title: "repo.git", Metadata: &WorktreeItemData{Path: "/src/repo.git", IsBare: true}},
+	}
+	app := NewAppWithItems(items)
+	action := &Action{ID: "hash", Label: "Copy Commit Hash"}
+
+	app.Update(ActionExecutedMsg{Action: action, Item: &items[0]})
+	if copied != hash {
+		t.Errorf("Expected the full hash %q to be copied, got %q", hash, copied)
+	}
+	if app.feedback.Type() != FeedbackSuccess || app.feedback.Message() != "Copied commit "+hash {
+		t.Errorf("Expected feedback naming the copied hash, got %q", app.feedback.Message())
+	}
+
+	// A bare repository has no commit checked out
+	for _, a := range actionsForItem(&items[1]) {
+		if a.ID == "hash" {
+			t.Error("A bare repository should not offer Copy Commit Hash")
+		}
+	}
+}
+
+// TestAppToggleFullHashLoadsHash verifies H in the details pane shows the
+// full commit hash, read from git since worktrees are listed abbreviated.
+func TestAppToggleFullHashLoadsHash(t *testing.T) {
+	repo := t.TempDir()
+	run := newTestRepo(t, repo)
+	hash := run("rev-parse", "HEAD")
+
+	app := NewAppWithPath(repo)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	listed := app.list.SelectedItem().Metadata.(*WorktreeItemData).CommitHash
+	if listed == "" || len(listed) >= len(hash) {
+		t.Fatalf("Expected git to list an abbreviated hash, got %q", listed)
+	}
+
+	path := app.selectedWorktreePath()
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	if app.fullHashLoading[path] {
+		t.Fatal("Expected no full hash to be read while short hashes are shown")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	if !app.fullHashLoading[path] {
+		t.Fatal("Expected the full hash to be read")
+	}
+	app.Update(loadFullHash(path, time.Minute)())
+	if !strings.Contains(app.details.View(), hash) {
+		t.Errorf("Expected the details pane to show the full hash %s, got:\n%s", hash, app.details.View())
+	}
+	if cmd := app.ensureFullHash(); cmd != nil {
+		t.Error("Expected no command when the full hash is cached")
+	}
+}
+
+// TestAppOpenActionResultsInFeedback verifies open action feedback content
+func TestAppOpenActionResultsInFeedback(t *testing.T) {
+	// Create a temporary directory to use as worktree path
+	items := []ListItem{
+		{ID: "/tmp", Title: "Tmp", Description: "Temp directory"},
+	}
+	app := NewAppWithItems(items)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// Send an open action
+	openAction := &Action{ID: "open", Label: "Open", Description: "Open worktree in new terminal"}
+	app.Update(ActionExecutedMsg{Action: openAction, Item: &items[0]})
+
+	// Feedback should be visible with some content
+	if !app.feedback.Visible() {
+		t.Error("Expected feedback to be visible")
+	}
+
+	view := app.feedback.View()
+	// Should contain either success indicator, info indicator, or cd command
+	hasContent := strings.Contains(view, "✓") ||
+		strings.Contains(view, "ℹ") ||
+		strings.Contains(view, "cd") ||
+		strings.Contains(view, "Opened") ||
+		strings.Contains(view, "Use this command")
+
+	if !hasContent {
+		t.Errorf("Expected meaningful feedback content, got: %s", view)
+	}
+}
+
+// TestAppPostCreateHookFinishedSuccess verifies a successful hook hands off to the shell wrapper
+func TestAppPostCreateHookFinishedSuccess(t *testing.T) {
+	app := NewAppWithItems(nil)
+
+	_, cmd := app.Update(PostCreateHookFinishedMsg{Path: "../feature"})
+
+	if cmd == nil {
+		t.Fatal("Expected quit command after successful hook")
+	}
+	if app.TargetPath() != "../feature" {
+		t.Errorf("Expected target path '../feature', got '%s'", app.TargetPath())
+	}
+	if app.ExitMessage() != "Post-create hook finished" {
+		t.Errorf("Expected the hook's success reported after exit, got %q", app.ExitMessage())
+	}
+}
+
+// TestAppPostCreateHookFinishedFailure verifies a failed hook shows an error and keeps the app open
+func TestAppPostCreateHookFinishedFailure(t *testing.T) {
+	app := NewAppWithItems(nil)
+
+	app.Update(PostCreateHookFinishedMsg{
+		Path: "../feature",
+		Err:  &hooks.HookError{Command: "npm install", Reason: "exit status 1"},
+	})
+
+	if app.quitting {
+		t.Error("App should not quit when the hook fails")
+	}
+	if app.TargetPath() != "" {
+		t.Error("Target path should not be set when the hook fails")
+	}
+	if !app.feedback.Visible() || app.feedback.Type() != FeedbackError {
+		t.Error("Expected error feedback after hook failure")
+	}
+	if !strings.Contains(app.feedback.Message(), "npm install") {
+		t.Errorf("Expected hook command in feedback, got: %s", app.feedback.Message())
+	}
+}
+
+// TestAppDeleteMainWorktreeRefused verifies deleting the main worktree shows an explanation
+func TestAppDeleteMainWorktreeRefused(t *testing.T) {
+	items := []ListItem{
+		{ID: "/repo", Title: "repo", Metadata: &WorktreeItemData{Path: "/repo", IsMain: true}},
+	}
+	app := NewAppWithItems(items)
+
+	action := &Action{ID: "delete", Label: "Delete"}
+	app.Update(ActionExecutedMsg{Action: action, Item: &items[0]})
+
+	if app.confirmDialog.Visible() {
+		t.Error("Confirm dialog should not open for the main worktree")
+	}
+	if !app.feedback.Visible() || app.feedback.Type() != FeedbackError {
+		t.Error("Expected error feedback when deleting the main worktree")
+	}
+}
+
+// TestAppBareRepositoryWorktrees verifies a bare clone with per-branch worktrees works from inside one of them.
+func TestAppBareRepositoryWorktrees(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	bare := filepath.Join(root, "repo.git")
+	run := newTestRepo(t, src)
+	run("branch", "-M", "main")
+	runGit(t, root, "clone", "--bare", src, bare)
+	runGit(t, bare, "worktree", "add", "../main", "main")
+	runGit(t, bare, "worktree", "add", "-b", "feature", "../feature")
+	sub := filepath.Join(root, "main", "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewAppWithPath(sub)
+	if app.gitError != nil {
+		t.Fatalf("Unexpected git error: %v", app.gitError)
+	}
+	items := app.list.Items()
+	if len(items) != 3 {
+		t.Fatalf("Expected the bare repository and two worktrees, got %d items", len(items))
+	}
+	if !isBareWorktreeItem(&items[0]) || !isMainWorktreeItem(&items[0]) {
+		t.Errorf("Expected the bare repository first, as the main entry, got %+v", items[0].Metadata)
+	}
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	if !strings.Contains(app.View(), "repo.git (bare)") {
+		t.Error("Expected the bare repository to be marked in the list")
+	}
+	for _, action := range actionsForItem(&items[0]) {
+		if action.ID == "open" || action.ID == "delete" {
+			t.Errorf("Bare repository should not offer %q", action.ID)
+		}
+	}
+
+	// Relative paths are siblings of the bare repository, not of the
+	// directory grove was started in
+	app.Update(CreateFormSubmittedMsg{Result: CreateFormResult{Branch: "hotfix", Path: "../hotfix", CreateBranch: true}})
+	if _, err := os.Stat(filepath.Join(root, "hotfix")); err != nil {
+		t.Fatalf("Expected the worktree next to the bare repository: %v (feedback %q)", err, app.feedback.Message())
+	}
+	if app.TargetPath() != filepath.Join(root, "hotfix") {
+		t.Errorf("Expected to cd into the new worktree, got %q", app.TargetPath())
+	}
+	worktrees, err := git.ListWorktrees(bare)
+	if err != nil || len(worktrees) != 4 {
+		t.Errorf("Expected 4 entries after creating a worktree, got %d (%v)", len(worktrees), err)
+	}
+}
+
+// TestAppDeleteTagsBranchTip verifies the branch tip can be tagged before the worktree is deleted.
+func TestAppDeleteTagsBranchTip(t *testing.T) {
+	repo := t.TempDir()
+	wtPath := filepath.Join(t.TempDir(), "wt")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "-b", "feature", wtPath)
+
+	app := NewAppWithPath(repo)
+	item := &ListItem{ID: wtPath, Title: "feature", Metadata: &WorktreeItemData{Path: wtPath, Branch: "feature"}}
+	tag := git.ArchiveTagName("feature", time.Now())
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if app.confirmDialog.TagOption() != tag {
+		t.Fatalf("Expected the dialog to offer tag %q, got %q", tag, app.confirmDialog.TagOption())
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	cmd := app.confirmDialog.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	app.Update(cmd())
+
+	if _, err := os.Stat(wtPath); !os.IsNotExist(err) {
+		t.Errorf("Expected the worktree to be removed, feedback: %q", app.feedback.Message())
+	}
+	if tagged, tip := run("rev-parse", tag), run("rev-parse", "feature"); tagged != tip {
+		t.Errorf("Tag points at %s, want the branch tip %s", tagged, tip)
+	}
+
+	// An existing tag stops the deletion
+	otherPath := filepath.Join(t.TempDir(), "other")
+	run("worktree", "add", otherPath, "feature")
+	item = &ListItem{ID: otherPath, Title: "feature", Metadata: &WorktreeItemData{Path: otherPath, Branch: "feature"}}
+	app.Update(ConfirmDialogResultMsg{Confirmed: true, Tag: tag, Data: item})
+	if _, err := os.Stat(otherPath); err != nil {
+		t.Error("Expected the worktree to be kept when the tag exists")
+	}
+	if app.feedback.Type() != FeedbackError || !strings.Contains(app.feedback.Message(), "already exists") {
+		t.Errorf("Expected a tag-exists error, got %q", app.feedback.Message())
+	}
+}
+
+// TestAppDeleteCurrentWorktreeRefused verifies deleting the worktree grove runs in asks to cd elsewhere
+func TestAppDeleteCurrentWorktreeRefused(t *testing.T) {
+	wtPath := t.TempDir()
+	t.Chdir(wtPath)
+	items := []ListItem{
+		{ID: wtPath, Title: "feature", Metadata: &WorktreeItemData{Path: wtPath, Branch: "feature"}},
+	}
+	app := NewAppWithItems(items)
+
+	action := &Action{ID: "delete", Label: "Delete"}
+	app.Update(ActionExecutedMsg{Action: action, Item: &items[0]})
+
+	if app.confirmDialog.Visible() {
+		t.Error("Confirm dialog should not open for the current worktree")
+	}
+	if app.feedback.Type() != FeedbackError || !strings.Contains(app.feedback.Message(), "cd elsewhere first") {
+		t.Errorf("Expected an error asking to cd elsewhere, got %q", app.feedback.Message())
+	}
+}
+
+// TestAppEnterHidesDeleteForMainWorktree verifies the action menu omits Delete for the main worktree
+func TestAppEnterHidesDeleteForMainWorktree(t *testing.T) {
+	items := []ListItem{
+		{ID: "/repo", Title: "repo", Metadata: &WorktreeItemData{Path: "/repo", IsMain: true}},
+	}
+	app := NewAppWithItems(items)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !app.actionMenu.Visible() {
+		t.Fatal("Action menu should be visible after Enter")
+	}
+	for _, a := range app.actionMenu.Actions() {
+		if a.ID == "delete" {
+			t.Error("Action menu should not offer Delete for the main worktree")
+		}
+	}
+}
+
+// TestAppJumpKeysUpdateDetails verifies 'G' and "gg" move the selection and update details
+func TestAppJumpKeysUpdateDetails(t *testing.T) {
+	items := []ListItem{
+		{ID: "1", Title: "First"},
+		{ID: "2", Title: "Second"},
+		{ID: "3", Title: "Third"},
+	}
+	app := NewAppWithItems(items)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if app.details.Item() == nil || app.details.Item().ID != "3" {
+		t.Error("'G' should select the last item and update details")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if app.details.Item() == nil || app.details.Item().ID != "1" {
+		t.Error("'gg' should select the first item and update details")
+	}
+}
+
+// TestAppPendingGCancelledByOtherKey verifies keys handled by the app cancel a pending 'g'
+func TestAppPendingGCancelledByOtherKey(t *testing.T) {
+	items := []ListItem{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	app := NewAppWithItems(items)
+	app.list.SetSelected(2)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+
+	if app.list.Selected() != 2 {
+		t.Errorf("'g', Esc, 'g' should not jump to top, got %d", app.list.Selected())
+	}
+}
+
+// TestAppDigitJumpOnEnter verifies Enter jumps to a typed item number instead of opening the menu
+func TestAppDigitJumpOnEnter(t *testing.T) {
+	items := []ListItem{{ID: "1", Title: "one"}, {ID: "2", Title: "two"}, {ID: "3", Title: "three"}}
+	app := NewAppWithItems(items)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	if !strings.Contains(app.View(), "Go to item 3") {
+		t.Error("Footer should show the typed number")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.actionMenu.Visible() {
+		t.Error("Enter should complete the number, not open the action menu")
+	}
+	if app.list.Selected() != 2 {
+		t.Errorf("After '3' Enter, Selected() = %d, want 2", app.list.Selected())
+	}
+	if app.details.Item() == nil || app.details.Item().ID != "3" {
+		t.Error("Details should follow the jump")
+	}
+	if strings.Contains(app.View(), "Go to item") {
+		t.Error("Footer should return to the help text after jumping")
+	}
+}
+
+// TestAppDigitJumpAfterDelay verifies the typed number applies once typing stops
+func TestAppDigitJumpAfterDelay(t *testing.T) {
+	items := []ListItem{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	app := NewAppWithItems(items)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	if cmd == nil {
+		t.Fatal("A digit should start the idle delay")
+	}
+	app.Update(DigitJumpMsg{seq: app.list.digitSeq})
+
+	if app.list.Selected() != 1 {
+		t.Errorf("After '2' and the delay, Selected() = %d, want 1", app.list.Selected())
+	}
+}
+
+// TestAppDigitJumpCancelledByEsc verifies Esc discards a typed number
+func TestAppDigitJumpCancelledByEsc(t *testing.T) {
+	items := []ListItem{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	app := NewAppWithItems(items)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app.Update(DigitJumpMsg{seq: app.list.digitSeq})
+
+	if app.list.Selected() != 0 {
+		t.Errorf("Esc should cancel the jump, got %d", app.list.Selected())
+	}
+
+	// Enter opens the menu again once the number is gone
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.actionMenu.Visible() {
+		t.Error("Enter without a typed number should open the action menu")
+	}
+}
+
+// TestAppToggleRowNumbers verifies the Settings toggle numbers the list rows
+func TestAppToggleRowNumbers(t *testing.T) {
+	app := NewAppWithItems([]ListItem{{ID: "1", Title: "one"}, {ID: "2", Title: "two"}})
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	app.Update(SettingToggledMsg{Key: settingRowNumbers, Enabled: true})
+	if !app.config.RowNumbersEnabled() || !strings.Contains(app.View(), "2. two") {
+		t.Error("Enabling row numbers should number the list rows")
+	}
+
+	app.Update(SettingToggledMsg{Key: settingRowNumbers, Enabled: false})
+	if strings.Contains(app.View(), "2. two") {
+		t.Error("Disabling row numbers should remove them")
+	}
+}
+
+// TestAppSaveAndRestoreState verifies the active tab and selection survive a restart
+func TestAppSaveAndRestoreState(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.yaml")
+	items := []ListItem{{ID: "/a", Title: "a"}, {ID: "/b", Title: "b"}, {ID: "/c", Title: "c"}}
+
+	app := NewAppWithItems(items)
+	app.statePath = statePath
+	app.list.SetSelected(2)
+	app.tabs.SetActive(TabBranches)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+
+	restored := NewAppWithItems(items)
+	restored.statePath = statePath
+	restored.restoreState()
+
+	if restored.tabs.Active() != TabBranches {
+		t.Errorf("Expected Branches tab to be restored, got %v", restored.tabs.Active())
+	}
+	if restored.list.Selected() != 2 {
+		t.Errorf("Expected selection 2 to be restored, got %d", restored.list.Selected())
+	}
+	if restored.details.Item() == nil || restored.details.Item().ID != "/c" {
+		t.Error("Restored selection should update details")
+	}
+}
+
+// TestAppSetLabel verifies a label replaces the directory name, is saved to
+// the state file, restored, and cleared by an empty value.
+func TestAppSetLabel(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.yaml")
+	worktrees := []git.Worktree{{Path: "/src/repo", Branch: "main", IsMain: true}, {Path: "/src/repo-x", Branch: "x"}}
+	newLabelApp := func() *App {
+		app := NewAppWithItems(nil)
+		app.statePath = statePath
+		app.setWorktrees(worktrees, nil)
+		app.list.SetSelected(1)
+		return app
+	}
+
+	app := newLabelApp()
+	item := app.list.SelectedItem()
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "label"}, Item: item})
+	if !app.inputPrompt.Visible() {
+		t.Fatal("Expected the label prompt")
+	}
+	app.inputPrompt.SetValue("login rework")
+	cmd := app.inputPrompt.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected the label to be submitted")
+	}
+	app.Update(cmd())
+
+	if got := app.list.SelectedItem().Title; got != "login rework" {
+		t.Errorf("Expected the label as title, got %q", got)
+	}
+	if app.worktrees[1].Name() != "repo-x" {
+		t.Error("The worktree name should stay the directory name")
+	}
+
+	restored := newLabelApp()
+	restored.restoreState()
+	if got := restored.list.Items()[1].Title; got != "login rework" {
+		t.Errorf("Expected the label to be restored, got %q", got)
+	}
+
+	// An empty label shows the directory name again
+	restored.Update(InputPromptSubmittedMsg{Value: "", Data: setLabelRequest{Item: restored.list.SelectedItem()}})
+	if got := restored.list.Items()[1].Title; got != "repo-x" {
+		t.Errorf("Expected the directory name after clearing, got %q", got)
+	}
+	state, err := config.LoadState(statePath)
+	if err != nil {
+		t.Fatalf("Failed to load state: %v", err)
+	}
+	if len(state.Labels) != 0 {
+		t.Errorf("Expected the label to be removed from the state, got %v", state.Labels)
+	}
+}
+
+// TestAppRestoreStateMissingWorktree verifies a vanished worktree leaves the selection unchanged
+func TestAppRestoreStateMissingWorktree(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.yaml")
+	if err := config.SaveState(statePath, config.State{ActiveTab: "Worktrees", SelectedWorktree: "/gone"}); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+
+	app := NewAppWithItems([]ListItem{{ID: "/a"}, {ID: "/b"}})
+	app.statePath = statePath
+	app.restoreState()
+
+	if app.list.Selected() != 0 {
+		t.Errorf("Selection should stay at 0 when the saved worktree is gone, got %d", app.list.Selected())
+	}
+}
+
+// TestAppStateDisabledWithoutPath verifies nothing is written when state is disabled
+func TestAppStateDisabledWithoutPath(t *testing.T) {
+	app := NewAppWithItems([]ListItem{{ID: "/a"}})
+
+	// No state path: quitting must not panic or write anything
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+
+	if app.statePath != "" {
+		t.Error("NewAppWithItems should not enable state persistence")
+	}
+}
+
+// TestAppQuickDeleteDirtyWorktreeConfirms verifies dirty worktrees confirm even with confirm_delete off
+func TestAppQuickDeleteDirtyWorktreeConfirms(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/to/dirty", Title: "dirty", Metadata: &WorktreeItemData{Path: "/path/to/dirty", ModifiedCount: 1, StatusKnown: true}},
+	}
+	app := NewAppWithItems(items)
+	confirm := false
+	app.config.ConfirmDelete = &confirm
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: &items[0]})
+
+	if !app.confirmDialog.Visible() {
+		t.Error("Dirty worktree should always show the confirm dialog")
+	}
+	if !app.confirmDialog.HasForceOption() {
+		t.Error("Force option should remain available for dirty worktrees")
+	}
+}
+
+// TestAppQuickDeleteDefaultConfirms verifies clean worktrees confirm by default
+func TestAppQuickDeleteDefaultConfirms(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/to/clean", Title: "clean", Metadata: &WorktreeItemData{Path: "/path/to/clean"}},
+	}
+	app := NewAppWithItems(items)
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: &items[0]})
+
+	if !app.confirmDialog.Visible() {
+		t.Error("Clean worktree should confirm when confirm_delete is enabled")
+	}
+}
+
+// TestAppQuickDeleteCleanWorktree verifies clean worktrees are removed without a dialog
+func TestAppQuickDeleteCleanWorktree(t *testing.T) {
+	repo := t.TempDir()
+	linked := filepath.Join(t.TempDir(), "linked")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "-b", "linked", linked)
+
+	app := NewAppWithPath(repo)
+	confirm := false
+	app.config.ConfirmDelete = &confirm
+
+	var item *ListItem
+	for i := range app.list.Items() {
+		if wtData, ok := app.list.Items()[i].Metadata.(*WorktreeItemData); ok && !wtData.IsMain {
+			item = &app.list.Items()[i]
+		}
+	}
+	if item == nil {
+		t.Fatal("Linked worktree not found in list")
+	}
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+
+	if app.confirmDialog.Visible() {
+		t.Error("Clean worktree should be deleted without confirmation")
+	}
+	if len(app.Worktrees()) != 1 {
+		t.Errorf("Expected 1 worktree after quick delete, got %d", len(app.Worktrees()))
+	}
+}
+
+// TestAppDeleteWarnsAboutUnpushedCommits verifies the delete dialog warns about commits not pushed yet.
+func TestAppDeleteWarnsAboutUnpushedCommits(t *testing.T) {
+	app := NewApp()
+	confirm := false
+	app.config.ConfirmDelete = &confirm
+
+	// Even a clean worktree asks when it has unpushed commits
+	item := &ListItem{ID: "/path/to/worktree", Title: "feature", Metadata: &WorktreeItemData{Path: "/path/to/worktree", Branch: "feature", Ahead: 3, StatusKnown: true}}
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if !app.confirmDialog.Visible() {
+		t.Fatal("Expected a confirmation for a worktree with unpushed commits")
+	}
+	if !strings.Contains(app.confirmDialog.Message(), "⚠ 3 local commits are not pushed") {
+		t.Errorf("Expected an unpushed commits warning, got %q", app.confirmDialog.Message())
+	}
+
+	app.confirmDialog.Hide()
+	item.Metadata.(*WorktreeItemData).Ahead = 1
+	confirm = true
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if !strings.Contains(app.confirmDialog.Message(), "⚠ 1 local commit is not pushed") {
+		t.Errorf("Expected a singular warning, got %q", app.confirmDialog.Message())
+	}
+
+	app.confirmDialog.Hide()
+	item.Metadata.(*WorktreeItemData).Ahead = 0
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if strings.Contains(app.confirmDialog.Message(), "⚠") {
+		t.Errorf("Expected no warning for a pushed worktree, got %q", app.confirmDialog.Message())
+	}
+}
+
+// TestAppQuickDeleteUnknownStatus verifies a worktree whose status was not read
+// yet, e.g. during a background scan, is checked with git before skipping the dialog.
+func TestAppQuickDeleteUnknownStatus(t *testing.T) {
+	repo := t.TempDir()
+	dirty := filepath.Join(t.TempDir(), "dirty")
+	clean := filepath.Join(t.TempDir(), "clean")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "-b", "dirty", dirty)
+	run("worktree", "add", "-b", "clean", clean)
+	if err := os.WriteFile(filepath.Join(dirty, "notes.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewAppWithPath(repo)
+	confirm := false
+	app.config.ConfirmDelete = &confirm
+
+	// A pending scan leaves the counts at zero until the status arrives
+	app.statusScan = map[string]bool{dirty: true, clean: true}
+	dirtyItem := app.worktreeItem(git.Worktree{Path: dirty, Branch: "dirty"}, nil)
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: &dirtyItem})
+	if !app.confirmDialog.Visible() {
+		t.Fatal("Expected a confirmation for a dirty worktree whose status is still loading")
+	}
+	if !app.confirmDialog.HasStashOption() {
+		t.Error("Expected the stash option for a dirty worktree whose status is still loading")
+	}
+	if _, err := os.Stat(dirty); err != nil {
+		t.Errorf("Expected the dirty worktree to be kept: %v", err)
+	}
+
+	app.confirmDialog.Hide()
+	cleanItem := app.worktreeItem(git.Worktree{Path: clean, Branch: "clean"}, nil)
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: &cleanItem})
+	if app.confirmDialog.Visible() {
+		t.Error("Expected a clean worktree to be deleted without confirmation once git says so")
+	}
+	if _, err := os.Stat(clean); !os.IsNotExist(err) {
+		t.Errorf("Expected the clean worktree to be removed, got %v", err)
+	}
+}
+
+// TestAppUnpushedCommitsLive verifies the count is read from git when the item has no status.
+func TestAppUnpushedCommitsLive(t *testing.T) {
+	origin := t.TempDir()
+	clone := filepath.Join(t.TempDir(), "clone")
+	run := newTestRepo(t, origin)
+	run("clone", origin, clone)
+	runGit(t, clone, "commit", "--allow-empty", "-m", "local one")
+	runGit(t, clone, "commit", "--allow-empty", "-m", "local two")
+
+	app := NewApp()
+	if got := app.unpushedCommits(&ListItem{ID: clone, Title: "clone"}); got != 2 {
+		t.Errorf("Expected 2 unpushed commits, got %d", got)
+	}
+
+	// An item whose status has not been loaded yet reports no ahead count
+	item := app.worktreeItem(git.Worktree{Path: clone, Branch: "master"}, nil)
+	if got := app.unpushedCommits(&item); got != 2 {
+		t.Errorf("Expected 2 unpushed commits for an item without status, got %d", got)
+	}
+}
+
+// TestAppBranchesTabShowsRemoteBranches verifies remote branches are listed only on the Branches tab.
+func TestAppBranchesTabShowsRemoteBranches(t *testing.T) {
+	sampleItems := []ListItem{
+		{ID: "main", Title: "main", Description: "Main worktree"},
+		{ID: "feature-1", Title: "feature-1", Description: "Feature branch"},
+	}
+	app := NewAppWithItems(sampleItems)
+	app.remoteBranches = []string{"origin/feature"}
+
+	app.tabs.SetActive(TabBranches)
+	app.handleTabChanged()
+	if len(app.list.Items()) != len(sampleItems)+1 {
+		t.Fatalf("Expected %d items on Branches tab, got %d", len(sampleItems)+1, len(app.list.Items()))
+	}
+
+	app.tabs.SetActive(TabWorktrees)
+	app.handleTabChanged()
+	if len(app.list.Items()) != len(sampleItems) {
+		t.Errorf("Expected %d items on Worktrees tab, got %d", len(sampleItems), len(app.list.Items()))
+	}
+}
+
+// TestAppTrackActionOpensCreateForm verifies the track action opens the prefilled create form.
+func TestAppTrackActionOpensCreateForm(t *testing.T) {
+	app := NewAppWithItems([]ListItem{{ID: "main", Title: "main"}})
+	item := remoteBranchToListItem("origin/feature/x")
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "track"}, Item: &item})
+
+	if !app.createForm.Visible() {
+		t.Fatal("Expected create form to be visible")
+	}
+	if app.createForm.TrackRemote() != "origin/feature/x" {
+		t.Errorf("Expected form to track 'origin/feature/x', got '%s'", app.createForm.TrackRemote())
+	}
+	if app.createForm.Branch() != "feature/x" {
+		t.Errorf("Expected branch 'feature/x', got '%s'", app.createForm.Branch())
+	}
+	if app.createForm.Path() != filepath.Join("..", "feature-x") {
+		t.Errorf("Expected path '../feature-x', got '%s'", app.createForm.Path())
+	}
+}
+
+// TestAppFetchKeyStartsSpinner verifies F starts a background fetch with a spinner.
+func TestAppFetchKeyStartsSpinner(t *testing.T) {
+	app := NewAppWithItems([]ListItem{{ID: "main", Title: "main"}})
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if cmd == nil {
+		t.Error("Expected fetch command")
+	}
+	if !app.spinner.Active() {
+		t.Error("Expected spinner to be active while fetching")
+	}
+	if !strings.Contains(app.View(), "Fetching") {
+		t.Error("View should show the fetch spinner")
+	}
+
+	// A second press while fetching does nothing
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}}); cmd != nil {
+		t.Error("Expected no command while a fetch is running")
+	}
+}
+
+// TestAppFetchFinished verifies fetch results are reported as feedback.
+func TestAppFetchFinished(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantType FeedbackType
+	}{
+		{"no remote", &git.NoRemoteError{Path: "/repo"}, FeedbackInfo},
+		{"failure", &git.FetchError{Reason: "network down"}, FeedbackError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := NewAppWithItems([]ListItem{{ID: "main", Title: "main"}})
+			app.spinner.Start("Fetching...")
+
+			app.Update(FetchFinishedMsg{Err: tt.err})
+
+			if app.spinner.Active() {
+				t.Error("Spinner should stop when fetch finishes")
+			}
+			if !app.feedback.Visible() || app.feedback.Type() != tt.wantType {
+				t.Errorf("Expected feedback type %v, got %v (visible: %v)", tt.wantType, app.feedback.Type(), app.feedback.Visible())
+			}
+		})
+	}
+}
+
+// TestAppPullAction verifies Pull starts a background pull only for pullable worktrees.
+func TestAppPullAction(t *testing.T) {
+	item := ListItem{ID: "/repo-x", Title: "x", Metadata: &WorktreeItemData{Path: "/repo-x", Branch: "x", Upstream: "origin/x"}}
+	app := NewAppWithItems([]ListItem{item})
+
+	_, cmd := app.Update(ActionExecutedMsg{Action: &Action{ID: "pull"}, Item: &item})
+	if cmd == nil {
+		t.Error("Expected pull command")
+	}
+	if !app.spinner.Active() || !strings.Contains(app.View(), "Pulling x") {
+		t.Error("Expected the pull spinner while pulling")
+	}
+
+	dirty := ListItem{ID: "/repo-y", Title: "y", Metadata: &WorktreeItemData{Path: "/repo-y", Branch: "y", Upstream: "origin/y", ModifiedCount: 2}}
+	app = NewAppWithItems([]ListItem{dirty})
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "pull"}, Item: &dirty})
+	if app.spinner.Active() || app.feedback.Type() != FeedbackError {
+		t.Error("Expected an error instead of a pull for a worktree with local changes")
+	}
+}
+
+// TestAppPullFinished verifies pull results are reported as feedback.
+func TestAppPullFinished(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      PullFinishedMsg
+		wantType FeedbackType
+		contains string
+	}{
+		{"fast-forwarded", PullFinishedMsg{Path: "/repo-x", Output: "Updating abc..def\nFast-forward"}, FeedbackSuccess, "Pulled"},
+		{"up to date", PullFinishedMsg{Path: "/repo-x", Output: "Already up to date."}, FeedbackInfo, "Already up to date"},
+		{"diverged", PullFinishedMsg{Path: "/repo-x", Err: &git.PullError{Path: "/repo-x", Reason: "fatal: Not possible to fast-forward, aborting.", Diverged: true}}, FeedbackError, "diverged"},
+		{"failure", PullFinishedMsg{Path: "/repo-x", Err: &git.PullError{Path: "/repo-x", Reason: "network down"}}, FeedbackError, "network down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := NewAppWithItems([]ListItem{{ID: "/repo-x", Title: "x"}})
+			app.spinner.Start("Pulling x...")
+
+			app.Update(tt.msg)
+
+			if app.spinner.Active() {
+				t.Error("Spinner should stop when pull finishes")
+			}
+			if app.feedback.Type() != tt.wantType || !strings.Contains(app.feedback.Message(), tt.contains) {
+				t.Errorf("Expected %v feedback containing %q, got %v %q", tt.wantType, tt.contains, app.feedback.Type(), app.feedback.Message())
+			}
+		})
+	}
+}
+
+// TestAppPushAction verifies Push starts a background push only for worktrees on a branch.
+func TestAppPushAction(t *testing.T) {
+	item := ListItem{ID: "/repo-x", Title: "x", Metadata: &WorktreeItemData{Path: "/repo-x", Branch: "x"}}
+	app := NewAppWithItems([]ListItem{item})
+
+	_, cmd := app.Update(ActionExecutedMsg{Action: &Action{ID: "push"}, Item: &item})
+	if cmd == nil {
+		t.Error("Expected push command")
+	}
+	if !app.spinner.Active() || !strings.Contains(app.View(), "Pushing x") {
+		t.Error("Expected the push spinner while pushing")
+	}
+
+	detached := ListItem{ID: "/repo-d", Title: "d", Metadata: &WorktreeItemData{Path: "/repo-d", IsDetached: true}}
+	app = NewAppWithItems([]ListItem{detached})
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "push"}, Item: &detached})
+	if app.spinner.Active() || app.feedback.Type() != FeedbackError {
+		t.Error("Expected an error instead of a push for a detached worktree")
+	}
+}
+
+// TestAppPushFinished verifies push results are reported as feedback.
+func TestAppPushFinished(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      PushFinishedMsg
+		wantType FeedbackType
+		contains string
+	}{
+		{"pushed", PushFinishedMsg{Path: "/repo-x", Output: "To origin\n   abc..def  x -> x"}, FeedbackSuccess, "Pushed"},
+		{"set upstream", PushFinishedMsg{Path: "/repo-x", SetUpstream: true, Output: "* [new branch]      x -> x"}, FeedbackSuccess, "set its upstream"},
+		{"up to date", PushFinishedMsg{Path: "/repo-x", Output: "Everything up-to-date"}, FeedbackInfo, "Everything up-to-date"},
+		{"auth", PushFinishedMsg{Path: "/repo-x", Err: &git.PushError{Path: "/repo-x", Reason: "fatal: Authentication failed", Auth: true}}, FeedbackError, "check your credentials"},
+		{"no remote", PushFinishedMsg{Path: "/repo-x", Err: &git.PushError{Path: "/repo-x", Reason: "fatal: 'origin' does not appear to be a git repository", NoRemote: true}}, FeedbackError, "No 'origin' remote"},
+		{"rejected", PushFinishedMsg{Path: "/repo-x", Err: &git.PushError{Path: "/repo-x", Reason: "! [rejected] x -> x (fetch first)"}}, FeedbackError, "rejected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := NewAppWithItems([]ListItem{{ID: "/repo-x", Title: "x"}})
+			app.spinner.Start("Pushing x...")
+
+			app.Update(tt.msg)
+
+			if app.spinner.Active() {
+				t.Error("Spinner should stop when push finishes")
+			}
+			if app.feedback.Type() != tt.wantType || !strings.Contains(app.feedback.Message(), tt.contains) {
+				t.Errorf("Expected %v feedback containing %q, got %v %q", tt.wantType, tt.contains, app.feedback.Type(), app.feedback.Message())
+			}
+		})
+	}
+}
+
+// TestAppViewDimmedBehindModal verifies the panes lose their colors while a modal is open.
+func TestAppViewDimmedBehindModal(t *testing.T) {
+	previous := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { lipgloss.SetColorProfile(previous) })
+
+	items := []ListItem{
+		{ID: "/repo", Title: "main", Metadata: &WorktreeItemData{Path: "/repo", Branch: "main", IsMain: true}},
+		{ID: "/repo-a", Title: "a", Metadata: &WorktreeItemData{Path: "/repo-a", Branch: "a", ModifiedCount: 1}},
+	}
+	app := NewAppWithItems(items)
+	app.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	panes := app.renderTwoPaneLayout()
+	dimmed := app.renderDimmed(panes)
+	if dimmed == panes || !strings.Contains(dimmed, "\x1b[") {
+		t.Fatal("Expected dimming to replace the pane colors with the muted color")
+	}
+	if !strings.Contains(ansi.Strip(dimmed), "main") {
+		t.Error("Expected the dimmed panes to keep their text")
+	}
+
+	if view := app.View(); !strings.Contains(view, panes) || strings.Contains(view, dimmed) {
+		t.Error("Expected undimmed panes without a modal")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.actionMenu.Visible() {
+		t.Fatal("Expected the action menu")
+	}
+	if view := app.View(); !strings.Contains(view, app.renderDimmed(app.renderTwoPaneLayout())) {
+		t.Error("Expected the panes to be dimmed behind the action menu")
+	}
+
+	app.actionMenu.Hide()
+	app.confirmDialog.Show("Title", "Message")
+	if view := app.View(); !strings.Contains(view, app.renderDimmed(app.renderTwoPaneLayout())) {
+		t.Error("Expected the panes to be dimmed behind the confirm dialog")
+	}
+}
+
+// TestAppViewSmallTerminals verifies tiny terminal sizes render without panicking.
+func TestAppViewSmallTerminals(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/to/main", Title: "main", Metadata: &WorktreeItemData{Path: "/path/to/main", Branch: "main"}},
+	}
+	sizes := []struct{ width, height int }{
+		{0, 0}, {1, 1}, {5, 3}, {19, 20}, {30, 8}, {39, 40}, {80, 9},
+	}
+
+	for _, size := range sizes {
+		app := NewAppWithItems(items)
+		app.Update(tea.WindowSizeMsg{Width: size.width, Height: size.height})
+		app.createForm.Show()
+
+		view := app.View()
+		if view == "" {
+			t.Errorf("View() at %dx%d returned empty output", size.width, size.height)
+		}
+		if app.list.width < 0 || app.details.width < 0 {
+			t.Errorf("Negative pane width at %dx%d", size.width, size.height)
+		}
+	}
+}
+
+// TestAppViewCompactLayout verifies narrow terminals show the list only.
+func TestAppViewCompactLayout(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/to/main", Title: "main", Metadata: &WorktreeItemData{Path: "/path/to/main", Branch: "main"}},
+	}
+
+	app := NewAppWithItems(items)
+	app.Update(tea.WindowSizeMsg{Width: 30, Height: 20})
+	view := app.View()
+	if !strings.Contains(view, "main") {
+		t.Error("Compact view should still show the list")
+	}
+	if strings.Contains(view, "Path") {
+		t.Error("Compact view should hide the details pane")
+	}
+
+	app.Update(tea.WindowSizeMsg{Width: 10, Height: 3})
+	if !strings.Contains(app.View(), "Terminal too small") {
+		t.Error("Tiny terminal should show 'Terminal too small'")
+	}
+}
+
+// TestAppLayoutOverride verifies a chosen layout wins over the width-based layout
+func TestAppLayoutOverride(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/to/main", Title: "main", Metadata: &WorktreeItemData{Path: "/path/to/main", Branch: "main"}},
+	}
+
+	// Narrow terminals stack the panes automatically
+	app := NewAppWithItems(items)
+	app.Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	if app.currentLayout() != LayoutVertical || app.details.width != 60 {
+		t.Errorf("Expected stacked panes at width 60, got %v with details width %d", app.currentLayout(), app.details.width)
+	}
+
+	app.SetLayout(LayoutHorizontal)
+	if app.currentLayout() != LayoutHorizontal || app.list.width != 60*40/100 {
+		t.Errorf("Horizontal override should place panes side by side, got list width %d", app.list.width)
+	}
+
+	// Wide terminals place them side by side unless vertical is chosen
+	app = NewAppWithItems(items)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	if app.currentLayout() != LayoutHorizontal {
+		t.Errorf("Expected side-by-side panes at width 120, got %v", app.currentLayout())
+	}
+
+	app.SetLayout(LayoutVertical)
+	if app.list.width != 120 || app.details.width != 120 {
+		t.Errorf("Vertical override should give both panes full width, got %d and %d", app.list.width, app.details.width)
+	}
+	view := app.View()
+	if strings.Index(view, "main") > strings.Index(view, "Path") {
+		t.Error("Vertical layout should render the list above the details")
+	}
+
+	// The override survives a resize
+	app.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	if app.currentLayout() != LayoutVertical {
+		t.Error("Layout override should survive a resize")
+	}
+}
+
+// TestAppCompactLayoutHidesDetails verifies the compact layout drops the
+// details pane and shows it on demand.
+func TestAppCompactLayoutHidesDetails(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/to/main", Title: "main", Description: "main", Metadata: &WorktreeItemData{Path: "/path/to/main", Branch: "main"}},
+		{ID: "/path/to/feature", Title: "feature", Description: "feature-x", Metadata: &WorktreeItemData{Path: "/path/to/feature", Branch: "feature-x", ModifiedCount: 1}},
+	}
+
+	app := NewAppWithItems(items)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	app.SetLayout(LayoutCompact)
+	if app.list.width != 120 || app.details.width != 0 {
+		t.Errorf("Expected a full-width list and no details, got widths %d and %d", app.list.width, app.details.width)
+	}
+	view := app.View()
+	if strings.Contains(view, "Path") {
+		t.Error("Compact layout should hide the details pane")
+	}
+	if !strings.Contains(view, "feature-x  ~1") {
+		t.Error("Compact layout should show the branch and status on each row")
+	}
+
+	// The details pane cannot take focus while hidden
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	if app.focusedPane != PaneList {
+		t.Error("Hidden details pane should not take focus")
+	}
+
+	// i shows the selected item's details below the list, Esc hides them
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if !strings.Contains(app.View(), "Path") || app.details.width != 120 {
+		t.Error("Expected i to show the details popover")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if strings.Contains(app.View(), "Path") || app.details.width != 0 {
+		t.Error("Expected Esc to hide the details popover")
+	}
+
+	// Other layouts keep the details pane and plain rows
+	app.SetLayout(LayoutHorizontal)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	view = app.View()
+	if !strings.Contains(view, "Path") || strings.Contains(view, "feature-x  ~1") {
+		t.Error("Horizontal layout should show the details pane beside plain rows")
+	}
+}
+
+// TestAppLayoutKeyCycles verifies L cycles the layout and saves it in the state file
+func TestAppLayoutKeyCycles(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.yaml")
+	items := []ListItem{{ID: "/a", Title: "a"}}
+
+	app := NewAppWithItems(items)
+	app.statePath = statePath
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	if app.Layout() != LayoutVertical {
+		t.Fatalf("Expected vertical layout after pressing L twice, got %v", app.Layout())
+	}
+	if !strings.Contains(app.feedback.Message(), "vertical") {
+		t.Errorf("Expected feedback naming the layout, got %q", app.feedback.Message())
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+
+	restored := NewAppWithItems(items)
+	restored.statePath = statePath
+	restored.restoreState()
+	if restored.Layout() != LayoutVertical {
+		t.Errorf("Expected vertical layout to be restored, got %v", restored.Layout())
+	}
+}
+
+// TestAppDetailsFocusScrolls verifies navigation keys scroll the focused details pane.
+func TestAppDetailsFocusScrolls(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/a", Title: "a", Metadata: &WorktreeItemData{Path: "/path/a", Branch: "a"}},
+		{ID: "/path/b", Title: "b", Metadata: &WorktreeItemData{Path: "/path/b", Branch: "b"}},
+	}
+	app := NewAppWithItems(items)
+	app.Update(tea.WindowSizeMsg{Width: 100, Height: 12})
+	app.View()
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	if !app.details.Focused() {
+		t.Fatal("Expected details to be focused after '>'")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if app.list.Selected() != 0 {
+		t.Error("List selection should not move while details are focused")
+	}
+	if app.details.Offset() != 1 {
+		t.Errorf("Expected details offset 1, got %d", app.details.Offset())
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'<'}})
+	if app.details.Focused() {
+		t.Error("Expected list focus after '<'")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if app.list.Selected() != 1 {
+		t.Error("List navigation should resume after returning focus")
+	}
+}
+
+// TestAppPaneFocusKeys verifies Ctrl+L and Ctrl+H move focus between panes.
+func TestAppPaneFocusKeys(t *testing.T) {
+	app := NewAppWithItems([]ListItem{{ID: "/path/a", Title: "a"}})
+	app.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+
+	if app.FocusedPane() != PaneList {
+		t.Fatal("List should have focus by default")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if app.FocusedPane() != PaneDetails || !app.details.Focused() || app.list.Focused() {
+		t.Error("Ctrl+L should focus the details pane")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlH})
+	if app.FocusedPane() != PaneList || app.details.Focused() || !app.list.Focused() {
+		t.Error("Ctrl+H should focus the list pane")
+	}
+
+	// Switching tabs returns focus to the list
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if app.FocusedPane() != PaneList {
+		t.Error("Switching tabs should return focus to the list")
+	}
+}
+
+// TestAppRecentCommitsLoadedOnce verifies commits load for the selected worktree and are cached.
+func TestAppRecentCommitsLoadedOnce(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/a", Title: "a", Metadata: &WorktreeItemData{Path: "/path/a", Branch: "a"}},
+		{ID: "/path/b", Title: "b", Metadata: &WorktreeItemData{Path: "/path/b", Branch: "b"}},
+	}
+	app := NewAppWithItems(items)
+	app.details.SetItem(app.list.SelectedItem())
+
+	if cmd := app.Init(); cmd == nil {
+		t.Fatal("Expected Init to return a command")
+	}
+	if !app.commitsLoading["/path/a"] {
+		t.Fatal("Expected commits of the selected worktree to be loading")
+	}
+
+	// Moving to another worktree loads only that one
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if !app.commitsLoading["/path/b"] {
+		t.Error("Expected commits of the newly selected worktree to be loading")
+	}
+
+	commits := []git.CommitInfo{{Hash: "abc1234", RelativeDate: "1 hour ago", Subject: "Initial"}}
+	app.Update(RecentCommitsLoadedMsg{Path: "/path/a", Commits: commits})
+	app.Update(RecentCommitsLoadedMsg{Path: "/path/b", Err: &git.NotGitRepoError{Path: "/path/b"}})
+
+	// Re-selecting a cached worktree shows its commits without another load
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if cmd != nil {
+		t.Error("Expected no command when commits are cached")
+	}
+	if !strings.Contains(app.details.View(), "Initial") {
+		t.Error("Expected cached commits in the details pane")
+	}
+}
+
+// TestAppRecentCommitsDisabled verifies recent_commits: 0 skips loading.
+func TestAppRecentCommitsDisabled(t *testing.T) {
+	app := NewAppWithItems([]ListItem{
+		{ID: "/path/a", Title: "a", Metadata: &WorktreeItemData{Path: "/path/a", Branch: "a"}},
+	})
+	zero := 0
+	app.config.RecentCommits = &zero
+	app.details.SetItem(app.list.SelectedItem())
+
+	if cmd := app.ensureRecentCommits(); cmd != nil {
+		t.Error("Expected no command when recent commits are disabled")
+	}
+}
+
+// TestAppDiskUsageLoadedOnce verifies the size of the selected worktree is computed once and shown.
+func TestAppDiskUsageLoadedOnce(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/a", Title: "a", Metadata: &WorktreeItemData{Path: "/path/a", Branch: "a"}},
+		{ID: "/path/b", Title: "b", Metadata: &WorktreeItemData{Path: "/path/b", Branch: "b"}},
+	}
+	app := NewAppWithItems(items)
+	app.details.SetItem(app.list.SelectedItem())
+
+	app.Init()
+	if !app.diskUsageLoading["/path/a"] {
+		t.Fatal("Expected disk usage of the selected worktree to be loading")
+	}
+	if app.diskUsageLoading["/path/b"] {
+		t.Error("Disk usage of unselected worktrees should not be computed")
+	}
+
+	app.Update(DiskUsageLoadedMsg{Path: "/path/a", Size: 3 * 1024 * 1024})
+	if cmd := app.ensureDiskUsage(); cmd != nil {
+		t.Error("Expected no command when disk usage is cached")
+	}
+	if !strings.Contains(app.details.View(), "3.0 MB") {
+		t.Error("Expected the cached disk usage in the details pane")
+	}
+
+	// A failed computation hides the size and is not retried
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app.Update(DiskUsageLoadedMsg{Path: "/path/b", Err: &git.NotGitRepoError{Path: "/path/b"}})
+	if cmd := app.ensureDiskUsage(); cmd != nil {
+		t.Error("Expected no retry after a failed computation")
+	}
+	if strings.Contains(app.details.View(), "Disk usage") {
+		t.Error("Disk usage should be hidden when it could not be computed")
+	}
+}
+
+// TestAppDiskUsageDisabled verifies disk_usage: false skips computing sizes.
+func TestAppDiskUsageDisabled(t *testing.T) {
+	app := NewAppWithItems([]ListItem{
+		{ID: "/path/a", Title: "a", Metadata: &WorktreeItemData{Path: "/path/a", Branch: "a"}},
+	})
+	disabled := false
+	app.config.DiskUsage = &disabled
+	app.details.SetItem(app.list.SelectedItem())
+
+	if cmd := app.ensureDiskUsage(); cmd != nil {
+		t.Error("Expected no command when disk usage is disabled")
+	}
+}
+
+// TestAppStashCountLoadedOnce verifies stashes are counted for the selected worktree only.
+func TestAppStashCountLoadedOnce(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/a", Title: "a", Metadata: &WorktreeItemData{Path: "/path/a", Branch: "a"}},
+		{ID: "/path/b", Title: "b", Metadata: &WorktreeItemData{Path: "/path/b", Branch: "b"}},
+	}
+	app := NewAppWithItems(items)
+	app.details.SetItem(app.list.SelectedItem())
+
+	app.Init()
+	if !app.stashLoading["/path/a"] || app.stashLoading["/path/b"] {
+		t.Fatal("Expected only the selected worktree's stashes to be loading")
+	}
+
+	app.Update(StashCountLoadedMsg{Path: "/path/a", Count: 2})
+	if cmd := app.ensureStashCount(); cmd != nil {
+		t.Error("Expected no command when the stash count is cached")
+	}
+	if !strings.Contains(app.details.View(), "2 stashes") {
+		t.Error("Expected the stash count in the details pane")
+	}
+
+	// Zero stashes omit the line
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app.Update(StashCountLoadedMsg{Path: "/path/b", Count: 0})
+	app.ensureStashCount()
+	if strings.Contains(app.details.View(), "stash") {
+		t.Error("Stash line should be omitted when there are no stashes")
+	}
+}
+
+// TestAppDiffStatLoadedOnce verifies the diff stat is loaded for the selected
+// worktree, cached, and reloaded after its status was refreshed.
+func TestAppDiffStatLoadedOnce(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/a", Title: "a", Metadata: &WorktreeItemData{Path: "/path/a", Branch: "a"}},
+		{ID: "/path/b", Title: "b", Metadata: &WorktreeItemData{Path: "/path/b", Branch: "b"}},
+	}
+	app := NewAppWithItems(items)
+	app.details.SetItem(app.list.SelectedItem())
+
+	app.Init()
+	if !app.diffStatLoading["/path/a"] || app.diffStatLoading["/path/b"] {
+		t.Fatal("Expected only the selected worktree's diff stat to be loading")
+	}
+
+	app.Update(DiffStatLoadedMsg{Path: "/path/a", Files: 4, Insertions: 120, Deletions: 33})
+	if cmd := app.ensureDiffStat(); cmd != nil {
+		t.Error("Expected no command when the diff stat is cached")
+	}
+	if !strings.Contains(app.details.View(), "Δ 4 files, +120 −33") {
+		t.Error("Expected the diff stat in the details pane")
+	}
+
+	// A refreshed status drops the cached diff stat of that worktree
+	app.handleWorktreeStatusRefreshed(WorktreeStatusRefreshedMsg{Path: "/path/a"})
+	if _, ok := app.diffStatCache["/path/a"]; ok {
+		t.Error("Expected the diff stat to be dropped after a status refresh")
+	}
+	if cmd := app.ensureDiffStat(); cmd == nil {
+		t.Error("Expected the diff stat to be reloaded")
+	}
+}
+
+// TestAppToggleReviewed verifies x marks the selected worktree as reviewed
+// and the mark survives a restart.
+func TestAppToggleReviewed(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.yaml")
+	worktrees := []git.Worktree{{Path: "/src/repo", Branch: "main", IsMain: true}, {Path: "/src/repo-x", Branch: "x"}}
+	newReviewApp := func() *App {
+		app := NewAppWithItems(nil)
+		app.statePath = statePath
+		app.setWorktrees(worktrees, nil)
+		app.list.SetSelected(1)
+		app.details.SetItem(app.list.SelectedItem())
+		return app
+	}
+	isReviewed := func(app *App) bool {
+		wtData, ok := app.list.Items()[1].Metadata.(*WorktreeItemData)
+		return ok && wtData.Reviewed
+	}
+
+	app := newReviewApp()
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if !isReviewed(app) {
+		t.Fatal("Expected x to mark the worktree as reviewed")
+	}
+	if !strings.Contains(app.list.View(), "repo-x ✓") {
+		t.Error("Expected the reviewed mark in the list")
+	}
+	if !strings.Contains(app.details.View(), "✓ Reviewed") {
+		t.Error("Expected the reviewed mark in the details pane")
+	}
+
+	restored := newReviewApp()
+	restored.restoreState()
+	if !isReviewed(restored) {
+		t.Fatal("Expected the reviewed mark to be restored")
+	}
+	if wtData := restored.list.Items()[0].Metadata.(*WorktreeItemData); wtData.Reviewed {
+		t.Error("Only the marked worktree should be reviewed")
+	}
+
+	// Pressing x again clears the mark
+	restored.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if isReviewed(restored) {
+		t.Error("Expected x to clear the reviewed mark")
+	}
+	state, err := config.LoadState(statePath)
+	if err != nil {
+		t.Fatalf("Failed to load state: %v", err)
+	}
+	if len(state.Reviewed) != 0 {
+		t.Errorf("Expected the mark to be removed from the state, got %v", state.Reviewed)
+	}
+}
+
+// TestAppSubmoduleStatusSkippedWithoutGitmodules verifies submodules are only
+// loaded for a selected worktree with a .gitmodules file.
+func TestAppSubmoduleStatusSkippedWithoutGitmodules(t *testing.T) {
+	plain := t.TempDir()
+	withSubmodules := t.TempDir()
+	if err := os.WriteFile(filepath.Join(withSubmodules, ".gitmodules"), []byte("[submodule \"lib\"]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .gitmodules: %v", err)
+	}
+	items := []ListItem{
+		{ID: plain, Title: "plain", Metadata: &WorktreeItemData{Path: plain, Branch: "a"}},
+		{ID: withSubmodules, Title: "sub", Metadata: &WorktreeItemData{Path: withSubmodules, Branch: "b"}},
+	}
+	app := NewAppWithItems(items)
+	app.details.SetItem(app.list.SelectedItem())
+
+	if cmd := app.ensureSubmoduleStatus(); cmd != nil {
+		t.Error("Expected no submodule command without a .gitmodules file")
+	}
+	if _, ok := app.submoduleCache[plain]; !ok {
+		t.Error("Expected a worktree without submodules to be cached as such")
+	}
+
+	app.list.MoveDown()
+	app.details.SetItem(app.list.SelectedItem())
+	if cmd := app.ensureSubmoduleStatus(); cmd == nil {
+		t.Fatal("Expected a submodule command for a worktree with a .gitmodules file")
+	}
+	app.Update(SubmoduleStatusLoadedMsg{Path: withSubmodules, Status: git.SubmoduleStatus{Total: 2, Uninitialized: 2}})
+	if !strings.Contains(app.details.View(), "2 submodules: 2 uninitialized") {
+		t.Error("Expected the submodule summary in the details pane")
+	}
+}
+
+// TestAppJumpToDirtyWorktree verifies ] and [ select worktrees with changes.
+func TestAppJumpToDirtyWorktree(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/a", Title: "a", Metadata: &WorktreeItemData{Path: "/path/a"}},
+		{ID: "/path/b", Title: "b", Metadata: &WorktreeItemData{Path: "/path/b", UntrackedCount: 1}},
+		{ID: "/path/c", Title: "c", Metadata: &WorktreeItemData{Path: "/path/c"}},
+	}
+	app := NewAppWithItems(items)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	if item := app.list.SelectedItem(); item == nil || item.ID != "/path/b" {
+		t.Fatalf("Expected ] to select the dirty worktree, got %v", item)
+	}
+	if item := app.details.Item(); item == nil || item.ID != "/path/b" {
+		t.Error("Expected the details pane to show the dirty worktree")
+	}
+
+	app.list.SetSelected(2)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	if item := app.list.SelectedItem(); item == nil || item.ID != "/path/b" {
+		t.Errorf("Expected [ to select the dirty worktree, got %v", item)
+	}
+
+	clean := NewAppWithItems(items[:1])
+	clean.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	if !strings.Contains(clean.feedback.View(), "No worktrees with changes") {
+		t.Error("Expected a message when no worktree has changes")
+	}
+}
+
+// TestAppBranchFromHere verifies the branch action prefills the base from the worktree.
+func TestAppBranchFromHere(t *testing.T) {
+	tests := []struct {
+		name string
+		data *WorktreeItemData
+		base string
+	}{
+		{"branch", &WorktreeItemData{Path: "/repo", Branch: "main", CommitHash: "abc1234", IsMain: true}, "main"},
+		{"detached", &WorktreeItemData{Path: "/repo-x", CommitHash: "def5678", IsDetached: true}, "def5678"},
+	}
+
+	for _, tt := range tests {
+		item := ListItem{ID: tt.data.Path, Title: tt.name, Metadata: tt.data}
+		app := NewAppWithItems([]ListItem{item})
+
+		app.Update(ActionExecutedMsg{Action: &Action{ID: "branch"}, Item: &item})
+
+		if !app.createForm.Visible() {
+			t.Errorf("%s: create form should open", tt.name)
+			continue
+		}
+		if got := app.createForm.BaseBranch(); got != tt.base {
+			t.Errorf("%s: expected base %q, got %q", tt.name, tt.base, got)
+		}
+	}
+
+	// Without a branch or commit there is nothing to branch from
+	item := ListItem{ID: "/repo-y", Title: "y", Metadata: &WorktreeItemData{Path: "/repo-y"}}
+	app := NewAppWithItems([]ListItem{item})
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "branch"}, Item: &item})
+	if app.createForm.Visible() || app.feedback.Type() != FeedbackError {
+		t.Error("Expected an error when the worktree has no branch or commit")
+	}
+}
+
+// TestAppNewFromDefaultBranch verifies N opens the create form to branch off the detected default branch.
+func TestAppNewFromDefaultBranch(t *testing.T) {
+	repo := t.TempDir()
+	run := newTestRepo(t, repo)
+	run("branch", "-M", "master")
+	run("checkout", "-b", "topic")
+
+	app := NewAppWithPath(repo)
+	// update skips the detail loaders batched in by Update
+	_, cmd := app.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if cmd == nil {
+		t.Fatal("N should return a command finding the default branch")
+	}
+	app.Update(cmd())
+
+	if !app.createForm.Visible() {
+		t.Fatalf("Create form should open, feedback: %q", app.feedback.Message())
+	}
+	if !app.createForm.CreateBranchEnabled() {
+		t.Error("Create form should create a new branch")
+	}
+	if got := app.createForm.BaseBranch(); got != "master" {
+		t.Errorf("Expected base master, got %q", got)
+	}
+	if app.createForm.Branch() != "" {
+		t.Errorf("Only the new branch name should be left to type, got %q", app.createForm.Branch())
+	}
+
+	// Without a default branch there is nothing to branch off
+	app.createForm.Hide()
+	app.Update(DefaultBaseLoadedMsg{})
+	if app.createForm.Visible() || app.feedback.Type() != FeedbackError {
+		t.Error("Expected an error when there is no default branch")
+	}
+}
+
+// TestAppConvertDetachedToBranch verifies the convert action prompts for a name and switches to the branch.
+func TestAppConvertDetachedToBranch(t *testing.T) {
+	repo := t.TempDir()
+	linked := filepath.Join(t.TempDir(), "linked")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "--detach", linked)
+
+	app := NewAppWithPath(repo)
+	item := ListItem{ID: linked, Title: "linked", Metadata: &WorktreeItemData{Path: linked, IsDetached: true}}
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "convert"}, Item: &item})
+	if !app.inputPrompt.Visible() {
+		t.Fatal("Convert should open the branch name prompt")
+	}
+
+	// Invalid names are rejected in the prompt
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("bad..name")})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.inputPrompt.Visible() || app.inputPrompt.ErrorMessage() == "" {
+		t.Fatal("Invalid branch name should keep the prompt open with an error")
+	}
+
+	app.inputPrompt.SetValue("keep-work")
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Submitting the prompt should return a command")
+	}
+	app.Update(cmd())
+
+	if app.feedback.Type() != FeedbackSuccess {
+		t.Errorf("Expected success feedback, got %q", app.feedback.Message())
+	}
+	found := false
+	for _, wt := range app.Worktrees() {
+		found = found || (wt.Branch == "keep-work" && !wt.IsDetached)
+	}
+	if !found {
+		t.Error("Reloaded worktrees should show the linked worktree on branch keep-work")
+	}
+
+	// Converting again with the same name reports the existing branch
+	app.convertToBranch(&item, "keep-work")
+	if app.feedback.Type() != FeedbackError || !strings.Contains(app.feedback.Message(), "already exists") {
+		t.Errorf("Expected an already-exists error, got %q", app.feedback.Message())
+	}
+}
+
+// TestAppRenameBranch verifies renaming a checked-out branch refreshes the list with the new name.
+func TestAppRenameBranch(t *testing.T) {
+	repo := t.TempDir()
+	linked := filepath.Join(t.TempDir(), "linked")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "-b", "old-name", linked)
+	run("branch", "taken")
+
+	app := NewAppWithPath(repo)
+	var item *ListItem
+	for i := range app.worktreeItems {
+		if wtData := app.worktreeItems[i].Metadata.(*WorktreeItemData); wtData.Branch == "old-name" {
+			item = &app.worktreeItems[i]
+		}
+	}
+	if item == nil {
+		t.Fatal("Expected a worktree on old-name")
+	}
+	app.list.SelectByID(item.ID)
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "rename"}, Item: item})
+	if !app.inputPrompt.Visible() || app.inputPrompt.Value() != "old-name" {
+		t.Fatalf("Rename should open the prompt with the current name, got %q", app.inputPrompt.Value())
+	}
+
+	app.inputPrompt.SetValue("new-name")
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Submitting the prompt should return a command")
+	}
+	app.Update(cmd())
+
+	if app.feedback.Type() != FeedbackSuccess {
+		t.Errorf("Expected success feedback, got %q", app.feedback.Message())
+	}
+	selected := app.list.SelectedItem()
+	if selected == nil || selected.Metadata.(*WorktreeItemData).Branch != "new-name" {
+		t.Errorf("Expected the renamed worktree to stay selected with its new branch, got %+v", selected)
+	}
+
+	// Renaming to an existing branch reports the conflict
+	app.renameBranch(selected, "new-name", "taken")
+	if app.feedback.Type() != FeedbackError || !strings.Contains(app.feedback.Message(), "already exists") {
+		t.Errorf("Expected an already-exists error, got %q", app.feedback.Message())
+	}
+}
+
+// TestAppRenameRequiresBranch verifies detached worktrees have no branch to rename.
+func TestAppRenameRequiresBranch(t *testing.T) {
+	item := ListItem{ID: "/repo-x", Title: "x", Metadata: &WorktreeItemData{Path: "/repo-x", IsDetached: true}}
+	app := NewAppWithItems([]ListItem{item})
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "rename"}, Item: &item})
+	if app.inputPrompt.Visible() || app.feedback.Type() != FeedbackError {
+		t.Error("Expected an error instead of a prompt for a detached worktree")
+	}
+}
+
+// TestAppConvertRequiresDetachedHead verifies worktrees on a branch are not converted.
+func TestAppConvertRequiresDetachedHead(t *testing.T) {
+	item := ListItem{ID: "/repo-x", Title: "x", Metadata: &WorktreeItemData{Path: "/repo-x", Branch: "x"}}
+	app := NewAppWithItems([]ListItem{item})
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "convert"}, Item: &item})
+	if app.inputPrompt.Visible() || app.feedback.Type() != FeedbackError {
+		t.Error("Expected an error instead of a prompt for a worktree on a branch")
+	}
+}
+
+// TestAppEmptyStateHint verifies a quick-create hint when only the main worktree exists.
+func TestAppEmptyStateHint(t *testing.T) {
+	app := NewAppWithItems([]ListItem{
+		{ID: "/repo", Title: "repo", Metadata: &WorktreeItemData{Path: "/repo", Branch: "main", IsMain: true}},
+	})
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	view := app.View()
+	if !strings.Contains(view, "No additional worktrees yet") {
+		t.Error("View should show the empty-state hint")
+	}
+	if !strings.Contains(view, "repo (main)") {
+		t.Error("The main worktree should stay visible")
+	}
+
+	// The hint is specific to the Worktrees tab
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if strings.Contains(app.View(), "No additional worktrees yet") {
+		t.Error("Hint should not show on other tabs")
+	}
+}
+
+// TestAppNoEmptyStateHintWithLinkedWorktrees verifies the hint is hidden once worktrees exist.
+func TestAppNoEmptyStateHintWithLinkedWorktrees(t *testing.T) {
+	app := NewAppWithItems([]ListItem{
+		{ID: "/repo", Title: "repo", Metadata: &WorktreeItemData{Path: "/repo", Branch: "main", IsMain: true}},
+		{ID: "/repo-x", Title: "repo-x", Metadata: &WorktreeItemData{Path: "/repo-x", Branch: "x"}},
+	})
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	if strings.Contains(app.View(), "No additional worktrees yet") {
+		t.Error("Hint should be hidden when linked worktrees exist")
+	}
+}
+
+// TestAppToggleCountUntracked verifies the Settings toggle switches untracked counting at runtime.
+func TestAppToggleCountUntracked(t *testing.T) {
+	t.Cleanup(func() { git.SetCountUntracked(true) })
+
+	app := NewAppWithItems(nil)
+	app.tabs.SetActive(TabSettings)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if cmd == nil {
+		t.Fatal("Expected a command after toggling")
+	}
+	app.Update(cmd())
+
+	if app.config.CountUntrackedEnabled() || git.CountUntracked() {
+		t.Error("Untracked files should no longer be counted")
+	}
+	if !app.feedback.Visible() || !strings.Contains(app.feedback.Message(), "no longer count") {
+		t.Errorf("Expected feedback about the toggle, got %q", app.feedback.Message())
+	}
+
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(cmd())
+	if !app.config.CountUntrackedEnabled() || !git.CountUntracked() {
+		t.Error("Untracked files should be counted again")
+	}
+}
+
+// TestAppViewShowsGitTimeout verifies View reports a timed out git load
+func TestAppViewShowsGitTimeout(t *testing.T) {
+	app := NewAppWithItems(nil)
+	app.gitError = &git.CommandError{Args: []string{"worktree", "list"}, Err: git.ErrTimeout}
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	view := app.View()
+
+	if !strings.Contains(view, "Git timed out") {
+		t.Error("View should report that git timed out")
+	}
+	if strings.Contains(view, "Not a Git Repository") {
+		t.Error("A timeout should not be reported as a missing repository")
+	}
+}
+
+// TestNewGitContext verifies the git timeout is applied to the context
+func TestNewGitContext(t *testing.T) {
+	ctx, cancel := newGitContext(0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("A zero timeout should not set a deadline")
+	}
+
+	ctx, cancel = newGitContext(time.Minute)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("A positive timeout should set a deadline")
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > time.Minute {
+		t.Errorf("Deadline should be within a minute, got %v", remaining)
+	}
+}
+
+// TestAppActionRequiresNewerGit verifies actions needing a newer git report the requirement
+func TestAppActionRequiresNewerGit(t *testing.T) {
+	items := []ListItem{{ID: "/path/a", Title: "a"}}
+	app := NewAppWithItems(items)
+	app.gitVersion = func() (int, int, int, error) { return 2, 25, 1, nil }
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "repair", Label: "Repair"}, Item: &items[0]})
+
+	if !app.feedback.Visible() || app.feedback.Type() != FeedbackError {
+		t.Fatal("Expected error feedback for an unsupported git version")
+	}
+	if !strings.Contains(app.feedback.Message(), "requires git ≥ 2.30") {
+		t.Errorf("Feedback should name the required version, got %q", app.feedback.Message())
+	}
+}
+
+// TestAppActionAllowedWithNewGit verifies the version check passes for supported git
+func TestAppActionAllowedWithNewGit(t *testing.T) {
+	app := NewAppWithItems(nil)
+	app.gitVersion = func() (int, int, int, error) { return 2, 39, 3, nil }
+
+	for id := range actionGitRequirements {
+		if cmd := app.checkActionGitVersion(&Action{ID: id, Label: id}); cmd != nil {
+			t.Errorf("Action %q should be allowed with git 2.39", id)
+		}
+	}
+	if cmd := app.checkActionGitVersion(&Action{ID: "open", Label: "Open"}); cmd != nil {
+		t.Error("Actions without a requirement should always be allowed")
+	}
+}
+
+// TestAppDeleteToTrash verifies trash mode says so in the dialog and moves the directory to the trash.
+func TestAppDeleteToTrash(t *testing.T) {
+	repo := t.TempDir()
+	linked := filepath.Join(t.TempDir(), "linked")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "-b", "feature", linked)
+	if err := os.WriteFile(filepath.Join(linked, "wip.txt"), []byte("unsaved"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	app := NewAppWithPath(repo)
+	useTrash := true
+	app.config.Delete.UseTrash = &useTrash
+	app.trashDir = filepath.Join(t.TempDir(), "trash")
+
+	var item *ListItem
+	for i := range app.worktreeItems {
+		if app.worktreeItems[i].Metadata.(*WorktreeItemData).Branch == "feature" {
+			item = &app.worktreeItems[i]
+		}
+	}
+	if item == nil {
+		t.Fatal("Expected a worktree on feature")
+	}
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if !app.confirmDialog.Visible() {
+		t.Fatal("Delete should ask for confirmation")
+	}
+	if !strings.Contains(app.confirmDialog.Title(), "Trash") || !strings.Contains(app.confirmDialog.Message(), app.trashDir) {
+		t.Errorf("Expected the dialog to mention the trash, got %q: %q", app.confirmDialog.Title(), app.confirmDialog.Message())
+	}
+	if app.confirmDialog.HasForceOption() {
+		t.Error("Trash mode keeps changes, so there should be no force option")
+	}
+
+	app.confirmDialog.Hide()
+	app.Update(ConfirmDialogResultMsg{Confirmed: true, Data: item})
+
+	if app.feedback.Type() != FeedbackSuccess {
+		t.Fatalf("Expected success feedback, got %q", app.feedback.Message())
+	}
+	if _, err := os.Stat(linked); !os.IsNotExist(err) {
+		t.Error("Expected the worktree directory to be moved away")
+	}
+	for _, wt := range app.worktreeItems {
+		if wt.ID == item.ID {
+			t.Error("Expected the worktree to be gone from the list")
+		}
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(app.trashDir, "*-linked", "wip.txt"))
+	if len(matches) != 1 {
+		t.Fatalf("Expected the uncommitted file in the trash, got %v", matches)
+	}
+	if _, err := os.Stat(filepath.Dir(matches[0]) + ".yaml"); err != nil {
+		t.Errorf("Expected trash info next to the entry: %v", err)
+	}
+}
+
+// TestAppCreateUnderBaseDir verifies a worktree without a path is created under the configured base directory.
+func TestAppCreateUnderBaseDir(t *testing.T) {
+	repo := filepath.Join(t.TempDir(), "myrepo")
+	if err := os.Mkdir(repo, 0755); err != nil {
+		t.Fatalf("Failed to create repo dir: %v", err)
+	}
+	newTestRepo(t, repo)
+
+	base := t.TempDir()
+	app := NewAppWithPath(repo)
+	app.config.Worktree.BaseDir = filepath.Join(base, "{repo}")
+	app.applyConfig()
+
+	app.createForm.Show()
+	app.createForm.branch = "feature/login"
+	want := filepath.Join(base, "myrepo", "feature-login")
+
+	cmd := app.createForm.submit()
+	if cmd == nil {
+		t.Fatalf("Expected the form to submit without a path, got %q", app.createForm.Error())
+	}
+	app.Update(cmd())
+
+	if _, err := os.Stat(filepath.Join(want, ".git")); err != nil {
+		t.Errorf("Expected the worktree under the base directory: %v", err)
+	}
+	if app.TargetPath() != want {
+		t.Errorf("Expected target path %q, got %q", want, app.TargetPath())
+	}
+}
+
+// TestAppCreatePathConflict verifies a taken path reopens the form with the entered values and the path focused.
+func TestAppCreatePathConflict(t *testing.T) {
+	repo := t.TempDir()
+	newTestRepo(t, repo)
+	taken := filepath.Join(t.TempDir(), "taken")
+	if err := os.MkdirAll(taken, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(taken, "file.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	app := NewAppWithPath(repo)
+	app.Update(CreateFormSubmittedMsg{Result: CreateFormResult{Branch: "feature", Path: taken, CreateBranch: true}})
+
+	if !app.createForm.Visible() {
+		t.Fatal("Expected the form to reopen")
+	}
+	if app.createForm.Branch() != "feature" || app.createForm.Path() != taken || !app.createForm.CreateBranchEnabled() {
+		t.Errorf("Expected the entered values to be kept, got branch %q path %q", app.createForm.Branch(), app.createForm.Path())
+	}
+	if app.createForm.Focused() != FieldPath {
+		t.Errorf("Expected the path field focused, got %d", app.createForm.Focused())
+	}
+	if !strings.Contains(app.createForm.Error(), "already exists") {
+		t.Errorf("Expected a path conflict message, got %q", app.createForm.Error())
+	}
+	if app.TargetPath() != "" {
+		t.Error("Expected no target path after a failed create")
+	}
+}
+
+// TestAppCreateFailureKeepsInput verifies a failed submission reopens the form with the typed values and the git error.
+func TestAppCreateFailureKeepsInput(t *testing.T) {
+	repo := t.TempDir()
+	newTestRepo(t, repo)
+	wtPath := filepath.Join(t.TempDir(), "wt")
+
+	app := NewAppWithPath(repo)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.createForm.Show()
+	app.createForm.branch = "no-such-branch"
+	app.createForm.path = wtPath
+	app.createForm.createBranch = false
+
+	cmd := app.createForm.submit()
+	if cmd == nil {
+		t.Fatalf("Expected the form to submit, got %q", app.createForm.Error())
+	}
+	app.Update(cmd())
+
+	if !app.createForm.Visible() {
+		t.Fatal("Expected the form to stay open after git failed")
+	}
+	if app.createForm.Branch() != "no-such-branch" || app.createForm.Path() != wtPath || app.createForm.CreateBranchEnabled() {
+		t.Errorf("Expected the typed values to be kept, got branch %q path %q", app.createForm.Branch(), app.createForm.Path())
+	}
+	if !strings.HasPrefix(app.createForm.Error(), "Failed to create worktree: ") {
+		t.Errorf("Expected the git error on the form, got %q", app.createForm.Error())
+	}
+	if !strings.Contains(app.View(), "Failed to create worktree") {
+		t.Error("Expected the error to be shown in the form")
+	}
+}
+
+// TestAppDeleteForceDefault verifies the delete dialog opens with force
+// checked when the config asks for it, and unchecked otherwise.
+func TestAppDeleteForceDefault(t *testing.T) {
+	item := &ListItem{ID: "/path/x", Title: "x", Metadata: &WorktreeItemData{Path: "/path/x", ModifiedCount: 1}}
+	app := NewAppWithItems([]ListItem{*item})
+
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if !app.confirmDialog.Visible() || app.confirmDialog.ForceSelected() {
+		t.Fatal("Expected the delete dialog with force unchecked by default")
+	}
+	app.confirmDialog.Hide()
+
+	forceDefault := true
+	app.config.Delete.ForceDefault = &forceDefault
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if !app.confirmDialog.ForceSelected() {
+		t.Error("Expected the delete dialog with force checked")
+	}
+	if app.confirmDialog.StashSelected() {
+		t.Error("Stash should stay unchecked when force is checked")
+	}
+
+	// Hiding resets the option for other dialogs
+	app.confirmDialog.Hide()
+	app.confirmDialog.Show("Title", "Message")
+	if app.confirmDialog.ForceSelected() || app.confirmDialog.HasForceOption() {
+		t.Error("Expected Hide to reset the force option")
+	}
+}
+
+// TestAppMergedBranch verifies a merged branch is checked once, shown in the
+// details and mentioned when deleting its worktree.
+func TestAppMergedBranch(t *testing.T) {
+	items := []ListItem{
+		{ID: "/path/x", Title: "x", Metadata: &WorktreeItemData{Path: "/path/x", Branch: "x"}},
+		{ID: "/path/d", Title: "d", Metadata: &WorktreeItemData{Path: "/path/d", IsDetached: true}},
+	}
+	app := NewAppWithItems(items)
+	app.details.SetItem(app.list.SelectedItem())
+
+	app.Init()
+	if !app.mergedLoading["/path/x"] {
+		t.Fatal("Expected the selected branch to be checked")
+	}
+	app.Update(MergedLoadedMsg{Path: "/path/x", Into: "main"})
+	if cmd := app.ensureMerged(); cmd != nil {
+		t.Error("Expected no command when the result is cached")
+	}
+	if !strings.Contains(app.details.View(), "✓ merged into main") {
+		t.Error("Expected the merged badge in the details pane")
+	}
+
+	item := app.list.SelectedItem()
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if !strings.Contains(app.confirmDialog.View(), "merged into main, safe to remove") {
+		t.Error("Expected the delete confirmation to mention the merge")
+	}
+	app.confirmDialog.Hide()
+
+	// Not merged: no badge and no note
+	app.Update(MergedLoadedMsg{Path: "/path/x"})
+	if strings.Contains(app.details.View(), "merged into") {
+		t.Error("Expected no merged badge for an unmerged branch")
+	}
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if strings.Contains(app.confirmDialog.View(), "safe to remove") {
+		t.Error("Expected no merge note for an unmerged branch")
+	}
+	app.confirmDialog.Hide()
+
+	// A detached HEAD has no branch to check
+	app.list.MoveDown()
+	app.details.SetItem(app.list.SelectedItem())
+	if cmd := app.ensureMerged(); cmd != nil {
+		t.Error("Expected no check for a detached worktree")
+	}
+}
+
+// TestAppSwitchCurrentWorktree verifies a remote branch can be checked out in
+// the current worktree only while it has no local changes.
+func TestAppSwitchCurrentWorktree(t *testing.T) {
+	origin := t.TempDir()
+	clone := filepath.Join(t.TempDir(), "clone")
+	newTestRepo(t, origin)
+	runGit(t, origin, "branch", "feature")
+	runGit(t, origin, "clone", origin, clone)
+	base := runGit(t, clone, "symbolic-ref", "--short", "HEAD")
+
+	app := NewAppWithPath(clone)
+	feature := &ListItem{ID: "origin/feature", Title: "origin/feature", Metadata: &RemoteBranchItemData{Ref: "origin/feature", Remote: "origin", Branch: "feature"}}
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "switch"}, Item: feature})
+	if app.feedback.Type() != FeedbackSuccess {
+		t.Fatalf("Expected the switch to succeed, got %q", app.feedback.Message())
+	}
+	if branch := runGit(t, clone, "symbolic-ref", "--short", "HEAD"); branch != "feature" {
+		t.Errorf("Current worktree is on %q, want feature", branch)
+	}
+
+	// Local changes block switching back
+	if err := os.WriteFile(filepath.Join(clone, "notes.txt"), []byte("work in progress"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	back := &ListItem{ID: "origin/" + base, Title: "origin/" + base, Metadata: &RemoteBranchItemData{Ref: "origin/" + base, Remote: "origin", Branch: base}}
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "switch"}, Item: back})
+	if app.feedback.Type() != FeedbackError || !strings.Contains(app.feedback.Message(), "uncommitted changes") {
+		t.Errorf("Expected an error about uncommitted changes, got %q", app.feedback.Message())
+	}
+	if branch := runGit(t, clone, "symbolic-ref", "--short", "HEAD"); branch != "feature" {
+		t.Errorf("A dirty worktree should stay on feature, got %q", branch)
+	}
+
+	// Without a current worktree there is nothing to switch
+	app = NewAppWithItems(nil)
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "switch"}, Item: feature})
+	if app.feedback.Type() != FeedbackError {
+		t.Error("Expected an error without a current worktree")
+	}
+}
+
+// TestAppDeleteStashesChanges verifies a dirty worktree can be deleted after stashing its changes.
+func TestAppDeleteStashesChanges(t *testing.T) {
+	repo := t.TempDir()
+	wtPath := filepath.Join(t.TempDir(), "wt")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "-b", "feature", wtPath)
+
+	app := NewAppWithPath(repo)
+	item := &ListItem{ID: wtPath, Title: "feature"}
+
+	// Clean worktrees have nothing to stash
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if !app.confirmDialog.Visible() || app.confirmDialog.HasStashOption() {
+		t.Fatal("Expected a confirmation without a stash option for a clean worktree")
+	}
+	app.confirmDialog.Hide()
+
+	if err := os.WriteFile(filepath.Join(wtPath, "notes.txt"), []byte("work in progress"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	app.Update(ActionExecutedMsg{Action: &Action{ID: "delete"}, Item: item})
+	if !app.confirmDialog.HasStashOption() {
+		t.Fatal("Expected a stash option for a dirty worktree")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	cmd := app.confirmDialog.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	app.Update(cmd())
+
+	if _, err := os.Stat(wtPath); !os.IsNotExist(err) {
+		t.Error("Expected the worktree to be removed")
+	}
+	if app.feedback.Type() != FeedbackSuccess || !strings.Contains(app.feedback.Message(), "stash") {
+		t.Errorf("Expected success feedback mentioning the stash, got %q", app.feedback.Message())
+	}
+
+	if output := run("stash", "list"); !strings.Contains(output, "before deleting worktree") {
+		t.Errorf("Expected the changes in the stash list, got %q", output)
+	}
+}
+
+// TestAppMessageLog verifies M opens the log of earlier messages and Esc closes it.
+func TestAppMessageLog(t *testing.T) {
+	app := NewAppWithItems([]ListItem{{ID: "main", Title: "main"}})
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	app.feedback.ShowError("Failed to fetch: timed out")
+	app.Update(ClearFeedbackMsg{})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	if !app.messageLog.Visible() {
+		t.Fatal("Expected M to open the message log")
+	}
+	if !strings.Contains(app.View(), "Failed to fetch: timed out") {
+		t.Error("Expected the cleared message in the log")
+	}
+
+	// Keys go to the log while it is open
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if app.createForm.Visible() {
+		t.Error("Keys should not reach the app while the log is open")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.messageLog.Visible() {
+		t.Error("Expected Esc to close the message log")
+	}
+}
+
+// TestAppAutoRefresh verifies a refresh tick reloads status counts in the background and keeps the selection.
+func TestAppAutoRefresh(t *testing.T) {
+	repo := t.TempDir()
+	first := filepath.Join(t.TempDir(), "first")
+	second := filepath.Join(t.TempDir(), "second")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "-b", "first", first)
+	run("worktree", "add", "-b", "second", second)
+
+	app := NewAppWithPath(repo)
+	// Refreshing is off by default
+	if _, cmd := app.handleRefreshTick(); cmd != nil || app.refreshing {
+		t.Fatal("Expected no refresh without an interval")
+	}
+
+	interval := 60
+	app.config.Refresh.IntervalSeconds = &interval
+	if !app.list.SelectByID(second) {
+		t.Fatal("Expected to select the second worktree")
+	}
+
+	// Nothing runs while a modal is open
+	app.actionMenu.Show(app.list.SelectedItem())
+	app.Update(RefreshTickMsg{})
+	if app.refreshing {
+		t.Error("Expected no refresh while the action menu is open")
+	}
+	app.actionMenu.Hide()
+
+	if err := os.WriteFile(filepath.Join(second, "notes.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	run("worktree", "remove", first)
+
+	app.Update(RefreshTickMsg{})
+	if !app.refreshing {
+		t.Fatal("Expected a refresh to start")
+	}
+	// A tick during a refresh does not start another one
+	app.Update(RefreshTickMsg{})
+	if !app.refreshing {
+		t.Error("Expected the running refresh to continue")
+	}
+
+	msg := runRefresh(repo, time.Minute)()
+
+	// Handling the result must not run git status again, even once the
+	// cached statuses have expired
+	git.InvalidateWorktreeStatus(second)
+	recorder := &recordingRunner{}
+	previous := git.SetRunner(recorder)
+	app.Update(msg)
+	git.SetRunner(previous)
+	for _, call := range recorder.calls {
+		if strings.HasPrefix(call, "status") {
+			t.Errorf("Expected no git status while handling the refresh, got %q", call)
+		}
+	}
+	if app.refreshing {
+		t.Error("Expected the refresh to be finished")
+	}
+	selected := app.list.SelectedItem()
+	if selected == nil || selected.ID != second {
+		t.Fatalf("Expected the second worktree to stay selected, got %+v", selected)
+	}
+	if !selected.Metadata.(*WorktreeItemData).HasChanges() {
+		t.Error("Expected the refreshed status to show the new file")
+	}
+	if len(app.Worktrees()) != 2 {
+		t.Errorf("Expected the removed worktree to disappear, got %d worktrees", len(app.Worktrees()))
+	}
+}
+
+// recordingRunner records git commands and fails them all, to check that
+// code runs no git.
+type recordingRunner struct {
+	calls []string
+}
+
+// Run records args and fails.
+func (r *recordingRunner) Run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	r.calls = append(r.calls, strings.Join(args, " "))
+	return nil, errors.New("git is not available in this test")
+}
+
+// newTestRepo creates a git repository at dir with an empty initial commit
+// and returns a function that runs git in it. The test is skipped when git
+// is not available.
+func newTestRepo(t *testing.T, dir string) func(args ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create repository directory: %v", err)
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		return runGit(t, dir, args...)
+	}
+	run("init")
+	run("commit", "--allow-empty", "-m", "initial")
+	return run
+}
+
+// runGit runs git in dir with a test identity for commits and returns its
+// trimmed output, failing the test if git fails.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// TestAppWatchRefreshesStatus verifies a watched change refreshes the selected worktree's status, and polling takes over when watching fails.
+func TestAppWatchRefreshesStatus(t *testing.T) {
+	repo := t.TempDir()
+	newTestRepo(t, repo)
+
+	app := NewAppWithPath(repo)
+	watch := true
+	app.config.Refresh.Watch = &watch
+	if cmd := app.startWatcher(); cmd == nil || app.watcher == nil {
+		t.Fatal("Expected the watcher to start")
+	}
+	watcher := app.watcher
+	t.Cleanup(func() { watcher.Close() })
+	if app.watcher.Path() != app.selectedWorktreePath() {
+		t.Errorf("Expected the selected worktree to be watched, got %q", app.watcher.Path())
+	}
+
+	if err := os.WriteFile(filepath.Join(app.selectedWorktreePath(), "notes.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	app.Update(WorktreeChangedMsg{Path: app.selectedWorktreePath()})
+	app.Update(WorktreeChangedMsg{Path: app.selectedWorktreePath()})
+
+	// Only the last change of a burst refreshes
+	if _, cmd := app.handleWatchRefreshDue(WatchRefreshDueMsg{seq: app.watchSeq - 1}); cmd != nil {
+		t.Error("Expected an outdated debounce to do nothing")
+	}
+	_, cmd := app.handleWatchRefreshDue(WatchRefreshDueMsg{seq: app.watchSeq})
+	if cmd == nil {
+		t.Fatal("Expected a status refresh")
+	}
+	msg := cmd()
+
+	// Handling the result must not run git status again, even once the
+	// cached status has expired
+	git.InvalidateWorktreeStatus(app.selectedWorktreePath())
+	recorder := &recordingRunner{}
+	previous := git.SetRunner(recorder)
+	app.Update(msg)
+	git.SetRunner(previous)
+	for _, call := range recorder.calls {
+		if strings.HasPrefix(call, "status") {
+			t.Errorf("Expected no git status while handling the status refresh, got %q", call)
+		}
+	}
+	if !app.list.SelectedItem().Metadata.(*WorktreeItemData).HasChanges() {
+		t.Error("Expected the refreshed status to show the new file")
+	}
+
+	// A failure to watch falls back to polling
+	original := maxWatchedDirs
+	maxWatchedDirs = 0
+	t.Cleanup(func() { maxWatchedDirs = original })
+	app.watcher.Watch("")
+	app.ensureWatched()
+	if app.watcher != nil || !app.watchFailed {
+		t.Fatal("Expected watching to stop")
+	}
+	if app.refreshInterval() != fallbackRefreshInterval {
+		t.Errorf("Expected polling every %v, got %v", fallbackRefreshInterval, app.refreshInterval())
+	}
+	if app.feedback.Type() != FeedbackInfo || !strings.Contains(app.feedback.Message(), "Cannot watch") {
+		t.Errorf("Expected the fallback to be reported, got %q", app.feedback.Message())
+	}
+}
+
+func TestAppStatusScanProgress(t *testing.T) {
+	app := NewAppWithItems(nil)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	var worktrees []git.Worktree
+	for i := 0; i < 12; i++ {
+		worktrees = append(worktrees, git.Worktree{
+			Path:   fmt.Sprintf("/nonexistent/wt-%d", i),
+			Branch: fmt.Sprintf("branch-%d", i),
+		})
+	}
+	app.startStatusScan(worktrees)
+	app.setWorktrees(worktrees, nil)
+
+	if cmd := app.ensureStatusScan(); cmd == nil {
+		t.Fatal("Expected statuses to be read in the background")
+	}
+	if app.statusScanRunning != statusScanConcurrency {
+		t.Errorf("Expected %d statuses read at a time, got %d", statusScanConcurrency, app.statusScanRunning)
+	}
+	if !strings.Contains(app.View(), "Scanning 0/12…") {
+		t.Error("Expected the footer to show the scan starting")
+	}
+
+	for i := 0; i < 5; i++ {
+		app.handleWorktreeStatusLoaded(WorktreeStatusLoadedMsg{
+			Path:   worktrees[i].Path,
+			Status: &git.WorktreeStatus{ModifiedCount: 2},
+		})
+	}
+	if !strings.Contains(app.View(), "Scanning 5/12…") {
+		t.Error("Expected the footer to show 5 of 12 statuses received")
+	}
+	if data := app.worktreeItems[0].Metadata.(*WorktreeItemData); data.ModifiedCount != 2 {
+		t.Errorf("Expected the received status in the list item, got %d modified", data.ModifiedCount)
+	}
+
+	// A status from a replaced scan is not counted
+	app.handleWorktreeStatusLoaded(WorktreeStatusLoadedMsg{Path: "/nonexistent/other"})
+	if !strings.Contains(app.View(), "Scanning 5/12…") {
+		t.Error("Expected an unknown worktree not to be counted")
+	}
+
+	for i := 5; i < 12; i++ {
+		app.handleWorktreeStatusLoaded(WorktreeStatusLoadedMsg{Path: worktrees[i].Path, Err: errors.New("failed")})
+	}
+	if strings.Contains(app.View(), "Scanning") {
+		t.Error("Expected the indicator to be hidden once all statuses arrived")
+	}
+}
+
+// TestAppHideMainWorktree verifies list.hide_main leaves the main worktree out of the Worktrees tab only.
+func TestAppHideMainWorktree(t *testing.T) {
+	items := []ListItem{
+		{ID: "/src/repo", Title: "repo", Metadata: &WorktreeItemData{Path: "/src/repo", Branch: "main", IsMain: true}},
+		{ID: "/src/feature", Title: "feature", Metadata: &WorktreeItemData{Path: "/src/feature", Branch: "feature"}},
+		{ID: "/src/fix", Title: "fix", Metadata: &WorktreeItemData{Path: "/src/fix", Branch: "fix"}},
+	}
+	app := NewAppWithItems(items)
+	app.list.SelectByID("/src/fix")
+
+	app.Update(SettingToggledMsg{Key: settingHideMain, Enabled: true})
+	listed := app.list.Items()
+	if len(listed) != 2 {
+		t.Fatalf("Expected 2 worktrees listed, got %d", len(listed))
+	}
+	for _, item := range listed {
+		if item.ID == "/src/repo" {
+			t.Error("Expected the main worktree to be hidden")
+		}
+	}
+	if item := app.list.SelectedItem(); item == nil || item.ID != "/src/fix" {
+		t.Errorf("Expected the selection to stay on fix, got %v", item)
+	}
+	if app.tabs.Label(TabWorktrees) != TabWorktrees.String()+" (2)" {
+		t.Errorf("Expected the Worktrees tab to count 2 worktrees, got %q", app.tabs.Label(TabWorktrees))
+	}
+
+	// The Branches tab still lists it
+	app.tabs.SetActive(TabBranches)
+	app.handleTabChanged()
+	if len(app.list.Items()) != 3 {
+		t.Errorf("Expected the Branches tab to list the main worktree, got %d items", len(app.list.Items()))
+	}
+	app.tabs.SetActive(TabWorktrees)
+	app.handleTabChanged()
+	if len(app.list.Items()) != 2 {
+		t.Errorf("Expected the main worktree hidden again on the Worktrees tab, got %d items", len(app.list.Items()))
+	}
+
+	app.Update(SettingToggledMsg{Key: settingHideMain, Enabled: false})
+	if len(app.list.Items()) != 3 || app.list.Items()[0].ID != "/src/repo" {
+		t.Error("Expected the main worktree listed again once the toggle is off")
+	}
+}
+
+// TestAppRemoveDirtyWorktreeSuggestsForce verifies a removal refused for uncommitted changes points to the force option.
+func TestAppRemoveDirtyWorktreeSuggestsForce(t *testing.T) {
+	repo := t.TempDir()
+	wtPath := filepath.Join(t.TempDir(), "wt")
+	run := newTestRepo(t, repo)
+	run("worktree", "add", "-b", "feature", wtPath)
+	if err := os.WriteFile(filepath.Join(wtPath, "draft.txt"), []byte("draft"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewAppWithPath(repo)
+	item := &ListItem{ID: wtPath, Title: "feature", Metadata: &WorktreeItemData{Path: wtPath, Branch: "feature"}}
+	app.removeWorktree(item, false)
+	if app.feedback.Type() != FeedbackError || !strings.Contains(app.feedback.Message(), "Force removal") {
+		t.Errorf("Expected feedback pointing to Force removal, got %q", app.feedback.Message())
+	}
+	if _, err := os.Stat(wtPath); err != nil {
+		t.Errorf("Expected the worktree to be kept: %v", err)
+	}
+}
+
+// TestAppFoldDetailsSections verifies z and Z fold the focused details pane's sections.
+func TestAppFoldDetailsSections(t *testing.T) {
+	items := []ListItem{{ID: "/src/feature", Title: "feature", Metadata: &WorktreeItemData{Path: "/src/feature", Branch: "feature"}}}
+	app := NewAppWithItems(items)
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.View()
+
+	// Only the focused details pane folds
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	if app.details.SectionCollapsed("Path") {
+		t.Error("z should not fold sections while the list has focus")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	if !app.details.SectionCollapsed("Path") || !strings.Contains(app.View(), "▸ Path") {
+		t.Error("Expected z to collapse the section at the top of the details pane")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Z'}})
+	if !app.details.SectionCollapsed("Branch") || !app.details.SectionCollapsed("Status") {
+		t.Error("Expected Z to collapse every section")
+	}
+}
+
+// TestAppCreateWithoutCheckout verifies a worktree created without checkout stays selected with a reminder.
+func TestAppCreateWithoutCheckout(t *testing.T) {
+	repo := t.TempDir()
+	wtPath := filepath.Join(t.TempDir(), "huge")
+	run := newTestRepo(t, repo)
+	if err := os.WriteFile(filepath.Join(repo, "README"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "README")
+	run("commit", "-m", "add README")
+
+	app := NewAppWithPath(repo)
+	app.Update(CreateFormSubmittedMsg{Result: CreateFormResult{Branch: "huge", Path: wtPath, CreateBranch: true, NoCheckout: true}})
+
+	if _, err := os.Stat(filepath.Join(wtPath, "README")); !os.IsNotExist(err) {
+		t.Errorf("Expected no files checked out, feedback: %q", app.feedback.Message())
+	}
+	if app.quitting || app.TargetPath() != "" {
+		t.Error("Expected grove to stay open after creating an empty worktree")
+	}
+	if !strings.Contains(app.feedback.Message(), "without checking out files") {
+		t.Errorf("Expected a reminder that the worktree is empty, got %q", app.feedback.Message())
+	}
+	if item := app.list.SelectedItem(); item == nil || item.ID != wtPath {
+		t.Errorf("Expected the new worktree to be selected, got %v", item)
+	}
+}
+
+// TestAppCreateSparseCheckout verifies sparse patterns check out only the chosen directories.
+func TestAppCreateSparseCheckout(t *testing.T) {
+	repo := t.TempDir()
+	wtPath := filepath.Join(t.TempDir(), "api")
+	run := newTestRepo(t, repo)
+	for _, dir := range []string{"api", "web"} {
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repo, dir, "main.go"), []byte("package main"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("add", ".")
+	run("commit", "-m", "add api and web")
+
+	app := NewAppWithPath(repo)
+	app.Update(CreateFormSubmittedMsg{Result: CreateFormResult{Branch: "api", Path: wtPath, CreateBranch: true, SparsePatterns: []string{"api"}}})
+
+	if _, err := os.Stat(filepath.Join(wtPath, "api", "main.go")); err != nil {
+		t.Errorf("Expected api/ to be checked out, feedback: %q", app.feedback.Message())
+	}
+	if _, err := os.Stat(filepath.Join(wtPath, "web")); !os.IsNotExist(err) {
+		t.Errorf("Expected web/ to be left out, got %v", err)
+	}
+	if app.TargetPath() != wtPath {
+		t.Errorf("Expected to switch to the populated worktree, got target %q", app.TargetPath())
+	}
+}
+
+// TestAppCreateCopiesFilesInBackground verifies copy_on_create copies files
+// without blocking the UI, and quits into the worktree once they are there.
+func TestAppCreateCopiesFilesInBackground(t *testing.T) {
+	repo := t.TempDir()
+	wtPath := filepath.Join(t.TempDir(), "feature")
+	newTestRepo(t, repo)
+	if err := os.WriteFile(filepath.Join(repo, ".env"), []byte("TOKEN=x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewAppWithPath(repo)
+	app.config.CopyOnCreate = []string{".env"}
+	result := CreateFormResult{Branch: "feature", Path: wtPath, CreateBranch: true}
+	_, cmd := app.Update(CreateFormSubmittedMsg{Result: result})
+	if cmd == nil || app.quitting {
+		t.Fatal("Expected the files to be copied in the background")
+	}
+	if _, err := os.Stat(filepath.Join(wtPath, ".env")); !os.IsNotExist(err) {
+		t.Error("Expected no file copied before the command runs")
+	}
+	if app.feedback.Message() != "Copying files..." {
+		t.Errorf("Expected copying to be reported, got %q", app.feedback.Message())
+	}
+
+	app.Update(copyFilesOnCreate(repo, wtPath, app.config.CopyOnCreate, result)())
+	if _, err := os.Stat(filepath.Join(wtPath, ".env")); err != nil {
+		t.Errorf("Expected .env to be copied: %v", err)
+	}
+	if !app.quitting || app.TargetPath() != wtPath {
+		t.Errorf("Expected grove to quit into %s, got %q", wtPath, app.TargetPath())
+	}
+
+	// A failed copy keeps grove open to show the error
+	app = NewAppWithPath(repo)
+	app.Update(FilesCopiedMsg{Path: wtPath, Result: result, Err: errors.New("permission denied")})
+	if app.quitting || app.TargetPath() != "" {
+		t.Error("Expected grove to stay open after copying failed")
+	}
+	if app.feedback.Type() != FeedbackError || !strings.Contains(app.feedback.Message(), "copying files failed: permission denied") {
+		t.Errorf("Expected the copy error, got %q", app.feedback.Message())
+	}
+}
+
+// TestAppCreateFromTag verifies T lists tags into the create form and both tag modes create worktrees.
+func TestAppCreateFromTag(t *testing.T) {
+	repo := t.TempDir()
+	newTestRepo(t, repo)
+	runGit(t, repo, "tag", "v1.0.0")
+	tagged := runGit(t, repo, "rev-parse", "v1.0.0")
+	runGit(t, repo, "commit", "--allow-empty", "-m", "later")
+
+	app := NewAppWithPath(repo)
+	msg := loadTags(repo, time.Minute)()
+	loaded, ok := msg.(TagsLoadedMsg)
+	if !ok || loaded.Err != nil {
+		t.Fatalf("Expected TagsLoadedMsg, got %#v", msg)
+	}
+	app.Update(loaded)
+	if !app.createForm.Visible() || app.createForm.SelectedTag() != "v1.0.0" {
+		t.Fatalf("Expected the create form to pick from the tags, got %v", app.createForm.SelectedTag())
+	}
+	app.createForm.Hide()
+
+	detached := filepath.Join(t.TempDir(), "inspect")
+	app.Update(CreateFormSubmittedMsg{Result: CreateFormResult{Path: detached, Detach: true, Commit: "v1.0.0"}})
+	if head := runGit(t, detached, "rev-parse", "HEAD"); head != tagged {
+		t.Errorf("Expected the detached worktree at the tag, got %s (feedback: %q)", head, app.feedback.Message())
+	}
+
+	app = NewAppWithPath(repo)
+	hotfix := filepath.Join(t.TempDir(), "hotfix")
+	app.Update(CreateFormSubmittedMsg{Result: CreateFormResult{Branch: "hotfix", Path: hotfix, CreateBranch: true, BaseBranch: "v1.0.0"}})
+	if branch := runGit(t, hotfix, "rev-parse", "--abbrev-ref", "HEAD"); branch != "hotfix" {
+		t.Errorf("Expected the new branch checked out, got %q (feedback: %q)", branch, app.feedback.Message())
+	}
+	if head := runGit(t, hotfix, "rev-parse", "HEAD"); head != tagged {
+		t.Errorf("Expected the new branch to start at the tag, got %s", head)
+	}
+
+	// A repository without tags says so instead of opening the form
+	app.Update(TagsLoadedMsg{})
+	if app.createForm.Visible() || app.feedback.Message() != "No tags in this repository" {
+		t.Errorf("Expected a message about missing tags, got %q", app.feedback.Message())
 	}
 }
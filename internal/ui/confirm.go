@@ -8,6 +8,13 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// ConfirmChoice is a button of the confirmation dialog. Its Value is sent
+// as ConfirmDialogResultMsg.Choice when chosen; a nil Value cancels.
+type ConfirmChoice struct {
+	Label string
+	Value interface{}
+}
+
 // ConfirmDialog is a modal dialog that asks for user confirmation.
 type ConfirmDialog struct {
 	visible       bool
@@ -18,7 +25,12 @@ type ConfirmDialog struct {
 	dangerMode    bool
 	forceOption   bool
 	forceSelected bool
-	selected      int // 0 = confirm, 1 = cancel
+	stashOption   bool
+	stashSelected bool
+	tagOption     string // tag offered for the checked out branch (empty = none)
+	tagSelected   bool
+	choices       []ConfirmChoice // replace the confirm and cancel buttons when set
+	selected      int             // index of the selected button (0 = confirm, 1 = cancel by default)
 	data          interface{}
 	width         int
 	height        int
@@ -62,18 +74,58 @@ func (d *ConfirmDialog) HasForceOption() bool {
 	return d.forceOption
 }
 
-// Selected returns the currently selected button (0 = confirm, 1 = cancel).
+// StashSelected returns whether the stash option is selected.
+func (d *ConfirmDialog) StashSelected() bool {
+	return d.stashSelected
+}
+
+// HasStashOption returns whether the dialog has a stash option.
+func (d *ConfirmDialog) HasStashOption() bool {
+	return d.stashOption
+}
+
+// TagSelected returns whether the tag option is selected.
+func (d *ConfirmDialog) TagSelected() bool {
+	return d.tagSelected
+}
+
+// TagOption returns the tag the dialog offers to create, empty if none.
+func (d *ConfirmDialog) TagOption() string {
+	return d.tagOption
+}
+
+// Selected returns the index of the currently selected button; with the
+// default buttons 0 = confirm, 1 = cancel.
 func (d *ConfirmDialog) Selected() int {
 	return d.selected
 }
 
+// Choices returns the buttons of the dialog: the choices if set, otherwise
+// a confirm button with the value true and a cancel button.
+func (d *ConfirmDialog) Choices() []ConfirmChoice {
+	if len(d.choices) > 0 {
+		return d.choices
+	}
+	return []ConfirmChoice{{Label: d.confirmLabel, Value: true}, {Label: d.cancelLabel}}
+}
+
+// SetChoices replaces the confirm and cancel buttons with choices, in
+// order, until the dialog is hidden. The last choice is selected, so it
+// should be the safe one, usually a cancel button with a nil Value.
+func (d *ConfirmDialog) SetChoices(choices []ConfirmChoice) {
+	d.choices = choices
+	d.selected = len(d.Choices()) - 1
+}
+
 // Show displays the confirmation dialog with the given title and message.
 func (d *ConfirmDialog) Show(title, message string) {
 	d.visible = true
 	d.title = title
 	d.message = message
-	d.selected = 1 // Default to cancel for safety
+	d.selected = len(d.Choices()) - 1 // Default to cancel for safety
 	d.forceSelected = false
+	d.stashSelected = false
+	d.tagSelected = false
 	d.data = nil
 }
 
@@ -94,6 +146,32 @@ func (d *ConfirmDialog) SetForceOption(enabled bool) {
 	d.forceOption = enabled
 }
 
+// SetForceSelected checks or unchecks the force option, if enabled.
+// Call it after showing the dialog, which unchecks it.
+func (d *ConfirmDialog) SetForceSelected(selected bool) {
+	if d.forceOption {
+		d.forceSelected = selected
+		if selected {
+			d.stashSelected = false
+		}
+	}
+}
+
+// SetStashOption enables or disables the checkbox for stashing changes
+// before confirming.
+func (d *ConfirmDialog) SetStashOption(enabled bool) {
+	d.stashOption = enabled
+}
+
+// SetTagOption offers a checkbox for tagging before confirming, naming the
+// tag to create. An empty tag removes the checkbox.
+func (d *ConfirmDialog) SetTagOption(tag string) {
+	d.tagOption = tag
+	if tag == "" {
+		d.tagSelected = false
+	}
+}
+
 // SetConfirmLabel sets the text for the confirm button.
 func (d *ConfirmDialog) SetConfirmLabel(label string) {
 	d.confirmLabel = label
@@ -110,6 +188,11 @@ func (d *ConfirmDialog) Hide() {
 	d.dangerMode = false
 	d.forceOption = false
 	d.forceSelected = false
+	d.stashOption = false
+	d.stashSelected = false
+	d.tagOption = ""
+	d.tagSelected = false
+	d.choices = nil
 	d.data = nil
 	d.selected = 1
 }
@@ -120,24 +203,46 @@ func (d *ConfirmDialog) SetSize(width, height int) {
 	d.height = height
 }
 
-// MoveLeft moves the selection to the left (toward confirm).
+// MoveLeft moves the selection to the previous button (toward confirm).
 func (d *ConfirmDialog) MoveLeft() {
 	if d.selected > 0 {
 		d.selected--
 	}
 }
 
-// MoveRight moves the selection to the right (toward cancel).
+// MoveRight moves the selection to the next button (toward cancel).
 func (d *ConfirmDialog) MoveRight() {
-	if d.selected < 1 {
+	if d.selected < len(d.Choices())-1 {
 		d.selected++
 	}
 }
 
-// ToggleForce toggles the force option checkbox.
+// ToggleForce toggles the force option checkbox. Forcing discards the
+// changes, so it clears the stash option.
 func (d *ConfirmDialog) ToggleForce() {
 	if d.forceOption {
 		d.forceSelected = !d.forceSelected
+		if d.forceSelected {
+			d.stashSelected = false
+		}
+	}
+}
+
+// ToggleStash toggles the stash option checkbox. Stashing leaves nothing to
+// force, so it clears the force option.
+func (d *ConfirmDialog) ToggleStash() {
+	if d.stashOption {
+		d.stashSelected = !d.stashSelected
+		if d.stashSelected {
+			d.forceSelected = false
+		}
+	}
+}
+
+// ToggleTag toggles the tag option checkbox.
+func (d *ConfirmDialog) ToggleTag() {
+	if d.tagOption != "" {
+		d.tagSelected = !d.tagSelected
 	}
 }
 
@@ -145,7 +250,45 @@ func (d *ConfirmDialog) ToggleForce() {
 type ConfirmDialogResultMsg struct {
 	Confirmed bool
 	Force     bool
-	Data      interface{}
+	// Stash asks for uncommitted changes to be stashed first
+	Stash bool
+	// Tag is the tag to create first, empty unless the tag option was checked
+	Tag string
+	// Choice is the Value of the chosen button (true for the default
+	// confirm button, nil when cancelled)
+	Choice interface{}
+	Data   interface{}
+}
+
+// choose hides the dialog and returns a command reporting the choice at
+// index. A choice without a value cancels.
+func (d *ConfirmDialog) choose(index int) tea.Cmd {
+	choice := d.Choices()[index].Value
+	if choice == nil {
+		d.Hide()
+		return func() tea.Msg {
+			return ConfirmDialogResultMsg{Confirmed: false}
+		}
+	}
+
+	force := d.forceSelected
+	stash := d.stashSelected
+	tag := ""
+	if d.tagSelected {
+		tag = d.tagOption
+	}
+	data := d.data
+	d.Hide()
+	return func() tea.Msg {
+		return ConfirmDialogResultMsg{
+			Confirmed: true,
+			Force:     force,
+			Stash:     stash,
+			Tag:       tag,
+			Choice:    choice,
+			Data:      data,
+		}
+	}
 }
 
 // Update handles input messages for the confirmation dialog.
@@ -163,17 +306,7 @@ func (d *ConfirmDialog) Update(msg tea.Msg) tea.Cmd {
 				return ConfirmDialogResultMsg{Confirmed: false}
 			}
 		case tea.KeyEnter:
-			confirmed := d.selected == 0
-			force := d.forceSelected
-			data := d.data
-			d.Hide()
-			return func() tea.Msg {
-				return ConfirmDialogResultMsg{
-					Confirmed: confirmed,
-					Force:     force,
-					Data:      data,
-				}
-			}
+			return d.choose(d.selected)
 		case tea.KeyLeft, tea.KeyShiftTab:
 			d.MoveLeft()
 		case tea.KeyRight, tea.KeyTab:
@@ -186,17 +319,8 @@ func (d *ConfirmDialog) Update(msg tea.Msg) tea.Cmd {
 				case 'l':
 					d.MoveRight()
 				case 'y':
-					// Quick confirm
-					force := d.forceSelected
-					data := d.data
-					d.Hide()
-					return func() tea.Msg {
-						return ConfirmDialogResultMsg{
-							Confirmed: true,
-							Force:     force,
-							Data:      data,
-						}
-					}
+					// Quick confirm with the first button
+					return d.choose(0)
 				case 'n':
 					// Quick cancel
 					d.Hide()
@@ -209,6 +333,12 @@ func (d *ConfirmDialog) Update(msg tea.Msg) tea.Cmd {
 				case 'f':
 					// 'f' also toggles force when enabled
 					d.ToggleForce()
+				case 's':
+					// 's' toggles stashing when enabled
+					d.ToggleStash()
+				case 't':
+					// 't' toggles tagging when enabled
+					d.ToggleTag()
 				}
 			}
 		}
@@ -238,36 +368,6 @@ func (d *ConfirmDialog) View() string {
 		Foreground(Colors.Text).
 		MarginBottom(1)
 
-	// Button styles
-	confirmBtnStyle := lipgloss.NewStyle().
-		Padding(0, 2)
-	cancelBtnStyle := lipgloss.NewStyle().
-		Padding(0, 2)
-
-	// Apply selection styling
-	if d.selected == 0 {
-		if d.dangerMode {
-			confirmBtnStyle = confirmBtnStyle.
-				Background(Colors.Error).
-				Foreground(Colors.OnError).
-				Bold(true)
-		} else {
-			confirmBtnStyle = confirmBtnStyle.
-				Background(Colors.Primary).
-				Foreground(Colors.OnPrimary).
-				Bold(true)
-		}
-		cancelBtnStyle = cancelBtnStyle.
-			Foreground(Colors.TextMuted)
-	} else {
-		confirmBtnStyle = confirmBtnStyle.
-			Foreground(Colors.TextMuted)
-		cancelBtnStyle = cancelBtnStyle.
-			Background(Colors.Primary).
-			Foreground(Colors.OnPrimary).
-			Bold(true)
-	}
-
 	var lines []string
 	lines = append(lines, titleStyle.Render(d.title))
 	lines = append(lines, messageStyle.Render(d.message))
@@ -286,15 +386,74 @@ func (d *ConfirmDialog) View() string {
 		lines = append(lines, checkboxStyle.Render(forceText))
 	}
 
-	// Buttons
-	confirmBtn := confirmBtnStyle.Render(d.confirmLabel)
-	cancelBtn := cancelBtnStyle.Render(d.cancelLabel)
-	buttons := lipgloss.JoinHorizontal(lipgloss.Center, confirmBtn, "  ", cancelBtn)
-	lines = append(lines, buttons)
+	// Stash option checkbox
+	if d.stashOption {
+		checkboxStyle := lipgloss.NewStyle().
+			Foreground(Colors.Text).
+			MarginBottom(1)
+
+		checkbox := "[ ]"
+		if d.stashSelected {
+			checkbox = "[x]"
+		}
+		stashText := checkbox + " Stash changes first (recover them with git stash pop)"
+		lines = append(lines, checkboxStyle.Render(stashText))
+	}
+
+	// Tag option checkbox
+	if d.tagOption != "" {
+		checkboxStyle := lipgloss.NewStyle().
+			Foreground(Colors.Text).
+			MarginBottom(1)
+
+		checkbox := "[ ]"
+		if d.tagSelected {
+			checkbox = "[x]"
+		}
+		tagText := checkbox + " Tag the branch tip as " + d.tagOption + " first"
+		lines = append(lines, checkboxStyle.Render(tagText))
+	}
+
+	// Buttons; a selected dangerous choice stands out in the error color
+	var buttons []string
+	for i, choice := range d.Choices() {
+		btnStyle := lipgloss.NewStyle().
+			Padding(0, 2)
+		switch {
+		case i != d.selected:
+			btnStyle = btnStyle.
+				Foreground(Colors.TextMuted)
+		case d.dangerMode && choice.Value != nil:
+			btnStyle = btnStyle.
+				Background(Colors.Error).
+				Foreground(Colors.OnError).
+				Bold(true)
+		default:
+			btnStyle = btnStyle.
+				Background(Colors.Primary).
+				Foreground(Colors.OnPrimary).
+				Bold(true)
+		}
+		if i > 0 {
+			buttons = append(buttons, "  ")
+		}
+		buttons = append(buttons, btnStyle.Render(choice.Label))
+	}
+	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Center, buttons...))
 
 	// Help text using centralized style
 	helpStyle := Styles.Help.MarginTop(1)
-	lines = append(lines, helpStyle.Render("y/n: quick answer • ←/→: select • Enter: confirm • Esc: cancel"))
+	help := "y/n: quick answer • ←/→: select • Enter: confirm • Esc: cancel"
+	if d.forceOption {
+		help = "f: force • " + help
+	}
+	if d.stashOption {
+		help = "s: stash • " + help
+	}
+	if d.tagOption != "" {
+		help = "t: tag • " + help
+	}
+	lines = append(lines, helpStyle.Render(help))
 
 	content := strings.Join(lines, "\n")
 
@@ -468,3 +468,130 @@ func TestConfirmDialogForceWithEnter(t *testing.T) {
 		t.Error("Expected Force true in result")
 	}
 }
+
+// TestConfirmDialogStashOption verifies 's' toggles stashing, which excludes forcing, and is reported on confirm.
+func TestConfirmDialogStashOption(t *testing.T) {
+	d := NewConfirmDialog()
+	d.Show("Title", "Message")
+	d.SetForceOption(true)
+
+	// Without the option 's' does nothing
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if d.StashSelected() {
+		t.Error("Expected stash to remain false when option not enabled")
+	}
+
+	d.SetStashOption(true)
+	if !strings.Contains(d.View(), "Stash changes first") {
+		t.Error("Expected the stash checkbox in the view")
+	}
+	d.ToggleForce()
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if !d.StashSelected() || d.ForceSelected() {
+		t.Errorf("Expected stash selected and force cleared, got stash=%v force=%v", d.StashSelected(), d.ForceSelected())
+	}
+
+	cmd := d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	result, ok := cmd().(ConfirmDialogResultMsg)
+	if !ok || !result.Confirmed || !result.Stash || result.Force {
+		t.Errorf("Expected a confirmed result with stash, got %+v", result)
+	}
+	if d.HasStashOption() || d.StashSelected() {
+		t.Error("Expected Hide to reset the stash option")
+	}
+}
+
+// TestConfirmDialogTagOption verifies the tag checkbox toggles and reports its tag
+func TestConfirmDialogTagOption(t *testing.T) {
+	d := NewConfirmDialog()
+	d.Show("Title", "Message")
+
+	// Without the option 't' does nothing
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if d.TagSelected() {
+		t.Error("Expected tag to remain false when option not enabled")
+	}
+
+	d.SetTagOption("archive/feature-2024-05-01")
+	if !strings.Contains(d.View(), "Tag the branch tip as archive/feature-2024-05-01") {
+		t.Error("Expected the tag checkbox in the view")
+	}
+	cmd := d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if result := cmd().(ConfirmDialogResultMsg); result.Tag != "" {
+		t.Errorf("Expected no tag when unchecked, got %q", result.Tag)
+	}
+
+	d.Show("Title", "Message")
+	d.SetTagOption("archive/feature-2024-05-01")
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	cmd = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if result := cmd().(ConfirmDialogResultMsg); result.Tag != "archive/feature-2024-05-01" {
+		t.Errorf("Expected the tag in the result, got %q", result.Tag)
+	}
+	if d.TagOption() != "" || d.TagSelected() {
+		t.Error("Expected Hide to reset the tag option")
+	}
+}
+
+// TestConfirmDialogThreeChoices verifies navigating and choosing among three buttons
+func TestConfirmDialogThreeChoices(t *testing.T) {
+	d := NewConfirmDialog()
+	d.SetChoices([]ConfirmChoice{
+		{Label: "Remove", Value: "remove"},
+		{Label: "Remove + Branch", Value: "remove-branch"},
+		{Label: "Cancel"},
+	})
+	d.ShowWithData("Delete Worktree?", "Message", "item")
+
+	if d.Selected() != 2 {
+		t.Errorf("Expected the last choice selected for safety, got %d", d.Selected())
+	}
+	view := d.View()
+	for _, label := range []string{"Remove", "Remove + Branch", "Cancel"} {
+		if !strings.Contains(view, label) {
+			t.Errorf("Expected %q in the view", label)
+		}
+	}
+
+	// Right stops at the last button, left at the first
+	d.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if d.Selected() != 2 {
+		t.Errorf("Expected selection to stay at 2, got %d", d.Selected())
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	d.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if d.Selected() != 0 {
+		t.Errorf("Expected selection to stop at 0, got %d", d.Selected())
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+
+	cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	result, ok := cmd().(ConfirmDialogResultMsg)
+	if !ok || !result.Confirmed || result.Choice != "remove-branch" || result.Data != "item" {
+		t.Errorf("Expected the middle choice with its data, got %+v", result)
+	}
+	if d.Visible() || len(d.Choices()) != 2 {
+		t.Error("Expected Hide to restore the default buttons")
+	}
+}
+
+// TestConfirmDialogChoiceCancel verifies a choice without a value cancels
+func TestConfirmDialogChoiceCancel(t *testing.T) {
+	d := NewConfirmDialog()
+	d.SetChoices([]ConfirmChoice{{Label: "Force", Value: 1}, {Label: "Stash", Value: 2}, {Label: "Cancel"}})
+	d.Show("Title", "Message")
+
+	cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if result := cmd().(ConfirmDialogResultMsg); result.Confirmed || result.Choice != nil {
+		t.Errorf("Expected a cancelled result, got %+v", result)
+	}
+
+	// The default buttons report true for confirm
+	d.Show("Title", "Message")
+	d.MoveLeft()
+	cmd = d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if result := cmd().(ConfirmDialogResultMsg); !result.Confirmed || result.Choice != true {
+		t.Errorf("Expected a confirmed result with choice true, got %+v", result)
+	}
+}
@@ -2,10 +2,16 @@
 package ui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/iatopilskii/grove/internal/git"
 )
 
 // CreateFormField identifies which field is currently focused.
@@ -18,13 +24,40 @@ const (
 	FieldPath
 	// FieldCreateNewBranch is the checkbox for creating a new branch.
 	FieldCreateNewBranch
+	// FieldDetach is the checkbox for checking out a commit with a detached HEAD.
+	FieldDetach
+	// FieldNoCheckout is the checkbox for creating the worktree without
+	// checking out any files.
+	FieldNoCheckout
+	// FieldSparse is the input for sparse checkout patterns.
+	FieldSparse
+	// FieldTag is the filter input of the tag picker.
+	FieldTag
 )
 
+// maxTagMatches is the number of matching tags the tag picker shows at once.
+const maxTagMatches = 5
+
 // CreateFormResult contains the data from a completed form.
 type CreateFormResult struct {
 	Branch       string
 	Path         string
 	CreateBranch bool
+	// TrackRemote is the remote-tracking branch the new branch should track.
+	// Empty unless the form was opened with ShowTrackRemote.
+	TrackRemote string
+	// BaseBranch is the branch or commit the new branch starts from.
+	// Empty unless the form was opened with ShowBranchFrom.
+	BaseBranch string
+	// Detach checks out Commit with a detached HEAD instead of a branch.
+	Detach bool
+	// Commit is the commit, tag or ref to check out when Detach is set.
+	Commit string
+	// NoCheckout creates the worktree without checking out any files.
+	NoCheckout bool
+	// SparsePatterns limits the checkout to these patterns, usually
+	// directories. Empty checks out the whole tree.
+	SparsePatterns []string
 }
 
 // CreateFormSubmittedMsg is sent when the form is submitted.
@@ -42,10 +75,29 @@ type CreateForm struct {
 	branch       string
 	path         string
 	createBranch bool
+	detach       bool // check out a commit without a branch
 	width        int
 	height       int
 	cursorPos    int // cursor position within the current input field
 	errorMessage string
+	trackRemote  string // remote-tracking branch to track (empty = normal mode)
+	baseBranch   string // branch or commit to branch off (empty = normal mode)
+	// defaultPath returns where a branch's worktree goes when the path is
+	// left empty; nil means the path is required, except when branching off
+	defaultPath func(branch string) string
+	// pathCandidates are the directories the path completes to, cycled by
+	// repeated Tabs until the path is edited
+	pathCandidates []string
+	pathCandidate  int
+	// noCheckout leaves the new worktree empty, for huge repositories
+	noCheckout bool
+	// sparse holds comma-separated sparse checkout patterns
+	sparse string
+	// tags are the tags to pick from, newest first; nil outside tag mode
+	tags []string
+	// tagFilter narrows the tags shown, tagIndex is the chosen match
+	tagFilter string
+	tagIndex  int
 }
 
 // NewCreateForm creates a new worktree creation form.
@@ -67,8 +119,110 @@ func (f *CreateForm) Show() {
 	f.branch = ""
 	f.path = ""
 	f.createBranch = true
+	f.detach = false
 	f.cursorPos = 0
 	f.errorMessage = ""
+	f.trackRemote = ""
+	f.baseBranch = ""
+	f.pathCandidates = nil
+	f.noCheckout = false
+	f.sparse = ""
+	f.tags = nil
+	f.tagFilter = ""
+	f.tagIndex = 0
+}
+
+// ShowTrackRemote makes the form visible for creating a worktree with a new
+// local branch that tracks the given remote-tracking branch. The branch and
+// path fields are prefilled and can still be edited.
+func (f *CreateForm) ShowTrackRemote(remoteRef, branch, path string) {
+	f.Show()
+	f.trackRemote = remoteRef
+	f.branch = branch
+	f.path = path
+	f.cursorPos = len(branch)
+}
+
+// ShowBranchFrom makes the form visible for creating a worktree with a new
+// branch starting at base, a branch name or commit hash. Only the new branch
+// name is needed; an empty path defaults to a sibling directory named after it.
+func (f *CreateForm) ShowBranchFrom(base string) {
+	f.Show()
+	f.baseBranch = base
+}
+
+// ShowFromTag makes the form visible for creating a worktree at one of tags,
+// listed newest first. The picker is filtered by typing; the worktree gets
+// a detached HEAD at the tag unless a new branch name is entered.
+func (f *CreateForm) ShowFromTag(tags []string) {
+	f.Show()
+	f.tags = tags
+	f.createBranch = false
+	f.focused = FieldTag
+}
+
+// ShowResult makes the form visible again with the values of a previous
+// submission, so the user can correct them after creation failed.
+func (f *CreateForm) ShowResult(result CreateFormResult) {
+	f.Show()
+	f.path = result.Path
+	f.trackRemote = result.TrackRemote
+	f.baseBranch = result.BaseBranch
+	f.noCheckout = result.NoCheckout
+	f.sparse = strings.Join(result.SparsePatterns, ", ")
+	if result.Detach {
+		f.detach = true
+		f.branch = result.Commit
+	} else {
+		f.branch = result.Branch
+		f.createBranch = result.CreateBranch
+	}
+	f.cursorPos = len(f.branch)
+}
+
+// FocusField moves focus to field, placing the cursor at the end of its input.
+func (f *CreateForm) FocusField(field CreateFormField) {
+	f.focused = field
+	switch field {
+	case FieldBranch:
+		f.cursorPos = len(f.branch)
+	case FieldPath:
+		f.cursorPos = len(f.path)
+	case FieldSparse:
+		f.cursorPos = len(f.sparse)
+	case FieldTag:
+		f.cursorPos = len(f.tagFilter)
+	default:
+		f.cursorPos = 0
+	}
+}
+
+// SetDefaultPath makes the path optional: an empty path becomes
+// defaultPath(branch), which the form shows as a hint. Nil makes the path
+// required again.
+func (f *CreateForm) SetDefaultPath(defaultPath func(branch string) string) {
+	f.defaultPath = defaultPath
+}
+
+// defaultPathFor returns where the worktree for branch goes when the path
+// is left empty.
+func (f *CreateForm) defaultPathFor(branch string) string {
+	if f.defaultPath != nil {
+		return f.defaultPath(branch)
+	}
+	return defaultWorktreePath(branch)
+}
+
+// BaseBranch returns the branch or commit the form branches off,
+// or an empty string in normal mode.
+func (f *CreateForm) BaseBranch() string {
+	return f.baseBranch
+}
+
+// TrackRemote returns the remote-tracking branch the form is tracking,
+// or an empty string in normal mode.
+func (f *CreateForm) TrackRemote() string {
+	return f.trackRemote
 }
 
 // Hide hides the form.
@@ -98,6 +252,59 @@ func (f *CreateForm) CreateBranchEnabled() bool {
 	return f.createBranch
 }
 
+// DetachEnabled returns whether the "detached HEAD" option is enabled.
+func (f *CreateForm) DetachEnabled() bool {
+	return f.detach
+}
+
+// NoCheckoutEnabled returns whether the "no checkout" option is enabled.
+func (f *CreateForm) NoCheckoutEnabled() bool {
+	return f.noCheckout
+}
+
+// detachAvailable reports whether the detach option is offered. Tracking a
+// remote branch and branching off always create a branch, so it is hidden;
+// in tag mode an empty branch name detaches instead.
+func (f *CreateForm) detachAvailable() bool {
+	return f.trackRemote == "" && f.baseBranch == "" && !f.tagMode()
+}
+
+// tagMode reports whether the form creates the worktree at a picked tag.
+func (f *CreateForm) tagMode() bool {
+	return f.tags != nil
+}
+
+// matchingTags returns the tags containing the filter, ignoring case.
+func (f *CreateForm) matchingTags() []string {
+	filter := strings.ToLower(f.tagFilter)
+	var matches []string
+	for _, tag := range f.tags {
+		if strings.Contains(strings.ToLower(tag), filter) {
+			matches = append(matches, tag)
+		}
+	}
+	return matches
+}
+
+// SelectedTag returns the tag chosen in the picker, or an empty string if
+// no tag matches the filter or the form is not in tag mode.
+func (f *CreateForm) SelectedTag() string {
+	matches := f.matchingTags()
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[min(f.tagIndex, len(matches)-1)]
+}
+
+// pathName returns the name an empty path defaults to: the branch name, or
+// in tag mode the tag when no branch is created.
+func (f *CreateForm) pathName() string {
+	if f.tagMode() && f.branch == "" {
+		return f.SelectedTag()
+	}
+	return f.branch
+}
+
 // Focused returns the currently focused field.
 func (f *CreateForm) Focused() CreateFormField {
 	return f.focused
@@ -120,9 +327,35 @@ func (f *CreateForm) focusNext() {
 		f.focused = FieldPath
 		f.cursorPos = len(f.path)
 	case FieldPath:
-		f.focused = FieldCreateNewBranch
+		if f.tagMode() {
+			// A new branch is created by naming it, so there is no checkbox
+			f.focused = FieldNoCheckout
+		} else {
+			f.focused = FieldCreateNewBranch
+		}
 		f.cursorPos = 0
 	case FieldCreateNewBranch:
+		if f.detachAvailable() {
+			f.focused = FieldDetach
+		} else {
+			f.focused = FieldNoCheckout
+		}
+		f.cursorPos = 0
+	case FieldDetach:
+		f.focused = FieldNoCheckout
+		f.cursorPos = 0
+	case FieldNoCheckout:
+		f.focused = FieldSparse
+		f.cursorPos = len(f.sparse)
+	case FieldSparse:
+		if f.tagMode() {
+			f.focused = FieldTag
+			f.cursorPos = len(f.tagFilter)
+		} else {
+			f.focused = FieldBranch
+			f.cursorPos = len(f.branch)
+		}
+	case FieldTag:
 		f.focused = FieldBranch
 		f.cursorPos = len(f.branch)
 	}
@@ -132,19 +365,57 @@ func (f *CreateForm) focusNext() {
 func (f *CreateForm) focusPrev() {
 	switch f.focused {
 	case FieldBranch:
-		f.focused = FieldCreateNewBranch
-		f.cursorPos = 0
+		if f.tagMode() {
+			f.focused = FieldTag
+			f.cursorPos = len(f.tagFilter)
+		} else {
+			f.focused = FieldSparse
+			f.cursorPos = len(f.sparse)
+		}
 	case FieldPath:
 		f.focused = FieldBranch
 		f.cursorPos = len(f.branch)
 	case FieldCreateNewBranch:
 		f.focused = FieldPath
 		f.cursorPos = len(f.path)
+	case FieldDetach:
+		f.focused = FieldCreateNewBranch
+		f.cursorPos = 0
+	case FieldNoCheckout:
+		if f.tagMode() {
+			f.focused = FieldPath
+			f.cursorPos = len(f.path)
+		} else if f.detachAvailable() {
+			f.focused = FieldDetach
+			f.cursorPos = 0
+		} else {
+			f.focused = FieldCreateNewBranch
+			f.cursorPos = 0
+		}
+	case FieldSparse:
+		f.focused = FieldNoCheckout
+		f.cursorPos = 0
+	case FieldTag:
+		f.focused = FieldSparse
+		f.cursorPos = len(f.sparse)
 	}
 }
 
 // validate checks if the form input is valid.
 func (f *CreateForm) validate() bool {
+	if f.tagMode() {
+		// Only the tag is required; the branch name and path are optional
+		if f.SelectedTag() == "" {
+			f.errorMessage = "No tag matches '" + f.tagFilter + "'"
+			return false
+		}
+		f.errorMessage = ""
+		return true
+	}
+	if f.branch == "" && f.detach {
+		f.errorMessage = "Commit or ref is required when detaching"
+		return false
+	}
 	if f.branch == "" && f.createBranch {
 		f.errorMessage = "Branch name is required"
 		return false
@@ -153,7 +424,7 @@ func (f *CreateForm) validate() bool {
 		f.errorMessage = "Existing branch name is required"
 		return false
 	}
-	if f.path == "" {
+	if f.path == "" && f.baseBranch == "" && f.defaultPath == nil {
 		f.errorMessage = "Path is required"
 		return false
 	}
@@ -167,11 +438,31 @@ func (f *CreateForm) submit() tea.Cmd {
 		return nil
 	}
 
+	path := f.path
+	if path == "" {
+		path = f.defaultPathFor(f.pathName())
+	}
+
 	result := CreateFormResult{
 		Branch:       f.branch,
-		Path:         f.path,
-		CreateBranch: f.createBranch,
+		Path:         path,
+		CreateBranch: f.createBranch || f.trackRemote != "" || f.baseBranch != "",
+		TrackRemote:  f.trackRemote,
+		BaseBranch:   f.baseBranch,
+		NoCheckout:   f.noCheckout,
 	}
+	if f.detach {
+		// The input holds the commit to check out rather than a branch
+		result = CreateFormResult{Path: path, Detach: true, Commit: f.branch, NoCheckout: f.noCheckout}
+	}
+	if tag := f.SelectedTag(); tag != "" {
+		if f.branch == "" {
+			result = CreateFormResult{Path: path, Detach: true, Commit: tag, NoCheckout: f.noCheckout}
+		} else {
+			result = CreateFormResult{Branch: f.branch, Path: path, CreateBranch: true, BaseBranch: tag, NoCheckout: f.noCheckout}
+		}
+	}
+	result.SparsePatterns = git.ParseSparsePatterns(f.sparse)
 
 	f.Hide()
 
@@ -180,6 +471,90 @@ func (f *CreateForm) submit() tea.Cmd {
 	}
 }
 
+// completePath completes the path field to the longest common prefix of
+// the directories it may name, or cycles through them when that adds
+// nothing. Returns false if there is nothing to complete.
+func (f *CreateForm) completePath() bool {
+	// Repeated Tabs cycle through the candidates shown
+	if len(f.pathCandidates) > 1 {
+		f.pathCandidate = (f.pathCandidate + 1) % len(f.pathCandidates)
+		f.path = f.pathCandidates[f.pathCandidate]
+		f.cursorPos = len(f.path)
+		return true
+	}
+
+	f.pathCandidates = nil
+	if f.path == "" {
+		return false
+	}
+	completed, candidates := completePath(f.path)
+	if len(candidates) == 0 || (completed == f.path && len(candidates) == 1) {
+		return false
+	}
+	f.pathCandidates = candidates
+	f.pathCandidate = -1
+	if completed == f.path {
+		// Nothing in common to add, so start cycling
+		f.pathCandidate = 0
+		completed = candidates[0]
+	}
+	f.path = completed
+	f.cursorPos = len(f.path)
+	return true
+}
+
+// completePath completes prefix to the directories whose paths start with
+// it, e.g. "../wo" to "../worktrees/". It returns the longest common prefix
+// of the candidates and the candidates themselves, each ending in a slash.
+// Relative paths are relative to the working directory and a leading "~/"
+// is the home directory. Hidden directories are only offered once their
+// name starts with a dot.
+func completePath(prefix string) (completed string, candidates []string) {
+	dirPart, namePart := "", prefix
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		dirPart, namePart = prefix[:i+1], prefix[i+1:]
+	}
+
+	dir := dirPart
+	if dir == "" {
+		dir = "."
+	} else if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return prefix, nil
+		}
+		dir = filepath.Join(home, rest)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return prefix, nil
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, namePart) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(namePart, ".")) {
+			continue
+		}
+		// Symlinks count when they lead to a directory
+		if info, err := os.Stat(filepath.Join(dir, name)); err != nil || !info.IsDir() {
+			continue
+		}
+		candidates = append(candidates, dirPart+name+"/")
+	}
+	if len(candidates) == 0 {
+		return prefix, nil
+	}
+	sort.Strings(candidates)
+
+	completed = candidates[0]
+	for _, candidate := range candidates[1:] {
+		for !strings.HasPrefix(candidate, completed) {
+			completed = completed[:len(completed)-1]
+		}
+	}
+	return completed, candidates
+}
+
 // insertChar inserts a character at the current cursor position.
 func (f *CreateForm) insertChar(char rune) {
 	switch f.focused {
@@ -195,6 +570,24 @@ func (f *CreateForm) insertChar(char rune) {
 		}
 		f.path = f.path[:f.cursorPos] + string(char) + f.path[f.cursorPos:]
 		f.cursorPos++
+		f.pathCandidates = nil
+	case FieldSparse:
+		if f.cursorPos > len(f.sparse) {
+			f.cursorPos = len(f.sparse)
+		}
+		// Pasted lines become separate patterns on a single line
+		if char == '\n' {
+			char = ','
+		}
+		f.sparse = f.sparse[:f.cursorPos] + string(char) + f.sparse[f.cursorPos:]
+		f.cursorPos++
+	case FieldTag:
+		if f.cursorPos > len(f.tagFilter) {
+			f.cursorPos = len(f.tagFilter)
+		}
+		f.tagFilter = f.tagFilter[:f.cursorPos] + string(char) + f.tagFilter[f.cursorPos:]
+		f.cursorPos++
+		f.tagIndex = 0
 	}
 }
 
@@ -210,6 +603,18 @@ func (f *CreateForm) deleteChar() {
 		if f.cursorPos > 0 && len(f.path) > 0 {
 			f.path = f.path[:f.cursorPos-1] + f.path[f.cursorPos:]
 			f.cursorPos--
+			f.pathCandidates = nil
+		}
+	case FieldSparse:
+		if f.cursorPos > 0 && len(f.sparse) > 0 {
+			f.sparse = f.sparse[:f.cursorPos-1] + f.sparse[f.cursorPos:]
+			f.cursorPos--
+		}
+	case FieldTag:
+		if f.cursorPos > 0 && len(f.tagFilter) > 0 {
+			f.tagFilter = f.tagFilter[:f.cursorPos-1] + f.tagFilter[f.cursorPos:]
+			f.cursorPos--
+			f.tagIndex = 0
 		}
 	}
 }
@@ -231,13 +636,16 @@ func (f *CreateForm) Update(msg tea.Msg) tea.Cmd {
 		case tea.KeyEnter:
 			return f.submit()
 		case tea.KeyTab:
-			f.focusNext()
+			// Tab completes a path being typed; Shift+Tab still goes back
+			if f.focused != FieldPath || !f.completePath() {
+				f.focusNext()
+			}
 		case tea.KeyShiftTab:
 			f.focusPrev()
 		case tea.KeyBackspace:
 			f.deleteChar()
 		case tea.KeyLeft:
-			if f.focused == FieldBranch || f.focused == FieldPath {
+			if f.focused == FieldBranch || f.focused == FieldPath || f.focused == FieldSparse || f.focused == FieldTag {
 				if f.cursorPos > 0 {
 					f.cursorPos--
 				}
@@ -251,10 +659,40 @@ func (f *CreateForm) Update(msg tea.Msg) tea.Cmd {
 				if f.cursorPos < len(f.path) {
 					f.cursorPos++
 				}
+			} else if f.focused == FieldSparse {
+				if f.cursorPos < len(f.sparse) {
+					f.cursorPos++
+				}
+			} else if f.focused == FieldTag {
+				if f.cursorPos < len(f.tagFilter) {
+					f.cursorPos++
+				}
+			}
+		case tea.KeyUp:
+			if f.focused == FieldTag && f.tagIndex > 0 {
+				f.tagIndex--
+			}
+		case tea.KeyDown:
+			if f.focused == FieldTag && f.tagIndex < len(f.matchingTags())-1 {
+				f.tagIndex++
 			}
 		case tea.KeySpace:
 			if f.focused == FieldCreateNewBranch {
-				f.createBranch = !f.createBranch
+				// Tracking a remote branch or branching off always creates a new branch
+				if f.trackRemote == "" && f.baseBranch == "" {
+					f.createBranch = !f.createBranch
+					if f.createBranch {
+						f.detach = false
+					}
+				}
+			} else if f.focused == FieldDetach {
+				// A detached worktree has no branch, so the options exclude each other
+				f.detach = !f.detach
+				if f.detach {
+					f.createBranch = false
+				}
+			} else if f.focused == FieldNoCheckout {
+				f.noCheckout = !f.noCheckout
 			} else {
 				f.insertChar(' ')
 			}
@@ -286,12 +724,18 @@ func (f *CreateForm) View() string {
 	labelStyle := lipgloss.NewStyle().
 		Foreground(Colors.TextMuted)
 
+	// Input fields shrink to fit narrow terminals
+	inputWidth := 40
+	if f.width > 0 && f.width-8 < inputWidth {
+		inputWidth = max(f.width-8, 10)
+	}
+
 	// Input field style (unfocused)
 	inputStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(Colors.TextMuted).
 		Padding(0, 1).
-		Width(40)
+		Width(inputWidth)
 
 	// Input field style (focused)
 	inputFocusedStyle := inputStyle.
@@ -307,11 +751,59 @@ func (f *CreateForm) View() string {
 		Bold(true)
 
 	var lines []string
-	lines = append(lines, titleStyle.Render("Create New Worktree"))
+	title := "Create New Worktree"
+	if f.trackRemote != "" {
+		title = "Track Remote Branch: " + f.trackRemote
+	} else if f.baseBranch != "" {
+		title = "Branch from " + f.baseBranch
+	} else if f.tagMode() {
+		title = "New Worktree from Tag"
+	}
+	lines = append(lines, titleStyle.Render(title))
+
+	// Tag picker: a filter input above the matching tags, newest first
+	if f.tagMode() {
+		lines = append(lines, labelStyle.Render("Tag (type to filter):"))
+		if f.focused == FieldTag {
+			lines = append(lines, inputFocusedStyle.Render(f.renderInputWithCursor(f.tagFilter, f.cursorPos)))
+		} else {
+			filterValue := f.tagFilter
+			if filterValue == "" {
+				filterValue = " "
+			}
+			lines = append(lines, inputStyle.Render(filterValue))
+		}
+
+		matches := f.matchingTags()
+		selected := min(f.tagIndex, len(matches)-1)
+		// Scroll so the chosen tag stays in view
+		first := max(0, selected-maxTagMatches+1)
+		for i := first; i < len(matches) && i < first+maxTagMatches; i++ {
+			if i == selected {
+				lines = append(lines, lipgloss.NewStyle().Bold(true).Foreground(Colors.Primary).Render(truncateEnd("› "+matches[i], inputWidth+2)))
+			} else {
+				lines = append(lines, checkboxStyle.Render(truncateEnd("  "+matches[i], inputWidth+2)))
+			}
+		}
+		if len(matches) == 0 {
+			lines = append(lines, Styles.Muted.Render("  no matching tags"))
+		} else if len(matches) > maxTagMatches {
+			lines = append(lines, Styles.Muted.Render(fmt.Sprintf("  %d of %d tags shown", maxTagMatches, len(matches))))
+		}
+		lines = append(lines, "")
+	}
 
 	// Branch name field
 	branchLabel := "Branch name:"
-	if !f.createBranch {
+	if f.trackRemote != "" {
+		branchLabel = "Local branch:"
+	} else if f.baseBranch != "" {
+		branchLabel = "New branch name:"
+	} else if f.tagMode() {
+		branchLabel = "New branch (empty for detached HEAD):"
+	} else if f.detach {
+		branchLabel = "Commit or tag:"
+	} else if !f.createBranch {
 		branchLabel = "Existing branch:"
 	}
 	lines = append(lines, labelStyle.Render(branchLabel))
@@ -338,34 +830,97 @@ func (f *CreateForm) View() string {
 		pathValue = f.renderInputWithCursor(f.path, f.cursorPos)
 		lines = append(lines, inputFocusedStyle.Render(pathValue))
 	} else {
+		if pathValue == "" && (f.baseBranch != "" || f.tagMode() || f.defaultPath != nil) && f.pathName() != "" {
+			// Show where the worktree goes when the path is left empty
+			hint := truncateMiddle(abbreviateHome(f.defaultPathFor(f.pathName())), max(inputWidth-2, 1))
+			pathValue = Styles.Muted.Render(hint)
+		}
 		if pathValue == "" {
 			pathValue = " "
 		}
 		lines = append(lines, inputStyle.Render(pathValue))
 	}
+	if f.focused == FieldPath && len(f.pathCandidates) > 1 {
+		// List the directories Tab cycles through by name
+		names := make([]string, len(f.pathCandidates))
+		for i, candidate := range f.pathCandidates {
+			names[i] = filepath.Base(candidate) + "/"
+		}
+		lines = append(lines, Styles.Muted.Render(truncateEnd(strings.Join(names, "  "), inputWidth+2)))
+	}
 	lines = append(lines, "")
 
-	// Create new branch checkbox
-	checkbox := "[ ]"
-	if f.createBranch {
-		checkbox = "[✓]"
+	// Branch checkboxes; in tag mode naming a branch creates one
+	if !f.tagMode() {
+		checkbox := "[ ]"
+		if f.createBranch {
+			checkbox = "[✓]"
+		}
+		checkboxLine := checkbox + " Create new branch"
+		if f.trackRemote != "" {
+			checkboxLine = "[✓] Create new branch tracking " + f.trackRemote
+		} else if f.baseBranch != "" {
+			checkboxLine = "[✓] Create new branch from " + f.baseBranch
+		}
+		if f.focused == FieldCreateNewBranch {
+			lines = append(lines, checkboxStyle.Bold(true).Foreground(Colors.Primary).Render(checkboxLine))
+		} else {
+			lines = append(lines, checkboxStyle.Render(checkboxLine))
+		}
+
+		// Detached HEAD checkbox
+		if f.detachAvailable() {
+			detachLine := "[ ] Detached HEAD (no branch)"
+			if f.detach {
+				detachLine = "[✓] Detached HEAD (no branch)"
+			}
+			if f.focused == FieldDetach {
+				lines = append(lines, checkboxStyle.Bold(true).Foreground(Colors.Primary).Render(detachLine))
+			} else {
+				lines = append(lines, checkboxStyle.Render(detachLine))
+			}
+		}
+		lines = append(lines, "")
+	}
+
+	// Advanced options, for huge repositories
+	lines = append(lines, labelStyle.Render("Advanced:"))
+	noCheckoutLine := "[ ] No checkout (leave the worktree empty)"
+	if f.noCheckout {
+		noCheckoutLine = "[✓] No checkout (leave the worktree empty)"
 	}
-	checkboxLine := checkbox + " Create new branch"
-	if f.focused == FieldCreateNewBranch {
-		lines = append(lines, checkboxStyle.Bold(true).Foreground(Colors.Primary).Render(checkboxLine))
+	if f.focused == FieldNoCheckout {
+		lines = append(lines, checkboxStyle.Bold(true).Foreground(Colors.Primary).Render(noCheckoutLine))
 	} else {
-		lines = append(lines, checkboxStyle.Render(checkboxLine))
+		lines = append(lines, checkboxStyle.Render(noCheckoutLine))
+	}
+	lines = append(lines, labelStyle.Render("Sparse checkout (comma-separated directories):"))
+	if f.focused == FieldSparse {
+		lines = append(lines, inputFocusedStyle.Render(f.renderInputWithCursor(f.sparse, f.cursorPos)))
+	} else {
+		sparseValue := f.sparse
+		if sparseValue == "" {
+			sparseValue = Styles.Muted.Render("whole tree")
+		}
+		lines = append(lines, inputStyle.Render(sparseValue))
 	}
 
 	// Error message
 	if f.errorMessage != "" {
 		lines = append(lines, "")
-		lines = append(lines, errorStyle.Render("✗ "+f.errorMessage))
+		// Git errors can be long, so keep them within the form
+		lines = append(lines, errorStyle.Render(wrapText("✗ "+f.errorMessage, inputWidth+2)))
 	}
 
 	// Help text
 	lines = append(lines, "")
-	lines = append(lines, Styles.Help.Render("Tab: next field • Space: toggle • Enter: create • Esc: cancel"))
+	helpText := "Tab: next field • Space: toggle • Enter: create • Esc: cancel"
+	if f.focused == FieldPath {
+		helpText = "Tab: complete path • Shift+Tab: previous field • Enter: create • Esc: cancel"
+	} else if f.focused == FieldTag {
+		helpText = "↑/↓: choose tag • Tab: next field • Enter: create • Esc: cancel"
+	}
+	lines = append(lines, Styles.Help.Render(helpText))
 
 	content := strings.Join(lines, "\n")
 
@@ -2,6 +2,9 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -111,6 +114,21 @@ func TestCreateFormFocusNext(t *testing.T) {
 		t.Error("Should move to FieldCreateNewBranch")
 	}
 
+	form.focusNext()
+	if form.Focused() != FieldDetach {
+		t.Error("Should move to FieldDetach")
+	}
+
+	form.focusNext()
+	if form.Focused() != FieldNoCheckout {
+		t.Error("Should move to FieldNoCheckout")
+	}
+
+	form.focusNext()
+	if form.Focused() != FieldSparse {
+		t.Error("Should move to FieldSparse")
+	}
+
 	form.focusNext()
 	if form.Focused() != FieldBranch {
 		t.Error("Should wrap to FieldBranch")
@@ -122,6 +140,21 @@ func TestCreateFormFocusPrev(t *testing.T) {
 	form := NewCreateForm()
 	form.Show()
 
+	form.focusPrev()
+	if form.Focused() != FieldSparse {
+		t.Error("Should move to FieldSparse")
+	}
+
+	form.focusPrev()
+	if form.Focused() != FieldNoCheckout {
+		t.Error("Should move to FieldNoCheckout")
+	}
+
+	form.focusPrev()
+	if form.Focused() != FieldDetach {
+		t.Error("Should move to FieldDetach")
+	}
+
 	form.focusPrev()
 	if form.Focused() != FieldCreateNewBranch {
 		t.Error("Should move to FieldCreateNewBranch")
@@ -293,6 +326,88 @@ func TestCreateFormUpdateTab(t *testing.T) {
 	}
 }
 
+// TestCompletePath verifies paths complete to the longest common prefix of matching directories.
+func TestCompletePath(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"worktrees/feature-a", "worktrees/feature-b", "work-notes", ".hidden", "other"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "worklog.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(root)
+
+	tests := []struct {
+		prefix     string
+		completed  string
+		candidates []string
+	}{
+		{"work", "work", []string{"work-notes/", "worktrees/"}},
+		{"workt", "worktrees/", []string{"worktrees/"}},
+		{"worktrees/f", "worktrees/feature-", []string{"worktrees/feature-a/", "worktrees/feature-b/"}},
+		{"./o", "./other/", []string{"./other/"}},
+		{root + "/ot", root + "/other/", []string{root + "/other/"}},
+		{".h", ".hidden/", []string{".hidden/"}},
+		{"worklog", "worklog", nil},
+		{"missing/x", "missing/x", nil},
+	}
+
+	for _, tt := range tests {
+		completed, candidates := completePath(tt.prefix)
+		if completed != tt.completed || !reflect.DeepEqual(candidates, tt.candidates) {
+			t.Errorf("completePath(%q) = %q, %q; want %q, %q", tt.prefix, completed, candidates, tt.completed, tt.candidates)
+		}
+	}
+
+	// Hidden directories are only offered for a dot prefix
+	if _, candidates := completePath("./"); len(candidates) != 3 {
+		t.Errorf("Expected 3 visible directories, got %q", candidates)
+	}
+}
+
+// TestCreateFormTabCompletesPath verifies Tab in the path field completes and cycles directories.
+func TestCreateFormTabCompletesPath(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"feature-a", "feature-b"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(root)
+
+	form := NewCreateForm()
+	form.Show()
+	form.FocusField(FieldPath)
+	form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("fe")})
+
+	form.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if form.Path() != "feature-" || form.Focused() != FieldPath {
+		t.Fatalf("Expected completion to feature- in the path field, got %q in field %v", form.Path(), form.Focused())
+	}
+	if !strings.Contains(form.View(), "feature-a/  feature-b/") {
+		t.Error("Expected the candidates to be listed")
+	}
+
+	form.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if form.Path() != "feature-a/" {
+		t.Errorf("Expected the first candidate, got %q", form.Path())
+	}
+	form.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if form.Path() != "feature-b/" {
+		t.Errorf("Expected the second candidate, got %q", form.Path())
+	}
+
+	// Once nothing is left to complete, Tab moves on
+	form.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	form.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if form.Focused() != FieldCreateNewBranch {
+		t.Errorf("Expected Tab to move to the next field, got %v", form.Focused())
+	}
+}
+
 // TestCreateFormUpdateBackspace verifies backspace handling.
 func TestCreateFormUpdateBackspace(t *testing.T) {
 	form := NewCreateForm()
@@ -564,7 +679,7 @@ func TestCreateFormRenderInputWithCursor(t *testing.T) {
 
 // TestCreateFormFieldConstants verifies field constants are distinct.
 func TestCreateFormFieldConstants(t *testing.T) {
-	fields := []CreateFormField{FieldBranch, FieldPath, FieldCreateNewBranch}
+	fields := []CreateFormField{FieldBranch, FieldPath, FieldCreateNewBranch, FieldDetach, FieldNoCheckout, FieldSparse}
 	seen := make(map[CreateFormField]bool)
 
 	for _, f := range fields {
@@ -574,3 +689,383 @@ func TestCreateFormFieldConstants(t *testing.T) {
 		seen[f] = true
 	}
 }
+
+// TestCreateFormShowTrackRemote verifies the track mode prefills fields and submits a tracking result.
+func TestCreateFormShowTrackRemote(t *testing.T) {
+	form := NewCreateForm()
+	form.ShowTrackRemote("origin/feature/x", "feature/x", "../feature-x")
+
+	if form.Branch() != "feature/x" || form.Path() != "../feature-x" {
+		t.Errorf("Expected prefilled branch and path, got '%s' and '%s'", form.Branch(), form.Path())
+	}
+	if form.TrackRemote() != "origin/feature/x" {
+		t.Errorf("Expected TrackRemote 'origin/feature/x', got '%s'", form.TrackRemote())
+	}
+	if !strings.Contains(form.View(), "origin/feature/x") {
+		t.Error("View should show the remote branch being tracked")
+	}
+
+	// Toggling the checkbox is ignored in track mode
+	form.focused = FieldCreateNewBranch
+	form.createBranch = false
+	form.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	cmd := form.submit()
+	if cmd == nil {
+		t.Fatal("Expected submit command")
+	}
+	msg, ok := cmd().(CreateFormSubmittedMsg)
+	if !ok {
+		t.Fatal("Expected CreateFormSubmittedMsg")
+	}
+	if !msg.Result.CreateBranch || msg.Result.TrackRemote != "origin/feature/x" {
+		t.Errorf("Expected tracking result with new branch, got %+v", msg.Result)
+	}
+
+	// A plain Show leaves track mode
+	form.Show()
+	if form.TrackRemote() != "" {
+		t.Error("Show should reset track mode")
+	}
+}
+
+// TestCreateFormShowBranchFrom verifies branch-from mode needs only a branch name.
+func TestCreateFormShowBranchFrom(t *testing.T) {
+	form := NewCreateForm()
+	form.ShowBranchFrom("main")
+
+	if form.BaseBranch() != "main" {
+		t.Errorf("Expected BaseBranch 'main', got '%s'", form.BaseBranch())
+	}
+	if !strings.Contains(form.View(), "Branch from main") {
+		t.Error("View should show the base being branched from")
+	}
+
+	// Toggling the checkbox is ignored in branch-from mode
+	form.focused = FieldCreateNewBranch
+	form.Update(tea.KeyMsg{Type: tea.KeySpace})
+	form.focused = FieldBranch
+	for _, r := range "feature/y" {
+		form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	cmd := form.submit()
+	if cmd == nil {
+		t.Fatalf("Expected submit command, got error %q", form.errorMessage)
+	}
+	msg, ok := cmd().(CreateFormSubmittedMsg)
+	if !ok {
+		t.Fatal("Expected CreateFormSubmittedMsg")
+	}
+	want := CreateFormResult{Branch: "feature/y", Path: "../feature-y", CreateBranch: true, BaseBranch: "main"}
+	if !reflect.DeepEqual(msg.Result, want) {
+		t.Errorf("Expected %+v, got %+v", want, msg.Result)
+	}
+
+	// A plain Show leaves branch-from mode and requires a path again
+	form.Show()
+	if form.BaseBranch() != "" {
+		t.Error("Show should reset branch-from mode")
+	}
+	form.branch = "x"
+	if form.validate() {
+		t.Error("Path should be required in normal mode")
+	}
+}
+
+// TestCreateFormShowResult verifies a previous submission can be shown again for correction.
+func TestCreateFormShowResult(t *testing.T) {
+	form := NewCreateForm()
+
+	form.ShowResult(CreateFormResult{Branch: "main", Path: "/src/wt", CreateBranch: false})
+	form.FocusField(FieldPath)
+	if !form.Visible() || form.Branch() != "main" || form.Path() != "/src/wt" || form.CreateBranchEnabled() {
+		t.Errorf("Expected the submitted values, got branch %q path %q create %v", form.Branch(), form.Path(), form.CreateBranchEnabled())
+	}
+	if form.Focused() != FieldPath || form.cursorPos != len("/src/wt") {
+		t.Errorf("Expected the cursor at the end of the path, got field %d pos %d", form.Focused(), form.cursorPos)
+	}
+
+	form.ShowResult(CreateFormResult{Path: "/src/wt", Detach: true, Commit: "v1.0"})
+	if !form.DetachEnabled() || form.Branch() != "v1.0" {
+		t.Errorf("Expected the detached commit in the input, got %q", form.Branch())
+	}
+
+	form.ShowResult(CreateFormResult{Branch: "feature", Path: "/src/wt", CreateBranch: true, TrackRemote: "origin/feature"})
+	if form.TrackRemote() != "origin/feature" {
+		t.Errorf("Expected the tracked remote branch kept, got %q", form.TrackRemote())
+	}
+}
+
+// TestCreateFormDefaultPath verifies a default path makes the path optional and is shown as a hint.
+func TestCreateFormDefaultPath(t *testing.T) {
+	form := NewCreateForm()
+	form.SetDefaultPath(func(branch string) string {
+		return "/worktrees/grove/" + strings.ReplaceAll(branch, "/", "-")
+	})
+	form.Show()
+	form.branch = "feature/login"
+
+	if !strings.Contains(form.View(), "/worktrees/grove/feature-login") {
+		t.Error("View should show where the worktree goes when the path is empty")
+	}
+
+	cmd := form.submit()
+	if cmd == nil {
+		t.Fatalf("Expected submit command, got error %q", form.errorMessage)
+	}
+	msg := cmd().(CreateFormSubmittedMsg)
+	if msg.Result.Path != "/worktrees/grove/feature-login" {
+		t.Errorf("Expected the default path, got %q", msg.Result.Path)
+	}
+
+	// A typed path still wins
+	form.Show()
+	form.branch = "feature/login"
+	form.path = "../elsewhere"
+	if msg := form.submit()().(CreateFormSubmittedMsg); msg.Result.Path != "../elsewhere" {
+		t.Errorf("Expected the typed path, got %q", msg.Result.Path)
+	}
+
+	form.SetDefaultPath(nil)
+	form.Show()
+	form.branch = "x"
+	if form.validate() {
+		t.Error("Path should be required again without a default path")
+	}
+}
+
+// TestCreateFormDetach verifies detach mode excludes a new branch and submits the commit.
+func TestCreateFormDetach(t *testing.T) {
+	form := NewCreateForm()
+	form.Show()
+
+	form.focused = FieldDetach
+	form.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if !form.DetachEnabled() || form.CreateBranchEnabled() {
+		t.Fatal("Enabling detach should disable creating a new branch")
+	}
+	if !strings.Contains(form.View(), "Commit or tag:") {
+		t.Error("View should ask for a commit in detach mode")
+	}
+
+	form.path = "../inspect"
+	if form.submit() != nil {
+		t.Fatal("Submit should fail without a commit")
+	}
+	if !strings.Contains(form.Error(), "Commit or ref is required") {
+		t.Errorf("Expected commit required error, got %q", form.Error())
+	}
+
+	form.branch = "v1.2.0"
+	cmd := form.submit()
+	if cmd == nil {
+		t.Fatal("Expected submit command")
+	}
+	msg := cmd().(CreateFormSubmittedMsg)
+	want := CreateFormResult{Path: "../inspect", Detach: true, Commit: "v1.2.0"}
+	if !reflect.DeepEqual(msg.Result, want) {
+		t.Errorf("Expected %+v, got %+v", want, msg.Result)
+	}
+
+	// Re-enabling a new branch turns detach off
+	form.Show()
+	form.focused = FieldDetach
+	form.Update(tea.KeyMsg{Type: tea.KeySpace})
+	form.focused = FieldCreateNewBranch
+	form.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if form.DetachEnabled() || !form.CreateBranchEnabled() {
+		t.Error("Enabling a new branch should disable detach")
+	}
+}
+
+// TestCreateFormDetachHiddenWhenTracking verifies detach is not offered in track mode.
+func TestCreateFormDetachHiddenWhenTracking(t *testing.T) {
+	form := NewCreateForm()
+	form.ShowTrackRemote("origin/x", "x", "../x")
+
+	form.focused = FieldCreateNewBranch
+	form.focusNext()
+	if form.Focused() != FieldNoCheckout {
+		t.Error("Focus should skip the detach option in track mode")
+	}
+	form.focusPrev()
+	if form.Focused() != FieldCreateNewBranch {
+		t.Error("Focus should skip the detach option going back in track mode")
+	}
+	if strings.Contains(form.View(), "Detached HEAD") {
+		t.Error("View should not offer detach in track mode")
+	}
+}
+
+// TestCreateFormNoCheckout verifies the no checkout option is toggled, submitted and restored.
+func TestCreateFormNoCheckout(t *testing.T) {
+	form := NewCreateForm()
+	form.Show()
+	form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("feature")})
+	form.Update(tea.KeyMsg{Type: tea.KeyTab})
+	form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("../feature")})
+
+	form.focused = FieldNoCheckout
+	form.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if !form.NoCheckoutEnabled() || !strings.Contains(form.View(), "[✓] No checkout") {
+		t.Fatal("Expected space to enable the no checkout option")
+	}
+
+	cmd := form.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected the form to submit")
+	}
+	result := cmd().(CreateFormSubmittedMsg).Result
+	if !result.NoCheckout || !result.CreateBranch {
+		t.Errorf("Expected a new branch without checkout, got %+v", result)
+	}
+
+	form.ShowResult(result)
+	if !form.NoCheckoutEnabled() {
+		t.Error("Expected the option restored after a failed creation")
+	}
+	form.Show()
+	if form.NoCheckoutEnabled() {
+		t.Error("Expected the option off when the form opens")
+	}
+}
+
+// TestCreateFormSparsePatterns verifies sparse patterns are typed, submitted as a list and restored.
+func TestCreateFormSparsePatterns(t *testing.T) {
+	form := NewCreateForm()
+	form.Show()
+	form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("feature")})
+	form.Update(tea.KeyMsg{Type: tea.KeyTab})
+	form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("../feature")})
+
+	form.FocusField(FieldSparse)
+	form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("services/api,libs\nshared")})
+	form.Update(tea.KeyMsg{Type: tea.KeySpace})
+	form.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if view := form.View(); !strings.Contains(view, "services/api,libs,shared") {
+		t.Errorf("Expected the patterns on one line, got:\n%s", view)
+	}
+
+	cmd := form.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected the form to submit")
+	}
+	result := cmd().(CreateFormSubmittedMsg).Result
+	want := []string{"services/api", "libs", "shared"}
+	if !reflect.DeepEqual(result.SparsePatterns, want) {
+		t.Errorf("SparsePatterns = %q, want %q", result.SparsePatterns, want)
+	}
+
+	form.ShowResult(result)
+	if !strings.Contains(form.View(), "services/api, libs, shared") {
+		t.Error("Expected the patterns restored after a failed creation")
+	}
+	form.Show()
+	if !strings.Contains(form.View(), "whole tree") {
+		t.Error("Expected no patterns when the form opens")
+	}
+}
+
+// TestCreateFormFromTag verifies the tag picker filters, chooses and submits a tag.
+func TestCreateFormFromTag(t *testing.T) {
+	form := NewCreateForm()
+	form.SetDefaultPath(func(name string) string { return "/src/" + name })
+	form.ShowFromTag([]string{"v2.0.0", "v1.1.0", "v1.0.0", "nightly"})
+
+	if form.Focused() != FieldTag || form.SelectedTag() != "v2.0.0" {
+		t.Fatalf("Expected the picker focused on the newest tag, got %v and %q", form.Focused(), form.SelectedTag())
+	}
+	if view := form.View(); strings.Contains(view, "Create new branch") || strings.Contains(view, "Detached HEAD (no branch)") {
+		t.Error("Tag mode should not offer the branch checkboxes")
+	}
+
+	form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V1")})
+	form.Update(tea.KeyMsg{Type: tea.KeyDown})
+	form.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if form.SelectedTag() != "v1.0.0" {
+		t.Errorf("Expected the filter to ignore case and Down to stop at the last match, got %q", form.SelectedTag())
+	}
+	form.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if form.SelectedTag() != "v1.1.0" {
+		t.Errorf("Expected Up to choose v1.1.0, got %q", form.SelectedTag())
+	}
+	if view := form.View(); strings.Contains(view, "nightly") || !strings.Contains(view, "› v1.1.0") {
+		t.Errorf("Expected only matching tags with the chosen one marked, got:\n%s", view)
+	}
+
+	// Without a branch name the worktree is detached at the tag
+	cmd := form.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected the form to submit")
+	}
+	want := CreateFormResult{Path: "/src/v1.1.0", Detach: true, Commit: "v1.1.0"}
+	if got := cmd().(CreateFormSubmittedMsg).Result; !reflect.DeepEqual(got, want) {
+		t.Errorf("Result = %+v, want %+v", got, want)
+	}
+
+	// A branch name creates a new branch off the tag
+	form.ShowFromTag([]string{"v2.0.0", "v1.1.0"})
+	form.Update(tea.KeyMsg{Type: tea.KeyDown})
+	form.Update(tea.KeyMsg{Type: tea.KeyTab})
+	form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("hotfix")})
+	cmd = form.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected the form to submit")
+	}
+	want = CreateFormResult{Branch: "hotfix", Path: "/src/hotfix", CreateBranch: true, BaseBranch: "v1.1.0"}
+	if got := cmd().(CreateFormSubmittedMsg).Result; !reflect.DeepEqual(got, want) {
+		t.Errorf("Result = %+v, want %+v", got, want)
+	}
+}
+
+// TestCreateFormFromTagNoMatch verifies a filter matching no tag cannot be submitted.
+func TestCreateFormFromTagNoMatch(t *testing.T) {
+	form := NewCreateForm()
+	form.ShowFromTag([]string{"v1.0.0"})
+	form.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v9")})
+
+	if !strings.Contains(form.View(), "no matching tags") {
+		t.Error("Expected the picker to say no tag matches")
+	}
+	if cmd := form.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatal("Expected no submission without a matching tag")
+	}
+	if form.Error() != "No tag matches 'v9'" {
+		t.Errorf("Error() = %q", form.Error())
+	}
+
+	form.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if form.SelectedTag() != "v1.0.0" {
+		t.Errorf("Expected v1.0.0 to match again after editing the filter, got %q", form.SelectedTag())
+	}
+}
+
+// TestCreateFormFromTagFocusCycle verifies Tab visits the tag mode fields in order.
+func TestCreateFormFromTagFocusCycle(t *testing.T) {
+	form := NewCreateForm()
+	form.ShowFromTag([]string{"v1.0.0"})
+
+	order := []CreateFormField{FieldBranch, FieldPath, FieldNoCheckout, FieldSparse, FieldTag}
+	for _, want := range order {
+		form.focusNext()
+		if form.Focused() != want {
+			t.Fatalf("focusNext() moved to %v, want %v", form.Focused(), want)
+		}
+	}
+	for i := len(order) - 2; i >= 0; i-- {
+		form.focusPrev()
+		if form.Focused() != order[i] {
+			t.Fatalf("focusPrev() moved to %v, want %v", form.Focused(), order[i])
+		}
+	}
+	form.focusPrev()
+	if form.Focused() != FieldTag {
+		t.Errorf("focusPrev() from the branch should return to the tag picker, got %v", form.Focused())
+	}
+
+	form.Show()
+	if form.SelectedTag() != "" || form.Focused() != FieldBranch {
+		t.Error("Show() should leave tag mode")
+	}
+}
@@ -6,13 +6,69 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/iatopilskii/grove/internal/git"
 )
 
+// shortHashLength is the number of characters of a commit hash shown by default.
+const shortHashLength = 7
+
 // Details is the details pane component that shows information about the selected item.
 type Details struct {
-	item   *ListItem
-	width  int
-	height int
+	item    *ListItem
+	width   int
+	height  int
+	focused bool
+	// offset is the index of the first visible content line
+	offset int
+	// maxOffset is the largest useful offset, computed when rendering
+	maxOffset int
+	// commits are the recent commits of the worktree at commitsPath
+	commits     []git.CommitInfo
+	commitsPath string
+	// diskUsage is the size in bytes of the worktree at diskUsagePath
+	diskUsage     int64
+	diskUsagePath string
+	// stashCount is the number of stashes of the worktree at stashPath
+	stashCount int
+	stashPath  string
+	// diffStat summarizes the uncommitted changes of the worktree at diffStatPath
+	diffStat     diffStat
+	diffStatPath string
+	// showFullPath wraps the path over several lines instead of shortening it
+	showFullPath bool
+	// showFullHash shows the whole commit hash instead of its first characters
+	showFullHash bool
+	// fullHash is the full commit hash of the worktree at fullHashPath
+	fullHash     string
+	fullHashPath string
+	// submodules summarizes the submodules of the worktree at submodulesPath
+	submodules     git.SubmoduleStatus
+	submodulesPath string
+	// mergedInto is the default branch the branch of the worktree at
+	// mergedPath is merged into, empty if it is not merged
+	mergedInto string
+	mergedPath string
+	// collapsed holds the names of the sections showing only their header,
+	// kept while the selection moves so the pane stays as arranged
+	collapsed map[string]bool
+	// sections are the sections of the displayed item, computed when rendering
+	sections []detailsSection
+}
+
+// detailsSection is a section of the details pane: a header line, such as
+// "Status", followed by its content.
+type detailsSection struct {
+	name string
+	// line is the index of the header among the content lines
+	line int
+}
+
+// diffStat counts the files and lines changed in a worktree since its last commit.
+type diffStat struct {
+	files      int
+	insertions int
+	deletions  int
 }
 
 // NewDetails creates a new details pane.
@@ -25,11 +81,178 @@ func (d *Details) Item() *ListItem {
 	return d.item
 }
 
-// SetItem sets the item to display.
+// SetItem sets the item to display. Showing a different item scrolls back to the top.
 func (d *Details) SetItem(item *ListItem) {
+	if item == nil || d.item == nil || item.ID != d.item.ID {
+		d.offset = 0
+	}
 	d.item = item
 }
 
+// SetCommits sets the recent commits of the worktree at path. They are shown
+// while that worktree is the displayed item.
+func (d *Details) SetCommits(path string, commits []git.CommitInfo) {
+	d.commitsPath = path
+	d.commits = commits
+}
+
+// SetDiskUsage sets the size in bytes of the worktree at path. It is shown
+// while that worktree is the displayed item; a negative size hides it.
+func (d *Details) SetDiskUsage(path string, size int64) {
+	d.diskUsagePath = path
+	d.diskUsage = size
+}
+
+// SetStashCount sets how many stashes belong to the worktree at path. They
+// are shown while that worktree is the displayed item.
+func (d *Details) SetStashCount(path string, count int) {
+	d.stashPath = path
+	d.stashCount = count
+}
+
+// SetDiffStat sets how many files and lines of the worktree at path changed
+// since its last commit. It is shown while that worktree is the displayed item.
+func (d *Details) SetDiffStat(path string, files, insertions, deletions int) {
+	d.diffStatPath = path
+	d.diffStat = diffStat{files: files, insertions: insertions, deletions: deletions}
+}
+
+// SetFullHash sets the full commit hash of the worktree at path, shown
+// instead of the listed one while the full hash is shown for that worktree.
+func (d *Details) SetFullHash(path, hash string) {
+	d.fullHashPath = path
+	d.fullHash = hash
+}
+
+// SetSubmoduleStatus sets the submodule summary of the worktree at path. It
+// is shown while that worktree is the displayed item and has submodules.
+func (d *Details) SetSubmoduleStatus(path string, status git.SubmoduleStatus) {
+	d.submodulesPath = path
+	d.submodules = status
+}
+
+// SetMergedInto records that the branch of the worktree at path is merged
+// into the branch into; an empty into means it is not merged. It is shown
+// while that worktree is the displayed item.
+func (d *Details) SetMergedInto(path, into string) {
+	d.mergedPath = path
+	d.mergedInto = into
+}
+
+// ToggleFullPath switches between the shortened path and the full path
+// wrapped over several lines.
+func (d *Details) ToggleFullPath() {
+	d.showFullPath = !d.showFullPath
+}
+
+// ShowFullPath returns whether the full path is shown.
+func (d *Details) ShowFullPath() bool {
+	return d.showFullPath
+}
+
+// ToggleFullHash switches between the short and the full commit hash.
+func (d *Details) ToggleFullHash() {
+	d.showFullHash = !d.showFullHash
+}
+
+// ShowFullHash returns whether the full commit hash is shown.
+func (d *Details) ShowFullHash() bool {
+	return d.showFullHash
+}
+
+// SectionCollapsed returns whether the section called name shows only its header.
+func (d *Details) SectionCollapsed(name string) bool {
+	return d.collapsed[name]
+}
+
+// currentSection returns the section the collapse keys act on: the first
+// one whose header is visible at the scroll offset, or the last one when
+// scrolled past every header. ok is false if nothing has sections.
+func (d *Details) currentSection() (section detailsSection, ok bool) {
+	if len(d.sections) == 0 {
+		return detailsSection{}, false
+	}
+	for _, s := range d.sections {
+		if s.line >= d.offset {
+			return s, true
+		}
+	}
+	return d.sections[len(d.sections)-1], true
+}
+
+// ToggleSection collapses the current section to its header, or expands it
+// again. Scroll to a section to make it current.
+func (d *Details) ToggleSection() {
+	section, ok := d.currentSection()
+	if !ok {
+		return
+	}
+	if d.collapsed == nil {
+		d.collapsed = make(map[string]bool)
+	}
+	d.collapsed[section.name] = !d.collapsed[section.name]
+}
+
+// ToggleAllSections collapses every section of the displayed item, or
+// expands them all if they are all collapsed already.
+func (d *Details) ToggleAllSections() {
+	allCollapsed := true
+	for _, s := range d.sections {
+		allCollapsed = allCollapsed && d.collapsed[s.name]
+	}
+	if d.collapsed == nil {
+		d.collapsed = make(map[string]bool)
+	}
+	for _, s := range d.sections {
+		d.collapsed[s.name] = !allCollapsed
+	}
+}
+
+// Focused returns whether the details pane has keyboard focus.
+func (d *Details) Focused() bool {
+	return d.focused
+}
+
+// SetFocused sets whether the details pane has keyboard focus.
+// While focused, navigation keys scroll the details instead of the list.
+func (d *Details) SetFocused(focused bool) {
+	d.focused = focused
+}
+
+// Offset returns the index of the first visible content line.
+func (d *Details) Offset() int {
+	return d.offset
+}
+
+// ScrollUp scrolls the content up by one line.
+func (d *Details) ScrollUp() {
+	if d.offset > 0 {
+		d.offset--
+	}
+}
+
+// ScrollDown scrolls the content down by one line.
+func (d *Details) ScrollDown() {
+	if d.offset < d.maxOffset {
+		d.offset++
+	}
+}
+
+// PageUp scrolls the content up by one page.
+func (d *Details) PageUp() {
+	d.offset = max(d.offset-d.pageSize(), 0)
+}
+
+// PageDown scrolls the content down by one page.
+func (d *Details) PageDown() {
+	d.offset = min(d.offset+d.pageSize(), d.maxOffset)
+}
+
+// pageSize returns the number of content lines visible at once.
+func (d *Details) pageSize() int {
+	return max(d.height-2, 1)
+}
+
 // SetSize sets the details pane dimensions.
 func (d *Details) SetSize(width, height int) {
 	d.width = width
@@ -51,13 +274,20 @@ func (d *Details) View() string {
 	var content string
 	if d.item == nil {
 		// Show placeholder when no item selected
+		d.sections = nil
 		content = Styles.Muted.Render("Select an item to view details")
 	} else {
 		content = d.renderItemDetails()
 	}
+	if innerHeight > 0 {
+		content = d.visibleContent(content, innerHeight)
+	}
 
 	// Use centralized box style with thin rounded border
 	boxStyle := Styles.Box
+	if d.focused {
+		boxStyle = boxStyle.BorderForeground(Colors.Primary)
+	}
 
 	if innerWidth > 0 {
 		boxStyle = boxStyle.Width(innerWidth)
@@ -69,104 +299,308 @@ func (d *Details) View() string {
 	return boxStyle.Render(content)
 }
 
+// visibleContent returns the lines of content that fit in height, starting
+// at the scroll offset. The last line is replaced by a marker when more
+// content follows below.
+func (d *Details) visibleContent(content string, height int) string {
+	lines := strings.Split(content, "\n")
+	d.maxOffset = max(len(lines)-height, 0)
+	d.offset = min(d.offset, d.maxOffset)
+
+	end := min(d.offset+height, len(lines))
+	visible := append([]string(nil), lines[d.offset:end]...)
+	if end < len(lines) {
+		visible[len(visible)-1] = Styles.Muted.Render("↓ more")
+	}
+	return strings.Join(visible, "\n")
+}
+
+// contentWidth returns the width available for text inside the border and
+// padding, or 0 if the pane size is not yet known.
+func (d *Details) contentWidth() int {
+	width := d.width - 2 - Styles.Box.GetHorizontalPadding()
+	if width < 0 {
+		return 0
+	}
+	return width
+}
+
 // renderItemDetails renders the detailed view for the selected item.
 func (d *Details) renderItemDetails() string {
 	// Title with primary color for emphasis
 	titleStyle := lipgloss.NewStyle().
 		Foreground(Colors.Text).
 		Bold(true)
-	title := titleStyle.Render(d.item.Title)
-
-	// Label style for field names
-	labelStyle := lipgloss.NewStyle().
-		Foreground(Colors.TextMuted).
-		Bold(true)
+	width := d.contentWidth()
+	title := titleStyle.Render(truncateEnd(d.item.Title, width))
 
 	// Value style for field values
 	valueStyle := lipgloss.NewStyle().
 		Foreground(Colors.Text)
 
+	d.sections = nil
 	var lines []string
 	lines = append(lines, title)
 	lines = append(lines, "")
 
 	// Check if we have worktree metadata
 	if wtData, ok := d.item.Metadata.(*WorktreeItemData); ok && wtData != nil {
-		// Show full path
-		lines = append(lines, labelStyle.Render("Path"))
-		lines = append(lines, valueStyle.Render(wtData.Path))
+		// Show the path, shortened in the middle unless the full path is asked for
+		if d.showFullPath {
+			lines = d.appendSection(lines, "Path", valueStyle.Render(wrapHard(wtData.Path, width)))
+		} else {
+			lines = d.appendSection(lines, "Path", valueStyle.Render(truncateMiddle(wtData.Path, width)))
+		}
 		lines = append(lines, "")
 
+		// Flag the main worktree, which cannot be removed
+		if wtData.IsMain {
+			lines = d.appendSection(lines, "Worktree", valueStyle.Render(wrapText("Main (holds the repository's .git)", width)))
+			lines = append(lines, "")
+		}
+
+		if wtData.Reviewed {
+			lines = append(lines, Styles.Muted.Render("✓ Reviewed"))
+			lines = append(lines, "")
+		}
+
 		// Show branch name
 		if wtData.IsBare {
-			lines = append(lines, labelStyle.Render("Type"))
-			lines = append(lines, valueStyle.Render("Bare repository"))
+			lines = d.appendSection(lines, "Type", valueStyle.Render("Bare repository"))
 		} else if wtData.IsDetached {
-			lines = append(lines, labelStyle.Render("State"))
-			lines = append(lines, valueStyle.Render("Detached HEAD"))
-			if wtData.CommitHash != "" {
-				lines = append(lines, "")
-				lines = append(lines, labelStyle.Render("Commit"))
-				lines = append(lines, valueStyle.Render(wtData.CommitHash))
-			}
+			lines = d.appendSection(lines, "State", valueStyle.Render("Detached HEAD"))
 		} else {
-			lines = append(lines, labelStyle.Render("Branch"))
-			lines = append(lines, valueStyle.Render(wtData.Branch))
+			branch := []string{valueStyle.Render(truncateEnd(wtData.Branch, width))}
+			if d.mergedPath == wtData.Path && d.mergedInto != "" {
+				mergedStyle := lipgloss.NewStyle().Foreground(Colors.Success)
+				branch = append(branch, mergedStyle.Render(truncateEnd("✓ merged into "+d.mergedInto, width)))
+			}
+			lines = d.appendSection(lines, "Branch", branch...)
+			lines = append(lines, "")
+
+			// Show tracking branch
+			if wtData.Upstream != "" {
+				lines = d.appendSection(lines, "Upstream", valueStyle.Render(truncateEnd("↗ "+wtData.Upstream, width)))
+			} else {
+				lines = d.appendSection(lines, "Upstream", Styles.Muted.Render("no upstream"))
+			}
 		}
 		lines = append(lines, "")
 
+		// Show the checked out commit, shortened unless asked otherwise
+		if !wtData.IsBare && wtData.CommitHash != "" {
+			lines = d.appendSection(lines, "Commit", valueStyle.Render(wrapHard(d.commitHash(wtData.Path, wtData.CommitHash), width)))
+			lines = append(lines, "")
+		}
+
 		// Show status with modified/staged file counts
 		if !wtData.IsBare {
-			lines = append(lines, labelStyle.Render("Status"))
-			statusLine := d.renderStatusLine(wtData)
-			lines = append(lines, statusLine)
+			status := []string{d.renderStatusLine(wtData)}
+
+			// The diff stat is only shown for a worktree with changes
+			if d.diffStatPath == wtData.Path && d.diffStat.files > 0 {
+				status = append(status, Styles.Muted.Render(formatDiffStat(d.diffStat)))
+			}
+
+			// Stashes are only mentioned when there are some
+			if d.stashPath == wtData.Path && d.stashCount > 0 {
+				noun := "stashes"
+				if d.stashCount == 1 {
+					noun = "stash"
+				}
+				status = append(status, Styles.Muted.Render(fmt.Sprintf("%d %s", d.stashCount, noun)))
+			}
+
+			// Submodules are only mentioned when the worktree has some
+			if d.submodulesPath == wtData.Path && d.submodules.Total > 0 {
+				status = append(status, formatSubmoduleStatus(d.submodules))
+			}
+			lines = d.appendSection(lines, "Status", status...)
 		}
+
+		// Show disk usage once computed for this worktree
+		if d.diskUsagePath == wtData.Path && d.diskUsage >= 0 {
+			lines = append(lines, "")
+			lines = d.appendSection(lines, "Disk usage", valueStyle.Render(formatSize(d.diskUsage)))
+		}
+
+		// Show recent commits once loaded for this worktree
+		if !wtData.IsBare && d.commitsPath == wtData.Path {
+			lines = append(lines, "")
+			lines = d.appendSection(lines, "Recent commits", d.renderCommits(width)...)
+		}
+	} else if rbData, ok := d.item.Metadata.(*RemoteBranchItemData); ok && rbData != nil {
+		lines = d.appendSection(lines, "Remote", valueStyle.Render(truncateEnd(rbData.Remote, width)))
+		lines = append(lines, "")
+		lines = d.appendSection(lines, "Remote branch", valueStyle.Render(truncateEnd(rbData.Branch, width)))
+		lines = append(lines, "")
+		lines = append(lines, Styles.Muted.Render(wrapText("Press Enter to track it in a new worktree", width)))
 	} else if d.item.Description != "" {
 		// Fallback to simple description
 		descStyle := lipgloss.NewStyle().
 			Foreground(Colors.TextMuted)
-		lines = append(lines, descStyle.Render(d.item.Description))
+		lines = append(lines, descStyle.Render(wrapText(d.item.Description, width)))
 	}
 
-	return strings.Join(lines, "\n")
+	content := strings.Join(lines, "\n")
+
+	// Mark the section the collapse keys act on while the pane has focus
+	if section, ok := d.currentSection(); ok && d.focused {
+		contentLines := strings.Split(content, "\n")
+		contentLines[section.line] = d.sectionHeader(section.name, true)
+		content = strings.Join(contentLines, "\n")
+	}
+	return content
 }
 
-// renderStatusLine renders the status line showing modified/staged/untracked counts.
-func (d *Details) renderStatusLine(wtData *WorktreeItemData) string {
-	// Style for clean status
-	cleanStyle := lipgloss.NewStyle().
-		Foreground(Colors.Success)
+// commitHash returns the hash of the worktree at path as shown: its first
+// shortHashLength characters, or the full hash when it is shown. git lists
+// worktrees with abbreviated hashes, so the full one set by SetFullHash is
+// preferred when known.
+func (d *Details) commitHash(path, hash string) string {
+	if d.showFullHash {
+		if d.fullHashPath == path && d.fullHash != "" {
+			return d.fullHash
+		}
+		return hash
+	}
+	if len(hash) <= shortHashLength {
+		return hash
+	}
+	return hash[:shortHashLength]
+}
 
-	// Style for modified files (yellow/warning)
-	modifiedStyle := lipgloss.NewStyle().
-		Foreground(Colors.Error)
+// appendSection appends a section to lines: its header with a ▾ marker
+// followed by body, or only the header with a ▸ marker when collapsed.
+func (d *Details) appendSection(lines []string, name string, body ...string) []string {
+	line := 0
+	for _, l := range lines {
+		line += strings.Count(l, "\n") + 1
+	}
+	d.sections = append(d.sections, detailsSection{name: name, line: line})
 
-	// Style for staged files (green/success)
-	stagedStyle := lipgloss.NewStyle().
-		Foreground(Colors.Success)
+	lines = append(lines, d.sectionHeader(name, false))
+	if d.collapsed[name] {
+		return lines
+	}
+	return append(lines, body...)
+}
+
+// sectionHeader renders the header of the section called name, marked as
+// collapsed or expanded, and highlighted if it is current.
+func (d *Details) sectionHeader(name string, current bool) string {
+	marker := "▾ "
+	if d.collapsed[name] {
+		marker = "▸ "
+	}
+	style := lipgloss.NewStyle().
+		Foreground(Colors.TextMuted).
+		Bold(true)
+	if current {
+		style = style.Foreground(Colors.Primary)
+	}
+	return style.Render(marker + name)
+}
+
+// renderCommits renders one line per recent commit: hash, subject and
+// relative date, with the subject truncated to fit width.
+func (d *Details) renderCommits(width int) []string {
+	if len(d.commits) == 0 {
+		return []string{Styles.Muted.Render("No commits")}
+	}
 
-	// Style for untracked files (muted)
-	untrackedStyle := lipgloss.NewStyle().
-		Foreground(Colors.TextMuted)
+	hashStyle := lipgloss.NewStyle().
+		Foreground(Colors.Primary)
+	subjectStyle := lipgloss.NewStyle().
+		Foreground(Colors.Text)
 
-	totalChanges := wtData.ModifiedCount + wtData.StagedCount + wtData.UntrackedCount
-	if totalChanges == 0 {
-		return cleanStyle.Render("✓ Clean")
+	var lines []string
+	for _, c := range d.commits {
+		prefix := c.Hash + " "
+		suffix := " (" + c.RelativeDate + ")"
+		subject := c.Subject
+		if width > 0 {
+			subjectWidth := width - lipgloss.Width(prefix) - lipgloss.Width(suffix)
+			if subjectWidth < 8 {
+				// Too narrow for the date; give the subject the room
+				suffix = ""
+				subjectWidth = width - lipgloss.Width(prefix)
+			}
+			subject = truncateEnd(subject, max(subjectWidth, 1))
+		}
+		lines = append(lines, hashStyle.Render(prefix)+subjectStyle.Render(subject)+Styles.Muted.Render(suffix))
 	}
+	return lines
+}
 
-	var parts []string
+// renderStatusLine renders the status line showing modified/staged/untracked
+// counts, colored by the most significant kind of change. Merge conflicts
+// block most operations, so they get a prominent warning line of their own.
+func (d *Details) renderStatusLine(wtData *WorktreeItemData) string {
+	status := git.WorktreeStatus{
+		ModifiedCount:  wtData.ModifiedCount,
+		StagedCount:    wtData.StagedCount,
+		UntrackedCount: wtData.UntrackedCount,
+	}
 
-	if wtData.StagedCount > 0 {
-		parts = append(parts, stagedStyle.Render(fmt.Sprintf("%d staged", wtData.StagedCount)))
+	var lines []string
+	if wtData.ConflictedCount > 0 {
+		conflictStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(Colors.Error)
+		noun := "conflicts"
+		if wtData.ConflictedCount == 1 {
+			noun = "conflict"
+		}
+		lines = append(lines, conflictStyle.Render(fmt.Sprintf("⚠ %d %s", wtData.ConflictedCount, noun)))
+		if status.IsClean() {
+			return lines[0]
+		}
 	}
 
-	if wtData.ModifiedCount > 0 {
-		parts = append(parts, modifiedStyle.Render(fmt.Sprintf("%d modified", wtData.ModifiedCount)))
+	style := lipgloss.NewStyle()
+	switch {
+	case status.IsClean():
+		return style.Foreground(Colors.Success).Render("✓ " + status.Summary())
+	case status.ModifiedCount > 0:
+		// Unstaged modifications need attention first
+		style = style.Foreground(Colors.Error)
+	case status.StagedCount > 0:
+		style = style.Foreground(Colors.Success)
+	default:
+		// Only untracked files
+		style = style.Foreground(Colors.TextMuted)
 	}
+	lines = append(lines, style.Render(status.Summary()))
+	return strings.Join(lines, "\n")
+}
 
-	if wtData.UntrackedCount > 0 {
-		parts = append(parts, untrackedStyle.Render(fmt.Sprintf("%d untracked", wtData.UntrackedCount)))
+// formatDiffStat renders a diff stat like "Δ 4 files, +120 −33".
+func formatDiffStat(stat diffStat) string {
+	noun := "files"
+	if stat.files == 1 {
+		noun = "file"
 	}
+	return fmt.Sprintf("Δ %d %s, +%d −%d", stat.files, noun, stat.insertions, stat.deletions)
+}
 
-	return strings.Join(parts, ", ")
+// formatSubmoduleStatus renders a submodule summary like "3 submodules:
+// 1 uninitialized, 1 modified", highlighted when some are not clean.
+func formatSubmoduleStatus(status git.SubmoduleStatus) string {
+	noun := "submodules"
+	if status.Total == 1 {
+		noun = "submodule"
+	}
+	var states []string
+	if status.Uninitialized > 0 {
+		states = append(states, fmt.Sprintf("%d uninitialized", status.Uninitialized))
+	}
+	if status.Modified > 0 {
+		states = append(states, fmt.Sprintf("%d modified", status.Modified))
+	}
+	if len(states) == 0 {
+		return Styles.Muted.Render(fmt.Sprintf("%d %s, all clean", status.Total, noun))
+	}
+	return lipgloss.NewStyle().Foreground(Colors.Error).Render(
+		fmt.Sprintf("%d %s: %s", status.Total, noun, strings.Join(states, ", ")))
 }
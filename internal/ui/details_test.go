@@ -3,6 +3,10 @@ package ui
 import (
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/iatopilskii/grove/internal/git"
 )
 
 // TestNewDetails verifies that NewDetails returns a properly initialized Details pane
@@ -359,3 +363,425 @@ func TestDetailsViewMultipleStatusCounts(t *testing.T) {
 		t.Error("View() should show untracked count")
 	}
 }
+
+// TestDetailsViewShowsMainWorktree verifies the main worktree is flagged in details
+func TestDetailsViewShowsMainWorktree(t *testing.T) {
+	details := NewDetails()
+	details.SetSize(80, 20)
+
+	details.SetItem(&ListItem{
+		ID:       "/repo",
+		Title:    "repo",
+		Metadata: &WorktreeItemData{Path: "/repo", Branch: "main", IsMain: true},
+	})
+	if !strings.Contains(details.View(), "Main") {
+		t.Error("View() should flag the main worktree")
+	}
+
+	details.SetItem(&ListItem{
+		ID:       "/feature",
+		Title:    "feature",
+		Metadata: &WorktreeItemData{Path: "/feature", Branch: "feature"},
+	})
+	if strings.Contains(details.View(), "Main (") {
+		t.Error("View() should not flag linked worktrees as main")
+	}
+}
+
+// TestDetailsViewShowsUpstream verifies View displays the tracking branch or "no upstream"
+func TestDetailsViewShowsUpstream(t *testing.T) {
+	details := NewDetails()
+	details.SetSize(80, 30)
+
+	details.SetItem(&ListItem{
+		ID:       "/path/to/worktree",
+		Title:    "feature-x",
+		Metadata: &WorktreeItemData{Path: "/path/to/worktree", Branch: "feature-x", Upstream: "origin/feature-x"},
+	})
+	if !strings.Contains(details.View(), "↗ origin/feature-x") {
+		t.Error("View() should show the upstream branch")
+	}
+
+	details.SetItem(&ListItem{
+		ID:       "/path/to/local",
+		Title:    "local",
+		Metadata: &WorktreeItemData{Path: "/path/to/local", Branch: "local"},
+	})
+	if !strings.Contains(details.View(), "no upstream") {
+		t.Error("View() should show 'no upstream' when there is no tracking branch")
+	}
+}
+
+// TestDetailsViewFitsLongPath verifies long paths do not push lines past the pane width
+func TestDetailsViewFitsLongPath(t *testing.T) {
+	details := NewDetails()
+	details.SetSize(30, 20)
+
+	longPath := "/home/user/projects/some/very/deeply/nested/directory/repo-feature-with-long-name"
+	details.SetItem(&ListItem{
+		ID:       longPath,
+		Title:    "repo-feature-with-a-very-long-worktree-name",
+		Metadata: &WorktreeItemData{Path: longPath, Branch: "feature/with-a-very-long-branch-name", Upstream: "origin/feature/with-a-very-long-branch-name"},
+	})
+
+	view := details.View()
+	for _, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > 30 {
+			t.Errorf("Line width %d exceeds pane width 30: %q", w, line)
+		}
+	}
+	if !strings.Contains(view, "long-name") {
+		t.Error("View() should keep the end of a truncated path")
+	}
+}
+
+// TestDetailsScroll verifies long content scrolls and shows a marker while more remains
+func TestDetailsScroll(t *testing.T) {
+	details := NewDetails()
+	details.SetSize(40, 6) // 4 content lines
+	details.SetItem(&ListItem{
+		ID:       "/path/to/worktree",
+		Title:    "feature",
+		Metadata: &WorktreeItemData{Path: "/path/to/worktree", Branch: "feature"},
+	})
+
+	view := details.View()
+	if !strings.Contains(view, "↓ more") {
+		t.Error("View() should show a marker when content continues below")
+	}
+	if strings.Count(view, "\n") != 5 {
+		t.Errorf("View() should fit the pane height, got %d lines", strings.Count(view, "\n")+1)
+	}
+
+	details.ScrollDown()
+	if details.Offset() != 1 {
+		t.Errorf("Expected offset 1 after ScrollDown, got %d", details.Offset())
+	}
+
+	details.PageDown()
+	details.PageDown()
+	details.PageDown()
+	view = details.View()
+	if strings.Contains(view, "↓ more") {
+		t.Error("Marker should disappear at the bottom of the content")
+	}
+	if !strings.Contains(view, "Clean") {
+		t.Error("Last content line should be visible at the bottom")
+	}
+
+	details.PageUp()
+	details.PageUp()
+	details.PageUp()
+	details.ScrollUp()
+	if details.Offset() != 0 {
+		t.Errorf("Expected offset 0 at the top, got %d", details.Offset())
+	}
+}
+
+// TestDetailsSetItemResetsScroll verifies a new item starts at the top
+func TestDetailsSetItemResetsScroll(t *testing.T) {
+	details := NewDetails()
+	details.SetSize(40, 6)
+	details.SetItem(&ListItem{ID: "a", Title: "a", Metadata: &WorktreeItemData{Path: "a", Branch: "a"}})
+	details.View()
+	details.ScrollDown()
+
+	details.SetItem(&ListItem{ID: "b", Title: "b", Metadata: &WorktreeItemData{Path: "b", Branch: "b"}})
+	if details.Offset() != 0 {
+		t.Errorf("Expected offset reset to 0, got %d", details.Offset())
+	}
+}
+
+// TestDetailsViewShowsRecentCommits verifies commits are shown only for their worktree
+func TestDetailsViewShowsRecentCommits(t *testing.T) {
+	details := NewDetails()
+	details.SetSize(80, 30)
+	details.SetItem(&ListItem{
+		ID:       "/path/to/worktree",
+		Title:    "feature",
+		Metadata: &WorktreeItemData{Path: "/path/to/worktree", Branch: "feature"},
+	})
+
+	if strings.Contains(details.View(), "Recent commits") {
+		t.Error("View() should not show commits before they are loaded")
+	}
+
+	details.SetCommits("/path/to/worktree", []git.CommitInfo{
+		{Hash: "abc1234", RelativeDate: "2 days ago", Subject: "Add feature"},
+	})
+	view := details.View()
+	for _, want := range []string{"Recent commits", "abc1234", "Add feature", "2 days ago"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() should contain %q", want)
+		}
+	}
+
+	details.SetCommits("/path/to/other", nil)
+	if strings.Contains(details.View(), "Recent commits") {
+		t.Error("View() should not show commits of another worktree")
+	}
+}
+
+// TestDetailsViewShowsConflicts verifies merge conflicts are shown as a warning
+func TestDetailsViewShowsConflicts(t *testing.T) {
+	details := NewDetails()
+	details.SetSize(60, 30)
+	details.SetItem(&ListItem{
+		ID:    "/path/to/conflicted",
+		Title: "conflicted",
+		Metadata: &WorktreeItemData{
+			Path:            "/path/to/conflicted",
+			Branch:          "feature",
+			ConflictedCount: 2,
+			ModifiedCount:   1,
+		},
+	})
+
+	view := details.View()
+	if !strings.Contains(view, "⚠ 2 conflicts") {
+		t.Error("View() should warn about conflicts")
+	}
+	if !strings.Contains(view, "1 modified") {
+		t.Error("View() should still show other changes")
+	}
+	if strings.Contains(view, "Clean") {
+		t.Error("A worktree with conflicts should not be shown as clean")
+	}
+}
+
+// TestDetailsViewShowsDiffStat verifies the diff stat is shown for the displayed worktree with changes
+func TestDetailsViewShowsDiffStat(t *testing.T) {
+	details := NewDetails()
+	details.SetSize(80, 30)
+	details.SetItem(&ListItem{
+		ID:       "/path/to/worktree",
+		Title:    "feature",
+		Metadata: &WorktreeItemData{Path: "/path/to/worktree", Branch: "feature", ModifiedCount: 4},
+	})
+
+	details.SetDiffStat("/path/to/worktree", 4, 120, 33)
+	if !strings.Contains(details.View(), "Δ 4 files, +120 −33") {
+		t.Error("View() should show the diff stat")
+	}
+
+	details.SetDiffStat("/path/to/worktree", 1, 2, 0)
+	if !strings.Contains(details.View(), "Δ 1 file, +2 −0") {
+		t.Error("View() should use the singular for one file")
+	}
+
+	details.SetDiffStat("/path/to/worktree", 0, 0, 0)
+	if strings.Contains(details.View(), "Δ") {
+		t.Error("View() should omit the diff stat without changes")
+	}
+
+	details.SetDiffStat("/path/to/other", 4, 120, 33)
+	if strings.Contains(details.View(), "Δ") {
+		t.Error("View() should not show the diff stat of another worktree")
+	}
+}
+
+// TestDetailsViewShowsSubmodules verifies the submodule summary is shown only for worktrees with submodules
+func TestDetailsViewShowsSubmodules(t *testing.T) {
+	details := NewDetails()
+	details.SetSize(80, 30)
+	details.SetItem(&ListItem{
+		ID:       "/path/to/worktree",
+		Title:    "feature",
+		Metadata: &WorktreeItemData{Path: "/path/to/worktree", Branch: "feature"},
+	})
+
+	details.SetSubmoduleStatus("/path/to/worktree", git.SubmoduleStatus{Total: 3, Uninitialized: 1, Modified: 1})
+	if !strings.Contains(details.View(), "3 submodules: 1 uninitialized, 1 modified") {
+		t.Error("View() should summarize the submodules")
+	}
+
+	details.SetSubmoduleStatus("/path/to/worktree", git.SubmoduleStatus{Total: 1})
+	if !strings.Contains(details.View(), "1 submodule, all clean") {
+		t.Error("View() should use the singular for one clean submodule")
+	}
+
+	details.SetSubmoduleStatus("/path/to/worktree", git.SubmoduleStatus{})
+	if strings.Contains(details.View(), "submodule") {
+		t.Error("View() should omit submodules when there are none")
+	}
+
+	details.SetSubmoduleStatus("/path/to/other", git.SubmoduleStatus{Total: 2})
+	if strings.Contains(details.View(), "submodule") {
+		t.Error("View() should not show the submodules of another worktree")
+	}
+}
+
+// TestDetailsViewShowsMerged verifies the merged badge is shown only for the worktree it belongs to
+func TestDetailsViewShowsMerged(t *testing.T) {
+	details := NewDetails()
+	details.SetSize(80, 30)
+	details.SetItem(&ListItem{
+		ID:       "/path/to/worktree",
+		Title:    "feature",
+		Metadata: &WorktreeItemData{Path: "/path/to/worktree", Branch: "feature"},
+	})
+
+	details.SetMergedInto("/path/to/worktree", "main")
+	if !strings.Contains(details.View(), "✓ merged into main") {
+		t.Error("View() should show the merged badge")
+	}
+
+	details.SetMergedInto("/path/to/worktree", "")
+	if strings.Contains(details.View(), "merged into") {
+		t.Error("View() should omit the badge for an unmerged branch")
+	}
+
+	details.SetMergedInto("/path/to/other", "main")
+	if strings.Contains(details.View(), "merged into") {
+		t.Error("View() should not show the badge of another worktree")
+	}
+}
+
+// TestDetailsToggleFullPath verifies the full path is wrapped instead of shortened when toggled
+func TestDetailsToggleFullPath(t *testing.T) {
+	path := "/home/user/projects/some-very-long-directory-name/repo-feature-with-a-long-name"
+	details := NewDetails()
+	details.SetSize(30, 40)
+	details.SetItem(&ListItem{
+		ID:       path,
+		Title:    "repo-feature",
+		Metadata: &WorktreeItemData{Path: path, Branch: "feature"},
+	})
+
+	joined := func() string {
+		// Rejoin the wrapped path from the box contents
+		var b strings.Builder
+		for _, line := range strings.Split(details.View(), "\n") {
+			b.WriteString(strings.Trim(line, "│ "))
+		}
+		return b.String()
+	}
+
+	if strings.Contains(joined(), path) {
+		t.Fatal("View() should shorten a long path by default")
+	}
+
+	details.ToggleFullPath()
+	if !details.ShowFullPath() {
+		t.Fatal("ShowFullPath() should be true after toggling")
+	}
+	if !strings.Contains(joined(), path) {
+		t.Errorf("View() should contain the full path, got %q", details.View())
+	}
+	for _, line := range strings.Split(details.View(), "\n") {
+		if lipgloss.Width(line) > 30 {
+			t.Errorf("Line %q exceeds the pane width", line)
+		}
+	}
+
+	details.ToggleFullPath()
+	if strings.Contains(joined(), path) {
+		t.Error("View() should shorten the path again after toggling back")
+	}
+}
+
+// TestDetailsToggleSection verifies collapsing a section hides its content and changes its marker.
+func TestDetailsToggleSection(t *testing.T) {
+	d := NewDetails()
+	d.SetSize(60, 40)
+	d.SetFocused(true)
+	d.SetItem(&ListItem{ID: "/src/feature", Title: "feature", Metadata: &WorktreeItemData{
+		Path:   "/src/feature",
+		Branch: "feature-branch",
+	}})
+	d.SetCommits("/src/feature", []git.CommitInfo{{Hash: "abc1234", Subject: "Add widgets", RelativeDate: "2 days ago"}})
+
+	view := d.View()
+	if !strings.Contains(view, "▾ Path") || !strings.Contains(view, "▾ Recent commits") {
+		t.Fatalf("Expected expanded section headers, got:\n%s", view)
+	}
+
+	// The section at the top of the pane is toggled
+	d.ToggleSection()
+	view = d.View()
+	if !d.SectionCollapsed("Path") || !strings.Contains(view, "▸ Path") {
+		t.Errorf("Expected the Path section collapsed, got:\n%s", view)
+	}
+	if strings.Contains(view, "/src/feature") {
+		t.Error("Expected the collapsed section to hide the path")
+	}
+	if !strings.Contains(view, "feature-branch") {
+		t.Error("Expected other sections to stay expanded")
+	}
+
+	// Collapsing stays while another worktree is shown
+	d.SetItem(&ListItem{ID: "/src/other", Title: "other", Metadata: &WorktreeItemData{Path: "/src/other", Branch: "other"}})
+	if strings.Contains(d.View(), "/src/other") {
+		t.Error("Expected the Path section to stay collapsed for another worktree")
+	}
+	d.SetItem(&ListItem{ID: "/src/feature", Title: "feature", Metadata: &WorktreeItemData{Path: "/src/feature", Branch: "feature-branch"}})
+
+	d.ToggleSection()
+	if !strings.Contains(d.View(), "/src/feature") {
+		t.Error("Expected toggling again to expand the section")
+	}
+
+	// Collapse all, then expand all
+	d.ToggleAllSections()
+	view = d.View()
+	if strings.Contains(view, "Add widgets") || strings.Contains(view, "feature-branch") || strings.Contains(view, "▾") {
+		t.Errorf("Expected every section collapsed, got:\n%s", view)
+	}
+	d.ToggleAllSections()
+	view = d.View()
+	if !strings.Contains(view, "Add widgets") || strings.Contains(view, "▸") {
+		t.Errorf("Expected every section expanded, got:\n%s", view)
+	}
+}
+
+// TestDetailsToggleFullHash verifies the commit hash is shortened until toggled.
+func TestDetailsToggleFullHash(t *testing.T) {
+	hash := "0123456789abcdef0123456789abcdef01234567"
+	details := NewDetails()
+	details.SetSize(80, 40)
+	details.SetItem(&ListItem{
+		ID:       "/src/feature",
+		Title:    "feature",
+		Metadata: &WorktreeItemData{Path: "/src/feature", Branch: "feature", CommitHash: hash},
+	})
+
+	view := details.View()
+	if !strings.Contains(view, "0123456") || strings.Contains(view, hash) {
+		t.Errorf("View() should show the short hash by default, got:\n%s", view)
+	}
+
+	details.ToggleFullHash()
+	if !details.ShowFullHash() {
+		t.Fatal("ShowFullHash() should be true after toggling")
+	}
+	if !strings.Contains(details.View(), hash) {
+		t.Errorf("View() should show the full hash after toggling, got:\n%s", details.View())
+	}
+
+	details.ToggleFullHash()
+	if strings.Contains(details.View(), hash) {
+		t.Error("View() should shorten the hash again after toggling back")
+	}
+
+	// git lists abbreviated hashes; the full one is shown once loaded
+	details.SetItem(&ListItem{ID: "/src/feature", Title: "feature", Metadata: &WorktreeItemData{Path: "/src/feature", Branch: "feature", CommitHash: hash[:9]}})
+	details.SetFullHash("/src/other", "fedcba9876543210fedcba9876543210fedcba98")
+	details.ToggleFullHash()
+	if view := details.View(); !strings.Contains(view, hash[:9]) || strings.Contains(view, "fedcba98") {
+		t.Errorf("View() should show the listed hash until the worktree's full hash is loaded, got:\n%s", view)
+	}
+	details.SetFullHash("/src/feature", hash)
+	if !strings.Contains(details.View(), hash) {
+		t.Errorf("View() should show the loaded full hash, got:\n%s", details.View())
+	}
+	details.ToggleFullHash()
+	if view := details.View(); strings.Contains(view, hash[:9]) || !strings.Contains(view, "0123456") {
+		t.Errorf("View() should shorten the hash after toggling back, got:\n%s", view)
+	}
+
+	// A bare repository has no commit checked out to show
+	details.SetItem(&ListItem{ID: "/src/repo.git", Title: "repo.git", Metadata: &WorktreeItemData{Path: "/src/repo.git", IsBare: true, CommitHash: hash}})
+	if strings.Contains(details.View(), "0123456") {
+		t.Error("View() should not show a commit for a bare repository")
+	}
+}
@@ -26,6 +26,7 @@ type Feedback struct {
 	feedbackType FeedbackType
 	visible      bool
 	duration     time.Duration
+	log          *MessageLog // records every message shown (nil = none)
 }
 
 // NewFeedback creates a new feedback component.
@@ -50,30 +51,38 @@ func (f *Feedback) Type() FeedbackType {
 	return f.feedbackType
 }
 
+// SetLog sets the message log that records every message shown; nil stops
+// recording.
+func (f *Feedback) SetLog(log *MessageLog) {
+	f.log = log
+}
+
 // ClearFeedbackMsg is sent to clear the feedback message.
 type ClearFeedbackMsg struct{}
 
 // ShowSuccess displays a success message.
 func (f *Feedback) ShowSuccess(message string) tea.Cmd {
-	f.message = message
-	f.feedbackType = FeedbackSuccess
-	f.visible = true
-	return f.scheduleClear()
+	return f.show(FeedbackSuccess, message)
 }
 
 // ShowError displays an error message.
 func (f *Feedback) ShowError(message string) tea.Cmd {
-	f.message = message
-	f.feedbackType = FeedbackError
-	f.visible = true
-	return f.scheduleClear()
+	return f.show(FeedbackError, message)
 }
 
 // ShowInfo displays an informational message.
 func (f *Feedback) ShowInfo(message string) tea.Cmd {
+	return f.show(FeedbackInfo, message)
+}
+
+// show displays a message of the given type and records it in the log.
+func (f *Feedback) show(feedbackType FeedbackType, message string) tea.Cmd {
 	f.message = message
-	f.feedbackType = FeedbackInfo
+	f.feedbackType = feedbackType
 	f.visible = true
+	if f.log != nil {
+		f.log.Record(feedbackType, message)
+	}
 	return f.scheduleClear()
 }
 
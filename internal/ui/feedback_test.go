@@ -208,3 +208,23 @@ func TestFeedbackTypeConstants(t *testing.T) {
 		t.Error("FeedbackError should not equal FeedbackInfo")
 	}
 }
+
+// TestFeedbackRecordsInLog verifies every message shown is recorded in the log.
+func TestFeedbackRecordsInLog(t *testing.T) {
+	f := NewFeedback()
+	f.ShowInfo("not recorded")
+
+	log := NewMessageLog(10)
+	f.SetLog(log)
+	f.ShowError("Failed")
+	f.ShowSuccess("Done")
+	f.ShowInfo("Note")
+
+	entries := log.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %+v", entries)
+	}
+	if entries[0].Type != FeedbackError || entries[1].Type != FeedbackSuccess || entries[2].Message != "Note" {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+}
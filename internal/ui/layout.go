@@ -0,0 +1,69 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+// Layout is the arrangement of the list and details panes.
+type Layout int
+
+const (
+	// LayoutAuto places the panes side by side on wide terminals and
+	// stacks them on narrow ones.
+	LayoutAuto Layout = iota
+	// LayoutHorizontal always places the list left of the details.
+	LayoutHorizontal
+	// LayoutVertical always places the list above the details.
+	LayoutVertical
+	// LayoutCompact drops the details pane and shows each worktree's
+	// branch and status on its row, using the full width. The details are
+	// shown on demand.
+	LayoutCompact
+)
+
+// LayoutCount is the total number of layouts.
+const LayoutCount = 4
+
+// verticalLayoutWidth is the terminal width below which LayoutAuto stacks
+// the panes.
+const verticalLayoutWidth = 80
+
+// String returns the name of the layout, as stored in the state file.
+func (l Layout) String() string {
+	switch l {
+	case LayoutAuto:
+		return "auto"
+	case LayoutHorizontal:
+		return "horizontal"
+	case LayoutVertical:
+		return "vertical"
+	case LayoutCompact:
+		return "compact"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLayout returns the layout with the given name.
+func ParseLayout(name string) (Layout, bool) {
+	for l := Layout(0); l < LayoutCount; l++ {
+		if l.String() == name {
+			return l, true
+		}
+	}
+	return LayoutAuto, false
+}
+
+// Next returns the layout after l, wrapping around to LayoutAuto.
+func (l Layout) Next() Layout {
+	return (l + 1) % LayoutCount
+}
+
+// resolve returns the layout used at the given terminal width: an explicit
+// layout wins, and LayoutAuto picks one by width.
+func (l Layout) resolve(width int) Layout {
+	if l != LayoutAuto {
+		return l
+	}
+	if width < verticalLayoutWidth {
+		return LayoutVertical
+	}
+	return LayoutHorizontal
+}
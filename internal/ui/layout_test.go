@@ -0,0 +1,48 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import "testing"
+
+// TestLayoutResolve verifies an explicit layout wins over the width-based choice
+func TestLayoutResolve(t *testing.T) {
+	tests := []struct {
+		layout Layout
+		width  int
+		want   Layout
+	}{
+		{LayoutAuto, 120, LayoutHorizontal},
+		{LayoutAuto, verticalLayoutWidth, LayoutHorizontal},
+		{LayoutAuto, verticalLayoutWidth - 1, LayoutVertical},
+		{LayoutHorizontal, 50, LayoutHorizontal},
+		{LayoutVertical, 200, LayoutVertical},
+		{LayoutCompact, 200, LayoutCompact},
+	}
+
+	for _, tt := range tests {
+		if got := tt.layout.resolve(tt.width); got != tt.want {
+			t.Errorf("%v.resolve(%d) = %v, want %v", tt.layout, tt.width, got, tt.want)
+		}
+	}
+}
+
+// TestParseLayout verifies layouts round-trip through their names
+func TestParseLayout(t *testing.T) {
+	for l := Layout(0); l < LayoutCount; l++ {
+		got, ok := ParseLayout(l.String())
+		if !ok || got != l {
+			t.Errorf("ParseLayout(%q) = %v, %v; want %v, true", l.String(), got, ok, l)
+		}
+	}
+
+	if _, ok := ParseLayout(""); ok {
+		t.Error("ParseLayout should reject an empty name")
+	}
+}
+
+// TestLayoutNext verifies layouts cycle back to auto
+func TestLayoutNext(t *testing.T) {
+	if LayoutAuto.Next() != LayoutHorizontal || LayoutHorizontal.Next() != LayoutVertical ||
+		LayoutVertical.Next() != LayoutCompact || LayoutCompact.Next() != LayoutAuto {
+		t.Error("Layouts should cycle auto → horizontal → vertical → compact → auto")
+	}
+}
@@ -2,7 +2,10 @@
 package ui
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -25,9 +28,56 @@ type WorktreeItemData struct {
 	CommitHash     string
 	IsBare         bool
 	IsDetached     bool
+	IsMain         bool
 	ModifiedCount  int
 	StagedCount    int
 	UntrackedCount int
+	// ConflictedCount is the number of files with unresolved merge conflicts.
+	ConflictedCount int
+	// Upstream is the tracking branch (e.g. "origin/main"), empty if none.
+	Upstream string
+	// Ahead and Behind count the commits not yet pushed to and pulled
+	// from the upstream.
+	Ahead  int
+	Behind int
+	// Reviewed is the personal reviewed/done mark kept in the state file.
+	Reviewed bool
+	// StatusKnown is set when the counts, upstream, Ahead and Behind come
+	// from a status read. It is false while a background scan is pending or
+	// after git status failed or timed out, when they are all zero.
+	StatusKnown bool
+}
+
+// HasChanges reports whether the worktree has modified, staged, untracked
+// or conflicted files.
+func (d *WorktreeItemData) HasChanges() bool {
+	return d.ModifiedCount+d.StagedCount+d.UntrackedCount+d.ConflictedCount > 0
+}
+
+// isDirtyItem reports whether item is a worktree with changes, based on
+// its last read status.
+func isDirtyItem(item ListItem) bool {
+	wtData, ok := item.Metadata.(*WorktreeItemData)
+	return ok && wtData != nil && wtData.HasChanges()
+}
+
+// RemoteBranchItemData holds data for a list item representing a remote-tracking branch.
+type RemoteBranchItemData struct {
+	// Ref is the full remote-tracking branch name (e.g. "origin/feature").
+	Ref string
+	// Remote is the remote name (e.g. "origin").
+	Remote string
+	// Branch is the branch name on the remote (e.g. "feature").
+	Branch string
+}
+
+// digitJumpDelay is how long after the last typed digit the number is
+// applied as a jump to that item.
+const digitJumpDelay = 700 * time.Millisecond
+
+// DigitJumpMsg is sent when no digit has been typed for digitJumpDelay.
+type DigitJumpMsg struct {
+	seq int // matches List.digitSeq unless more digits were typed since
 }
 
 // List is a scrollable list component.
@@ -36,8 +86,25 @@ type List struct {
 	selected int
 	width    int
 	height   int
-	offsetX  int // X position on screen for mouse handling
-	offsetY  int // Y position on screen for mouse handling
+	offsetX  int  // X position on screen for mouse handling
+	offsetY  int  // Y position on screen for mouse handling
+	pendingG bool // true after a single 'g', awaiting a second for "gg"
+	// digits buffers a typed 1-based item number, e.g. "12", until it is
+	// applied after an idle delay or on Enter
+	digits   string
+	digitSeq int // incremented per typed digit so only the last delay applies
+	// showNumbers prefixes each row with its 1-based index
+	showNumbers bool
+	blurred     bool // true when another pane has keyboard focus
+	// emptyHint is guidance shown below the items, e.g. when there is
+	// nothing to act on yet (empty = none)
+	emptyHint string
+	// scrollOffset is the index of the first rendered item, updated whenever
+	// the selection, items or size change so the selected item stays visible
+	scrollOffset int
+	// inline adds each worktree's branch and status to its row, for layouts
+	// without a details pane
+	inline bool
 }
 
 // NewList creates a new list with the given items.
@@ -48,6 +115,50 @@ func NewList(items []ListItem) *List {
 	}
 }
 
+// SetEmptyHint sets guidance shown below the items. An empty hint hides it.
+func (l *List) SetEmptyHint(hint string) {
+	l.emptyHint = hint
+	l.scrollToSelected()
+}
+
+// EmptyHint returns the guidance shown below the items.
+func (l *List) EmptyHint() string {
+	return l.emptyHint
+}
+
+// ShowNumbers returns whether rows are prefixed with their index.
+func (l *List) ShowNumbers() bool {
+	return l.showNumbers
+}
+
+// SetShowNumbers sets whether rows are prefixed with their index. Numbers
+// are also shown while an item number is being typed.
+func (l *List) SetShowNumbers(show bool) {
+	l.showNumbers = show
+}
+
+// Inline returns whether worktree rows show their branch and status.
+func (l *List) Inline() bool {
+	return l.inline
+}
+
+// SetInline sets whether worktree rows show their branch and status after
+// the title, as used when there is no details pane.
+func (l *List) SetInline(inline bool) {
+	l.inline = inline
+}
+
+// Focused returns whether the list has keyboard focus.
+func (l *List) Focused() bool {
+	return !l.blurred
+}
+
+// SetFocused sets whether the list has keyboard focus.
+// An unfocused list renders its selection muted.
+func (l *List) SetFocused(focused bool) {
+	l.blurred = !focused
+}
+
 // Items returns all items in the list.
 func (l *List) Items() []ListItem {
 	return l.items
@@ -62,6 +173,7 @@ func (l *List) SetItems(items []ListItem) {
 	} else if l.selected >= len(items) {
 		l.selected = len(items) - 1
 	}
+	l.scrollToSelected()
 }
 
 // Selected returns the index of the currently selected item.
@@ -71,19 +183,28 @@ func (l *List) Selected() int {
 
 // SetSelected sets the selected index with bounds checking.
 func (l *List) SetSelected(index int) {
-	if len(l.items) == 0 {
-		l.selected = 0
-		return
-	}
-	if index < 0 {
+	switch {
+	case len(l.items) == 0 || index < 0:
 		l.selected = 0
-		return
-	}
-	if index >= len(l.items) {
+	case index >= len(l.items):
 		l.selected = len(l.items) - 1
-		return
+	default:
+		l.selected = index
+	}
+	l.scrollToSelected()
+}
+
+// SelectByID selects the item with the given ID.
+// Returns false and leaves the selection unchanged if no item matches.
+func (l *List) SelectByID(id string) bool {
+	for i, item := range l.items {
+		if item.ID == id {
+			l.selected = i
+			l.scrollToSelected()
+			return true
+		}
 	}
-	l.selected = index
+	return false
 }
 
 // SelectedItem returns the currently selected item, or nil if the list is empty.
@@ -102,6 +223,7 @@ func (l *List) MoveDown() {
 	if l.selected < len(l.items)-1 {
 		l.selected++
 	}
+	l.scrollToSelected()
 }
 
 // MoveUp moves the selection up by one.
@@ -112,42 +234,142 @@ func (l *List) MoveUp() {
 	if l.selected > 0 {
 		l.selected--
 	}
+	l.scrollToSelected()
 }
 
-// PageDown moves the selection down by one page (based on visible height).
-func (l *List) PageDown() {
+// MoveToTop moves the selection to the first item.
+func (l *List) MoveToTop() {
+	l.selected = 0
+	l.scrollToSelected()
+}
+
+// MoveToBottom moves the selection to the last item.
+func (l *List) MoveToBottom() {
 	if len(l.items) == 0 {
 		return
 	}
-	pageSize := l.height
-	if pageSize < 1 {
-		pageSize = 1
+	l.selected = len(l.items) - 1
+	l.scrollToSelected()
+}
+
+// NextMatching selects the next item after the selected one for which
+// match returns true, wrapping around at the end. It returns false and
+// leaves the selection unchanged if no item matches.
+func (l *List) NextMatching(match func(ListItem) bool) bool {
+	return l.selectMatching(1, match)
+}
+
+// PrevMatching is like NextMatching but searches backwards, wrapping
+// around at the start.
+func (l *List) PrevMatching(match func(ListItem) bool) bool {
+	return l.selectMatching(-1, match)
+}
+
+// selectMatching selects the first matching item in the given direction,
+// ending with the selected item itself.
+func (l *List) selectMatching(step int, match func(ListItem) bool) bool {
+	n := len(l.items)
+	for i := 1; i <= n; i++ {
+		index := ((l.selected+step*i)%n + n) % n
+		if match(l.items[index]) {
+			l.selected = index
+			l.scrollToSelected()
+			return true
+		}
+	}
+	return false
+}
+
+// CancelPendingKey discards a partially entered key sequence such as a
+// single 'g' or a typed item number.
+func (l *List) CancelPendingKey() {
+	l.pendingG = false
+	l.digits = ""
+}
+
+// PendingNumber returns the item number typed so far, or "" if none.
+func (l *List) PendingNumber() string {
+	return l.digits
+}
+
+// JumpToPendingNumber selects the item with the typed 1-based number,
+// clamped to the list, and clears the number. It returns false if no
+// number was typed.
+func (l *List) JumpToPendingNumber() bool {
+	if l.digits == "" {
+		return false
+	}
+	n, err := strconv.Atoi(l.digits)
+	l.digits = ""
+	if err != nil {
+		// Too many digits to parse: beyond any item
+		n = len(l.items)
 	}
-	l.selected += pageSize
+	l.SetSelected(n - 1)
+	return true
+}
+
+// PageDown moves the selection down by one page of visible rows.
+func (l *List) PageDown() {
+	if len(l.items) == 0 {
+		return
+	}
+	l.selected += l.visibleRows()
 	if l.selected >= len(l.items) {
 		l.selected = len(l.items) - 1
 	}
+	l.scrollToSelected()
 }
 
-// PageUp moves the selection up by one page (based on visible height).
+// PageUp moves the selection up by one page of visible rows.
 func (l *List) PageUp() {
 	if len(l.items) == 0 {
 		return
 	}
-	pageSize := l.height
-	if pageSize < 1 {
-		pageSize = 1
-	}
-	l.selected -= pageSize
+	l.selected -= l.visibleRows()
 	if l.selected < 0 {
 		l.selected = 0
 	}
+	l.scrollToSelected()
+}
+
+// visibleRows returns how many items fit in the list's height: the empty
+// hint below the items takes a blank line and its own lines. At least one
+// row is assumed so paging always moves.
+func (l *List) visibleRows() int {
+	rows := l.height
+	if l.emptyHint != "" {
+		rows -= 1 + lipgloss.Height(wrapText(l.emptyHint, l.width))
+	}
+	return max(rows, 1)
+}
+
+// renderedRows returns how many items View renders: all of them unless the
+// height is set and too small.
+func (l *List) renderedRows() int {
+	if l.height <= 0 {
+		return len(l.items)
+	}
+	return min(l.visibleRows(), len(l.items))
+}
+
+// scrollToSelected moves the scroll offset the least needed to show the
+// selected item, keeping the rendered rows filled.
+func (l *List) scrollToSelected() {
+	rows := l.renderedRows()
+	if l.selected < l.scrollOffset {
+		l.scrollOffset = l.selected
+	} else if l.selected >= l.scrollOffset+rows {
+		l.scrollOffset = l.selected - rows + 1
+	}
+	l.scrollOffset = max(min(l.scrollOffset, len(l.items)-rows), 0)
 }
 
 // SetSize sets the list dimensions for rendering.
 func (l *List) SetSize(width, height int) {
 	l.width = width
 	l.height = height
+	l.scrollToSelected()
 }
 
 // SetOffset sets the screen position of the list for mouse handling.
@@ -165,7 +387,27 @@ func (l *List) IsInBounds(x, y int) bool {
 // Update handles input messages for the list.
 func (l *List) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
+	case DigitJumpMsg:
+		// Only the delay started by the last digit applies the number
+		if msg.seq == l.digitSeq {
+			l.JumpToPendingNumber()
+		}
 	case tea.KeyMsg:
+		// Any key other than 'g' cancels a pending "gg" sequence
+		pendingG := l.pendingG
+		l.pendingG = false
+
+		// Digits build an item number; any other key discards it
+		if isDigitKey(msg) {
+			l.digits += string(msg.Runes[0])
+			l.digitSeq++
+			seq := l.digitSeq
+			return tea.Tick(digitJumpDelay, func(time.Time) tea.Msg {
+				return DigitJumpMsg{seq: seq}
+			})
+		}
+		l.digits = ""
+
 		switch msg.Type {
 		case tea.KeyDown:
 			l.MoveDown()
@@ -182,6 +424,15 @@ func (l *List) Update(msg tea.Msg) tea.Cmd {
 					l.MoveDown()
 				case 'k':
 					l.MoveUp()
+				case 'g':
+					// "gg" jumps to the first item
+					if pendingG {
+						l.MoveToTop()
+					} else {
+						l.pendingG = true
+					}
+				case 'G':
+					l.MoveToBottom()
 				}
 			}
 		}
@@ -190,9 +441,10 @@ func (l *List) Update(msg tea.Msg) tea.Cmd {
 		case tea.MouseButtonLeft:
 			// Handle click to select item
 			if len(l.items) > 0 && l.IsInBounds(msg.X, msg.Y) {
-				// Calculate which item was clicked
-				clickedIndex := msg.Y - l.offsetY
-				if clickedIndex >= 0 && clickedIndex < len(l.items) {
+				// Rows below the rendered items, e.g. the hint, select nothing
+				row := msg.Y - l.offsetY
+				clickedIndex := l.scrollOffset + row
+				if row >= 0 && row < l.renderedRows() && clickedIndex < len(l.items) {
 					l.SetSelected(clickedIndex)
 				}
 			}
@@ -205,9 +457,17 @@ func (l *List) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// isDigitKey reports whether msg is a single digit keypress.
+func isDigitKey(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] >= '0' && msg.Runes[0] <= '9'
+}
+
 // View renders the list.
 func (l *List) View() string {
 	if len(l.items) == 0 {
+		if l.emptyHint != "" {
+			return Styles.Muted.Render(wrapText(l.emptyHint, l.width))
+		}
 		return Styles.Muted.Render("No items")
 	}
 
@@ -221,6 +481,9 @@ func (l *List) View() string {
 	// Focus indicator is subtle: colored text with indicator symbol, no background
 	selectedStyle := Styles.ListItem.Selected
 	normalStyle := Styles.ListItem.Normal
+	if l.blurred {
+		selectedStyle = Styles.Muted.PaddingRight(1)
+	}
 
 	// Apply width if set
 	if effectiveWidth > 0 {
@@ -228,14 +491,120 @@ func (l *List) View() string {
 		normalStyle = normalStyle.Width(effectiveWidth)
 	}
 
+	// Numbers are right-aligned so titles stay aligned, e.g. " 9. " and "10. "
+	numberWidth := 0
+	if l.showNumbers || l.digits != "" {
+		numberWidth = len(strconv.Itoa(len(l.items)))
+	}
+
+	// Only the rows that fit are rendered, scrolled to the selected item
+	end := min(l.scrollOffset+l.renderedRows(), len(l.items))
+
 	var lines []string
-	for i, item := range l.items {
+	for i := l.scrollOffset; i < end; i++ {
+		item := l.items[i]
+		prefix, suffix, info, badge := "", "", "", ""
+		if numberWidth > 0 {
+			prefix = fmt.Sprintf("%*d. ", numberWidth, i+1)
+		}
+		if wtData, ok := item.Metadata.(*WorktreeItemData); ok && wtData != nil {
+			if wtData.IsBare {
+				suffix = " (bare)"
+			} else if wtData.IsMain {
+				suffix = " (main)"
+			}
+			if wtData.Reviewed {
+				suffix += " ✓"
+			}
+			if l.inline {
+				info = "  " + inlineInfo(item.Description, wtData)
+			}
+			badge = syncBadge(wtData)
+		}
+
+		// The badge sits at the right edge, so titles stay aligned
+		rowSelected, rowNormal := selectedStyle, normalStyle
+		badgeWidth := lipgloss.Width(badge)
+		if effectiveWidth > 0 && badge != "" {
+			rowSelected = rowSelected.Width(max(effectiveWidth-badgeWidth, 1))
+			rowNormal = rowNormal.Width(max(effectiveWidth-badgeWidth, 1))
+		}
+
+		// Long titles lose their middle, e.g. "feature/JIR…cription", so
+		// both ends stay visible and the row does not wrap
+		title := item.Title
+		if effectiveWidth > 0 {
+			available := effectiveWidth - badgeWidth - selectedStyle.GetPaddingRight() - lipgloss.Width(prefix) - lipgloss.Width(suffix)
+			// The inline branch and status give way to the title
+			if info != "" {
+				room := available - lipgloss.Width(title)
+				if room < lipgloss.Width(info) {
+					info = truncateEnd(info, max(room, 0))
+				}
+				if room <= 2 {
+					info = ""
+				}
+				available -= lipgloss.Width(info)
+			}
+			title = truncateMiddle(title, max(available, 1))
+		}
+		title = prefix + title + suffix + info
+
 		if i == l.selected {
-			lines = append(lines, FocusIndicator.Symbol+selectedStyle.Render(item.Title))
+			lines = append(lines, FocusIndicator.Symbol+rowSelected.Render(title)+badge)
 		} else {
-			lines = append(lines, FocusIndicator.SymbolInactive+normalStyle.Render(item.Title))
+			lines = append(lines, FocusIndicator.SymbolInactive+rowNormal.Render(title)+badge)
 		}
 	}
 
+	if l.emptyHint != "" {
+		lines = append(lines, "", Styles.Muted.Render(wrapText(l.emptyHint, l.width)))
+	}
+
 	return strings.Join(lines, "\n")
 }
+
+// inlineInfo summarizes a worktree on one line for inline rows: its
+// description, such as the branch, and its status, e.g.
+// "feature  ~2 +1 ?3" or "main  ✓".
+func inlineInfo(description string, wtData *WorktreeItemData) string {
+	if wtData.IsBare {
+		return description
+	}
+	var parts []string
+	if wtData.ConflictedCount > 0 {
+		parts = append(parts, fmt.Sprintf("!%d", wtData.ConflictedCount))
+	}
+	if wtData.ModifiedCount > 0 {
+		parts = append(parts, fmt.Sprintf("~%d", wtData.ModifiedCount))
+	}
+	if wtData.StagedCount > 0 {
+		parts = append(parts, fmt.Sprintf("+%d", wtData.StagedCount))
+	}
+	if wtData.UntrackedCount > 0 {
+		parts = append(parts, fmt.Sprintf("?%d", wtData.UntrackedCount))
+	}
+	status := "✓"
+	if len(parts) > 0 {
+		status = strings.Join(parts, " ")
+	}
+	if description == "" {
+		return status
+	}
+	return description + "  " + status
+}
+
+// syncBadge renders how the worktree's branch differs from its upstream:
+// "↑2" when it has commits to push, "↓1" when it has commits to pull, and
+// both when it has diverged. Empty when it is in sync or has no upstream.
+func syncBadge(wtData *WorktreeItemData) string {
+	var parts []string
+	if wtData.Ahead > 0 {
+		// Unpushed commits are lost if the worktree's branch is deleted
+		parts = append(parts, lipgloss.NewStyle().Foreground(Colors.Error).Render(fmt.Sprintf("↑%d", wtData.Ahead)))
+	}
+	if wtData.Behind > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(Colors.Info).Render(fmt.Sprintf("↓%d", wtData.Behind)))
+	}
+	return strings.Join(parts, "")
+}
@@ -1,10 +1,12 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // TestNewList verifies that NewList returns a properly initialized List
@@ -384,6 +386,36 @@ func TestListPageDown(t *testing.T) {
 	}
 }
 
+// TestListPageDownVisibleRows verifies a page is the rows left for items
+// when the empty hint takes part of the height
+func TestListPageDownVisibleRows(t *testing.T) {
+	items := make([]ListItem, 20)
+	for i := range items {
+		items[i] = ListItem{ID: string(rune('a' + i)), Title: "Item"}
+	}
+	list := NewList(items)
+	list.SetSize(40, 8)
+	list.SetOffset(0, 3)
+	list.SetEmptyHint("press n to create one")
+
+	// A blank line and one line of hint leave 6 rows for items
+	list.PageDown()
+	if list.Selected() != 6 {
+		t.Errorf("after PageDown with height=8 and a hint, Selected() = %d, want 6", list.Selected())
+	}
+	list.PageUp()
+	if list.Selected() != 0 {
+		t.Errorf("after PageUp, Selected() = %d, want 0", list.Selected())
+	}
+
+	// A height smaller than the hint still pages by one row
+	list.SetSize(40, 2)
+	list.PageDown()
+	if list.Selected() != 1 {
+		t.Errorf("PageDown should move at least one row, got %d", list.Selected())
+	}
+}
+
 // TestListPageUp verifies PageUp moves selection by page size
 func TestListPageUp(t *testing.T) {
 	// Create a list with 20 items
@@ -568,6 +600,72 @@ func TestListMouseClickOutOfBounds(t *testing.T) {
 	// Should not crash
 }
 
+// TestListMouseClickScrolled verifies clicks map to the rendered rows once
+// the list has scrolled
+func TestListMouseClickScrolled(t *testing.T) {
+	items := make([]ListItem, 20)
+	for i := range items {
+		items[i] = ListItem{ID: fmt.Sprintf("%d", i), Title: fmt.Sprintf("Item %d", i)}
+	}
+	list := NewList(items)
+	list.SetSize(80, 5)
+	list.SetOffset(0, 2)
+
+	// Selecting the last item scrolls to show it, before any rendering
+	list.SetSelected(19)
+	if list.scrollOffset != 15 {
+		t.Fatalf("scrollOffset = %d, want 15", list.scrollOffset)
+	}
+	view := list.View()
+	if strings.Contains(view, "Item 14") || !strings.Contains(view, "Item 15") || !strings.Contains(view, "Item 19") {
+		t.Errorf("View should render items 15-19 only, got %q", view)
+	}
+
+	click := func(y int) {
+		list.Update(tea.MouseMsg{Type: tea.MouseLeft, Button: tea.MouseButtonLeft, X: 5, Y: y})
+	}
+	click(2)
+	if list.Selected() != 15 {
+		t.Errorf("click on the first visible row selected %d, want 15", list.Selected())
+	}
+	click(4)
+	if list.Selected() != 17 {
+		t.Errorf("click on the third visible row selected %d, want 17", list.Selected())
+	}
+
+	// Rows below the rendered items select nothing
+	list.SetItems(items[:3])
+	list.SetSelected(0)
+	click(5)
+	if list.Selected() != 0 {
+		t.Errorf("click below the items should not change selection, got %d", list.Selected())
+	}
+}
+
+// TestListViewScrollsToSelection verifies moving up past the first rendered
+// row scrolls back
+func TestListViewScrollsToSelection(t *testing.T) {
+	items := make([]ListItem, 10)
+	for i := range items {
+		items[i] = ListItem{ID: fmt.Sprintf("%d", i), Title: fmt.Sprintf("Item %d", i)}
+	}
+	list := NewList(items)
+	list.SetSize(80, 3)
+
+	list.SetSelected(9)
+	list.SetSelected(4)
+	view := list.View()
+	if list.scrollOffset != 4 || !strings.Contains(view, "Item 4") || strings.Contains(view, "Item 7") {
+		t.Errorf("Expected items 4-6 rendered, scrollOffset %d, got %q", list.scrollOffset, view)
+	}
+
+	// Without a height every item is rendered
+	list.SetSize(80, 0)
+	if view := list.View(); !strings.Contains(view, "Item 0") || !strings.Contains(view, "Item 9") {
+		t.Errorf("Expected every item without a height, got %q", view)
+	}
+}
+
 // TestListMouseClickEmpty verifies clicking on empty list doesn't crash
 func TestListMouseClickEmpty(t *testing.T) {
 	list := NewList(nil)
@@ -796,3 +894,393 @@ func TestListItemWithNilMetadata(t *testing.T) {
 		t.Error("Type assertion on nil should return false")
 	}
 }
+
+// TestListViewShowsMainTag verifies the main worktree is tagged in the list
+func TestListViewShowsMainTag(t *testing.T) {
+	list := NewList([]ListItem{
+		{ID: "/repo", Title: "repo", Metadata: &WorktreeItemData{Path: "/repo", IsMain: true}},
+		{ID: "/feature", Title: "feature", Metadata: &WorktreeItemData{Path: "/feature"}},
+	})
+	list.SetSize(40, 10)
+
+	view := list.View()
+	if !strings.Contains(view, "repo (main)") {
+		t.Error("View() should tag the main worktree with '(main)'")
+	}
+	if strings.Contains(view, "feature (main)") {
+		t.Error("View() should not tag linked worktrees")
+	}
+}
+
+// TestListViewTruncatesLongTitles verifies long titles are shortened in the middle to fit one row
+func TestListViewTruncatesLongTitles(t *testing.T) {
+	branch := "feature/JIRA-1234-very-long-description"
+	list := NewList([]ListItem{
+		{ID: "/repo", Title: branch, Metadata: &WorktreeItemData{Path: "/repo", IsMain: true}},
+		{ID: "/short", Title: "short"},
+	})
+	list.SetSize(24, 10)
+	list.SetShowNumbers(true)
+
+	lines := strings.Split(list.View(), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per item, got %d: %q", len(lines), lines)
+	}
+	for _, line := range lines {
+		if lipgloss.Width(line) > 24 {
+			t.Errorf("Line %q is wider than the list", line)
+		}
+	}
+	if !strings.Contains(lines[0], "1. feat") || !strings.Contains(lines[0], ellipsis+"ption (main)") {
+		t.Errorf("Expected both ends of the title with number and tag kept, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "short") || strings.Contains(lines[1], ellipsis) {
+		t.Errorf("Titles that fit should not be truncated, got %q", lines[1])
+	}
+
+	// Without a width nothing is truncated
+	list.SetSize(0, 10)
+	if !strings.Contains(list.View(), branch) {
+		t.Error("Titles should be complete when the list has no width")
+	}
+}
+
+// TestListViewSyncBadge verifies rows show unpushed and unpulled commits at a stable width.
+func TestListViewSyncBadge(t *testing.T) {
+	list := NewList([]ListItem{
+		{ID: "/ahead", Title: "ahead", Metadata: &WorktreeItemData{Path: "/ahead", Ahead: 2}},
+		{ID: "/behind", Title: "behind", Metadata: &WorktreeItemData{Path: "/behind", Behind: 1}},
+		{ID: "/diverged", Title: "diverged", Metadata: &WorktreeItemData{Path: "/diverged", Ahead: 3, Behind: 4}},
+		{ID: "/synced", Title: "synced", Metadata: &WorktreeItemData{Path: "/synced"}},
+	})
+	list.SetSize(30, 10)
+
+	lines := strings.Split(list.View(), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected one line per item, got %d: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "↑2") || strings.Contains(lines[0], "↓") {
+		t.Errorf("Expected only the ahead indicator, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "↓1") || strings.Contains(lines[1], "↑") {
+		t.Errorf("Expected only the behind indicator, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "↑3") || !strings.Contains(lines[2], "↓4") {
+		t.Errorf("Expected both indicators when diverged, got %q", lines[2])
+	}
+	if strings.ContainsAny(lines[3], "↑↓") {
+		t.Errorf("Expected no indicator when in sync, got %q", lines[3])
+	}
+	for _, line := range lines {
+		if lipgloss.Width(line) != lipgloss.Width(lines[3]) {
+			t.Errorf("Expected every row to be as wide as the list, got %d for %q", lipgloss.Width(line), line)
+		}
+	}
+}
+
+// TestListViewInline verifies inline rows show each worktree's branch and status.
+func TestListViewInline(t *testing.T) {
+	list := NewList([]ListItem{
+		{ID: "/clean", Title: "clean", Description: "main", Metadata: &WorktreeItemData{Path: "/clean", Ahead: 1}},
+		{ID: "/dirty", Title: "dirty", Description: "feature", Metadata: &WorktreeItemData{Path: "/dirty", ModifiedCount: 2, UntrackedCount: 3}},
+	})
+	list.SetSize(60, 10)
+	if strings.Contains(list.View(), "feature") {
+		t.Error("Rows should not show the branch unless inline")
+	}
+
+	list.SetInline(true)
+	lines := strings.Split(list.View(), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per item, got %d: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "main  ✓") || !strings.Contains(lines[0], "↑1") {
+		t.Errorf("Expected branch, clean status and sync badge, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "feature  ~2 ?3") {
+		t.Errorf("Expected branch and change counts, got %q", lines[1])
+	}
+
+	// The title keeps its room on narrow lists
+	list.SetSize(12, 10)
+	if !strings.Contains(list.View(), "dirty") {
+		t.Errorf("Expected the title to win over inline details, got %q", list.View())
+	}
+}
+
+// TestListGJumpsToBottom verifies 'G' selects the last item
+func TestListGJumpsToBottom(t *testing.T) {
+	list := NewList([]ListItem{{ID: "1"}, {ID: "2"}, {ID: "3"}})
+
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+
+	if list.Selected() != 2 {
+		t.Errorf("After 'G', Selected() = %d, want 2", list.Selected())
+	}
+}
+
+// TestListGGJumpsToTop verifies "gg" selects the first item
+func TestListGGJumpsToTop(t *testing.T) {
+	list := NewList([]ListItem{{ID: "1"}, {ID: "2"}, {ID: "3"}})
+	list.SetSelected(2)
+
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if list.Selected() != 2 {
+		t.Errorf("A single 'g' should not move the selection, got %d", list.Selected())
+	}
+
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if list.Selected() != 0 {
+		t.Errorf("After 'gg', Selected() = %d, want 0", list.Selected())
+	}
+}
+
+// TestListGFollowedByOtherKeyCancels verifies a single 'g' followed by another key does not jump
+func TestListGFollowedByOtherKeyCancels(t *testing.T) {
+	list := NewList([]ListItem{{ID: "1"}, {ID: "2"}, {ID: "3"}})
+	list.SetSelected(2)
+
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+
+	// 'k' moved up once; the second 'g' only starts a new sequence
+	if list.Selected() != 1 {
+		t.Errorf("'g', 'k', 'g' should only move up once, got %d", list.Selected())
+	}
+}
+
+// TestListGOnEmptyList verifies jump keys don't panic on an empty list
+func TestListGOnEmptyList(t *testing.T) {
+	list := NewList(nil)
+
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+
+	if list.Selected() != 0 {
+		t.Errorf("Selected() on empty list = %d, want 0", list.Selected())
+	}
+}
+
+// TestListDigitsJumpAfterDelay verifies typed digits select the numbered item once idle
+func TestListDigitsJumpAfterDelay(t *testing.T) {
+	items := make([]ListItem, 15)
+	list := NewList(items)
+
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	cmd := list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	if cmd == nil {
+		t.Fatal("A digit should start the idle delay")
+	}
+	if list.PendingNumber() != "12" {
+		t.Errorf("PendingNumber() = %q, want %q", list.PendingNumber(), "12")
+	}
+	if list.Selected() != 0 {
+		t.Errorf("Selection should not move while typing, got %d", list.Selected())
+	}
+
+	// The delay started by the first digit is stale
+	list.Update(DigitJumpMsg{seq: 1})
+	if list.Selected() != 0 {
+		t.Errorf("A stale delay should not jump, got %d", list.Selected())
+	}
+
+	list.Update(DigitJumpMsg{seq: 2})
+	if list.Selected() != 11 {
+		t.Errorf("After typing 12, Selected() = %d, want 11", list.Selected())
+	}
+	if list.PendingNumber() != "" {
+		t.Error("The number should be cleared after jumping")
+	}
+}
+
+// TestListJumpToPendingNumberClamps verifies out-of-range numbers select the nearest item
+func TestListJumpToPendingNumberClamps(t *testing.T) {
+	list := NewList([]ListItem{{ID: "1"}, {ID: "2"}, {ID: "3"}})
+
+	for _, tt := range []struct {
+		number string
+		want   int
+	}{
+		{"9", 2},
+		{"0", 0},
+		{"2", 1},
+		{"99999999999999999999", 2},
+	} {
+		for _, r := range tt.number {
+			list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		if !list.JumpToPendingNumber() {
+			t.Errorf("JumpToPendingNumber() after %q = false, want true", tt.number)
+		}
+		if list.Selected() != tt.want {
+			t.Errorf("After %q, Selected() = %d, want %d", tt.number, list.Selected(), tt.want)
+		}
+	}
+
+	if list.JumpToPendingNumber() {
+		t.Error("JumpToPendingNumber() without a number should return false")
+	}
+}
+
+// TestListNonDigitClearsNumber verifies other keys discard a typed number
+func TestListNonDigitClearsNumber(t *testing.T) {
+	list := NewList([]ListItem{{ID: "1"}, {ID: "2"}, {ID: "3"}})
+
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if list.PendingNumber() != "" {
+		t.Errorf("A non-digit should clear the number, got %q", list.PendingNumber())
+	}
+
+	list.Update(DigitJumpMsg{seq: 1})
+	if list.Selected() != 1 {
+		t.Errorf("Only 'j' should have moved the selection, got %d", list.Selected())
+	}
+}
+
+// TestListViewRowNumbers verifies rows are numbered only when enabled
+func TestListViewRowNumbers(t *testing.T) {
+	items := make([]ListItem, 10)
+	for i := range items {
+		items[i] = ListItem{ID: string(rune('a' + i)), Title: "item-" + string(rune('a'+i))}
+	}
+	list := NewList(items)
+	list.SetSize(40, 20)
+
+	if strings.Contains(list.View(), "1. item-a") {
+		t.Error("Rows should not be numbered by default")
+	}
+
+	list.SetShowNumbers(true)
+	lines := strings.Split(list.View(), "\n")
+	if !strings.Contains(lines[0], " 1. item-a") {
+		t.Errorf("First row should be right-aligned as ' 1. item-a', got %q", lines[0])
+	}
+	if !strings.Contains(lines[9], "10. item-j") {
+		t.Errorf("Last row should be '10. item-j', got %q", lines[9])
+	}
+
+	// The selection indicator stays in its own column before the number
+	if !strings.HasPrefix(lines[0], FocusIndicator.Symbol) {
+		t.Errorf("Selected row should start with the focus indicator, got %q", lines[0])
+	}
+	first := lipgloss.Width(lines[0][:strings.Index(lines[0], "item-a")])
+	last := lipgloss.Width(lines[9][:strings.Index(lines[9], "item-j")])
+	if first != last {
+		t.Errorf("Titles should line up, got columns %d and %d", first, last)
+	}
+}
+
+// TestListViewNumbersWhileTyping verifies rows are numbered while an item number is typed
+func TestListViewNumbersWhileTyping(t *testing.T) {
+	list := NewList([]ListItem{{ID: "1", Title: "one"}, {ID: "2", Title: "two"}})
+
+	list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	if !strings.Contains(list.View(), "2. two") {
+		t.Error("Rows should be numbered while typing a number")
+	}
+
+	list.JumpToPendingNumber()
+	if strings.Contains(list.View(), "2. two") {
+		t.Error("Numbers should hide again after jumping")
+	}
+}
+
+// TestListSelectByID verifies selection by item ID
+func TestListSelectByID(t *testing.T) {
+	list := NewList([]ListItem{{ID: "a"}, {ID: "b"}, {ID: "c"}})
+
+	if !list.SelectByID("c") || list.Selected() != 2 {
+		t.Errorf("SelectByID(\"c\") should select index 2, got %d", list.Selected())
+	}
+	if list.SelectByID("missing") {
+		t.Error("SelectByID should return false for unknown IDs")
+	}
+	if list.Selected() != 2 {
+		t.Error("SelectByID with unknown ID should leave selection unchanged")
+	}
+}
+
+// TestListFocus verifies the list defaults to focused and can be blurred.
+func TestListFocus(t *testing.T) {
+	list := NewList([]ListItem{{ID: "a", Title: "a"}})
+	if !list.Focused() {
+		t.Error("New list should be focused")
+	}
+
+	list.SetFocused(false)
+	if list.Focused() {
+		t.Error("List should not be focused after SetFocused(false)")
+	}
+	if !strings.Contains(list.View(), "a") {
+		t.Error("Unfocused list should still render its items")
+	}
+}
+
+// TestListEmptyHint verifies the hint is rendered below items and replaces "No items"
+func TestListEmptyHint(t *testing.T) {
+	list := NewList(nil)
+	list.SetEmptyHint("press n to create one")
+	if view := list.View(); !strings.Contains(view, "press n to create one") || strings.Contains(view, "No items") {
+		t.Errorf("Empty list should show the hint instead of 'No items', got %q", view)
+	}
+
+	list.SetItems([]ListItem{{ID: "a", Title: "Item A"}})
+	view := list.View()
+	if !strings.Contains(view, "Item A") || !strings.Contains(view, "press n to create one") {
+		t.Errorf("Hint should be shown below the items, got %q", view)
+	}
+
+	list.SetEmptyHint("")
+	if strings.Contains(list.View(), "press n") {
+		t.Error("Clearing the hint should hide it")
+	}
+}
+
+// TestListNextMatching verifies dirty worktrees are cycled through in both
+// directions, skipping clean ones and wrapping at the ends
+func TestListNextMatching(t *testing.T) {
+	item := func(id string, modified int) ListItem {
+		return ListItem{ID: id, Title: id, Metadata: &WorktreeItemData{Path: id, ModifiedCount: modified}}
+	}
+	list := NewList([]ListItem{
+		item("clean1", 0),
+		item("dirty1", 2),
+		item("clean2", 0),
+		item("clean3", 0),
+		item("dirty2", 1),
+		{ID: "origin/x", Title: "origin/x", Metadata: &RemoteBranchItemData{Ref: "origin/x"}},
+	})
+
+	for _, want := range []string{"dirty1", "dirty2", "dirty1"} {
+		if !list.NextMatching(isDirtyItem) {
+			t.Fatal("NextMatching should find a dirty item")
+		}
+		if got := list.SelectedItem().ID; got != want {
+			t.Errorf("NextMatching selected %q, want %q", got, want)
+		}
+	}
+	for _, want := range []string{"dirty2", "dirty1"} {
+		if !list.PrevMatching(isDirtyItem) {
+			t.Fatal("PrevMatching should find a dirty item")
+		}
+		if got := list.SelectedItem().ID; got != want {
+			t.Errorf("PrevMatching selected %q, want %q", got, want)
+		}
+	}
+
+	list.SetItems([]ListItem{item("clean1", 0), item("clean2", 0)})
+	list.SetSelected(1)
+	if list.NextMatching(isDirtyItem) || list.PrevMatching(isDirtyItem) {
+		t.Error("Expected no match in a clean list")
+	}
+	if list.Selected() != 1 {
+		t.Error("Selection should not change without a match")
+	}
+
+	if NewList(nil).NextMatching(isDirtyItem) {
+		t.Error("Expected no match in an empty list")
+	}
+}
@@ -0,0 +1,168 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultMessageLogSize is how many feedback messages the log keeps.
+const defaultMessageLogSize = 100
+
+// LogEntry is a feedback message recorded in the message log.
+type LogEntry struct {
+	Time    time.Time
+	Type    FeedbackType
+	Message string
+}
+
+// MessageLog keeps the most recent feedback messages so they can be reviewed
+// after they disappear, and shows them in a panel.
+type MessageLog struct {
+	entries []LogEntry // ring buffer of up to cap(entries) messages
+	start   int        // index of the oldest entry once the buffer is full
+	visible bool
+	scroll  int // entries skipped from the newest
+	width   int
+	height  int
+	// now returns the time recorded with each message; replaced in tests
+	now func() time.Time
+}
+
+// NewMessageLog creates a message log keeping the last size messages.
+func NewMessageLog(size int) *MessageLog {
+	if size < 1 {
+		size = 1
+	}
+	return &MessageLog{
+		entries: make([]LogEntry, 0, size),
+		now:     time.Now,
+	}
+}
+
+// Record adds a message to the log, dropping the oldest one when it is full.
+func (l *MessageLog) Record(feedbackType FeedbackType, message string) {
+	entry := LogEntry{Time: l.now(), Type: feedbackType, Message: message}
+	if len(l.entries) < cap(l.entries) {
+		l.entries = append(l.entries, entry)
+		return
+	}
+	l.entries[l.start] = entry
+	l.start = (l.start + 1) % len(l.entries)
+}
+
+// Entries returns the recorded messages, oldest first.
+func (l *MessageLog) Entries() []LogEntry {
+	entries := make([]LogEntry, 0, len(l.entries))
+	entries = append(entries, l.entries[l.start:]...)
+	return append(entries, l.entries[:l.start]...)
+}
+
+// Len returns how many messages are recorded.
+func (l *MessageLog) Len() int {
+	return len(l.entries)
+}
+
+// Visible returns whether the panel is showing.
+func (l *MessageLog) Visible() bool {
+	return l.visible
+}
+
+// Show opens the panel at the newest message.
+func (l *MessageLog) Show() {
+	l.visible = true
+	l.scroll = 0
+}
+
+// Hide closes the panel.
+func (l *MessageLog) Hide() {
+	l.visible = false
+}
+
+// SetSize sets the panel dimensions.
+func (l *MessageLog) SetSize(width, height int) {
+	l.width = width
+	l.height = height
+}
+
+// visibleRows returns how many messages fit in the panel.
+func (l *MessageLog) visibleRows() int {
+	if l.height <= 0 {
+		return 10
+	}
+	// Leave room for the border, title, help and the rest of the screen
+	return max(l.height-12, 3)
+}
+
+// Update scrolls the panel and closes it on Esc, q or M.
+func (l *MessageLog) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !l.visible {
+		return nil
+	}
+
+	maxScroll := max(len(l.entries)-l.visibleRows(), 0)
+	switch keyMsg.String() {
+	case "esc", "q", "M":
+		l.Hide()
+	case "down", "j":
+		// Newest messages are on top, so moving down goes back in time
+		l.scroll = min(l.scroll+1, maxScroll)
+	case "up", "k":
+		l.scroll = max(l.scroll-1, 0)
+	}
+	return nil
+}
+
+// View renders the recorded messages, newest first, with their time and type.
+func (l *MessageLog) View() string {
+	if !l.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(Colors.Text).
+		Bold(true).
+		MarginBottom(1)
+	timeStyle := lipgloss.NewStyle().
+		Foreground(Colors.TextMuted)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("Messages (%d)", len(l.entries)))}
+	if len(l.entries) == 0 {
+		lines = append(lines, Styles.Muted.Render("No messages yet"))
+	}
+
+	// Room for the message after the time and icon, inside the box
+	messageWidth := 0
+	if l.width > 0 {
+		messageWidth = max(l.width-8-len("15:04:05 ✗ "), 10)
+	}
+
+	entries := l.Entries()
+	end := len(entries) - l.scroll
+	start := max(end-l.visibleRows(), 0)
+	for i := end - 1; i >= start; i-- {
+		entry := entries[i]
+		icon, color := "ℹ", Colors.Info
+		switch entry.Type {
+		case FeedbackSuccess:
+			icon, color = "✓", Colors.Success
+		case FeedbackError:
+			icon, color = "✗", Colors.Error
+		}
+		message := entry.Message
+		if messageWidth > 0 {
+			message = truncateEnd(message, messageWidth)
+		}
+		lines = append(lines, timeStyle.Render(entry.Time.Format("15:04:05"))+" "+
+			lipgloss.NewStyle().Foreground(color).Render(icon+" "+message))
+	}
+
+	lines = append(lines, Styles.Help.MarginTop(1).Render("↑/↓: scroll • Esc/M: close"))
+
+	return Styles.Box.Padding(Padding.Small, Padding.Medium).Render(strings.Join(lines, "\n"))
+}
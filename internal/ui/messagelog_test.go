@@ -0,0 +1,101 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestMessageLogRecord verifies messages are recorded in order with their time and type.
+func TestMessageLogRecord(t *testing.T) {
+	log := NewMessageLog(10)
+	at := time.Date(2024, 3, 5, 14, 30, 0, 0, time.Local)
+	log.now = func() time.Time { return at }
+
+	log.Record(FeedbackError, "Failed to fetch")
+	log.Record(FeedbackSuccess, "Fetched")
+
+	entries := log.Entries()
+	want := []LogEntry{
+		{Time: at, Type: FeedbackError, Message: "Failed to fetch"},
+		{Time: at, Type: FeedbackSuccess, Message: "Fetched"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %+v", len(want), entries)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("Entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+}
+
+// TestMessageLogCap verifies the oldest messages are dropped once the log is full.
+func TestMessageLogCap(t *testing.T) {
+	log := NewMessageLog(3)
+	for i := 1; i <= 5; i++ {
+		log.Record(FeedbackInfo, fmt.Sprintf("message %d", i))
+	}
+
+	if log.Len() != 3 {
+		t.Fatalf("Expected 3 entries, got %d", log.Len())
+	}
+	for i, entry := range log.Entries() {
+		if want := fmt.Sprintf("message %d", i+3); entry.Message != want {
+			t.Errorf("Entry %d = %q, want %q", i, entry.Message, want)
+		}
+	}
+}
+
+// TestMessageLogView verifies the panel lists messages newest first and closes on Esc.
+func TestMessageLogView(t *testing.T) {
+	log := NewMessageLog(10)
+	log.now = func() time.Time { return time.Date(2024, 3, 5, 9, 5, 7, 0, time.Local) }
+
+	if log.View() != "" {
+		t.Error("Hidden log should render nothing")
+	}
+	log.Show()
+	if !strings.Contains(log.View(), "No messages yet") {
+		t.Errorf("Expected an empty hint, got:\n%s", log.View())
+	}
+
+	log.Record(FeedbackError, "first")
+	log.Record(FeedbackInfo, "second")
+	view := log.View()
+	if !strings.Contains(view, "09:05:07") {
+		t.Errorf("Expected the message time, got:\n%s", view)
+	}
+	if strings.Index(view, "second") > strings.Index(view, "first") {
+		t.Errorf("Expected the newest message first, got:\n%s", view)
+	}
+
+	log.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if log.Visible() {
+		t.Error("Expected Esc to close the log")
+	}
+}
+
+// TestMessageLogScroll verifies scrolling reveals older messages and stops at the oldest.
+func TestMessageLogScroll(t *testing.T) {
+	log := NewMessageLog(50)
+	log.SetSize(80, 15) // 3 rows
+	for i := 1; i <= 5; i++ {
+		log.Record(FeedbackInfo, fmt.Sprintf("message %d", i))
+	}
+	log.Show()
+
+	if view := log.View(); strings.Contains(view, "message 2") || !strings.Contains(view, "message 5") {
+		t.Errorf("Expected the newest messages only, got:\n%s", view)
+	}
+	for range 5 {
+		log.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if view := log.View(); !strings.Contains(view, "message 1") || strings.Contains(view, "message 4") {
+		t.Errorf("Expected the oldest messages after scrolling, got:\n%s", view)
+	}
+}
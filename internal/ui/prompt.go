@@ -0,0 +1,207 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// InputPromptSubmittedMsg is sent when the prompt is submitted with a valid value.
+type InputPromptSubmittedMsg struct {
+	Value string
+	Data  interface{}
+}
+
+// InputPromptCancelledMsg is sent when the prompt is cancelled.
+type InputPromptCancelledMsg struct{}
+
+// InputPrompt is a modal asking for a single line of text.
+type InputPrompt struct {
+	visible      bool
+	title        string
+	label        string
+	value        string
+	cursorPos    int
+	errorMessage string
+	validate     func(string) error // checks the value on submit (nil = any non-empty value)
+	optional     bool               // an empty value may be submitted
+	data         interface{}
+	width        int
+	height       int
+}
+
+// NewInputPrompt creates a new hidden input prompt.
+func NewInputPrompt() *InputPrompt {
+	return &InputPrompt{}
+}
+
+// Visible returns whether the prompt is currently visible.
+func (p *InputPrompt) Visible() bool {
+	return p.visible
+}
+
+// Show displays the prompt with an empty value. Data is passed back in
+// InputPromptSubmittedMsg, and validate, if not nil, is checked on submit.
+func (p *InputPrompt) Show(title, label string, validate func(string) error, data interface{}) {
+	p.visible = true
+	p.title = title
+	p.label = label
+	p.value = ""
+	p.cursorPos = 0
+	p.errorMessage = ""
+	p.validate = validate
+	p.optional = false
+	p.data = data
+}
+
+// SetOptional allows submitting an empty value, which skips validation.
+func (p *InputPrompt) SetOptional(optional bool) {
+	p.optional = optional
+}
+
+// Hide closes the prompt.
+func (p *InputPrompt) Hide() {
+	p.visible = false
+	p.validate = nil
+	p.data = nil
+}
+
+// Value returns the current input value.
+func (p *InputPrompt) Value() string {
+	return p.value
+}
+
+// SetValue sets the input value and moves the cursor to its end.
+func (p *InputPrompt) SetValue(value string) {
+	p.value = value
+	p.cursorPos = len(value)
+}
+
+// ErrorMessage returns the current validation error, if any.
+func (p *InputPrompt) ErrorMessage() string {
+	return p.errorMessage
+}
+
+// SetSize sets the prompt dimensions.
+func (p *InputPrompt) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// submit validates the value and returns a command sending InputPromptSubmittedMsg.
+// On a validation error the prompt stays open and shows the error.
+func (p *InputPrompt) submit() tea.Cmd {
+	value := strings.TrimSpace(p.value)
+	if value == "" && !p.optional {
+		p.errorMessage = p.label + " is required"
+		return nil
+	}
+	if value != "" && p.validate != nil {
+		if err := p.validate(value); err != nil {
+			p.errorMessage = err.Error()
+			return nil
+		}
+	}
+
+	data := p.data
+	p.Hide()
+	return func() tea.Msg {
+		return InputPromptSubmittedMsg{Value: value, Data: data}
+	}
+}
+
+// Update handles input messages for the prompt.
+func (p *InputPrompt) Update(msg tea.Msg) tea.Cmd {
+	if !p.visible {
+		return nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEsc:
+		p.Hide()
+		return func() tea.Msg {
+			return InputPromptCancelledMsg{}
+		}
+	case tea.KeyEnter:
+		return p.submit()
+	case tea.KeyBackspace:
+		if p.cursorPos > 0 {
+			p.value = p.value[:p.cursorPos-1] + p.value[p.cursorPos:]
+			p.cursorPos--
+		}
+		p.errorMessage = ""
+	case tea.KeyLeft:
+		if p.cursorPos > 0 {
+			p.cursorPos--
+		}
+	case tea.KeyRight:
+		if p.cursorPos < len(p.value) {
+			p.cursorPos++
+		}
+	case tea.KeySpace:
+		p.insert(" ")
+	case tea.KeyRunes:
+		p.insert(string(keyMsg.Runes))
+	}
+	return nil
+}
+
+// insert adds text at the cursor position.
+func (p *InputPrompt) insert(text string) {
+	p.value = p.value[:p.cursorPos] + text + p.value[p.cursorPos:]
+	p.cursorPos += len(text)
+	p.errorMessage = ""
+}
+
+// View renders the prompt.
+func (p *InputPrompt) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(Colors.Text).
+		Bold(true).
+		MarginBottom(1)
+	labelStyle := lipgloss.NewStyle().
+		Foreground(Colors.TextMuted)
+	errorStyle := lipgloss.NewStyle().
+		Foreground(Colors.Error).
+		Bold(true)
+
+	// The input shrinks to fit narrow terminals
+	inputWidth := 40
+	if p.width > 0 && p.width-8 < inputWidth {
+		inputWidth = max(p.width-8, 10)
+	}
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Colors.Primary).
+		Padding(0, 1).
+		Width(inputWidth)
+
+	value := p.value[:p.cursorPos] + "│" + p.value[p.cursorPos:]
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(p.title))
+	lines = append(lines, labelStyle.Render(p.label+":"))
+	lines = append(lines, inputStyle.Render(value))
+
+	if p.errorMessage != "" {
+		lines = append(lines, "")
+		lines = append(lines, errorStyle.Render("✗ "+p.errorMessage))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, Styles.Help.Render("Enter: confirm • Esc: cancel"))
+
+	boxStyle := Styles.Box.Padding(Padding.Small, Padding.Medium)
+	return boxStyle.Render(strings.Join(lines, "\n"))
+}
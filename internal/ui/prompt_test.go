@@ -0,0 +1,130 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestInputPromptSubmit verifies typed text is submitted with the prompt's data.
+func TestInputPromptSubmit(t *testing.T) {
+	prompt := NewInputPrompt()
+	prompt.Show("Title", "Branch name", nil, "payload")
+
+	prompt.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("feature")})
+	prompt.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	prompt.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	if prompt.Value() != "featurE" {
+		t.Errorf("Expected value 'featurE', got %q", prompt.Value())
+	}
+
+	cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter should return a command")
+	}
+	msg, ok := cmd().(InputPromptSubmittedMsg)
+	if !ok {
+		t.Fatalf("Expected InputPromptSubmittedMsg, got %T", cmd())
+	}
+	if msg.Value != "featurE" || msg.Data != "payload" {
+		t.Errorf("Unexpected submitted message: %+v", msg)
+	}
+	if prompt.Visible() {
+		t.Error("Prompt should hide after submitting")
+	}
+}
+
+// TestInputPromptValidation verifies invalid values keep the prompt open with an error.
+func TestInputPromptValidation(t *testing.T) {
+	prompt := NewInputPrompt()
+	prompt.Show("Title", "Branch name", func(value string) error {
+		if strings.Contains(value, "..") {
+			return errors.New("cannot contain '..'")
+		}
+		return nil
+	}, nil)
+
+	if cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Empty value should not submit")
+	}
+	if prompt.ErrorMessage() != "Branch name is required" {
+		t.Errorf("Unexpected error message %q", prompt.ErrorMessage())
+	}
+
+	prompt.SetValue("a..b")
+	if cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Invalid value should not submit")
+	}
+	if !prompt.Visible() {
+		t.Error("Prompt should stay open on a validation error")
+	}
+	if !strings.Contains(prompt.View(), "cannot contain '..'") {
+		t.Error("View should show the validation error")
+	}
+
+	// Typing clears the error
+	prompt.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if prompt.ErrorMessage() != "" {
+		t.Error("Typing should clear the error")
+	}
+}
+
+// TestInputPromptOptional verifies an optional prompt submits an empty value
+// without validating it.
+func TestInputPromptOptional(t *testing.T) {
+	prompt := NewInputPrompt()
+	prompt.Show("Title", "Label", func(string) error {
+		return errors.New("invalid")
+	}, "data")
+	prompt.SetOptional(true)
+
+	cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Empty value should submit when optional")
+	}
+	if msg, ok := cmd().(InputPromptSubmittedMsg); !ok || msg.Value != "" || msg.Data != "data" {
+		t.Errorf("Unexpected message %+v", msg)
+	}
+
+	// Showing the prompt again makes the value required
+	prompt.Show("Title", "Label", nil, nil)
+	if cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Show should reset the prompt to a required value")
+	}
+}
+
+// TestInputPromptCancel verifies Esc hides the prompt and reports cancellation.
+func TestInputPromptCancel(t *testing.T) {
+	prompt := NewInputPrompt()
+	prompt.Show("Title", "Branch name", nil, nil)
+
+	cmd := prompt.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if prompt.Visible() {
+		t.Error("Esc should hide the prompt")
+	}
+	if cmd == nil {
+		t.Fatal("Esc should return a command")
+	}
+	if _, ok := cmd().(InputPromptCancelledMsg); !ok {
+		t.Errorf("Expected InputPromptCancelledMsg, got %T", cmd())
+	}
+}
+
+// TestInputPromptCursor verifies text is inserted at the cursor.
+func TestInputPromptCursor(t *testing.T) {
+	prompt := NewInputPrompt()
+	prompt.Show("Title", "Name", nil, nil)
+	prompt.SetValue("ac")
+
+	prompt.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	prompt.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if prompt.Value() != "abc" {
+		t.Errorf("Expected 'abc', got %q", prompt.Value())
+	}
+	if !strings.Contains(prompt.View(), "ab│c") {
+		t.Error("View should render the cursor after the inserted text")
+	}
+}
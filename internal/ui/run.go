@@ -0,0 +1,63 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// runProgram runs model as a Bubble Tea program and returns the final
+// model; replaced in tests.
+var runProgram = func(model tea.Model) (tea.Model, error) {
+	var opts []tea.ProgramOption
+
+	// A shell wrapper captures stdout to find the target path, so draw on
+	// stderr, which is still the terminal, and detect colors from it
+	if !isTerminal(os.Stdout) && isTerminal(os.Stderr) {
+		stderr := lipgloss.NewRenderer(os.Stderr)
+		lipgloss.SetColorProfile(stderr.ColorProfile())
+		lipgloss.SetHasDarkBackground(stderr.HasDarkBackground())
+		opts = append(opts, tea.WithOutput(os.Stderr))
+	}
+
+	return tea.NewProgram(model, opts...).Run()
+}
+
+// Run runs app until the user quits and returns the worktree path they
+// asked to switch to, or "" if none. The caller passes it on to the shell
+// wrapper (see cli.TargetPathLine).
+func Run(app *App) (targetPath string, err error) {
+	final, err := runProgram(app)
+	if err != nil {
+		return "", err
+	}
+	if finalApp, ok := final.(*App); ok {
+		return finalApp.TargetPath(), nil
+	}
+	return "", nil
+}
+
+// terminalOutput returns the file the UI is drawn on: stdout, or stderr when
+// a shell wrapper captures stdout.
+func terminalOutput() *os.File {
+	if !isTerminal(os.Stdout) && isTerminal(os.Stderr) {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// copyToClipboard asks the terminal to put text on the system clipboard
+// with an OSC 52 escape sequence; replaced in tests. Terminals without
+// OSC 52 support ignore it.
+var copyToClipboard = func(text string) {
+	termenv.NewOutput(terminalOutput()).Copy(text)
+}
+
+// isTerminal reports whether f is a terminal rather than a pipe or file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
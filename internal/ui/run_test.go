@@ -0,0 +1,54 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// useProgramRunner replaces runProgram for the duration of the test.
+func useProgramRunner(t *testing.T, run func(tea.Model) (tea.Model, error)) {
+	t.Helper()
+	original := runProgram
+	runProgram = run
+	t.Cleanup(func() { runProgram = original })
+}
+
+// TestRunReturnsTargetPath verifies Run reports the path the user chose to switch to.
+func TestRunReturnsTargetPath(t *testing.T) {
+	useProgramRunner(t, func(model tea.Model) (tea.Model, error) {
+		app := model.(*App)
+		app.targetPath = "/src/repo-feature"
+		app.quitting = true
+		return app, nil
+	})
+
+	targetPath, err := Run(NewAppWithItems(nil))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if targetPath != "/src/repo-feature" {
+		t.Errorf("Expected target path '/src/repo-feature', got %q", targetPath)
+	}
+}
+
+// TestRunWithoutTargetPath verifies quitting normally reports no path, and errors are passed on.
+func TestRunWithoutTargetPath(t *testing.T) {
+	useProgramRunner(t, func(model tea.Model) (tea.Model, error) {
+		return model, nil
+	})
+
+	targetPath, err := Run(NewAppWithItems(nil))
+	if err != nil || targetPath != "" {
+		t.Errorf("Expected no target path, got %q (err: %v)", targetPath, err)
+	}
+
+	useProgramRunner(t, func(model tea.Model) (tea.Model, error) {
+		return nil, errors.New("no terminal")
+	})
+	if _, err := Run(NewAppWithItems(nil)); err == nil {
+		t.Error("Expected the program error to be returned")
+	}
+}
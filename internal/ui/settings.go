@@ -0,0 +1,186 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// settingCountUntracked is the key of the toggle for counting untracked files.
+	settingCountUntracked = "status.count_untracked"
+	// settingRowNumbers is the key of the toggle for numbering list rows.
+	settingRowNumbers = "row_numbers"
+	// settingHideMain is the key of the toggle for hiding the main worktree.
+	settingHideMain = "list.hide_main"
+)
+
+// Setting is a toggle shown on the Settings tab.
+type Setting struct {
+	// Key identifies the setting, matching its config file path.
+	Key         string
+	Label       string
+	Description string
+	Enabled     bool
+}
+
+// SettingToggledMsg is sent when the user toggles a setting.
+type SettingToggledMsg struct {
+	Key     string
+	Enabled bool
+}
+
+// RepoInfo describes where git keeps the repository, shown on the Settings
+// tab to help debug worktree administration.
+type RepoInfo struct {
+	// Toplevel is the root of the current worktree (empty for a bare repository)
+	Toplevel string
+	// GitDir is the git directory of the current worktree
+	GitDir string
+	// CommonDir is the git directory shared by all worktrees
+	CommonDir string
+}
+
+// SettingsView lists settings that can be toggled at runtime.
+type SettingsView struct {
+	settings []Setting
+	selected int
+	width    int
+	repoInfo *RepoInfo // nil hides the repository section
+}
+
+// NewSettingsView creates a settings view with the given settings.
+func NewSettingsView(settings []Setting) *SettingsView {
+	return &SettingsView{settings: settings}
+}
+
+// Settings returns the listed settings.
+func (s *SettingsView) Settings() []Setting {
+	return s.settings
+}
+
+// SetSettings replaces the listed settings, keeping the selection in range.
+func (s *SettingsView) SetSettings(settings []Setting) {
+	s.settings = settings
+	if s.selected >= len(settings) {
+		s.selected = 0
+	}
+}
+
+// RepoInfo returns the repository locations shown below the settings, or nil.
+func (s *SettingsView) RepoInfo() *RepoInfo {
+	return s.repoInfo
+}
+
+// SetRepoInfo sets the repository locations shown below the settings;
+// nil hides them.
+func (s *SettingsView) SetRepoInfo(info *RepoInfo) {
+	s.repoInfo = info
+}
+
+// Selected returns the index of the selected setting.
+func (s *SettingsView) Selected() int {
+	return s.selected
+}
+
+// SetWidth sets the width available for rendering.
+func (s *SettingsView) SetWidth(width int) {
+	s.width = width
+}
+
+// Update handles navigation and toggling. Toggling flips the selected
+// setting and returns a command sending SettingToggledMsg.
+func (s *SettingsView) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(s.settings) == 0 {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if s.selected > 0 {
+			s.selected--
+		}
+	case "down", "j":
+		if s.selected < len(s.settings)-1 {
+			s.selected++
+		}
+	case "enter", " ":
+		setting := &s.settings[s.selected]
+		setting.Enabled = !setting.Enabled
+		toggled := SettingToggledMsg{Key: setting.Key, Enabled: setting.Enabled}
+		return func() tea.Msg {
+			return toggled
+		}
+	}
+	return nil
+}
+
+// View renders the settings with a checkbox each.
+func (s *SettingsView) View() string {
+	if len(s.settings) == 0 {
+		return Styles.Muted.Render("No settings")
+	}
+
+	selectedStyle := Styles.ListItem.Selected
+	normalStyle := Styles.ListItem.Normal
+	descStyle := lipgloss.NewStyle().
+		Foreground(Colors.TextMuted).
+		PaddingLeft(lipgloss.Width(FocusIndicator.Symbol) + 4)
+
+	var lines []string
+	for i, setting := range s.settings {
+		checkbox := "[ ] "
+		if setting.Enabled {
+			checkbox = "[✓] "
+		}
+		if i == s.selected {
+			lines = append(lines, FocusIndicator.Symbol+selectedStyle.Render(checkbox+setting.Label))
+		} else {
+			lines = append(lines, FocusIndicator.SymbolInactive+normalStyle.Render(checkbox+setting.Label))
+		}
+		if setting.Description != "" {
+			lines = append(lines, descStyle.Render(wrapText(setting.Description, s.width-descStyle.GetPaddingLeft())))
+		}
+	}
+
+	lines = append(lines, "", Styles.Help.Render("Space/Enter: toggle (not saved to the config file) • R: reload theme • D: reset to defaults"))
+
+	if s.repoInfo != nil {
+		lines = append(lines, "", s.renderRepoInfo())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderRepoInfo renders the repository's top level, git dir and common dir.
+func (s *SettingsView) renderRepoInfo() string {
+	labelStyle := lipgloss.NewStyle().
+		Foreground(Colors.TextMuted).
+		Bold(true)
+	valueStyle := lipgloss.NewStyle().
+		Foreground(Colors.Text)
+
+	toplevel := s.repoInfo.Toplevel
+	if toplevel == "" {
+		toplevel = "none (bare repository)"
+	}
+	fields := []struct{ label, value string }{
+		{"Top level", toplevel},
+		{"Git dir", s.repoInfo.GitDir},
+		{"Common dir", s.repoInfo.CommonDir},
+	}
+
+	const labelWidth = 12
+	lines := []string{labelStyle.Render("Repository")}
+	for _, field := range fields {
+		value := field.value
+		if s.width > labelWidth {
+			value = truncateMiddle(value, s.width-labelWidth)
+		}
+		lines = append(lines, labelStyle.Render(fmt.Sprintf("%-*s", labelWidth, field.label))+valueStyle.Render(value))
+	}
+	return strings.Join(lines, "\n")
+}
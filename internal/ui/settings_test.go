@@ -0,0 +1,74 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestSettingsViewToggle verifies Space toggles the selected setting and reports it
+func TestSettingsViewToggle(t *testing.T) {
+	view := NewSettingsView([]Setting{
+		{Key: "a", Label: "Option A", Enabled: true},
+		{Key: "b", Label: "Option B"},
+	})
+
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if view.Selected() != 1 {
+		t.Fatalf("Expected selection 1, got %d", view.Selected())
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if view.Selected() != 1 {
+		t.Error("Selection should stop at the last setting")
+	}
+
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if cmd == nil {
+		t.Fatal("Expected a command after toggling")
+	}
+	msg, ok := cmd().(SettingToggledMsg)
+	if !ok || msg.Key != "b" || !msg.Enabled {
+		t.Errorf("Expected SettingToggledMsg{b, true}, got %+v", msg)
+	}
+	if !view.Settings()[1].Enabled {
+		t.Error("Toggled setting should be enabled")
+	}
+}
+
+// TestSettingsViewRender verifies settings render with checkboxes
+func TestSettingsViewRender(t *testing.T) {
+	view := NewSettingsView([]Setting{
+		{Key: "a", Label: "Option A", Description: "Explains A", Enabled: true},
+		{Key: "b", Label: "Option B"},
+	})
+
+	out := view.View()
+	for _, want := range []string{"[✓] Option A", "[ ] Option B", "Explains A"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View should contain %q, got %q", want, out)
+		}
+	}
+}
+
+// TestSettingsViewRepoInfo verifies the repository section is shown only when set
+func TestSettingsViewRepoInfo(t *testing.T) {
+	view := NewSettingsView([]Setting{{Key: "a", Label: "Option A"}})
+	if strings.Contains(view.View(), "Repository") {
+		t.Error("View should not show the repository section without repo info")
+	}
+
+	view.SetRepoInfo(&RepoInfo{Toplevel: "/repo", GitDir: "/repo/.git/worktrees/x", CommonDir: "/repo/.git"})
+	out := view.View()
+	for _, want := range []string{"Repository", "Top level", "/repo", "Git dir", "/repo/.git/worktrees/x", "Common dir", "/repo/.git"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View should contain %q, got %q", want, out)
+		}
+	}
+
+	view.SetRepoInfo(&RepoInfo{GitDir: "/repo.git", CommonDir: "/repo.git"})
+	if !strings.Contains(view.View(), "bare repository") {
+		t.Error("View should mention a bare repository has no top level")
+	}
+}
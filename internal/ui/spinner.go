@@ -0,0 +1,91 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// spinnerFrames are the animation frames of the spinner.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// SpinnerTickMsg advances the spinner animation.
+type SpinnerTickMsg struct {
+	// id ties the tick to the run that scheduled it, so ticks from a
+	// stopped run do not keep animating a later one.
+	id int
+}
+
+// Spinner displays an animated indicator while a background task runs.
+type Spinner struct {
+	label    string
+	active   bool
+	frame    int
+	id       int
+	interval time.Duration
+}
+
+// NewSpinner creates a new, inactive spinner.
+func NewSpinner() *Spinner {
+	return &Spinner{
+		interval: 100 * time.Millisecond,
+	}
+}
+
+// Active returns whether the spinner is running.
+func (s *Spinner) Active() bool {
+	return s.active
+}
+
+// Label returns the text shown next to the spinner.
+func (s *Spinner) Label() string {
+	return s.label
+}
+
+// Start shows the spinner with the given label and returns the command
+// that drives the animation.
+func (s *Spinner) Start(label string) tea.Cmd {
+	s.label = label
+	s.active = true
+	s.frame = 0
+	s.id++
+	return s.tick()
+}
+
+// Stop hides the spinner.
+func (s *Spinner) Stop() {
+	s.active = false
+	s.label = ""
+}
+
+// tick returns a command that advances the spinner after the interval.
+func (s *Spinner) tick() tea.Cmd {
+	id := s.id
+	return tea.Tick(s.interval, func(time.Time) tea.Msg {
+		return SpinnerTickMsg{id: id}
+	})
+}
+
+// Update handles messages for the spinner component.
+func (s *Spinner) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case SpinnerTickMsg:
+		if !s.active || msg.id != s.id {
+			return nil
+		}
+		s.frame = (s.frame + 1) % len(spinnerFrames)
+		return s.tick()
+	}
+	return nil
+}
+
+// View renders the spinner and its label.
+func (s *Spinner) View() string {
+	if !s.active {
+		return ""
+	}
+	style := lipgloss.NewStyle().Foreground(Colors.Info)
+	return style.Render(spinnerFrames[s.frame] + " " + s.label)
+}
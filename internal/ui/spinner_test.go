@@ -0,0 +1,62 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNewSpinner verifies a new spinner is inactive.
+func TestNewSpinner(t *testing.T) {
+	s := NewSpinner()
+	if s.Active() {
+		t.Error("New spinner should not be active")
+	}
+	if s.View() != "" {
+		t.Error("Inactive spinner should render nothing")
+	}
+}
+
+// TestSpinnerStartStop verifies Start shows the label and Stop hides it.
+func TestSpinnerStartStop(t *testing.T) {
+	s := NewSpinner()
+
+	cmd := s.Start("Fetching...")
+	if cmd == nil {
+		t.Error("Start should return a tick command")
+	}
+	if !s.Active() {
+		t.Error("Spinner should be active after Start")
+	}
+	if !strings.Contains(s.View(), "Fetching...") {
+		t.Errorf("View should contain label, got %q", s.View())
+	}
+
+	s.Stop()
+	if s.Active() {
+		t.Error("Spinner should not be active after Stop")
+	}
+}
+
+// TestSpinnerTick verifies ticks advance the frame only for the current run.
+func TestSpinnerTick(t *testing.T) {
+	s := NewSpinner()
+	s.Start("Working")
+
+	if cmd := s.Update(SpinnerTickMsg{id: s.id}); cmd == nil {
+		t.Error("Tick for the current run should schedule the next tick")
+	}
+	if s.frame != 1 {
+		t.Errorf("Expected frame 1, got %d", s.frame)
+	}
+
+	// Ticks from an earlier run are ignored
+	if cmd := s.Update(SpinnerTickMsg{id: s.id - 1}); cmd != nil {
+		t.Error("Stale tick should not schedule another tick")
+	}
+
+	s.Stop()
+	if cmd := s.Update(SpinnerTickMsg{id: s.id}); cmd != nil {
+		t.Error("Tick after Stop should not schedule another tick")
+	}
+}
@@ -4,6 +4,7 @@ package ui
 import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/iatopilskii/grove/internal/config"
+	"github.com/muesli/termenv"
 )
 
 // Colors defines the adaptive color palette for the application.
@@ -208,11 +209,53 @@ func rebuildStyles() {
 		Padding(0, 1)
 }
 
-// LoadAndApplyTheme loads the theme configuration from the default path
+// LoadAndApplyTheme loads the theme configuration from the resolved path
 // and applies it to the global styles. Returns any error encountered
-// while loading (invalid YAML), but always applies valid defaults.
+// while loading (invalid YAML, or a config.InvalidColorsError naming the
+// invalid colors), but always applies valid defaults in their place.
 func LoadAndApplyTheme() error {
-	cfg, err := config.LoadConfig(config.DefaultConfigPath())
+	cfg, err := config.LoadConfig(config.ResolveConfigPath())
 	ApplyThemeConfig(cfg)
 	return err
 }
+
+// BackgroundQuery is the terminal's answer when asked for its background.
+type BackgroundQuery struct {
+	// Answered is false if the terminal did not report its background
+	Answered bool
+	Dark     bool
+}
+
+// queryBackground asks the terminal the UI is drawn on for its background
+// color; replaced in tests. The answer may take a moment to arrive, so it
+// is not queried while drawing.
+var queryBackground = func() BackgroundQuery {
+	// Only a queried color is an RGB color; COLORFGBG and the fallback
+	// are ANSI colors, which tell nothing new
+	bg := termenv.NewOutput(terminalOutput()).BackgroundColor()
+	if _, ok := bg.(termenv.RGBColor); !ok {
+		return BackgroundQuery{}
+	}
+	_, _, l := termenv.ConvertToRGB(bg).Hsl()
+	return BackgroundQuery{Answered: true, Dark: l < 0.5}
+}
+
+// applyBackground makes adaptive colors follow the queried background and
+// rebuilds the styles. Without an answer the previous detection is kept.
+func applyBackground(q BackgroundQuery) {
+	if q.Answered {
+		lipgloss.SetHasDarkBackground(q.Dark)
+	}
+	rebuildStyles()
+}
+
+// RefreshAdaptiveColors asks the terminal for its background again and
+// rebuilds the styles, so adaptive colors follow a switch between a light
+// and a dark terminal theme during a session. It returns false, keeping the
+// previous detection, if the terminal does not answer: some terminals and
+// multiplexers such as tmux do not report their background.
+func RefreshAdaptiveColors() bool {
+	q := queryBackground()
+	applyBackground(q)
+	return q.Answered
+}
@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/lipgloss"
@@ -371,3 +374,67 @@ func TestConfigToAdaptive(t *testing.T) {
 		t.Errorf("Dark: got %s, want #D67890", result.Dark)
 	}
 }
+
+// TestLoadAndApplyThemeInvalidColors verifies invalid colors are reported and fall back to defaults.
+func TestLoadAndApplyThemeInvalidColors(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	configPath := filepath.Join(configHome, "grove", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	yamlContent := "theme:\n  colors:\n    primary:\n      dark: \"bogus\"\n    error:\n      dark: \"#FF0000\"\n"
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	t.Cleanup(func() { ApplyThemeConfig(config.DefaultConfig()) })
+
+	err := LoadAndApplyTheme()
+	if err == nil || !strings.Contains(err.Error(), "theme.colors.primary.dark") {
+		t.Errorf("Expected a warning naming the invalid color, got %v", err)
+	}
+	if Colors.Primary.Dark != config.DefaultConfig().Theme.Colors.Primary.Dark {
+		t.Errorf("Invalid primary color should fall back to the default, got %s", Colors.Primary.Dark)
+	}
+	if Colors.Error.Dark != "#FF0000" {
+		t.Errorf("Valid error color should apply, got %s", Colors.Error.Dark)
+	}
+}
+
+// TestRefreshAdaptiveColors verifies the queried background is applied and styles still render.
+func TestRefreshAdaptiveColors(t *testing.T) {
+	orig := queryBackground
+	wasDark := lipgloss.HasDarkBackground()
+	t.Cleanup(func() {
+		queryBackground = orig
+		lipgloss.SetHasDarkBackground(wasDark)
+		rebuildStyles()
+	})
+
+	queryBackground = func() BackgroundQuery { return BackgroundQuery{Answered: true, Dark: !wasDark} }
+	if !RefreshAdaptiveColors() {
+		t.Error("Expected an answered query to be reported")
+	}
+	if lipgloss.HasDarkBackground() == wasDark {
+		t.Error("Expected the queried background to be applied")
+	}
+	if !strings.Contains(Styles.Selected.Render("main"), "main") {
+		t.Error("Expected styles to still render")
+	}
+
+	// Without an answer the previous detection is kept
+	queryBackground = func() BackgroundQuery { return BackgroundQuery{} }
+	if RefreshAdaptiveColors() {
+		t.Error("Expected an unanswered query to be reported")
+	}
+	if lipgloss.HasDarkBackground() == wasDark {
+		t.Error("Expected the previous detection to be kept")
+	}
+	if !strings.Contains(Styles.Box.Render("main"), "main") {
+		t.Error("Expected styles to still render")
+	}
+
+	// The real query must not panic, whatever the test output is
+	queryBackground = orig
+	RefreshAdaptiveColors()
+}
@@ -2,6 +2,7 @@
 package ui
 
 import (
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -34,6 +35,16 @@ func (t Tab) String() string {
 	}
 }
 
+// ParseTab returns the tab with the given display name.
+func ParseTab(name string) (Tab, bool) {
+	for i := Tab(0); i < TabCount; i++ {
+		if i.String() == name {
+			return i, true
+		}
+	}
+	return TabWorktrees, false
+}
+
 // TabCount is the total number of tabs.
 const TabCount = 3
 
@@ -48,6 +59,7 @@ type TabPosition struct {
 type Tabs struct {
 	active Tab
 	width  int
+	counts map[Tab]int // item counts shown after the tab names
 }
 
 // NewTabs creates a new tab bar with Worktrees as the default active tab.
@@ -84,14 +96,30 @@ func (t *Tabs) SetWidth(w int) {
 	t.width = w
 }
 
+// SetCounts sets the item counts shown as badges after the tab names,
+// e.g. "Worktrees (7)". Tabs without a count, and the Settings tab, show
+// their name only. A nil map removes all badges.
+func (t *Tabs) SetCounts(counts map[Tab]int) {
+	t.counts = counts
+}
+
+// Label returns the rendered text of tab, including its count badge.
+func (t *Tabs) Label(tab Tab) string {
+	count, ok := t.counts[tab]
+	if !ok || tab == TabSettings {
+		return tab.String()
+	}
+	return tab.String() + " (" + strconv.Itoa(count) + ")"
+}
+
 // GetTabPositions calculates the screen positions of each tab.
 func (t *Tabs) GetTabPositions() []TabPosition {
 	positions := make([]TabPosition, TabCount)
 	currentX := 0
 
-	// Each tab has padding of 2 on each side ("  TabName  ")
+	// Each tab has padding of 2 on each side ("  TabName (7)  ")
 	for i := Tab(0); i < TabCount; i++ {
-		tabWidth := len(i.String()) + 4 // 2 padding on each side
+		tabWidth := lipgloss.Width(t.Label(i)) + 4 // 2 padding on each side
 		positions[i] = TabPosition{
 			Tab:    i,
 			StartX: currentX,
@@ -147,9 +175,9 @@ func (t *Tabs) View() string {
 	var tabs []string
 	for i := Tab(0); i < TabCount; i++ {
 		if i == t.active {
-			tabs = append(tabs, activeStyle.Render(i.String()))
+			tabs = append(tabs, activeStyle.Render(t.Label(i)))
 		} else {
-			tabs = append(tabs, inactiveStyle.Render(i.String()))
+			tabs = append(tabs, inactiveStyle.Render(t.Label(i)))
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestTabString(t *testing.T) {
@@ -326,3 +327,76 @@ func TestTabsGetTabPositions(t *testing.T) {
 		}
 	}
 }
+
+// TestTabsViewShowsCounts verifies count badges are rendered after the tab names
+func TestTabsViewShowsCounts(t *testing.T) {
+	tabs := NewTabs()
+	tabs.SetCounts(map[Tab]int{TabWorktrees: 7, TabBranches: 12, TabSettings: 3})
+
+	view := tabs.View()
+	if !strings.Contains(view, "Worktrees (7)") {
+		t.Error("View should contain 'Worktrees (7)'")
+	}
+	if !strings.Contains(view, "Branches (12)") {
+		t.Error("View should contain 'Branches (12)'")
+	}
+	if strings.Contains(view, "Settings (") {
+		t.Error("Settings tab should not show a count")
+	}
+
+	tabs.SetCounts(nil)
+	if strings.Contains(tabs.View(), "(") {
+		t.Error("View should not contain badges after SetCounts(nil)")
+	}
+}
+
+// TestTabsGetTabPositionsWithCounts verifies positions match the labels with badges
+func TestTabsGetTabPositionsWithCounts(t *testing.T) {
+	tabs := NewTabs()
+	plain := tabs.GetTabPositions()
+	tabs.SetCounts(map[Tab]int{TabWorktrees: 7, TabBranches: 12})
+	positions := tabs.GetTabPositions()
+
+	if positions[0].StartX != 0 {
+		t.Errorf("first tab StartX = %d, want 0", positions[0].StartX)
+	}
+	for i := 1; i < len(positions); i++ {
+		if positions[i].StartX != positions[i-1].EndX {
+			t.Errorf("position %d StartX (%d) should equal position %d EndX (%d)",
+				i, positions[i].StartX, i-1, positions[i-1].EndX)
+		}
+	}
+	for i, pos := range positions {
+		if width := pos.EndX - pos.StartX; width != lipgloss.Width(tabs.Label(Tab(i)))+4 {
+			t.Errorf("position %d width = %d, want label width + padding", i, width)
+		}
+	}
+
+	// The badges widen Worktrees and Branches but not Settings
+	if positions[TabSettings].EndX-positions[TabSettings].StartX != plain[TabSettings].EndX-plain[TabSettings].StartX {
+		t.Error("Settings tab width should not change")
+	}
+	if positions[TabSettings].StartX != plain[TabSettings].StartX+len(" (7)")+len(" (12)") {
+		t.Errorf("Settings StartX = %d, want shifted by the badges", positions[TabSettings].StartX)
+	}
+
+	// The rendered row is exactly as wide as the positions claim
+	row := strings.Split(tabs.View(), "\n")[0]
+	if lipgloss.Width(row) != positions[len(positions)-1].EndX {
+		t.Errorf("rendered row width = %d, want %d", lipgloss.Width(row), positions[len(positions)-1].EndX)
+	}
+}
+
+// TestParseTab verifies tabs are parsed from their display names
+func TestParseTab(t *testing.T) {
+	for i := Tab(0); i < TabCount; i++ {
+		tab, ok := ParseTab(i.String())
+		if !ok || tab != i {
+			t.Errorf("ParseTab(%q) = %v, %v; want %v, true", i.String(), tab, ok, i)
+		}
+	}
+
+	if _, ok := ParseTab("Unknown"); ok {
+		t.Error("ParseTab should reject unknown names")
+	}
+}
@@ -0,0 +1,170 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ellipsis marks text that has been shortened to fit.
+const ellipsis = "…"
+
+// truncateEnd shortens s to at most width cells, ending with an ellipsis
+// when text was removed. A non-positive width leaves s unchanged.
+func truncateEnd(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+lipgloss.Width(ellipsis) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + ellipsis
+}
+
+// truncateMiddle shortens s to at most width cells by replacing its middle
+// with an ellipsis, keeping both ends visible (useful for paths such as
+// "/home/…/repo-feature"). A non-positive width leaves s unchanged.
+func truncateMiddle(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	if width <= lipgloss.Width(ellipsis) {
+		return truncateEnd(s, width)
+	}
+
+	runes := []rune(s)
+	keep := width - lipgloss.Width(ellipsis)
+	head := keep / 2
+	tail := keep - head
+	for head+tail > 0 && lipgloss.Width(string(runes[:head])+ellipsis+string(runes[len(runes)-tail:])) > width {
+		if tail >= head {
+			tail--
+		} else {
+			head--
+		}
+	}
+	return string(runes[:head]) + ellipsis + string(runes[len(runes)-tail:])
+}
+
+// wrapText word-wraps s to lines of at most width cells. Words longer than
+// width are truncated with an ellipsis. A non-positive width leaves s unchanged.
+func wrapText(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(s) {
+		word = truncateEnd(word, width)
+		switch {
+		case line == "":
+			line = word
+		case lipgloss.Width(line)+1+lipgloss.Width(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapHard breaks s into lines of at most width cells regardless of word
+// boundaries, so nothing of a long path is lost. A non-positive width
+// leaves s unchanged.
+func wrapHard(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+
+	var lines []string
+	var line []rune
+	for _, r := range s {
+		if len(line) > 0 && lipgloss.Width(string(append(line, r))) > width {
+			lines = append(lines, string(line))
+			line = nil
+		}
+		line = append(line, r)
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatSize formats a size in bytes for humans, e.g. "512 B" or "1.5 MB".
+// Units are powers of 1024.
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes) / unit
+	suffixes := []string{"KB", "MB", "GB", "TB"}
+	i := 0
+	for value >= unit && i < len(suffixes)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", value, suffixes[i])
+}
+
+// abbreviateHome shortens a path inside the home directory to start with
+// "~", e.g. "~/worktrees/repo". Other paths are returned unchanged.
+func abbreviateHome(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" || !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(home, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	if rel == "." {
+		return "~"
+	}
+	return filepath.Join("~", rel)
+}
+
+// isCurrentWorktree reports whether the working directory is the worktree
+// at path or lies inside it. Git cannot remove such a worktree.
+func isCurrentWorktree(path string) bool {
+	wd, err := os.Getwd()
+	if err != nil || path == "" {
+		return false
+	}
+	return pathWithin(wd, path)
+}
+
+// pathWithin reports whether path is dir or lies inside it, comparing
+// absolute paths with symlinks resolved where possible.
+func pathWithin(path, dir string) bool {
+	path, dir = canonicalPath(path), canonicalPath(dir)
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+// canonicalPath returns the absolute form of path with symlinks resolved,
+// falling back to the cleaned absolute path when it cannot be resolved.
+func canonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
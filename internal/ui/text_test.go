@@ -0,0 +1,186 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TestTruncateEnd verifies text is shortened with a trailing ellipsis.
+func TestTruncateEnd(t *testing.T) {
+	tests := []struct {
+		input    string
+		width    int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"feature-branch", 8, "feature…"},
+		{"anything", 0, "anything"},
+	}
+
+	for _, tt := range tests {
+		if got := truncateEnd(tt.input, tt.width); got != tt.expected {
+			t.Errorf("truncateEnd(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.expected)
+		}
+	}
+}
+
+// TestTruncateMiddle verifies both ends of long paths are kept.
+func TestTruncateMiddle(t *testing.T) {
+	path := "/home/user/projects/very/deep/nested/repo-feature"
+
+	got := truncateMiddle(path, 20)
+	if lipgloss.Width(got) > 20 {
+		t.Errorf("truncateMiddle width = %d, want <= 20 (%q)", lipgloss.Width(got), got)
+	}
+	if !strings.HasPrefix(got, "/home") || !strings.HasSuffix(got, "feature") || !strings.Contains(got, ellipsis) {
+		t.Errorf("truncateMiddle(%q, 20) = %q, want both ends kept", path, got)
+	}
+
+	if got := truncateMiddle("/short", 20); got != "/short" {
+		t.Errorf("Short path should be unchanged, got %q", got)
+	}
+	if got := truncateMiddle(path, 1); lipgloss.Width(got) > 1 {
+		t.Errorf("truncateMiddle at width 1 = %q, too wide", got)
+	}
+}
+
+// TestTruncateMiddleBranchNames verifies exact fits, truncation and tiny widths, including wide runes.
+func TestTruncateMiddleBranchNames(t *testing.T) {
+	branch := "feature/JIRA-1234-very-long-description"
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"exact fit", "feature/login", 13, "feature/login"},
+		{"one cell short", "feature/login", 12, "featu…/login"},
+		{"needs truncation", branch, 19, "feature/J…scription"},
+		{"very short", branch, 2, "…n"},
+		{"width of ellipsis", branch, 1, "…"},
+		{"wide runes fit", "機能/ログイン", 13, "機能/ログイン"},
+		{"wide runes truncated", "機能/ログイン", 8, "機能…ン"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateMiddle(tt.s, tt.width)
+			if got != tt.want {
+				t.Errorf("truncateMiddle(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+			if lipgloss.Width(got) > tt.width {
+				t.Errorf("truncateMiddle(%q, %d) is %d cells wide", tt.s, tt.width, lipgloss.Width(got))
+			}
+		})
+	}
+}
+
+// TestWrapText verifies words are wrapped to the width.
+func TestWrapText(t *testing.T) {
+	got := wrapText("the quick brown fox jumps over averyveryverylongword", 10)
+	for _, line := range strings.Split(got, "\n") {
+		if lipgloss.Width(line) > 10 {
+			t.Errorf("Line %q exceeds width 10", line)
+		}
+	}
+	if !strings.HasPrefix(got, "the quick\n") {
+		t.Errorf("Expected words packed per line, got %q", got)
+	}
+}
+
+// TestWrapHard verifies long words are broken instead of truncated.
+func TestWrapHard(t *testing.T) {
+	got := wrapHard("/home/user/projects/repo-feature", 10)
+	if want := "/home/user\n/projects/\nrepo-featu\nre"; got != want {
+		t.Errorf("wrapHard() = %q, want %q", got, want)
+	}
+	if got := wrapHard("/short", 10); got != "/short" {
+		t.Errorf("Short text should be unchanged, got %q", got)
+	}
+}
+
+// TestFormatSize verifies sizes are shown in human-readable units.
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GB"},
+		{2048 * 1024 * 1024 * 1024 * 1024, "2048.0 TB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatSize(tt.bytes); got != tt.want {
+			t.Errorf("formatSize(%d) = %q, want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
+
+// TestAbbreviateHome verifies paths in the home directory start with "~".
+func TestAbbreviateHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{filepath.Join(home, "worktrees", "grove"), filepath.Join("~", "worktrees", "grove")},
+		{home, "~"},
+		{home + "-other", home + "-other"},
+		{"/elsewhere/grove", "/elsewhere/grove"},
+		{"../feature", "../feature"},
+	}
+
+	for _, tt := range tests {
+		if got := abbreviateHome(tt.path); got != tt.expected {
+			t.Errorf("abbreviateHome(%q) = %q, want %q", tt.path, got, tt.expected)
+		}
+	}
+}
+
+// TestIsCurrentWorktree verifies a worktree is current when the working
+// directory is the worktree itself or lies inside it.
+func TestIsCurrentWorktree(t *testing.T) {
+	root := t.TempDir()
+	worktree := filepath.Join(root, "feature")
+	nested := filepath.Join(worktree, "src", "pkg")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(root, "link")
+	if err := os.Symlink(worktree, link); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(nested)
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{nested, true},
+		{worktree, true},
+		{link, true},
+		{root, true},
+		{filepath.Join(nested, "deeper"), false},
+		{root + "-other", false},
+		{filepath.Join(root, "feat"), false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isCurrentWorktree(tt.path); got != tt.expected {
+			t.Errorf("isCurrentWorktree(%q) = %v, want %v", tt.path, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// maxWatchedDirs limits how many directories of a worktree are watched;
+// larger worktrees fall back to polling. Replaced in tests.
+var maxWatchedDirs = 2000
+
+// WorktreeChangedMsg is sent when the watcher saw a change to the watched
+// worktree or to the repository's list of worktrees.
+type WorktreeChangedMsg struct {
+	// Path is the worktree whose files changed, or empty
+	Path string
+	// Worktrees is set when worktrees were added or removed
+	Worktrees bool
+}
+
+// worktreeWatcher watches the files of one worktree, to keep its status
+// current, and the repository's common git directory, to notice worktrees
+// being added or removed. fsnotify is not recursive, so every directory of
+// the worktree is watched; the .git entry is skipped.
+type worktreeWatcher struct {
+	fs *fsnotify.Watcher
+	// commonDir is the git directory shared by all worktrees
+	commonDir string
+	// adminDir is the common dir's "worktrees" directory, which holds an
+	// entry per linked worktree
+	adminDir string
+
+	mu   sync.Mutex
+	path string   // watched worktree (empty = none)
+	dirs []string // watched directories of the worktree
+}
+
+// newWorktreeWatcher creates a watcher noticing worktrees added to or
+// removed from the repository whose common git directory is commonDir.
+func newWorktreeWatcher(commonDir string) (*worktreeWatcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &worktreeWatcher{
+		fs:        fsWatcher,
+		commonDir: commonDir,
+		adminDir:  filepath.Join(commonDir, "worktrees"),
+	}
+
+	// The common dir tells when "worktrees" itself appears or goes away
+	if err := fsWatcher.Add(commonDir); err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+	if err := fsWatcher.Add(w.adminDir); err != nil && !os.IsNotExist(err) {
+		fsWatcher.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Path returns the watched worktree, or "" if none.
+func (w *worktreeWatcher) Path() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.path
+}
+
+// Watch moves the watch to the worktree at path; "" stops watching
+// worktree files. It fails when the worktree has more than maxWatchedDirs
+// directories or the system runs out of watches.
+func (w *worktreeWatcher) Watch(path string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if path == w.path {
+		return nil
+	}
+	for _, dir := range w.dirs {
+		_ = w.fs.Remove(dir)
+	}
+	w.path = ""
+	w.dirs = nil
+	if path == "" {
+		return nil
+	}
+
+	var dirs []string
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are left out
+			return fs.SkipDir
+		}
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if len(dirs) == maxWatchedDirs {
+			return fmt.Errorf("%s has more than %d directories", path, maxWatchedDirs)
+		}
+		dirs = append(dirs, p)
+		return nil
+	})
+	if err == nil {
+		for _, dir := range dirs {
+			if err = w.fs.Add(dir); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		for _, dir := range dirs {
+			_ = w.fs.Remove(dir)
+		}
+		return err
+	}
+
+	w.path = path
+	w.dirs = dirs
+	return nil
+}
+
+// Close stops watching; the command returned by Wait then ends.
+func (w *worktreeWatcher) Close() error {
+	return w.fs.Close()
+}
+
+// Wait returns a command that sends a WorktreeChangedMsg for the next
+// relevant change. It sends nothing once the watcher is closed.
+func (w *worktreeWatcher) Wait() tea.Cmd {
+	return func() tea.Msg {
+		for {
+			select {
+			case event, ok := <-w.fs.Events:
+				if !ok {
+					return nil
+				}
+				if msg, ok := w.classify(event); ok {
+					return msg
+				}
+			case _, ok := <-w.fs.Errors:
+				// Errors such as a queue overflow are outdated by the next event
+				if !ok {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// classify turns an event into a message, ignoring changes in the common
+// dir other than to its worktrees. New directories in the worktree are
+// watched as well.
+func (w *worktreeWatcher) classify(event fsnotify.Event) (WorktreeChangedMsg, bool) {
+	if event.Name == w.adminDir {
+		// The directory appears with the first linked worktree
+		if event.Has(fsnotify.Create) {
+			_ = w.fs.Add(w.adminDir)
+		}
+		return WorktreeChangedMsg{Worktrees: true}, true
+	}
+	if filepath.Dir(event.Name) == w.adminDir {
+		return WorktreeChangedMsg{Worktrees: true}, true
+	}
+	// Git writes the index and lock files there, also when reading status
+	if filepath.Dir(event.Name) == w.commonDir {
+		return WorktreeChangedMsg{}, false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.path == "" || !isWithin(event.Name, w.path) {
+		return WorktreeChangedMsg{}, false
+	}
+	if isWithin(event.Name, filepath.Join(w.path, ".git")) {
+		return WorktreeChangedMsg{}, false
+	}
+	if event.Has(fsnotify.Create) && len(w.dirs) < maxWatchedDirs {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() && w.fs.Add(event.Name) == nil {
+			w.dirs = append(w.dirs, event.Name)
+		}
+	}
+	return WorktreeChangedMsg{Path: w.path}, true
+}
+
+// isWithin reports whether path is dir or inside it.
+func isWithin(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
@@ -0,0 +1,137 @@
+// Package ui provides the terminal user interface for the git worktree manager.
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestWatcher creates a watcher for a fake common dir, closed when the test ends.
+func newTestWatcher(t *testing.T) (*worktreeWatcher, string) {
+	t.Helper()
+	commonDir := t.TempDir()
+	w, err := newWorktreeWatcher(commonDir)
+	if err != nil {
+		t.Fatalf("newWorktreeWatcher failed: %v", err)
+	}
+	t.Cleanup(func() { w.Close() })
+	return w, commonDir
+}
+
+// waitForChange runs the watcher's command, failing the test if no change arrives in time.
+func waitForChange(t *testing.T, cmd tea.Cmd) WorktreeChangedMsg {
+	t.Helper()
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	select {
+	case msg := <-done:
+		changed, ok := msg.(WorktreeChangedMsg)
+		if !ok {
+			t.Fatalf("Expected WorktreeChangedMsg, got %T", msg)
+		}
+		return changed
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a change")
+		return WorktreeChangedMsg{}
+	}
+}
+
+// TestWorktreeWatcherFileChange verifies changes anywhere in the watched worktree are reported, but not in .git.
+func TestWorktreeWatcherFileChange(t *testing.T) {
+	w, _ := newTestWatcher(t)
+	wtPath := t.TempDir()
+	writeTestFile(t, filepath.Join(wtPath, ".git", "index"), "")
+	writeTestFile(t, filepath.Join(wtPath, "src", "main.go"), "package main")
+
+	if err := w.Watch(wtPath); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if w.Path() != wtPath {
+		t.Errorf("Expected watched path %q, got %q", wtPath, w.Path())
+	}
+
+	cmd := w.Wait()
+	writeTestFile(t, filepath.Join(wtPath, ".git", "index"), "changed")
+	writeTestFile(t, filepath.Join(wtPath, "src", "main.go"), "package main // edited")
+	if msg := waitForChange(t, cmd); msg.Path != wtPath || msg.Worktrees {
+		t.Errorf("Expected a change in %q, got %+v", wtPath, msg)
+	}
+}
+
+// TestWorktreeWatcherWorktreesChanged verifies adding a worktree is reported while the common dir's own files are ignored.
+func TestWorktreeWatcherWorktreesChanged(t *testing.T) {
+	w, commonDir := newTestWatcher(t)
+
+	writeTestFile(t, filepath.Join(commonDir, "index.lock"), "")
+	if err := os.Mkdir(filepath.Join(commonDir, "worktrees"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if msg := waitForChange(t, w.Wait()); !msg.Worktrees {
+		t.Errorf("Expected worktrees to change, got %+v", msg)
+	}
+
+	// Entries inside the new directory are reported too
+	if err := os.Mkdir(filepath.Join(commonDir, "worktrees", "feature"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if msg := waitForChange(t, w.Wait()); !msg.Worktrees {
+		t.Errorf("Expected worktrees to change, got %+v", msg)
+	}
+}
+
+// TestWorktreeWatcherMove verifies watching another worktree stops reporting the previous one.
+func TestWorktreeWatcherMove(t *testing.T) {
+	w, _ := newTestWatcher(t)
+	first, second := t.TempDir(), t.TempDir()
+
+	if err := w.Watch(first); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if err := w.Watch(second); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	cmd := w.Wait()
+	writeTestFile(t, filepath.Join(first, "ignored.txt"), "x")
+	writeTestFile(t, filepath.Join(second, "seen.txt"), "x")
+	if msg := waitForChange(t, cmd); msg.Path != second {
+		t.Errorf("Expected a change in %q, got %+v", second, msg)
+	}
+}
+
+// TestWorktreeWatcherTooManyDirs verifies a worktree with too many directories is not watched.
+func TestWorktreeWatcherTooManyDirs(t *testing.T) {
+	w, _ := newTestWatcher(t)
+	original := maxWatchedDirs
+	maxWatchedDirs = 2
+	t.Cleanup(func() { maxWatchedDirs = original })
+
+	wtPath := t.TempDir()
+	for _, dir := range []string{"a", "b"} {
+		if err := os.Mkdir(filepath.Join(wtPath, dir), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+
+	if err := w.Watch(wtPath); err == nil {
+		t.Error("Expected an error for too many directories")
+	}
+	if w.Path() != "" {
+		t.Errorf("Expected nothing watched, got %q", w.Path())
+	}
+}
+
+// writeTestFile writes content to path, creating its directory.
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}